@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS rule_namespaces (
+  user_id text NOT NULL,
+  namespace text NOT NULL,
+  version bigint NOT NULL DEFAULT 1,
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, namespace)
+);
+
+CREATE TABLE IF NOT EXISTS rule_groups (
+  user_id text NOT NULL,
+  namespace text NOT NULL,
+  name text NOT NULL,
+  rule_group bytea NOT NULL,
+  version bigint NOT NULL DEFAULT 1,
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, namespace, name)
+);
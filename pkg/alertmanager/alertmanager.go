@@ -15,8 +15,10 @@ import (
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
+	"github.com/pkg/errors"
 	"github.com/prometheus/alertmanager/api"
 	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
 	"github.com/prometheus/alertmanager/inhibit"
@@ -39,6 +41,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/route"
+
+	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
+	"github.com/cortexproject/cortex/pkg/util/services"
 )
 
 const notificationLogMaintenancePeriod = 15 * time.Minute
@@ -53,6 +58,16 @@ type Config struct {
 	PeerTimeout time.Duration
 	Retention   time.Duration
 	ExternalURL *url.URL
+
+	// When sharding is enabled, the state is replicated to the other replicas
+	// of the tenant via the Replicator instead of the gossip cluster Peer.
+	ShardingEnabled bool
+	Replicator      Replicator
+
+	// Used to persist the state to the storage, and restore it when the
+	// state can't be read from the other alertmanagers. Optional.
+	Store           AlertStateStore
+	PersistInterval time.Duration
 }
 
 // An Alertmanager manages the alerts for one user.
@@ -60,6 +75,8 @@ type Alertmanager struct {
 	cfg             *Config
 	api             *api.API
 	logger          log.Logger
+	state           *replicatedStates
+	persister       *statePersister
 	nflog           *nflog.Log
 	silences        *silence.Silences
 	marker          types.Marker
@@ -83,6 +100,7 @@ type Alertmanager struct {
 
 	activeMtx sync.Mutex
 	active    bool
+	paused    bool
 }
 
 var (
@@ -115,6 +133,10 @@ func New(cfg *Config, reg *prometheus.Registry) (*Alertmanager, error) {
 
 	am.registry = reg
 
+	if cfg.ShardingEnabled {
+		am.state = newReplicatedStates(cfg.UserID, cfg.Replicator, am.logger, am.registry)
+	}
+
 	am.wg.Add(1)
 	nflogID := fmt.Sprintf("nflog:%s", cfg.UserID)
 	var err error
@@ -128,7 +150,9 @@ func New(cfg *Config, reg *prometheus.Registry) (*Alertmanager, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create notification log: %v", err)
 	}
-	if cfg.Peer != nil {
+	if am.state != nil {
+		am.nflog.SetBroadcast(am.state.AddState("nfl:"+cfg.UserID, am.nflog))
+	} else if cfg.Peer != nil {
 		c := cfg.Peer.AddState("nfl:"+cfg.UserID, am.nflog, am.registry)
 		am.nflog.SetBroadcast(c.Broadcast)
 	}
@@ -145,7 +169,9 @@ func New(cfg *Config, reg *prometheus.Registry) (*Alertmanager, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create silences: %v", err)
 	}
-	if cfg.Peer != nil {
+	if am.state != nil {
+		am.silences.SetBroadcast(am.state.AddState("sil:"+cfg.UserID, am.silences))
+	} else if cfg.Peer != nil {
 		c := cfg.Peer.AddState("sil:"+cfg.UserID, am.silences, am.registry)
 		am.silences.SetBroadcast(c.Broadcast)
 	}
@@ -195,14 +221,123 @@ func New(cfg *Config, reg *prometheus.Registry) (*Alertmanager, error) {
 	}
 
 	am.dispatcherMetrics = dispatch.NewDispatcherMetrics(am.registry)
+
+	if cfg.Store != nil {
+		am.persister = newStatePersister(cfg.UserID, cfg.PersistInterval, am, cfg.Store, am.logger, am.registry)
+	}
+
+	if am.state == nil {
+		if err := am.startPersistingState(); err != nil {
+			return nil, err
+		}
+		return am, nil
+	}
+
+	// The state is read from the other replicas of the tenant in the background, like the gossip
+	// cluster does when settling, so that the alertmanagers of the other tenants can be created in
+	// the meanwhile. The notifications are not sent until the state has been settled.
+	if err := am.state.StartAsync(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to start state replication: %v", err)
+	}
+
+	am.wg.Add(1)
+	go func() {
+		defer am.wg.Done()
+
+		select {
+		case <-am.state.readyc:
+		case <-am.stop:
+			return
+		}
+
+		if err := am.startPersistingState(); err != nil {
+			level.Warn(am.logger).Log("msg", "failed to persist the state", "err", err)
+		}
+	}()
+
 	return am, nil
 }
 
+// startPersistingState restores the state from the storage, if needed, and starts persisting it.
+// When sharding is enabled, it must be called once the state has been read from the other replicas.
+func (am *Alertmanager) startPersistingState() error {
+	if am.persister == nil {
+		return nil
+	}
+
+	am.restoreState()
+
+	if err := services.StartAndAwaitRunning(context.Background(), am.persister); err != nil {
+		return fmt.Errorf("failed to start state persister: %v", err)
+	}
+	return nil
+}
+
+// restoreState restores the state from the storage, if no state has been received from the
+// other alertmanagers, which happens when all the alertmanagers of the tenant are restarted.
+func (am *Alertmanager) restoreState() {
+	states := am.localStates()
+	for _, st := range states {
+		b, err := st.MarshalBinary()
+		if err != nil {
+			level.Warn(am.logger).Log("msg", "failed to read the local state, not restoring the state from the storage", "err", err)
+			return
+		}
+		if len(b) > 0 {
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultPersistTimeout)
+	defer cancel()
+
+	fullState, err := am.cfg.Store.GetFullState(ctx, am.cfg.UserID)
+	if err == alerts.ErrStateNotFound {
+		return
+	}
+	if err != nil {
+		level.Warn(am.logger).Log("msg", "failed to read the state from the storage, starting with an empty state", "err", err)
+		return
+	}
+
+	for _, part := range fullState.Parts {
+		st, ok := states[part.Key]
+		if !ok {
+			level.Warn(am.logger).Log("msg", "unknown key in the state read from the storage", "key", part.Key)
+			continue
+		}
+		if err := st.Merge(part.Data); err != nil {
+			level.Warn(am.logger).Log("msg", "failed to merge the state read from the storage", "key", part.Key, "err", err)
+		}
+	}
+
+	level.Info(am.logger).Log("msg", "state restored from the storage")
+}
+
+// localStates returns the states of the Alertmanager, by the key used to replicate and persist them.
+func (am *Alertmanager) localStates() map[string]cluster.State {
+	return map[string]cluster.State{
+		"nfl:" + am.cfg.UserID: am.nflog,
+		"sil:" + am.cfg.UserID: am.silences,
+	}
+}
+
+// position returns the position of this instance among the replicas of the tenant.
+func (am *Alertmanager) position() int {
+	if am.state != nil {
+		return am.state.Position()
+	}
+	if am.cfg.Peer != nil {
+		return am.cfg.Peer.Position()
+	}
+	return 0
+}
+
 // clusterWait returns a function that inspects the current peer state and returns
 // a duration of one base timeout for each peer with a higher ID than ourselves.
-func clusterWait(p *cluster.Peer, timeout time.Duration) func() time.Duration {
+func clusterWait(position func() int, timeout time.Duration) func() time.Duration {
 	return func() time.Duration {
-		return time.Duration(p.Position()) * timeout
+		return time.Duration(position()) * timeout
 	}
 }
 
@@ -235,7 +370,16 @@ func (am *Alertmanager) ApplyConfig(userID string, conf *config.Config, rawCfg s
 
 	am.inhibitor = inhibit.NewInhibitor(am.alerts, conf.InhibitRules, am.marker, log.With(am.logger, "component", "inhibitor"))
 
-	waitFunc := clusterWait(am.cfg.Peer, am.cfg.PeerTimeout)
+	// The replicas of the tenant don't send the state updates to the alertmanagers not
+	// owning the tenant, so the state of a paused alertmanager could be outdated.
+	am.activeMtx.Lock()
+	paused := am.paused
+	am.activeMtx.Unlock()
+	if paused && am.state != nil {
+		am.state.Settle(context.Background())
+	}
+
+	waitFunc := clusterWait(am.position, am.cfg.PeerTimeout)
 	timeoutFunc := func(d time.Duration) time.Duration {
 		if d < notify.MinTimeout {
 			d = notify.MinTimeout
@@ -256,10 +400,15 @@ func (am *Alertmanager) ApplyConfig(userID string, conf *config.Config, rawCfg s
 		am.nflog,
 		am.cfg.Peer,
 	)
+	var stage notify.Stage = pipeline
+	if am.state != nil {
+		stage = notify.MultiStage{&stateSettleStage{state: am.state}, pipeline}
+	}
+
 	am.dispatcher = dispatch.NewDispatcher(
 		am.alerts,
 		dispatch.NewRoute(conf.Route, nil),
-		pipeline,
+		stage,
 		am.marker,
 		timeoutFunc,
 		log.With(am.logger, "component", "dispatcher"),
@@ -272,6 +421,7 @@ func (am *Alertmanager) ApplyConfig(userID string, conf *config.Config, rawCfg s
 	// Ensure the alertmanager is set to active
 	am.activeMtx.Lock()
 	am.active = true
+	am.paused = false
 	am.activeMtx.Unlock()
 
 	am.configHashMetric.Set(md5HashAsMetricValue([]byte(rawCfg)))
@@ -292,6 +442,7 @@ func (am *Alertmanager) Pause() {
 	// Set to inactive
 	am.activeMtx.Lock()
 	am.active = false
+	am.paused = true
 	am.activeMtx.Unlock()
 
 	// Stop the inhibitor and dispatcher which will be recreated when
@@ -305,6 +456,12 @@ func (am *Alertmanager) Pause() {
 		am.dispatcher = nil
 	}
 
+	// When sharding is enabled, the alertmanager is paused because the tenant is now owned
+	// by other alertmanagers, and expiring the silences would replicate the expiration to them.
+	if am.state != nil {
+		return
+	}
+
 	// Remove all of the active silences from the alertmanager
 	silences, _, err := am.silences.Query()
 	if err != nil {
@@ -320,6 +477,13 @@ func (am *Alertmanager) Pause() {
 
 // Stop stops the Alertmanager.
 func (am *Alertmanager) Stop() {
+	// The state is persisted before stopping the maintenance of the notification log and silences.
+	if am.persister != nil {
+		if err := services.StopAndAwaitTerminated(context.Background(), am.persister); err != nil {
+			level.Warn(am.logger).Log("msg", "error while stopping state persister", "err", err)
+		}
+	}
+
 	if am.inhibitor != nil {
 		am.inhibitor.Stop()
 	}
@@ -331,6 +495,37 @@ func (am *Alertmanager) Stop() {
 	am.alerts.Close()
 	close(am.stop)
 	am.wg.Wait()
+
+	if am.state != nil {
+		if err := services.StopAndAwaitTerminated(context.Background(), am.state); err != nil {
+			level.Warn(am.logger).Log("msg", "error while stopping state replication", "err", err)
+		}
+	}
+}
+
+// mergePartialState merges a partial state received from another replica of the tenant.
+func (am *Alertmanager) mergePartialState(part *clusterpb.Part) error {
+	if am.state == nil {
+		return errors.New("state replication is not enabled")
+	}
+	return am.state.MergePartialState(part)
+}
+
+// getFullState returns the full state to be sent to another replica of the tenant or persisted.
+func (am *Alertmanager) getFullState() (*clusterpb.FullState, error) {
+	if am.state != nil {
+		return am.state.GetFullState()
+	}
+
+	fullState := &clusterpb.FullState{}
+	for key, st := range am.localStates() {
+		b, err := st.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		fullState.Parts = append(fullState.Parts, clusterpb.Part{Key: key, Data: b})
+	}
+	return fullState, nil
 }
 
 // buildIntegrationsMap builds a map of name to the list of integration notifiers off of a
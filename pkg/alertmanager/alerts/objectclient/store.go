@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"path"
 
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
 	"github.com/thanos-io/thanos/pkg/runutil"
 
 	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
@@ -17,9 +18,13 @@ import (
 // =======================
 // Object Name: "alerts/<user_id>"
 // Storage Format: Encoded AlertConfigDesc
+//
+// Object Name: "alertmanager-state/<user_id>"
+// Storage Format: Encoded clusterpb.FullState
 
 const (
 	alertPrefix = "alerts/"
+	statePrefix = "alertmanager-state/"
 )
 
 // AlertStore allows cortex alertmanager configs to be stored using an object store backend.
@@ -100,3 +105,47 @@ func (a *AlertStore) SetAlertConfig(ctx context.Context, cfg alerts.AlertConfigD
 func (a *AlertStore) DeleteAlertConfig(ctx context.Context, user string) error {
 	return a.client.DeleteObject(ctx, path.Join(alertPrefix, user))
 }
+
+// GetFullState returns the state of a specified user's alertmanager
+func (a *AlertStore) GetFullState(ctx context.Context, user string) (*clusterpb.FullState, error) {
+	readCloser, err := a.client.GetObject(ctx, path.Join(statePrefix, user))
+	if err == chunk.ErrStorageObjectNotFound {
+		return nil, alerts.ErrStateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	defer runutil.CloseWithLogOnErr(util.Logger, readCloser, "close alertmanager state reader")
+
+	buf, err := ioutil.ReadAll(readCloser)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &clusterpb.FullState{}
+	if err := state.Unmarshal(buf); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// SetFullState sets the state of a specified user's alertmanager
+func (a *AlertStore) SetFullState(ctx context.Context, user string, state *clusterpb.FullState) error {
+	stateBytes, err := state.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return a.client.PutObject(ctx, path.Join(statePrefix, user), bytes.NewReader(stateBytes))
+}
+
+// DeleteFullState deletes the state of a specified user's alertmanager
+func (a *AlertStore) DeleteFullState(ctx context.Context, user string) error {
+	err := a.client.DeleteObject(ctx, path.Join(statePrefix, user))
+	if err == chunk.ErrStorageObjectNotFound {
+		return nil
+	}
+	return err
+}
@@ -1,11 +1,10 @@
 package alertmanager
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"os"
-	"path/filepath"
 
 	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
 	"github.com/cortexproject/cortex/pkg/tenant"
@@ -13,8 +12,6 @@ import (
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
-	"github.com/prometheus/alertmanager/config"
-	"github.com/prometheus/alertmanager/template"
 	"gopkg.in/yaml.v2"
 )
 
@@ -87,6 +84,13 @@ func (am *MultitenantAlertmanager) SetUserConfig(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if maxSize := am.limits.AlertmanagerMaxConfigSize(userID); maxSize > 0 && len(payload) > maxSize {
+		err := ConfigValidationErrors{{Message: fmt.Sprintf("configuration is too big: %d bytes (limit: %d bytes)", len(payload), maxSize)}}
+		level.Warn(logger).Log("msg", errValidatingConfig, "err", err.Error())
+		respondValidationErrors(logger, w, err)
+		return
+	}
+
 	cfg := &UserConfig{}
 	err = yaml.Unmarshal(payload, cfg)
 	if err != nil {
@@ -96,9 +100,14 @@ func (am *MultitenantAlertmanager) SetUserConfig(w http.ResponseWriter, r *http.
 	}
 
 	cfgDesc := alerts.ToProto(cfg.AlertmanagerConfig, cfg.TemplateFiles, userID)
-	if err := validateUserConfig(logger, cfgDesc); err != nil {
+	if err := validateUserConfig(logger, cfgDesc, am.limits); err != nil {
 		level.Warn(logger).Log("msg", errValidatingConfig, "err", err.Error())
-		http.Error(w, fmt.Sprintf("%s: %s", errValidatingConfig, err.Error()), http.StatusBadRequest)
+
+		if validationErrs, ok := err.(ConfigValidationErrors); ok {
+			respondValidationErrors(logger, w, validationErrs)
+		} else {
+			http.Error(w, fmt.Sprintf("%s: %s", errValidatingConfig, err.Error()), http.StatusBadRequest)
+		}
 		return
 	}
 
@@ -128,57 +137,41 @@ func (am *MultitenantAlertmanager) DeleteUserConfig(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// The persisted state is deleted with the configuration, otherwise it would be
+	// restored if the user uploads a new configuration.
+	if stateStore, ok := am.store.(AlertStateStore); ok {
+		if err := stateStore.DeleteFullState(r.Context(), userID); err != nil {
+			level.Warn(logger).Log("msg", "unable to delete the Alertmanager state", "err", err.Error())
+		}
+	}
+
 	w.WriteHeader(http.StatusOK)
 }
 
-// Partially copied from: https://github.com/prometheus/alertmanager/blob/8e861c646bf67599a1704fc843c6a94d519ce312/cli/check_config.go#L65-L96
-func validateUserConfig(logger log.Logger, cfg alerts.AlertConfigDesc) error {
-	// We don't have a valid use case for empty configurations. If a tenant does not have a
-	// configuration set and issue a request to the Alertmanager, we'll a) upload an empty
-	// config and b) immediately start an Alertmanager instance for them if a fallback
-	// configuration is provisioned.
-	if cfg.RawConfig == "" {
-		return fmt.Errorf("configuration provided is empty, if you'd like to remove your configuration please use the delete configuration endpoint")
-	}
-
-	amCfg, err := config.Load(cfg.RawConfig)
-	if err != nil {
-		return err
-	}
+// configValidationResponse is the response returned when the uploaded Alertmanager config is not valid.
+type configValidationResponse struct {
+	Status    string                 `json:"status"`
+	ErrorType string                 `json:"errorType"`
+	Error     string                 `json:"error"`
+	Errors    ConfigValidationErrors `json:"errors"`
+}
 
-	// Create templates on disk in a temporary directory.
-	// Note: This means the validation will succeed if we can write to tmp but
-	// not to configured data dir, and on the flipside, it'll fail if we can't write
-	// to tmpDir. Ignoring both cases for now as they're ultra rare but will revisit if
-	// we see this in the wild.
-	tmpDir, err := ioutil.TempDir("", "validate-config")
+func respondValidationErrors(logger log.Logger, w http.ResponseWriter, errs ConfigValidationErrors) {
+	b, err := json.Marshal(&configValidationResponse{
+		Status:    "error",
+		ErrorType: "bad_data",
+		Error:     fmt.Sprintf("%s: %s", errValidatingConfig, errs.Error()),
+		Errors:    errs,
+	})
 	if err != nil {
-		return err
-	}
-	defer os.RemoveAll(tmpDir)
-
-	for _, tmpl := range cfg.Templates {
-		_, err := createTemplateFile(tmpDir, cfg.User, tmpl.Filename, tmpl.Body)
-		if err != nil {
-			level.Error(logger).Log("msg", "unable to create template file", "err", err, "user", cfg.User)
-			return fmt.Errorf("unable to create template file '%s'", tmpl.Filename)
-		}
-	}
-
-	templateFiles := make([]string, len(amCfg.Templates))
-	for i, t := range amCfg.Templates {
-		templateFiles[i] = filepath.Join(tmpDir, "templates", cfg.User, t)
+		level.Error(logger).Log("msg", "error marshaling json response", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	_, err = template.FromGlobs(templateFiles...)
-	if err != nil {
-		return err
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	if n, err := w.Write(b); err != nil {
+		level.Error(logger).Log("msg", "error writing response", "bytesWritten", n, "err", err)
 	}
-
-	// Note: Not validating the MultitenantAlertmanager.transformConfig function as that
-	// that function shouldn't break configuration. Only way it can fail is if the base
-	// autoWebhookURL itself is broken. In that case, I would argue, we should accept the config
-	// not reject it.
-
-	return nil
 }
@@ -3,6 +3,7 @@ package alertmanager
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -20,8 +21,11 @@ func TestAMConfigValidationAPI(t *testing.T) {
 	testCases := []struct {
 		name     string
 		cfg      string
+		limits   mockAlertManagerLimits
 		response string
 		err      error
+		// Expected field of the first validation error, if any.
+		errField string
 	}{
 		{
 			name: "It is not a valid payload without receivers",
@@ -34,7 +38,8 @@ alertmanager_config: |
     repeat_interval: 4h
     group_by: [cluster, alertname]
 `,
-			err: fmt.Errorf("error validating Alertmanager config: undefined receiver \"default-receiver\" used in route"),
+			err:      fmt.Errorf("error validating Alertmanager config: undefined receiver \"default-receiver\" used in route"),
+			errField: "alertmanager_config",
 		},
 		{
 			name: "It is valid",
@@ -66,7 +71,8 @@ template_files:
   "good.tpl": "good-templ"
   "not/very/good.tpl": "bad-template"
 `,
-			err: fmt.Errorf("error validating Alertmanager config: unable to create template file 'not/very/good.tpl'"),
+			err:      fmt.Errorf("error validating Alertmanager config: unable to create template file 'not/very/good.tpl'"),
+			errField: `template_files["not/very/good.tpl"]`,
 		},
 		{
 			name: "It is not valid with .",
@@ -84,7 +90,8 @@ template_files:
   "good.tpl": "good-templ"
   ".": "bad-template"
 `,
-			err: fmt.Errorf("error validating Alertmanager config: unable to create template file '.'"),
+			err:      fmt.Errorf("error validating Alertmanager config: unable to create template file '.'"),
+			errField: `template_files["."]`,
 		},
 		{
 			name: "It is not valid if the config is empty due to wrong indendatation",
@@ -102,7 +109,8 @@ template_files:
   "good.tpl": "good-templ"
   "not/very/good.tpl": "bad-template"
 `,
-			err: fmt.Errorf("error validating Alertmanager config: configuration provided is empty, if you'd like to remove your configuration please use the delete configuration endpoint"),
+			err:      fmt.Errorf("error validating Alertmanager config: configuration provided is empty, if you'd like to remove your configuration please use the delete configuration endpoint"),
+			errField: "alertmanager_config",
 		},
 		{
 			name: "It is not valid if the config is empty due to wrong key",
@@ -120,16 +128,160 @@ template_files:
   "good.tpl": "good-templ"
   "not/very/good.tpl": "bad-template"
 `,
-			err: fmt.Errorf("error validating Alertmanager config: configuration provided is empty, if you'd like to remove your configuration please use the delete configuration endpoint"),
+			err:      fmt.Errorf("error validating Alertmanager config: configuration provided is empty, if you'd like to remove your configuration please use the delete configuration endpoint"),
+			errField: "alertmanager_config",
+		},
+		{
+			name: "It is not valid if a template can't be executed",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+      slack_configs:
+        - api_url: http://localhost/slack
+          title: '{{ template "slack.custom.title" . }}'
+  templates:
+    - 'slack.tpl'
+template_files:
+  "slack.tpl": '{{ define "slack.custom.title" }}{{ .CommonLabels.alertname | unknownFunc }}{{ end }}'
+`,
+			err:      fmt.Errorf(`error validating Alertmanager config: template: slack.tpl:1: function "unknownFunc" not defined`),
+			errField: "template_files",
+		},
+		{
+			name: "It is not valid if a receiver references an undefined template",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+      slack_configs:
+        - api_url: http://localhost/slack
+          title: '{{ template "slack.undefined.title" . }}'
+`,
+			err:      fmt.Errorf(`error validating Alertmanager config: receiver 'default-receiver': unable to execute the template of 'alertmanager_config.receivers[0].slack_configs[0].title': template: :1:12: executing "" at <{{template "slack.undefined.title" .}}>: template "slack.undefined.title" not defined`),
+			errField: "alertmanager_config.receivers[0].slack_configs[0].title",
+		},
+		{
+			name: "It is valid with templates executed against the sample alert",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+      slack_configs:
+        - api_url: http://localhost/slack
+          title: '{{ template "slack.custom.title" . }}'
+          text: '{{ range .Alerts }}{{ .Annotations.summary }}{{ end }}'
+  templates:
+    - 'slack.tpl'
+template_files:
+  "slack.tpl": '{{ define "slack.custom.title" }}{{ .CommonLabels.alertname | toUpper }}{{ end }}'
+`,
+		},
+		{
+			name: "It is not valid with a template path outside of the templates directory",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+  templates:
+    - '../../../etc/*'
+`,
+			err:      fmt.Errorf("error validating Alertmanager config: template path '../../../etc/*' is not allowed, only the paths of the uploaded template files can be used"),
+			errField: "alertmanager_config.templates[0]",
+		},
+		{
+			name: "It is not valid with a receiver reading a local file",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+      webhook_configs:
+        - url: http://localhost/webhook
+          http_config:
+            bearer_token_file: /etc/secret
+`,
+			err:      fmt.Errorf("error validating Alertmanager config: the 'bearer_token_file' option is not allowed in 'alertmanager_config.receivers[0].webhook_configs[0].http_config', local files can't be used"),
+			errField: "alertmanager_config.receivers[0].webhook_configs[0].http_config.bearer_token_file",
+		},
+		{
+			name: "It is not valid with the global HTTP config reading a local file",
+			cfg: `
+alertmanager_config: |
+  global:
+    http_config:
+      tls_config:
+        ca_file: /etc/ca.pem
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+`,
+			err:      fmt.Errorf("error validating Alertmanager config: the 'ca_file' option is not allowed in 'alertmanager_config.global.http_config.tls_config', local files can't be used"),
+			errField: "alertmanager_config.global.http_config.tls_config.ca_file",
+		},
+		{
+			name: "It is not valid if the config is too big",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+`,
+			limits: mockAlertManagerLimits{maxConfigSize: 10},
+			err:    fmt.Errorf("error validating Alertmanager config: configuration is too big: 108 bytes (limit: 10 bytes)"),
+		},
+		{
+			name: "It is not valid with too many templates",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+template_files:
+  "first.tpl": "first"
+  "second.tpl": "second"
+`,
+			limits:   mockAlertManagerLimits{maxTemplatesCount: 1},
+			err:      fmt.Errorf("error validating Alertmanager config: too many templates in the configuration: 2 (limit: 1)"),
+			errField: "template_files",
+		},
+		{
+			name: "It is not valid with a template too big",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+template_files:
+  "first.tpl": "first template"
+`,
+			limits:   mockAlertManagerLimits{maxTemplateSize: 5},
+			err:      fmt.Errorf("error validating Alertmanager config: template file 'first.tpl' is too big: 14 bytes (limit: 5 bytes)"),
+			errField: `template_files["first.tpl"]`,
 		},
 	}
 
-	am := &MultitenantAlertmanager{
-		store:  noopAlertStore{},
-		logger: util.Logger,
-	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			am := &MultitenantAlertmanager{
+				store:  noopAlertStore{},
+				limits: &tc.limits,
+				logger: util.Logger,
+			}
+
 			req := httptest.NewRequest(http.MethodPost, "http://alertmanager/api/v1/alerts", bytes.NewReader([]byte(tc.cfg)))
 			ctx := user.InjectOrgID(req.Context(), "testing")
 			w := httptest.NewRecorder()
@@ -144,9 +296,15 @@ template_files:
 				require.Equal(t, "", string(body))
 			} else {
 				require.Equal(t, http.StatusBadRequest, resp.StatusCode)
-				require.Equal(t, tc.err.Error()+"\n", string(body))
-			}
+				require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 
+				validationResp := configValidationResponse{}
+				require.NoError(t, json.Unmarshal(body, &validationResp))
+				require.Equal(t, "error", validationResp.Status)
+				require.Equal(t, tc.err.Error(), validationResp.Error)
+				require.NotEmpty(t, validationResp.Errors)
+				require.Equal(t, tc.errField, validationResp.Errors[0].Field)
+			}
 		})
 	}
 }
@@ -0,0 +1,281 @@
+package alertmanager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+
+	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
+)
+
+const (
+	// Fields of the config uploaded by the user, used to report the validation errors.
+	fieldAlertmanagerConfig = "alertmanager_config"
+	fieldTemplateFiles      = "template_files"
+)
+
+var (
+	// The types of the HTTP client config of the receivers, which can reference local files.
+	basicAuthType        = reflect.TypeOf(commoncfg.BasicAuth{})
+	httpClientConfigType = reflect.TypeOf(commoncfg.HTTPClientConfig{})
+	tlsConfigType        = reflect.TypeOf(commoncfg.TLSConfig{})
+)
+
+// ConfigValidationError is an error found validating a field of the Alertmanager config uploaded by a user.
+type ConfigValidationError struct {
+	// Field is the path of the invalid field, like "alertmanager_config.receivers[0].slack_configs[0].title".
+	// Empty if the error is not about a specific field.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ConfigValidationErrors is the list of errors found validating the Alertmanager config uploaded by a user.
+type ConfigValidationErrors []ConfigValidationError
+
+func (e ConfigValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Message)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ConfigValidationErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, ConfigValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// validateUserConfig validates the Alertmanager config uploaded by a user, returning ConfigValidationErrors
+// if it's not valid. The validation builds the notifiers of the receivers and executes their templates
+// against a sample alert, so that the config doesn't fail later when the user Alertmanager is created.
+// Partially copied from: https://github.com/prometheus/alertmanager/blob/8e861c646bf67599a1704fc843c6a94d519ce312/cli/check_config.go#L65-L96
+func validateUserConfig(logger log.Logger, cfg alerts.AlertConfigDesc, limits Limits) error {
+	var errs ConfigValidationErrors
+
+	// We don't have a valid use case for empty configurations. If a tenant does not have a
+	// configuration set and issue a request to the Alertmanager, we'll a) upload an empty
+	// config and b) immediately start an Alertmanager instance for them if a fallback
+	// configuration is provisioned.
+	if cfg.RawConfig == "" {
+		errs.add(fieldAlertmanagerConfig, "configuration provided is empty, if you'd like to remove your configuration please use the delete configuration endpoint")
+		return errs
+	}
+
+	if maxCount := limits.AlertmanagerMaxTemplatesCount(cfg.User); maxCount > 0 && len(cfg.Templates) > maxCount {
+		errs.add(fieldTemplateFiles, "too many templates in the configuration: %d (limit: %d)", len(cfg.Templates), maxCount)
+	}
+	if maxSize := limits.AlertmanagerMaxTemplateSize(cfg.User); maxSize > 0 {
+		for _, tmpl := range cfg.Templates {
+			if size := len(tmpl.Body); size > maxSize {
+				errs.add(templateFileField(tmpl.Filename), "template file '%s' is too big: %d bytes (limit: %d bytes)", tmpl.Filename, size, maxSize)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	amCfg, err := config.Load(cfg.RawConfig)
+	if err != nil {
+		errs.add(fieldAlertmanagerConfig, "%s", err.Error())
+		return errs
+	}
+
+	// The template paths are relative to the user templates directory, and can't point outside of it.
+	for i, t := range amCfg.Templates {
+		if filepath.IsAbs(t) || strings.HasPrefix(filepath.Clean(t), "..") {
+			errs.add(fmt.Sprintf("%s.templates[%d]", fieldAlertmanagerConfig, i), "template path '%s' is not allowed, only the paths of the uploaded template files can be used", t)
+		}
+	}
+
+	// The receivers can't read local files, like credentials and certificates of the Cortex deployment.
+	validateNoLocalFiles(reflect.ValueOf(amCfg.Global), fieldAlertmanagerConfig+".global", &errs)
+	for i, rcv := range amCfg.Receivers {
+		validateNoLocalFiles(reflect.ValueOf(rcv), fmt.Sprintf("%s.receivers[%d]", fieldAlertmanagerConfig, i), &errs)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// Create templates on disk in a temporary directory.
+	// Note: This means the validation will succeed if we can write to tmp but
+	// not to configured data dir, and on the flipside, it'll fail if we can't write
+	// to tmpDir. Ignoring both cases for now as they're ultra rare but will revisit if
+	// we see this in the wild.
+	tmpDir, err := ioutil.TempDir("", "validate-config")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, tmpl := range cfg.Templates {
+		_, err := createTemplateFile(tmpDir, cfg.User, tmpl.Filename, tmpl.Body)
+		if err != nil {
+			level.Error(logger).Log("msg", "unable to create template file", "err", err, "user", cfg.User)
+			errs.add(templateFileField(tmpl.Filename), "unable to create template file '%s'", tmpl.Filename)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	templateFiles := make([]string, len(amCfg.Templates))
+	for i, t := range amCfg.Templates {
+		templateFiles[i] = filepath.Join(tmpDir, "templates", cfg.User, t)
+	}
+
+	tmpl, err := template.FromGlobs(templateFiles...)
+	if err != nil {
+		errs.add(fieldTemplateFiles, "%s", err.Error())
+		return errs
+	}
+	tmpl.ExternalURL = &url.URL{Scheme: "http", Host: "localhost", Path: "/alertmanager"}
+
+	// Build the notifiers, like the user Alertmanager does when applying the config.
+	if _, err := buildIntegrationsMap(amCfg.Receivers, tmpl, logger); err != nil {
+		errs.add(fieldAlertmanagerConfig+".receivers", "%s", err.Error())
+		return errs
+	}
+
+	// Execute the templates of the receivers against a sample alert.
+	alert := sampleAlert()
+	for i, rcv := range amCfg.Receivers {
+		data := tmpl.Data(rcv.Name, model.LabelSet{model.AlertNameLabel: alert.Labels[model.AlertNameLabel]}, alert)
+		validateTemplates(reflect.ValueOf(rcv), fmt.Sprintf("%s.receivers[%d]", fieldAlertmanagerConfig, i), func(field, text string) {
+			if _, err := tmpl.ExecuteTextString(text, data); err != nil {
+				errs.add(field, "receiver '%s': unable to execute the template of '%s': %s", rcv.Name, field, err.Error())
+			}
+		})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// Note: Not validating the MultitenantAlertmanager.transformConfig function as that
+	// that function shouldn't break configuration. Only way it can fail is if the base
+	// autoWebhookURL itself is broken. In that case, I would argue, we should accept the config
+	// not reject it.
+
+	return nil
+}
+
+func templateFileField(filename string) string {
+	return fmt.Sprintf("%s[%q]", fieldTemplateFiles, filename)
+}
+
+// sampleAlert returns the alert used to execute the templates of the receivers.
+func sampleAlert() *types.Alert {
+	now := time.Now()
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{
+				model.AlertNameLabel: "SampleAlert",
+				"severity":           "critical",
+				"instance":           "localhost:9090",
+			},
+			Annotations: model.LabelSet{
+				"summary":     "Sample alert summary",
+				"description": "Sample alert description",
+			},
+			StartsAt:     now,
+			EndsAt:       now.Add(time.Hour),
+			GeneratorURL: "http://localhost/graph",
+		},
+		UpdatedAt: now,
+	}
+}
+
+// validateNoLocalFiles walks the config and reports the HTTP client options reading local files.
+func validateNoLocalFiles(v reflect.Value, field string, errs *ConfigValidationErrors) {
+	walkConfig(v, field, func(v reflect.Value, field string) {
+		var files map[string]string
+		switch v.Type() {
+		case basicAuthType:
+			files = map[string]string{"password_file": v.FieldByName("PasswordFile").String()}
+		case httpClientConfigType:
+			files = map[string]string{"bearer_token_file": v.FieldByName("BearerTokenFile").String()}
+		case tlsConfigType:
+			files = map[string]string{
+				"ca_file":   v.FieldByName("CAFile").String(),
+				"cert_file": v.FieldByName("CertFile").String(),
+				"key_file":  v.FieldByName("KeyFile").String(),
+			}
+		}
+
+		for name, value := range files {
+			if value != "" {
+				errs.add(field+"."+name, "the '%s' option is not allowed in '%s', local files can't be used", name, field)
+			}
+		}
+	}, nil)
+}
+
+// validateTemplates walks the config and calls the execute function for each string which is a template.
+func validateTemplates(v reflect.Value, field string, execute func(field, text string)) {
+	walkConfig(v, field, nil, func(field, s string) {
+		if strings.Contains(s, "{{") {
+			execute(field, s)
+		}
+	})
+}
+
+var stringType = reflect.TypeOf("")
+
+// walkConfig walks the exported fields of the config, calling onStruct for each struct and onString
+// for each value of type string. The secrets are not strings, so they're never passed to onString.
+func walkConfig(v reflect.Value, field string, onStruct func(v reflect.Value, field string), onString func(field, s string)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walkConfig(v.Elem(), field, onStruct, onString)
+		}
+
+	case reflect.Struct:
+		if onStruct != nil {
+			onStruct(v, field)
+		}
+
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+
+			name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+			if name == "" || name == "-" {
+				// Inlined structs keep the field path of their parent.
+				walkConfig(v.Field(i), field, onStruct, onString)
+				continue
+			}
+			walkConfig(v.Field(i), field+"."+name, onStruct, onString)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkConfig(v.Index(i), fmt.Sprintf("%s[%d]", field, i), onStruct, onString)
+		}
+
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walkConfig(iter.Value(), fmt.Sprintf("%s[%v]", field, iter.Key()), onStruct, onString)
+		}
+
+	case reflect.String:
+		if onString != nil && v.Type() == stringType {
+			onString(field, v.String())
+		}
+	}
+}
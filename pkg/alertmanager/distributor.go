@@ -0,0 +1,182 @@
+package alertmanager
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/httpgrpc/server"
+
+	"github.com/cortexproject/cortex/pkg/ring"
+	"github.com/cortexproject/cortex/pkg/tenant"
+	util_log "github.com/cortexproject/cortex/pkg/util/log"
+)
+
+// Distributor forwards the requests to the alertmanagers owning the tenant when sharding is enabled.
+type Distributor struct {
+	cfg              ClientConfig
+	alertmanagerRing ring.ReadRing
+	clientsPool      ClientsPool
+	logger           log.Logger
+}
+
+// NewDistributor constructs a new Distributor.
+func NewDistributor(cfg ClientConfig, alertmanagersRing ring.ReadRing, clientsPool ClientsPool, logger log.Logger) *Distributor {
+	return &Distributor{
+		cfg:              cfg,
+		alertmanagerRing: alertmanagersRing,
+		clientsPool:      clientsPool,
+		logger:           log.With(logger, "component", "AlertmanagerDistributor"),
+	}
+}
+
+// isWriteRequest returns whether the request changes the alerts, in which case it has to be sent
+// to all the alertmanagers owning the tenant. Silences are replicated as part of the state.
+func (d *Distributor) isWriteRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/alerts")
+}
+
+// ServeHTTP forwards the request to the alertmanagers owning the tenant.
+func (d *Distributor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := util_log.WithContext(r.Context(), d.logger)
+
+	userID, err := tenant.TenantID(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	req, err := server.HTTPRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if d.isWriteRequest(r) {
+		d.doWrite(r.Context(), w, userID, req, logger)
+	} else {
+		d.doRead(r.Context(), w, userID, req, logger)
+	}
+}
+
+// doWrite sends the request to all the alertmanagers owning the tenant, and succeeds once a quorum succeeded.
+func (d *Distributor) doWrite(ctx context.Context, w http.ResponseWriter, userID string, req *httpgrpc.HTTPRequest, logger log.Logger) {
+	var (
+		mtx          sync.Mutex
+		firstSuccess *httpgrpc.HTTPResponse
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.RemoteTimeout)
+
+	err := ring.DoBatch(ctx, RingOp, d.alertmanagerRing, []uint32{shardByUser(userID)}, func(desc ring.IngesterDesc, _ []int) error {
+		resp, err := d.doRequest(ctx, desc.GetAddr(), req)
+		if err != nil {
+			level.Warn(logger).Log("msg", "failed to send the request to alertmanager", "addr", desc.GetAddr(), "err", err)
+			return err
+		}
+
+		mtx.Lock()
+		if firstSuccess == nil {
+			firstSuccess = resp
+		}
+		mtx.Unlock()
+
+		return nil
+	}, cancel)
+
+	if err != nil {
+		// The cleanup function is not called if the ring can't be read.
+		cancel()
+		respondFromError(w, err, logger)
+		return
+	}
+
+	mtx.Lock()
+	resp := firstSuccess
+	mtx.Unlock()
+
+	respondFromHTTPGRPCResponse(w, resp)
+}
+
+// doRead sends the request to the alertmanagers owning the tenant in random order, until one succeeds.
+func (d *Distributor) doRead(ctx context.Context, w http.ResponseWriter, userID string, req *httpgrpc.HTTPRequest, logger log.Logger) {
+	replicationSet, err := d.alertmanagerRing.Get(shardByUser(userID), RingOp, nil, nil, nil)
+	if err != nil {
+		respondFromError(w, err, logger)
+		return
+	}
+
+	addrs := replicationSet.GetAddresses()
+	rand.Shuffle(len(addrs), func(i, j int) {
+		addrs[i], addrs[j] = addrs[j], addrs[i]
+	})
+
+	var lastErr error
+	for _, addr := range addrs {
+		resp, err := d.doReadFrom(ctx, addr, req)
+		if err == nil {
+			respondFromHTTPGRPCResponse(w, resp)
+			return
+		}
+
+		level.Warn(logger).Log("msg", "failed to send the request to alertmanager", "addr", addr, "err", err)
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no alertmanager owns the tenant")
+	}
+	respondFromError(w, lastErr, logger)
+}
+
+func (d *Distributor) doReadFrom(ctx context.Context, addr string, req *httpgrpc.HTTPRequest) (*httpgrpc.HTTPResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.RemoteTimeout)
+	defer cancel()
+
+	return d.doRequest(ctx, addr, req)
+}
+
+func (d *Distributor) doRequest(ctx context.Context, addr string, req *httpgrpc.HTTPRequest) (*httpgrpc.HTTPResponse, error) {
+	c, err := d.clientsPool.GetClientFor(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get alertmanager client from pool (alertmanager address: %s)", addr)
+	}
+
+	resp, err := c.HandleRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server returns an error for 5xx responses, but the clients could be mocked.
+	if resp.Code/100 == 5 {
+		return nil, httpgrpc.ErrorFromHTTPResponse(resp)
+	}
+
+	return resp, nil
+}
+
+func respondFromError(w http.ResponseWriter, err error, logger log.Logger) {
+	if resp, ok := httpgrpc.HTTPResponseFromError(errors.Cause(err)); ok {
+		respondFromHTTPGRPCResponse(w, resp)
+		return
+	}
+
+	level.Error(logger).Log("msg", "failed to forward the request to the alertmanagers", "err", err)
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func respondFromHTTPGRPCResponse(w http.ResponseWriter, resp *httpgrpc.HTTPResponse) {
+	for _, h := range resp.Headers {
+		for _, v := range h.Values {
+			w.Header().Add(h.Key, v)
+		}
+	}
+	w.WriteHeader(int(resp.Code))
+	w.Write(resp.Body) //nolint:errcheck
+}
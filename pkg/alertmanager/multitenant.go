@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -18,12 +19,18 @@ import (
 	"github.com/go-kit/kit/log/level"
 	"github.com/pkg/errors"
 	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
 	amconfig "github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/httpgrpc/server"
+	"github.com/weaveworks/common/user"
 
+	"github.com/cortexproject/cortex/pkg/alertmanager/alertmanagerpb"
 	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
 	"github.com/cortexproject/cortex/pkg/ring"
+	"github.com/cortexproject/cortex/pkg/ring/client"
 	"github.com/cortexproject/cortex/pkg/ring/kv"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
@@ -75,6 +82,8 @@ const (
 
 var (
 	statusTemplate *template.Template
+
+	errInvalidPersistInterval = errors.New("invalid alertmanager persist interval, must be greater than zero")
 )
 
 func init() {
@@ -95,6 +104,8 @@ type MultitenantAlertmanagerConfig struct {
 	ExternalURL  flagext.URLValue `yaml:"external_url"`
 	PollInterval time.Duration    `yaml:"poll_interval"`
 
+	PersistInterval time.Duration `yaml:"persist_interval"`
+
 	DeprecatedClusterBindAddr      string              `yaml:"cluster_bind_address"`
 	DeprecatedClusterAdvertiseAddr string              `yaml:"cluster_advertise_address"`
 	DeprecatedPeers                flagext.StringSlice `yaml:"peers"`
@@ -104,6 +115,8 @@ type MultitenantAlertmanagerConfig struct {
 	ShardingEnabled bool       `yaml:"sharding_enabled"`
 	ShardingRing    RingConfig `yaml:"sharding_ring"`
 
+	AlertmanagerClient ClientConfig `yaml:"alertmanager_client"`
+
 	FallbackConfigFile string `yaml:"fallback_config_file"`
 	AutoWebhookRoot    string `yaml:"auto_webhook_root"`
 
@@ -113,6 +126,18 @@ type MultitenantAlertmanagerConfig struct {
 	EnableAPI bool `yaml:"enable_api"`
 }
 
+// Limits defines limits used by the Alertmanager.
+type Limits interface {
+	// AlertmanagerMaxConfigSize returns the maximum size, in bytes, of the configuration a tenant can upload.
+	AlertmanagerMaxConfigSize(tenant string) int
+
+	// AlertmanagerMaxTemplatesCount returns the maximum number of templates a tenant can upload.
+	AlertmanagerMaxTemplatesCount(tenant string) int
+
+	// AlertmanagerMaxTemplateSize returns the maximum size, in bytes, of each template a tenant can upload.
+	AlertmanagerMaxTemplateSize(tenant string) int
+}
+
 type ClusterConfig struct {
 	ListenAddr       string                 `yaml:"listen_address"`
 	AdvertiseAddr    string                 `yaml:"advertise_address"`
@@ -137,6 +162,7 @@ func (cfg *MultitenantAlertmanagerConfig) RegisterFlags(f *flag.FlagSet) {
 	f.StringVar(&cfg.FallbackConfigFile, "alertmanager.configs.fallback", "", "Filename of fallback config to use if none specified for instance.")
 	f.StringVar(&cfg.AutoWebhookRoot, "alertmanager.configs.auto-webhook-root", "", "Root of URL to generate if config is "+autoWebhookURL)
 	f.DurationVar(&cfg.PollInterval, "alertmanager.configs.poll-interval", 15*time.Second, "How frequently to poll Cortex configs")
+	f.DurationVar(&cfg.PersistInterval, "alertmanager.persist-interval", 15*time.Minute, "The interval between persisting the current alertmanager state (notification log and silences) of each tenant to the storage. The state is restored from the storage when an alertmanager of the tenant starts and the state can't be read from the other alertmanagers. Supported only by the azure, gcs and s3 storage.")
 
 	// Flags prefixed with `cluster` are deprecated in favor of their `alertmanager` prefix equivalent.
 	// TODO: New flags introduced in Cortex 1.7, remove old ones in Cortex 1.9
@@ -149,6 +175,8 @@ func (cfg *MultitenantAlertmanagerConfig) RegisterFlags(f *flag.FlagSet) {
 
 	f.BoolVar(&cfg.ShardingEnabled, "alertmanager.sharding-enabled", false, "Shard tenants across multiple alertmanager instances.")
 
+	cfg.AlertmanagerClient.RegisterFlagsWithPrefix("alertmanager.alertmanager-client", f)
+
 	cfg.ShardingRing.RegisterFlags(f)
 	cfg.Store.RegisterFlags(f)
 	cfg.Cluster.RegisterFlags(f)
@@ -156,7 +184,7 @@ func (cfg *MultitenantAlertmanagerConfig) RegisterFlags(f *flag.FlagSet) {
 
 func (cfg *ClusterConfig) RegisterFlags(f *flag.FlagSet) {
 	prefix := "alertmanager.cluster."
-	f.StringVar(&cfg.ListenAddr, prefix+"listen-address", defaultClusterAddr, "Listen address and port for the cluster. Not specifying this flag disables high-availability mode.")
+	f.StringVar(&cfg.ListenAddr, prefix+"listen-address", defaultClusterAddr, "Listen address and port for the cluster. Not specifying this flag disables high-availability mode. The cluster is not used when sharding is enabled.")
 	f.StringVar(&cfg.AdvertiseAddr, prefix+"advertise-address", "", "Explicit address or hostname to advertise in cluster.")
 	f.Var(&cfg.Peers, prefix+"peers", "Comma-separated list of initial peers.")
 	f.DurationVar(&cfg.PeerTimeout, prefix+"peer-timeout", defaultPeerTimeout, "Time to wait between peers to send notifications.")
@@ -196,6 +224,9 @@ func (cfg *MultitenantAlertmanagerConfig) Validate() error {
 	if err := cfg.Store.Validate(); err != nil {
 		return errors.Wrap(err, "invalid storage config")
 	}
+	if cfg.PersistInterval <= 0 {
+		return errInvalidPersistInterval
+	}
 	return nil
 }
 
@@ -233,11 +264,20 @@ type MultitenantAlertmanager struct {
 	ringLifecycler *ring.BasicLifecycler
 	ring           *ring.Ring
 
+	// Used to replicate the state of the tenants and route the API requests
+	// to the alertmanagers owning the tenant, when sharding is enabled.
+	alertmanagerClientsPool ClientsPool
+	distributor             *Distributor
+
+	// Serves the API requests forwarded by the other alertmanagers.
+	grpcServer *server.Server
+
 	// Subservices manager (ring, lifecycler)
 	subservices        *services.Manager
 	subservicesWatcher *services.FailureWatcher
 
-	store AlertStore
+	store  AlertStore
+	limits Limits
 
 	// The fallback config is stored as a string and parsed every time it's needed
 	// because we mutate the parsed results and don't want those changes to take
@@ -265,7 +305,7 @@ type MultitenantAlertmanager struct {
 }
 
 // NewMultitenantAlertmanager creates a new MultitenantAlertmanager.
-func NewMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, logger log.Logger, registerer prometheus.Registerer) (*MultitenantAlertmanager, error) {
+func NewMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, limits Limits, logger log.Logger, registerer prometheus.Registerer) (*MultitenantAlertmanager, error) {
 	err := os.MkdirAll(cfg.DataDir, 0777)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create Alertmanager data directory %q: %s", cfg.DataDir, err)
@@ -290,7 +330,11 @@ func NewMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, logger log.L
 	cfg.Cluster.SupportDeprecatedFlagset(cfg, logger)
 
 	var peer *cluster.Peer
-	if cfg.Cluster.ListenAddr != "" {
+	// When sharding is enabled, the state of each tenant is replicated to the alertmanagers
+	// owning the tenant via gRPC instead of being gossiped to the whole cluster.
+	if cfg.ShardingEnabled {
+		level.Info(logger).Log("msg", "sharding is enabled, the alertmanager gossip cluster is disabled")
+	} else if cfg.Cluster.ListenAddr != "" {
 		peer, err = cluster.Create(
 			log.With(logger, "component", "cluster"),
 			registerer,
@@ -331,10 +375,10 @@ func NewMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, logger log.L
 		}
 	}
 
-	return createMultitenantAlertmanager(cfg, fallbackConfig, peer, store, ringStore, logger, registerer)
+	return createMultitenantAlertmanager(cfg, fallbackConfig, peer, store, ringStore, limits, logger, registerer)
 }
 
-func createMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, fallbackConfig []byte, peer *cluster.Peer, store AlertStore, ringStore kv.Client, logger log.Logger, registerer prometheus.Registerer) (*MultitenantAlertmanager, error) {
+func createMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, fallbackConfig []byte, peer *cluster.Peer, store AlertStore, ringStore kv.Client, limits Limits, logger log.Logger, registerer prometheus.Registerer) (*MultitenantAlertmanager, error) {
 	am := &MultitenantAlertmanager{
 		cfg:                 cfg,
 		fallbackConfig:      string(fallbackConfig),
@@ -344,6 +388,7 @@ func createMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, fallbackC
 		multitenantMetrics:  newMultitenantAlertmanagerMetrics(registerer),
 		peer:                peer,
 		store:               store,
+		limits:              limits,
 		logger:              log.With(logger, "component", "MultiTenantAlertmanager"),
 		registry:            registerer,
 		ringCheckErrors: promauto.With(registerer).NewCounter(prometheus.CounterOpts{
@@ -399,8 +444,13 @@ func createMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, fallbackC
 		if am.registry != nil {
 			am.registry.MustRegister(am.ring)
 		}
+
+		am.alertmanagerClientsPool = newAlertmanagerClientsPool(client.NewRingServiceDiscovery(am.ring), cfg.AlertmanagerClient, logger, am.registry)
+		am.distributor = NewDistributor(cfg.AlertmanagerClient, am.ring, am.alertmanagerClientsPool, am.logger)
 	}
 
+	am.grpcServer = server.NewServer(http.HandlerFunc(am.serveRequest))
+
 	if registerer != nil {
 		registerer.MustRegister(am.alertmanagerMetrics)
 	}
@@ -576,11 +626,7 @@ func (am *MultitenantAlertmanager) loadAlertmanagerConfigs(ctx context.Context)
 }
 
 func (am *MultitenantAlertmanager) isConfigOwned(userID string) (bool, error) {
-	ringHasher := fnv.New32a()
-	// Hasher never returns err.
-	_, _ = ringHasher.Write([]byte(userID))
-
-	alertmanagers, err := am.ring.Get(ringHasher.Sum32(), RingOp, nil, nil, nil)
+	alertmanagers, err := am.ring.Get(shardByUser(userID), RingOp, nil, nil, nil)
 	if err != nil {
 		return false, errors.Wrap(err, "error reading ring to verify config ownership")
 	}
@@ -588,6 +634,14 @@ func (am *MultitenantAlertmanager) isConfigOwned(userID string) (bool, error) {
 	return alertmanagers.Includes(am.ringLifecycler.GetInstanceAddr()), nil
 }
 
+// shardByUser returns the ring token of the tenant.
+func shardByUser(userID string) uint32 {
+	ringHasher := fnv.New32a()
+	// Hasher never returns err.
+	_, _ = ringHasher.Write([]byte(userID))
+	return ringHasher.Sum32()
+}
+
 func (am *MultitenantAlertmanager) syncConfigs(cfgs map[string]alerts.AlertConfigDesc) {
 	level.Debug(am.logger).Log("msg", "adding configurations", "num_configs", len(cfgs))
 	for user, cfg := range cfgs {
@@ -711,14 +765,22 @@ func (am *MultitenantAlertmanager) setConfig(cfg alerts.AlertConfigDesc) error {
 
 func (am *MultitenantAlertmanager) newAlertmanager(userID string, amConfig *amconfig.Config, rawCfg string) (*Alertmanager, error) {
 	reg := prometheus.NewRegistry()
+
+	// The state is persisted only if the storage supports it.
+	stateStore, _ := am.store.(AlertStateStore)
+
 	newAM, err := New(&Config{
-		UserID:      userID,
-		DataDir:     am.cfg.DataDir,
-		Logger:      util.Logger,
-		Peer:        am.peer,
-		PeerTimeout: am.cfg.Cluster.PeerTimeout,
-		Retention:   am.cfg.Retention,
-		ExternalURL: am.cfg.ExternalURL.URL,
+		UserID:          userID,
+		DataDir:         am.cfg.DataDir,
+		Logger:          util.Logger,
+		Peer:            am.peer,
+		PeerTimeout:     am.cfg.Cluster.PeerTimeout,
+		Retention:       am.cfg.Retention,
+		ExternalURL:     am.cfg.ExternalURL.URL,
+		ShardingEnabled: am.cfg.ShardingEnabled,
+		Replicator:      am,
+		Store:           stateStore,
+		PersistInterval: am.cfg.PersistInterval,
 	}, reg)
 	if err != nil {
 		return nil, fmt.Errorf("unable to start Alertmanager for user %v: %v", userID, err)
@@ -732,13 +794,34 @@ func (am *MultitenantAlertmanager) newAlertmanager(userID string, amConfig *amco
 	return newAM, nil
 }
 
-// ServeHTTP serves the Alertmanager's web UI and API.
+// ServeHTTP serves the Alertmanager's web UI and API. When sharding is enabled, the
+// requests are forwarded to the alertmanagers owning the tenant.
 func (am *MultitenantAlertmanager) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	if am.State() != services.Running {
 		http.Error(w, "Alertmanager not ready", http.StatusServiceUnavailable)
 		return
 	}
 
+	if am.cfg.ShardingEnabled {
+		am.distributor.ServeHTTP(w, req)
+		return
+	}
+
+	am.serveRequest(w, req)
+}
+
+// HandleRequest implements the gRPC Alertmanager service, serving a request forwarded by another alertmanager.
+func (am *MultitenantAlertmanager) HandleRequest(ctx context.Context, in *httpgrpc.HTTPRequest) (*httpgrpc.HTTPResponse, error) {
+	return am.grpcServer.Handle(ctx, in)
+}
+
+// serveRequest serves a request with the Alertmanager of the tenant running in this instance.
+func (am *MultitenantAlertmanager) serveRequest(w http.ResponseWriter, req *http.Request) {
+	if am.State() != services.Running {
+		http.Error(w, "Alertmanager not ready", http.StatusServiceUnavailable)
+		return
+	}
+
 	userID, err := tenant.TenantID(req.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
@@ -809,12 +892,214 @@ type StatusHandler struct {
 
 // ServeHTTP serves the status of the alertmanager.
 func (s StatusHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if s.am.peer == nil {
+		http.Error(w, "the alertmanager gossip cluster is not enabled", http.StatusNotFound)
+		return
+	}
+
 	err := statusTemplate.Execute(w, s.am.peer.Info())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
+// ReplicateStateForUser implements Replicator, sending the partial state to the other alertmanagers owning the tenant.
+func (am *MultitenantAlertmanager) ReplicateStateForUser(ctx context.Context, userID string, part *clusterpb.Part) error {
+	level.Debug(am.logger).Log("msg", "replicating state", "user", userID, "key", part.Key)
+
+	ctx, cancel := context.WithTimeout(user.InjectOrgID(ctx, userID), am.cfg.AlertmanagerClient.RemoteTimeout)
+
+	selfAddress := am.ringLifecycler.GetInstanceAddr()
+	err := ring.DoBatch(ctx, RingOp, am.ring, []uint32{shardByUser(userID)}, func(desc ring.IngesterDesc, _ []int) error {
+		if desc.GetAddr() == selfAddress {
+			return nil
+		}
+
+		c, err := am.alertmanagerClientsPool.GetClientFor(desc.GetAddr())
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.UpdateState(ctx, part)
+		if err != nil {
+			return err
+		}
+
+		switch resp.Status {
+		case alertmanagerpb.MERGE_ERROR:
+			level.Error(am.logger).Log("msg", "state replication failed", "user", userID, "key", part.Key, "addr", desc.GetAddr(), "err", resp.Error)
+		case alertmanagerpb.USER_NOT_FOUND:
+			level.Debug(am.logger).Log("msg", "user not found while trying to replicate state", "user", userID, "key", part.Key, "addr", desc.GetAddr())
+		}
+		return nil
+	}, cancel)
+	if err != nil {
+		// The cleanup function is not called if the ring can't be read.
+		cancel()
+	}
+	return err
+}
+
+// ReadFullStateForUser implements Replicator, reading the full state from the other alertmanagers owning the tenant.
+// The alertmanagers not running the Alertmanager of the tenant yet are ignored.
+func (am *MultitenantAlertmanager) ReadFullStateForUser(ctx context.Context, userID string) ([]*clusterpb.FullState, error) {
+	replicationSet, err := am.ring.Get(shardByUser(userID), RingOp, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(user.InjectOrgID(ctx, userID), am.cfg.AlertmanagerClient.RemoteTimeout)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		mtx     sync.Mutex
+		results []*clusterpb.FullState
+		lastErr error
+		queried int
+	)
+
+	selfAddress := am.ringLifecycler.GetInstanceAddr()
+	for _, desc := range replicationSet.Ingesters {
+		addr := desc.GetAddr()
+		if addr == selfAddress {
+			continue
+		}
+
+		queried++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			state, err := am.readStateFrom(ctx, addr)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			if err != nil {
+				level.Warn(am.logger).Log("msg", "failed to read state from another alertmanager", "user", userID, "addr", addr, "err", err)
+				lastErr = err
+				return
+			}
+			if state != nil {
+				results = append(results, state)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Fail only if all the other alertmanagers failed, so that the state is
+	// read from any of them.
+	if queried > 0 && len(results) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return results, nil
+}
+
+// readStateFrom reads the full state of the tenant in the context from the alertmanager at
+// the given address. It returns nil if the alertmanager is not running the tenant Alertmanager.
+func (am *MultitenantAlertmanager) readStateFrom(ctx context.Context, addr string) (*clusterpb.FullState, error) {
+	c, err := am.alertmanagerClientsPool.GetClientFor(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.ReadState(ctx, &alertmanagerpb.ReadStateRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status {
+	case alertmanagerpb.READ_OK:
+		return resp.State, nil
+	case alertmanagerpb.READ_USER_NOT_FOUND:
+		return nil, nil
+	default:
+		return nil, errors.New(resp.Error)
+	}
+}
+
+// GetPositionForUser implements Replicator.
+func (am *MultitenantAlertmanager) GetPositionForUser(userID string) int {
+	replicationSet, err := am.ring.Get(shardByUser(userID), RingOp, nil, nil, nil)
+	if err != nil {
+		level.Error(am.logger).Log("msg", "unable to read the ring to get the position of the alertmanager", "user", userID, "err", err)
+		return 0
+	}
+
+	// The addresses are sorted so that all the replicas agree on their positions.
+	addrs := replicationSet.GetAddresses()
+	sort.Strings(addrs)
+
+	selfAddress := am.ringLifecycler.GetInstanceAddr()
+	for i, addr := range addrs {
+		if addr == selfAddress {
+			return i
+		}
+	}
+	return 0
+}
+
+// UpdateState implements the gRPC Alertmanager service, merging a partial state sent by another alertmanager.
+func (am *MultitenantAlertmanager) UpdateState(ctx context.Context, part *clusterpb.Part) (*alertmanagerpb.UpdateStateResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	am.alertmanagersMtx.Lock()
+	userAM, ok := am.alertmanagers[userID]
+	am.alertmanagersMtx.Unlock()
+
+	if !ok {
+		// The state could be sent to an alertmanager which doesn't run the Alertmanager of the tenant yet.
+		return &alertmanagerpb.UpdateStateResponse{
+			Status: alertmanagerpb.USER_NOT_FOUND,
+			Error:  "alertmanager for this user does not exist",
+		}, nil
+	}
+
+	if err := userAM.mergePartialState(part); err != nil {
+		return &alertmanagerpb.UpdateStateResponse{
+			Status: alertmanagerpb.MERGE_ERROR,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	return &alertmanagerpb.UpdateStateResponse{Status: alertmanagerpb.OK}, nil
+}
+
+// ReadState implements the gRPC Alertmanager service, returning the full state of the tenant to another alertmanager.
+func (am *MultitenantAlertmanager) ReadState(ctx context.Context, _ *alertmanagerpb.ReadStateRequest) (*alertmanagerpb.ReadStateResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	am.alertmanagersMtx.Lock()
+	userAM, ok := am.alertmanagers[userID]
+	am.alertmanagersMtx.Unlock()
+
+	if !ok || !userAM.IsActive() {
+		return &alertmanagerpb.ReadStateResponse{
+			Status: alertmanagerpb.READ_USER_NOT_FOUND,
+			Error:  "alertmanager for this user does not exist",
+		}, nil
+	}
+
+	state, err := userAM.getFullState()
+	if err != nil {
+		return &alertmanagerpb.ReadStateResponse{
+			Status: alertmanagerpb.READ_ERROR,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	return &alertmanagerpb.ReadStateResponse{
+		Status: alertmanagerpb.READ_OK,
+		State:  state,
+	}, nil
+}
+
 func createTemplateFile(dataDir, userID, fn, content string) (bool, error) {
 	if fn != filepath.Base(fn) {
 		return false, fmt.Errorf("template file name '%s' is not not valid", fn)
@@ -10,16 +10,21 @@ import (
 	"net/http/pprof"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-kit/kit/log"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/user"
+	"google.golang.org/grpc"
 
+	"github.com/cortexproject/cortex/pkg/alertmanager/alertmanagerpb"
 	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
 	"github.com/cortexproject/cortex/pkg/ring"
 	"github.com/cortexproject/cortex/pkg/ring/kv/consul"
@@ -75,6 +80,24 @@ func (m *mockAlertStore) DeleteAlertConfig(_ context.Context, _ string) error {
 	return fmt.Errorf("not implemented")
 }
 
+type mockAlertManagerLimits struct {
+	maxConfigSize     int
+	maxTemplatesCount int
+	maxTemplateSize   int
+}
+
+func (m *mockAlertManagerLimits) AlertmanagerMaxConfigSize(tenant string) int {
+	return m.maxConfigSize
+}
+
+func (m *mockAlertManagerLimits) AlertmanagerMaxTemplatesCount(tenant string) int {
+	return m.maxTemplatesCount
+}
+
+func (m *mockAlertManagerLimits) AlertmanagerMaxTemplateSize(tenant string) int {
+	return m.maxTemplateSize
+}
+
 func mockAlertmanagerConfig(t *testing.T) *MultitenantAlertmanagerConfig {
 	t.Helper()
 
@@ -120,7 +143,7 @@ func TestLoadAllConfigs(t *testing.T) {
 
 	reg := prometheus.NewPedanticRegistry()
 	cfg := mockAlertmanagerConfig(t)
-	am, err := createMultitenantAlertmanager(cfg, nil, nil, mockStore, nil, log.NewNopLogger(), reg)
+	am, err := createMultitenantAlertmanager(cfg, nil, nil, mockStore, nil, &mockAlertManagerLimits{}, log.NewNopLogger(), reg)
 	require.NoError(t, err)
 
 	// Ensure the configs are synced correctly
@@ -225,7 +248,7 @@ func TestAlertmanager_NoExternalURL(t *testing.T) {
 
 	// Create the Multitenant Alertmanager.
 	reg := prometheus.NewPedanticRegistry()
-	_, err := NewMultitenantAlertmanager(amConfig, log.NewNopLogger(), reg)
+	_, err := NewMultitenantAlertmanager(amConfig, &mockAlertManagerLimits{}, log.NewNopLogger(), reg)
 
 	require.EqualError(t, err, "unable to create Alertmanager because the external URL has not been configured")
 }
@@ -244,7 +267,7 @@ func TestAlertmanager_ServeHTTP(t *testing.T) {
 
 	// Create the Multitenant Alertmanager.
 	reg := prometheus.NewPedanticRegistry()
-	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, log.NewNopLogger(), reg)
+	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, &mockAlertManagerLimits{}, log.NewNopLogger(), reg)
 	require.NoError(t, err)
 
 	require.NoError(t, services.StartAndAwaitRunning(context.Background(), am))
@@ -354,7 +377,7 @@ receivers:
 	amConfig.ExternalURL = externalURL
 
 	// Create the Multitenant Alertmanager.
-	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, log.NewNopLogger(), nil)
+	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, &mockAlertManagerLimits{}, log.NewNopLogger(), nil)
 	require.NoError(t, err)
 	am.fallbackConfig = fallbackCfg
 
@@ -450,7 +473,7 @@ func TestAlertmanager_InitialSyncWithSharding(t *testing.T) {
 				}))
 			}
 
-			am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, log.NewNopLogger(), nil)
+			am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), nil)
 			require.NoError(t, err)
 			defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
 
@@ -575,7 +598,7 @@ func TestAlertmanager_PerTenantSharding(t *testing.T) {
 				}
 
 				reg := prometheus.NewPedanticRegistry()
-				am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, log.NewNopLogger(), reg)
+				am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), reg)
 				require.NoError(t, err)
 				defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
 
@@ -733,7 +756,7 @@ func TestAlertmanager_SyncOnRingTopologyChanges(t *testing.T) {
 			}
 
 			reg := prometheus.NewPedanticRegistry()
-			am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, log.NewNopLogger(), reg)
+			am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), reg)
 			require.NoError(t, err)
 
 			require.NoError(t, ringStore.CAS(ctx, RingKey, func(in interface{}) (interface{}, bool, error) {
@@ -788,7 +811,7 @@ func TestAlertmanager_RingLifecyclerShouldAutoForgetUnhealthyInstances(t *testin
 		configs: map[string]alerts.AlertConfigDesc{},
 	}
 
-	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, log.NewNopLogger(), nil)
+	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), nil)
 	require.NoError(t, err)
 	require.NoError(t, services.StartAndAwaitRunning(ctx, am))
 	defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
@@ -823,7 +846,7 @@ func TestAlertmanager_InitialSyncFailureWithSharding(t *testing.T) {
 		WithListErr: fmt.Errorf("a fetch list failure"),
 	}
 
-	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, log.NewNopLogger(), nil)
+	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), nil)
 	require.NoError(t, err)
 	defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
 
@@ -834,3 +857,176 @@ func TestAlertmanager_InitialSyncFailureWithSharding(t *testing.T) {
 	require.False(t, am.ringLifecycler.IsRegistered())
 	require.NotNil(t, am.ring)
 }
+
+func TestAlertmanager_StateReplicationWithSharding(t *testing.T) {
+	const (
+		instances         = 3
+		replicationFactor = 3
+		userID            = "user-1"
+	)
+
+	ctx := context.Background()
+	ringStore := consul.NewInMemoryClient(ring.GetCodec())
+	mockStore := &mockAlertStore{
+		configs: map[string]alerts.AlertConfigDesc{},
+	}
+	clientPool := newPassthroughAlertmanagerClientPool()
+
+	var (
+		amInstances []*MultitenantAlertmanager
+		instanceIDs []string
+	)
+
+	for i := 1; i <= instances; i++ {
+		instanceID := fmt.Sprintf("alertmanager-%d", i)
+		instanceAddr := fmt.Sprintf("127.0.0.%d", i)
+
+		amConfig := mockAlertmanagerConfig(t)
+		amConfig.ShardingEnabled = true
+		amConfig.ShardingRing.ReplicationFactor = replicationFactor
+		amConfig.ShardingRing.InstanceID = instanceID
+		amConfig.ShardingRing.InstanceAddr = instanceAddr
+		// Do not check the ring topology changes or poll in an interval in this test (we explicitly sync alertmanagers).
+		amConfig.PollInterval = time.Hour
+		amConfig.ShardingRing.RingCheckPeriod = time.Hour
+
+		am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), nil)
+		require.NoError(t, err)
+
+		// The alertmanagers communicate with each other without gRPC.
+		am.alertmanagerClientsPool = clientPool
+		am.distributor.clientsPool = clientPool
+		clientPool.setServer(am.ringLifecycler.GetInstanceAddr(), am)
+
+		require.NoError(t, services.StartAndAwaitRunning(ctx, am))
+		defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
+
+		amInstances = append(amInstances, am)
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+
+	// Wait until the ring has settled.
+	{
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		for _, am := range amInstances {
+			for _, id := range instanceIDs {
+				require.NoError(t, ring.WaitInstanceState(ctx, am.ring, id, ring.ACTIVE))
+			}
+		}
+	}
+
+	mockStore.configs[userID] = alerts.AlertConfigDesc{
+		User:      userID,
+		RawConfig: simpleConfigOne,
+		Templates: []*alerts.TemplateDesc{},
+	}
+	for _, am := range amInstances {
+		require.NoError(t, am.loadAndSyncConfigs(ctx, reasonPeriodic))
+		require.Len(t, am.alertmanagers, 1)
+	}
+
+	// Each replica should have a different position.
+	var positions []int
+	for _, am := range amInstances {
+		positions = append(positions, am.GetPositionForUser(userID))
+	}
+	assert.ElementsMatch(t, []int{0, 1, 2}, positions)
+
+	// Create a silence through the first instance.
+	silence := fmt.Sprintf(`{"matchers":[{"name":"instance","value":"prometheus-one","isRegex":false}],"startsAt":"%s","endsAt":"%s","createdBy":"test","comment":"test"}`,
+		time.Now().Format(time.RFC3339), time.Now().Add(time.Hour).Format(time.RFC3339))
+	req := httptest.NewRequest("POST", "http://localhost/api/prom/api/v2/silences", strings.NewReader(silence))
+	req.Header.Set("Content-Type", "application/json")
+	reqCtx := user.InjectOrgID(req.Context(), userID)
+
+	w := httptest.NewRecorder()
+	amInstances[0].ServeHTTP(w, req.WithContext(reqCtx))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The silence should be replicated to all the replicas.
+	for _, am := range amInstances {
+		userAM := am.alertmanagers[userID]
+		test.Poll(t, 5*time.Second, 1, func() interface{} {
+			silences, _, err := userAM.silences.Query()
+			require.NoError(t, err)
+			return len(silences)
+		})
+	}
+
+	// The silence should be returned by any instance.
+	for _, am := range amInstances {
+		req := httptest.NewRequest("GET", "http://localhost/api/prom/api/v2/silences", nil)
+		w := httptest.NewRecorder()
+		am.ServeHTTP(w, req.WithContext(reqCtx))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "prometheus-one")
+	}
+
+	// The state of the tenant should be read from the other replicas.
+	states, err := amInstances[0].ReadFullStateForUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, states, instances-1)
+
+	// The state of an unknown tenant should be reported as not found.
+	resp, err := amInstances[0].ReadState(user.InjectOrgID(ctx, "unknown"), &alertmanagerpb.ReadStateRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, alertmanagerpb.READ_USER_NOT_FOUND, resp.Status)
+
+	updateResp, err := amInstances[0].UpdateState(user.InjectOrgID(ctx, "unknown"), &clusterpb.Part{Key: "sil:unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, alertmanagerpb.USER_NOT_FOUND, updateResp.Status)
+
+	updateResp, err = amInstances[0].UpdateState(user.InjectOrgID(ctx, userID), &clusterpb.Part{Key: "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, alertmanagerpb.MERGE_ERROR, updateResp.Status)
+}
+
+// passthroughAlertmanagerClientPool returns clients calling the alertmanagers directly, without gRPC.
+type passthroughAlertmanagerClientPool struct {
+	mtx     sync.Mutex
+	servers map[string]alertmanagerpb.AlertmanagerServer
+}
+
+func newPassthroughAlertmanagerClientPool() *passthroughAlertmanagerClientPool {
+	return &passthroughAlertmanagerClientPool{servers: map[string]alertmanagerpb.AlertmanagerServer{}}
+}
+
+func (p *passthroughAlertmanagerClientPool) setServer(addr string, server alertmanagerpb.AlertmanagerServer) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.servers[addr] = server
+}
+
+func (p *passthroughAlertmanagerClientPool) GetClientFor(addr string) (Client, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	server, ok := p.servers[addr]
+	if !ok {
+		return nil, fmt.Errorf("alertmanager %s not found", addr)
+	}
+	return &passthroughAlertmanagerClient{server: server, addr: addr}, nil
+}
+
+type passthroughAlertmanagerClient struct {
+	server alertmanagerpb.AlertmanagerServer
+	addr   string
+}
+
+func (c *passthroughAlertmanagerClient) HandleRequest(ctx context.Context, in *httpgrpc.HTTPRequest, _ ...grpc.CallOption) (*httpgrpc.HTTPResponse, error) {
+	return c.server.HandleRequest(ctx, in)
+}
+
+func (c *passthroughAlertmanagerClient) UpdateState(ctx context.Context, in *clusterpb.Part, _ ...grpc.CallOption) (*alertmanagerpb.UpdateStateResponse, error) {
+	return c.server.UpdateState(ctx, in)
+}
+
+func (c *passthroughAlertmanagerClient) ReadState(ctx context.Context, in *alertmanagerpb.ReadStateRequest, _ ...grpc.CallOption) (*alertmanagerpb.ReadStateResponse, error) {
+	return c.server.ReadState(ctx, in)
+}
+
+func (c *passthroughAlertmanagerClient) RemoteAddress() string {
+	return c.addr
+}
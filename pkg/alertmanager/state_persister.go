@@ -0,0 +1,111 @@
+package alertmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/cortexproject/cortex/pkg/util/services"
+)
+
+// Maximum time spent persisting or restoring the state of a tenant.
+const defaultPersistTimeout = 30 * time.Second
+
+// persistableState is the state of the Alertmanager of a tenant which can be persisted.
+type persistableState interface {
+	// getFullState returns the current full state.
+	getFullState() (*clusterpb.FullState, error)
+
+	// position returns the position of this instance among the replicas of the tenant.
+	position() int
+
+	// IsActive returns whether the Alertmanager is running the tenant configuration.
+	IsActive() bool
+}
+
+// statePersister periodically persists the state of the Alertmanager of a tenant to the
+// storage, so that it can be restored when all the alertmanagers of the tenant are restarted.
+// Only the first replica of the tenant persists the state, to avoid concurrent writes.
+type statePersister struct {
+	services.Service
+
+	userID string
+	state  persistableState
+	store  AlertStateStore
+	logger log.Logger
+
+	persistTotal       prometheus.Counter
+	persistFailed      prometheus.Counter
+	lastPersistSuccess prometheus.Gauge
+}
+
+func newStatePersister(userID string, interval time.Duration, state persistableState, store AlertStateStore, logger log.Logger, r prometheus.Registerer) *statePersister {
+	s := &statePersister{
+		userID: userID,
+		state:  state,
+		store:  store,
+		logger: logger,
+		persistTotal: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_state_persist_total",
+			Help: "Number of times we have tried to persist the state to the storage.",
+		}),
+		persistFailed: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_state_persist_failed_total",
+			Help: "Number of times we have failed to persist the state to the storage.",
+		}),
+		lastPersistSuccess: promauto.With(r).NewGauge(prometheus.GaugeOpts{
+			Name: "alertmanager_state_persist_last_success_timestamp_seconds",
+			Help: "Unix timestamp of the last successful persist of the state to the storage.",
+		}),
+	}
+
+	s.Service = services.NewTimerService(interval, nil, s.iteration, s.stopping)
+
+	return s
+}
+
+func (s *statePersister) iteration(ctx context.Context) error {
+	s.persist(ctx)
+
+	// Failing to persist the state is not fatal, it will be retried on the next iteration.
+	return nil
+}
+
+func (s *statePersister) stopping(_ error) error {
+	// Persist the latest state, because all the alertmanagers of the tenant could be stopping.
+	s.persist(context.Background())
+	return nil
+}
+
+func (s *statePersister) persist(ctx context.Context) {
+	// The state of a paused Alertmanager is not replicated anymore, so it could be outdated.
+	if !s.state.IsActive() || s.state.position() != 0 {
+		return
+	}
+
+	s.persistTotal.Inc()
+	if err := s.doPersist(ctx); err != nil {
+		s.persistFailed.Inc()
+		level.Error(s.logger).Log("msg", "failed to persist the alertmanager state", "err", err)
+		return
+	}
+
+	s.lastPersistSuccess.SetToCurrentTime()
+}
+
+func (s *statePersister) doPersist(ctx context.Context) error {
+	fullState, err := s.state.getFullState()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultPersistTimeout)
+	defer cancel()
+
+	return s.store.SetFullState(ctx, s.userID, fullState)
+}
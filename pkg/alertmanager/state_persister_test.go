@@ -0,0 +1,195 @@
+package alertmanager
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
+	amconfig "github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
+	"github.com/cortexproject/cortex/pkg/util/services"
+	"github.com/cortexproject/cortex/pkg/util/test"
+)
+
+type fakePersistableState struct {
+	fullState *clusterpb.FullState
+	pos       int
+	active    bool
+}
+
+func (s *fakePersistableState) getFullState() (*clusterpb.FullState, error) {
+	return s.fullState, nil
+}
+
+func (s *fakePersistableState) position() int {
+	return s.pos
+}
+
+func (s *fakePersistableState) IsActive() bool {
+	return s.active
+}
+
+type fakeStateStore struct {
+	mtx    sync.Mutex
+	states map[string]*clusterpb.FullState
+}
+
+func newFakeStateStore() *fakeStateStore {
+	return &fakeStateStore{states: map[string]*clusterpb.FullState{}}
+}
+
+func (s *fakeStateStore) GetFullState(_ context.Context, user string) (*clusterpb.FullState, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	state, ok := s.states[user]
+	if !ok {
+		return nil, alerts.ErrStateNotFound
+	}
+	return state, nil
+}
+
+func (s *fakeStateStore) SetFullState(_ context.Context, user string, state *clusterpb.FullState) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.states[user] = state
+	return nil
+}
+
+func (s *fakeStateStore) DeleteFullState(_ context.Context, user string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.states, user)
+	return nil
+}
+
+func (s *fakeStateStore) hasState(user string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, ok := s.states[user]
+	return ok
+}
+
+func TestStatePersister(t *testing.T) {
+	fullState := &clusterpb.FullState{Parts: []clusterpb.Part{{Key: "sil:user-1", Data: []byte("silences")}}}
+
+	tests := map[string]struct {
+		position        int
+		active          bool
+		expectPersisted bool
+	}{
+		"first replica of an active alertmanager": {
+			position:        0,
+			active:          true,
+			expectPersisted: true,
+		},
+		"other replicas of an active alertmanager": {
+			position:        1,
+			active:          true,
+			expectPersisted: false,
+		},
+		"paused alertmanager": {
+			position:        0,
+			active:          false,
+			expectPersisted: false,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			store := newFakeStateStore()
+			state := &fakePersistableState{fullState: fullState, pos: testData.position, active: testData.active}
+
+			p := newStatePersister("user-1", 10*time.Millisecond, state, store, log.NewNopLogger(), nil)
+			require.NoError(t, services.StartAndAwaitRunning(context.Background(), p))
+
+			if testData.expectPersisted {
+				test.Poll(t, time.Second, true, func() interface{} {
+					return store.hasState("user-1")
+				})
+				assert.Greater(t, testutil.ToFloat64(p.lastPersistSuccess), float64(0))
+			} else {
+				time.Sleep(50 * time.Millisecond)
+				assert.False(t, store.hasState("user-1"))
+				assert.Equal(t, float64(0), testutil.ToFloat64(p.persistTotal))
+			}
+
+			require.NoError(t, services.StopAndAwaitTerminated(context.Background(), p))
+			assert.Equal(t, float64(0), testutil.ToFloat64(p.persistFailed))
+		})
+	}
+}
+
+func TestStatePersister_ShouldPersistWhenStopping(t *testing.T) {
+	store := newFakeStateStore()
+	state := &fakePersistableState{fullState: &clusterpb.FullState{}, active: true}
+
+	p := newStatePersister("user-1", time.Hour, state, store, log.NewNopLogger(), nil)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), p))
+	assert.False(t, store.hasState("user-1"))
+
+	require.NoError(t, services.StopAndAwaitTerminated(context.Background(), p))
+	assert.True(t, store.hasState("user-1"))
+	assert.Equal(t, float64(1), testutil.ToFloat64(p.persistTotal))
+}
+
+func TestAlertmanager_RestoreStateFromStorage(t *testing.T) {
+	const userID = "user-1"
+
+	store := newFakeStateStore()
+	amConfig, err := amconfig.Load(simpleConfigOne)
+	require.NoError(t, err)
+
+	newTestAlertmanager := func() *Alertmanager {
+		dataDir, err := ioutil.TempDir(os.TempDir(), "alertmanager")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, os.RemoveAll(dataDir))
+		})
+
+		am, err := New(&Config{
+			UserID:          userID,
+			DataDir:         dataDir,
+			Logger:          log.NewNopLogger(),
+			Retention:       time.Hour,
+			ExternalURL:     &url.URL{},
+			Store:           store,
+			PersistInterval: time.Hour,
+		}, prometheus.NewPedanticRegistry())
+		require.NoError(t, err)
+		require.NoError(t, am.ApplyConfig(userID, amConfig, simpleConfigOne))
+		return am
+	}
+
+	// Create a silence and stop the alertmanager, which persists the state.
+	am := newTestAlertmanager()
+	_, err = am.silences.Set(&silencepb.Silence{
+		Matchers: []*silencepb.Matcher{{Name: "instance", Pattern: "prometheus-one"}},
+		StartsAt: time.Now(),
+		EndsAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	am.Stop()
+	require.True(t, store.hasState(userID))
+
+	// A new alertmanager without any local state should restore the state from the storage.
+	am = newTestAlertmanager()
+	defer am.Stop()
+
+	silences, _, err := am.silences.Query()
+	require.NoError(t, err)
+	require.Len(t, silences, 1)
+	assert.Equal(t, "prometheus-one", silences[0].Matchers[0].Pattern)
+}
@@ -0,0 +1,242 @@
+package alertmanager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/cortexproject/cortex/pkg/util/services"
+)
+
+const (
+	// Maximum number of partial states waiting to be replicated. Further states are dropped.
+	defaultStateReplicationBufferSize = 1024
+
+	// Maximum time spent reading the full state from the other replicas when settling.
+	defaultSettleReadTimeout = 15 * time.Second
+)
+
+// Replicator is used to exchange the state of the Alertmanager of a tenant with
+// the other replicas of the tenant when sharding is enabled.
+type Replicator interface {
+	// ReplicateStateForUser sends the partial state to the other replicas of the tenant.
+	ReplicateStateForUser(ctx context.Context, userID string, part *clusterpb.Part) error
+
+	// ReadFullStateForUser returns the full states of the tenant read from the other replicas.
+	ReadFullStateForUser(ctx context.Context, userID string) ([]*clusterpb.FullState, error)
+
+	// GetPositionForUser returns the position of this instance among the replicas of the tenant.
+	GetPositionForUser(userID string) int
+}
+
+// replicatedStates holds the states of the Alertmanager of a tenant (silences and notification log)
+// and keeps them in sync with the other replicas of the tenant via the Replicator, replacing the
+// gossip cluster when sharding is enabled.
+type replicatedStates struct {
+	services.Service
+
+	userID     string
+	replicator Replicator
+	logger     log.Logger
+
+	mtx    sync.Mutex
+	states map[string]cluster.State
+
+	msgc   chan *clusterpb.Part
+	readyc chan struct{}
+
+	partialStateMergesTotal  prometheus.Counter
+	partialStateMergesFailed prometheus.Counter
+	stateReplicationTotal    prometheus.Counter
+	stateReplicationFailed   prometheus.Counter
+	fetchReplicaStateTotal   prometheus.Counter
+	fetchReplicaStateFailed  prometheus.Counter
+}
+
+func newReplicatedStates(userID string, replicator Replicator, logger log.Logger, r prometheus.Registerer) *replicatedStates {
+	s := &replicatedStates{
+		userID:     userID,
+		replicator: replicator,
+		logger:     logger,
+		states:     map[string]cluster.State{},
+		msgc:       make(chan *clusterpb.Part, defaultStateReplicationBufferSize),
+		readyc:     make(chan struct{}),
+		partialStateMergesTotal: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_partial_state_merges_total",
+			Help: "Number of times we have received a partial state to merge.",
+		}),
+		partialStateMergesFailed: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_partial_state_merges_failed_total",
+			Help: "Number of times we have failed to merge a partial state.",
+		}),
+		stateReplicationTotal: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_state_replication_total",
+			Help: "Number of times we have tried to replicate a state to other alertmanagers.",
+		}),
+		stateReplicationFailed: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_state_replication_failed_total",
+			Help: "Number of times we have failed to replicate a state to other alertmanagers.",
+		}),
+		fetchReplicaStateTotal: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_state_fetch_replica_state_total",
+			Help: "Number of times we have tried to read and merge the full state from the other replicas.",
+		}),
+		fetchReplicaStateFailed: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_state_fetch_replica_state_failed_total",
+			Help: "Number of times we have failed to read and merge the full state from the other replicas.",
+		}),
+	}
+
+	s.Service = services.NewBasicService(s.starting, s.running, nil)
+
+	return s
+}
+
+// AddState adds a new state that will be replicated, and returns the function to be
+// used by the state to broadcast its changes. Must be called before the service is started.
+func (s *replicatedStates) AddState(key string, cs cluster.State) func([]byte) {
+	s.mtx.Lock()
+	s.states[key] = cs
+	s.mtx.Unlock()
+
+	return func(b []byte) {
+		select {
+		case s.msgc <- &clusterpb.Part{Key: key, Data: b}:
+		default:
+			level.Warn(s.logger).Log("msg", "dropping state replication message, buffer full", "key", key)
+			s.stateReplicationFailed.Inc()
+		}
+	}
+}
+
+// MergePartialState merges a partial state received from another replica.
+func (s *replicatedStates) MergePartialState(p *clusterpb.Part) error {
+	s.partialStateMergesTotal.Inc()
+
+	s.mtx.Lock()
+	st, ok := s.states[p.Key]
+	s.mtx.Unlock()
+
+	if !ok {
+		s.partialStateMergesFailed.Inc()
+		return errors.Errorf("key not found while merging")
+	}
+
+	if err := st.Merge(p.Data); err != nil {
+		s.partialStateMergesFailed.Inc()
+		return err
+	}
+
+	return nil
+}
+
+// GetFullState returns the current full state.
+func (s *replicatedStates) GetFullState() (*clusterpb.FullState, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	all := &clusterpb.FullState{
+		Parts: make([]clusterpb.Part, 0, len(s.states)),
+	}
+
+	for key, st := range s.states {
+		b, err := st.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+
+		all.Parts = append(all.Parts, clusterpb.Part{Key: key, Data: b})
+	}
+
+	return all, nil
+}
+
+// Position returns the position of this instance among the replicas of the tenant, which is used
+// to delay the notifications sent by the other replicas and avoid duplicated notifications.
+func (s *replicatedStates) Position() int {
+	return s.replicator.GetPositionForUser(s.userID)
+}
+
+// WaitReady waits until the initial state has been read from the other replicas.
+func (s *replicatedStates) WaitReady(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.readyc:
+		return nil
+	}
+}
+
+// Settle reads the full state from the other replicas and merges it into the local state.
+// Failing to read the state from the other replicas is not an error, because there could be
+// no other replica owning the tenant.
+func (s *replicatedStates) Settle(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, defaultSettleReadTimeout)
+	defer cancel()
+
+	s.fetchReplicaStateTotal.Inc()
+	fullStates, err := s.replicator.ReadFullStateForUser(ctx, s.userID)
+	if err != nil {
+		s.fetchReplicaStateFailed.Inc()
+		level.Info(s.logger).Log("msg", "unable to read the state from the other replicas, starting with the local state", "err", err)
+		return
+	}
+
+	for _, fs := range fullStates {
+		for i := range fs.Parts {
+			if err := s.MergePartialState(&fs.Parts[i]); err != nil {
+				s.fetchReplicaStateFailed.Inc()
+				level.Warn(s.logger).Log("msg", "failed to merge the state read from another replica", "key", fs.Parts[i].Key, "err", err)
+			}
+		}
+	}
+
+	level.Debug(s.logger).Log("msg", "state settled from the other replicas", "replicas", len(fullStates))
+}
+
+// stateSettleStage is a notify.Stage waiting until the state has been read from the other
+// replicas to forward the alerts, like the notify.GossipSettleStage does for the gossip cluster.
+type stateSettleStage struct {
+	state *replicatedStates
+}
+
+// Exec implements notify.Stage.
+func (n *stateSettleStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	if err := n.state.WaitReady(ctx); err != nil {
+		return ctx, nil, err
+	}
+	return ctx, alerts, nil
+}
+
+func (s *replicatedStates) starting(ctx context.Context) error {
+	defer close(s.readyc)
+
+	s.Settle(ctx)
+	return nil
+}
+
+func (s *replicatedStates) running(ctx context.Context) error {
+	for {
+		select {
+		case p := <-s.msgc:
+			// A failed replication is not retried: the other replicas get the
+			// state only once they read the full state from this replica.
+			s.stateReplicationTotal.Inc()
+			if err := s.replicator.ReplicateStateForUser(ctx, s.userID, p); err != nil {
+				s.stateReplicationFailed.Inc()
+				level.Error(s.logger).Log("msg", "failed to replicate state to other alertmanagers", "key", p.Key, "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
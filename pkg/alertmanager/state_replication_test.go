@@ -0,0 +1,182 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/util/services"
+	"github.com/cortexproject/cortex/pkg/util/test"
+)
+
+type fakeState struct {
+	mtx    sync.Mutex
+	merges [][]byte
+	binary []byte
+}
+
+func (s *fakeState) MarshalBinary() ([]byte, error) {
+	return s.binary, nil
+}
+
+func (s *fakeState) Merge(b []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.merges = append(s.merges, b)
+	return nil
+}
+
+func (s *fakeState) getMerges() [][]byte {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([][]byte{}, s.merges...)
+}
+
+type fakeReplicator struct {
+	mtx        sync.Mutex
+	replicated []*clusterpb.Part
+	fullStates []*clusterpb.FullState
+	readErr    error
+
+	// If set, reading the full state blocks until the channel is closed.
+	readBlock chan struct{}
+}
+
+func (r *fakeReplicator) ReplicateStateForUser(_ context.Context, _ string, p *clusterpb.Part) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.replicated = append(r.replicated, p)
+	return nil
+}
+
+func (r *fakeReplicator) ReadFullStateForUser(ctx context.Context, _ string) ([]*clusterpb.FullState, error) {
+	if r.readBlock != nil {
+		select {
+		case <-r.readBlock:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return r.fullStates, r.readErr
+}
+
+func (r *fakeReplicator) GetPositionForUser(_ string) int {
+	return 1
+}
+
+func (r *fakeReplicator) getReplicated() []*clusterpb.Part {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return append([]*clusterpb.Part{}, r.replicated...)
+}
+
+func TestReplicatedStates(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	replicator := &fakeReplicator{
+		fullStates: []*clusterpb.FullState{
+			{Parts: []clusterpb.Part{{Key: "nflog", Data: []byte("remote-nflog")}, {Key: "unknown", Data: []byte("unknown")}}},
+		},
+	}
+
+	s := newReplicatedStates("user-1", replicator, log.NewNopLogger(), reg)
+	nflog := &fakeState{binary: []byte("local-nflog")}
+	broadcast := s.AddState("nflog", nflog)
+
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), s))
+	defer services.StopAndAwaitTerminated(context.Background(), s) //nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, s.WaitReady(ctx))
+
+	// The state read from the other replicas should be merged when starting.
+	assert.Equal(t, [][]byte{[]byte("remote-nflog")}, nflog.getMerges())
+
+	// The broadcasted state should be replicated.
+	broadcast([]byte("update"))
+	test.Poll(t, time.Second, []*clusterpb.Part{{Key: "nflog", Data: []byte("update")}}, func() interface{} {
+		return replicator.getReplicated()
+	})
+
+	// The partial states received from the other replicas should be merged.
+	require.NoError(t, s.MergePartialState(&clusterpb.Part{Key: "nflog", Data: []byte("partial")}))
+	require.Error(t, s.MergePartialState(&clusterpb.Part{Key: "unknown", Data: []byte("partial")}))
+	assert.Equal(t, [][]byte{[]byte("remote-nflog"), []byte("partial")}, nflog.getMerges())
+
+	fullState, err := s.GetFullState()
+	require.NoError(t, err)
+	assert.Equal(t, &clusterpb.FullState{Parts: []clusterpb.Part{{Key: "nflog", Data: []byte("local-nflog")}}}, fullState)
+
+	assert.Equal(t, 1, s.Position())
+
+	assert.Equal(t, float64(4), testutil.ToFloat64(s.partialStateMergesTotal))
+	assert.Equal(t, float64(2), testutil.ToFloat64(s.partialStateMergesFailed))
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.fetchReplicaStateTotal))
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.fetchReplicaStateFailed))
+}
+
+func TestReplicatedStates_SettleWithoutOtherReplicas(t *testing.T) {
+	replicator := &fakeReplicator{readErr: errors.New("no replicas")}
+
+	s := newReplicatedStates("user-1", replicator, log.NewNopLogger(), nil)
+	nflog := &fakeState{}
+	s.AddState("nflog", nflog)
+
+	// Failing to read the state from the other replicas should not prevent the state from starting.
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), s))
+	defer services.StopAndAwaitTerminated(context.Background(), s) //nolint:errcheck
+
+	assert.Empty(t, nflog.getMerges())
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.fetchReplicaStateFailed))
+}
+
+func TestAlertmanager_ShouldSettleStateInBackground(t *testing.T) {
+	dataDir, err := ioutil.TempDir(os.TempDir(), "alertmanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir) //nolint:errcheck
+
+	replicator := &fakeReplicator{readBlock: make(chan struct{})}
+
+	// Creating the alertmanager should not wait for the state to be read from the other replicas.
+	am, err := New(&Config{
+		UserID:          "user-1",
+		DataDir:         dataDir,
+		Logger:          log.NewNopLogger(),
+		Retention:       time.Hour,
+		ExternalURL:     &url.URL{},
+		ShardingEnabled: true,
+		Replicator:      replicator,
+	}, prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+	defer am.Stop()
+
+	// The alerts should not be forwarded until the state has been settled.
+	stage := &stateSettleStage{state: am.state}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, alerts, err := stage.Exec(ctx, log.NewNopLogger(), &types.Alert{})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Empty(t, alerts)
+
+	close(replicator.readBlock)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, alerts, err = stage.Exec(ctx, log.NewNopLogger(), &types.Alert{})
+	require.NoError(t, err)
+	assert.Len(t, alerts, 1)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
 
 	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
 	"github.com/cortexproject/cortex/pkg/alertmanager/alerts/configdb"
@@ -26,6 +27,15 @@ type AlertStore interface {
 	DeleteAlertConfig(ctx context.Context, user string) error
 }
 
+// AlertStateStore stores the state (silences and notification log) of the users Alertmanagers.
+// It's implemented only by the object storage backends.
+type AlertStateStore interface {
+	// GetFullState returns the state of the user Alertmanager, or alerts.ErrStateNotFound if the state doesn't exist.
+	GetFullState(ctx context.Context, user string) (*clusterpb.FullState, error)
+	SetFullState(ctx context.Context, user string, state *clusterpb.FullState) error
+	DeleteFullState(ctx context.Context, user string) error
+}
+
 // AlertStoreConfig configures the alertmanager backend
 type AlertStoreConfig struct {
 	Type     string        `yaml:"type"`
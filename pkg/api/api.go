@@ -5,6 +5,7 @@ import (
 	"flag"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/NYTimes/gziphandler"
@@ -17,9 +18,11 @@ import (
 	"github.com/weaveworks/common/server"
 
 	"github.com/cortexproject/cortex/pkg/alertmanager"
+	"github.com/cortexproject/cortex/pkg/alertmanager/alertmanagerpb"
 	"github.com/cortexproject/cortex/pkg/chunk/purger"
 	"github.com/cortexproject/cortex/pkg/compactor"
 	"github.com/cortexproject/cortex/pkg/distributor"
+	"github.com/cortexproject/cortex/pkg/frontend/activequeries"
 	frontendv1 "github.com/cortexproject/cortex/pkg/frontend/v1"
 	"github.com/cortexproject/cortex/pkg/frontend/v1/frontendv1pb"
 	frontendv2 "github.com/cortexproject/cortex/pkg/frontend/v2"
@@ -67,6 +70,9 @@ type API struct {
 	logger    log.Logger
 	sourceIPs *middleware.SourceIPExtractor
 	indexPage *IndexPageContent
+
+	capabilitiesMtx sync.RWMutex
+	capabilities    TenantCapabilities
 }
 
 func New(cfg Config, serverCfg server.Config, s *server.Server, logger log.Logger) (*API, error) {
@@ -142,6 +148,8 @@ func (a *API) RegisterRoutesWithPrefix(prefix string, handler http.Handler, auth
 // RegisterAlertmanager registers endpoints associated with the alertmanager. It will only
 // serve endpoints using the legacy http-prefix if it is not run as a single binary.
 func (a *API) RegisterAlertmanager(am *alertmanager.MultitenantAlertmanager, target, apiEnabled bool) {
+	alertmanagerpb.RegisterAlertmanagerServer(a.server.GRPC, am)
+
 	a.indexPage.AddLink(SectionAdminEndpoints, "/multitenant_alertmanager/status", "Alertmanager Status")
 	a.indexPage.AddLink(SectionAdminEndpoints, "/multitenant_alertmanager/ring", "Alertmanager Ring Status")
 	// Ensure this route is registered before the prefixed AM route
@@ -149,21 +157,21 @@ func (a *API) RegisterAlertmanager(am *alertmanager.MultitenantAlertmanager, tar
 	a.RegisterRoute("/multitenant_alertmanager/ring", http.HandlerFunc(am.RingHandler), false, "GET", "POST")
 
 	// UI components lead to a large number of routes to support, utilize a path prefix instead
-	a.RegisterRoutesWithPrefix(a.cfg.AlertmanagerHTTPPrefix, am, true)
+	a.RegisterRoutesWithPrefix(a.cfg.AlertmanagerHTTPPrefix, a.requireCapability(CapabilityAlertmanager, am), true)
 	level.Debug(a.logger).Log("msg", "api: registering alertmanager", "path_prefix", a.cfg.AlertmanagerHTTPPrefix)
 
 	// If the target is Alertmanager, enable the legacy behaviour. Otherwise only enable
 	// the component routed API.
 	if target {
 		a.RegisterRoute("/status", am.GetStatusHandler(), false, "GET")
-		a.RegisterRoutesWithPrefix(a.cfg.LegacyHTTPPrefix, am, true)
+		a.RegisterRoutesWithPrefix(a.cfg.LegacyHTTPPrefix, a.requireCapability(CapabilityAlertmanager, am), true)
 	}
 
 	// MultiTenant Alertmanager Experimental API routes
 	if apiEnabled {
-		a.RegisterRoute("/api/v1/alerts", http.HandlerFunc(am.GetUserConfig), true, "GET")
-		a.RegisterRoute("/api/v1/alerts", http.HandlerFunc(am.SetUserConfig), true, "POST")
-		a.RegisterRoute("/api/v1/alerts", http.HandlerFunc(am.DeleteUserConfig), true, "DELETE")
+		a.RegisterRoute("/api/v1/alerts", a.requireCapability(CapabilityAlertmanager, http.HandlerFunc(am.GetUserConfig)), true, "GET")
+		a.RegisterRoute("/api/v1/alerts", a.requireCapability(CapabilityAlertmanager, http.HandlerFunc(am.SetUserConfig)), true, "POST")
+		a.RegisterRoute("/api/v1/alerts", a.requireCapability(CapabilityAlertmanager, http.HandlerFunc(am.DeleteUserConfig)), true, "DELETE")
 	}
 }
 
@@ -189,7 +197,9 @@ func (a *API) RegisterRuntimeConfig(runtimeConfigHandler http.HandlerFunc) {
 func (a *API) RegisterDistributor(d *distributor.Distributor, pushConfig distributor.Config) {
 	client.RegisterPushOnlyIngesterServer(a.server.GRPC, d)
 
-	a.RegisterRoute("/api/v1/push", push.Handler(pushConfig, a.sourceIPs, d.Push), true, "POST")
+	a.RegisterRoute("/api/v1/push", a.requireCapability(CapabilityPush, push.Handler(pushConfig, a.sourceIPs, d.Push)), true, "POST")
+	a.RegisterRoute("/otlp/v1/metrics", a.requireCapability(CapabilityPush, push.OTLPHandler(pushConfig, a.sourceIPs, d.Push)), true, "POST")
+	a.RegisterRoute("/api/v1/push/influx/write", a.requireCapability(CapabilityPush, push.InfluxHandler(pushConfig, a.sourceIPs, d.Push)), true, "POST")
 
 	a.indexPage.AddLink(SectionAdminEndpoints, "/distributor/all_user_stats", "Usage Statistics")
 	a.indexPage.AddLink(SectionAdminEndpoints, "/distributor/ha_tracker", "HA Tracking Status")
@@ -198,7 +208,7 @@ func (a *API) RegisterDistributor(d *distributor.Distributor, pushConfig distrib
 	a.RegisterRoute("/distributor/ha_tracker", d.HATracker, false, "GET")
 
 	// Legacy Routes
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/push", push.Handler(pushConfig, a.sourceIPs, d.Push), true, "POST")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/push", a.requireCapability(CapabilityPush, push.Handler(pushConfig, a.sourceIPs, d.Push)), true, "POST")
 	a.RegisterRoute("/all_user_stats", http.HandlerFunc(d.AllUserStatsHandler), false, "GET")
 	a.RegisterRoute("/ha-tracker", d.HATracker, false, "GET")
 }
@@ -220,33 +230,41 @@ func (a *API) RegisterIngester(i Ingester, pushConfig distributor.Config) {
 	a.indexPage.AddLink(SectionDangerous, "/ingester/shutdown", "Trigger Ingester Shutdown (Dangerous)")
 	a.RegisterRoute("/ingester/flush", http.HandlerFunc(i.FlushHandler), false, "GET", "POST")
 	a.RegisterRoute("/ingester/shutdown", http.HandlerFunc(i.ShutdownHandler), false, "GET", "POST")
-	a.RegisterRoute("/ingester/push", push.Handler(pushConfig, a.sourceIPs, i.Push), true, "POST") // For testing and debugging.
+	a.RegisterRoute("/ingester/push", a.requireCapability(CapabilityPush, push.Handler(pushConfig, a.sourceIPs, i.Push)), true, "POST") // For testing and debugging.
 
 	// Legacy Routes
 	a.RegisterRoute("/flush", http.HandlerFunc(i.FlushHandler), false, "GET", "POST")
 	a.RegisterRoute("/shutdown", http.HandlerFunc(i.ShutdownHandler), false, "GET", "POST")
-	a.RegisterRoute("/push", push.Handler(pushConfig, a.sourceIPs, i.Push), true, "POST") // For testing and debugging.
+	a.RegisterRoute("/push", a.requireCapability(CapabilityPush, push.Handler(pushConfig, a.sourceIPs, i.Push)), true, "POST") // For testing and debugging.
 }
 
 // RegisterChunksPurger registers the endpoints associated with the Purger/DeleteStore. They do not exactly
 // match the Prometheus API but mirror it closely enough to justify their routing under the Prometheus
 // component/
 func (a *API) RegisterChunksPurger(store *purger.DeleteStore, deleteRequestCancelPeriod time.Duration) {
-	deleteRequestHandler := purger.NewDeleteRequestHandler(store, deleteRequestCancelPeriod, prometheus.DefaultRegisterer)
+	a.registerDeleteRequestHandler(purger.NewDeleteRequestHandler(store, deleteRequestCancelPeriod, prometheus.DefaultRegisterer))
+}
 
-	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/admin/tsdb/delete_series", http.HandlerFunc(deleteRequestHandler.AddDeleteRequestHandler), true, "PUT", "POST")
-	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/admin/tsdb/delete_series", http.HandlerFunc(deleteRequestHandler.GetAllDeleteRequestsHandler), true, "GET")
-	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/admin/tsdb/cancel_delete_request", http.HandlerFunc(deleteRequestHandler.CancelDeleteRequestHandler), true, "PUT", "POST")
+func (a *API) registerDeleteRequestHandler(deleteRequestHandler *purger.DeleteRequestHandler) {
+	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/admin/tsdb/delete_series", a.requireCapability(CapabilityPush, http.HandlerFunc(deleteRequestHandler.AddDeleteRequestHandler)), true, "PUT", "POST")
+	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/admin/tsdb/delete_series", a.requireCapability(CapabilityQuery, http.HandlerFunc(deleteRequestHandler.GetAllDeleteRequestsHandler)), true, "GET")
+	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/admin/tsdb/cancel_delete_request", a.requireCapability(CapabilityPush, http.HandlerFunc(deleteRequestHandler.CancelDeleteRequestHandler)), true, "PUT", "POST")
 
 	// Legacy Routes
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/admin/tsdb/delete_series", http.HandlerFunc(deleteRequestHandler.AddDeleteRequestHandler), true, "PUT", "POST")
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/admin/tsdb/delete_series", http.HandlerFunc(deleteRequestHandler.GetAllDeleteRequestsHandler), true, "GET")
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/admin/tsdb/cancel_delete_request", http.HandlerFunc(deleteRequestHandler.CancelDeleteRequestHandler), true, "PUT", "POST")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/admin/tsdb/delete_series", a.requireCapability(CapabilityPush, http.HandlerFunc(deleteRequestHandler.AddDeleteRequestHandler)), true, "PUT", "POST")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/admin/tsdb/delete_series", a.requireCapability(CapabilityQuery, http.HandlerFunc(deleteRequestHandler.GetAllDeleteRequestsHandler)), true, "GET")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/admin/tsdb/cancel_delete_request", a.requireCapability(CapabilityPush, http.HandlerFunc(deleteRequestHandler.CancelDeleteRequestHandler)), true, "PUT", "POST")
 }
 
 func (a *API) RegisterBlocksPurger(api *purger.BlocksPurgerAPI) {
-	a.RegisterRoute("/purger/delete_tenant", http.HandlerFunc(api.DeleteTenant), true, "POST")
-	a.RegisterRoute("/purger/delete_tenant_status", http.HandlerFunc(api.DeleteTenantStatus), true, "GET")
+	a.RegisterRoute("/purger/delete_tenant", a.requireCapability(CapabilityPush, http.HandlerFunc(api.DeleteTenant)), true, "POST")
+	a.RegisterRoute("/purger/delete_tenant_status", a.requireCapability(CapabilityQuery, http.HandlerFunc(api.DeleteTenantStatus)), true, "GET")
+}
+
+// RegisterBlocksSeriesDeletion registers the endpoints used to manage series delete requests when
+// running the blocks storage. They're the same endpoints registered by RegisterChunksPurger.
+func (a *API) RegisterBlocksSeriesDeletion(store *purger.BlocksDeleteStore, deleteRequestCancelPeriod time.Duration) {
+	a.registerDeleteRequestHandler(purger.NewDeleteRequestHandler(store, deleteRequestCancelPeriod, prometheus.DefaultRegisterer))
 }
 
 // RegisterRuler registers routes associated with the Ruler service.
@@ -263,28 +281,31 @@ func (a *API) RegisterRuler(r *ruler.Ruler) {
 // RegisterRulerAPI registers routes associated with the Ruler API
 func (a *API) RegisterRulerAPI(r *ruler.API) {
 	// Prometheus Rule API Routes
-	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/rules", http.HandlerFunc(r.PrometheusRules), true, "GET")
-	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/alerts", http.HandlerFunc(r.PrometheusAlerts), true, "GET")
+	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/rules", a.requireCapability(CapabilityRules, http.HandlerFunc(r.PrometheusRules)), true, "GET")
+	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/alerts", a.requireCapability(CapabilityRules, http.HandlerFunc(r.PrometheusAlerts)), true, "GET")
 
 	// Ruler API Routes
-	a.RegisterRoute("/api/v1/rules", http.HandlerFunc(r.ListRules), true, "GET")
-	a.RegisterRoute("/api/v1/rules/{namespace}", http.HandlerFunc(r.ListRules), true, "GET")
-	a.RegisterRoute("/api/v1/rules/{namespace}/{groupName}", http.HandlerFunc(r.GetRuleGroup), true, "GET")
-	a.RegisterRoute("/api/v1/rules/{namespace}", http.HandlerFunc(r.CreateRuleGroup), true, "POST")
-	a.RegisterRoute("/api/v1/rules/{namespace}/{groupName}", http.HandlerFunc(r.DeleteRuleGroup), true, "DELETE")
-	a.RegisterRoute("/api/v1/rules/{namespace}", http.HandlerFunc(r.DeleteNamespace), true, "DELETE")
+	a.RegisterRoute("/api/v1/rules", a.requireCapability(CapabilityRules, http.HandlerFunc(r.ListRules)), true, "GET")
+	a.RegisterRoute("/api/v1/rules/{namespace}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.ListRules)), true, "GET")
+	a.RegisterRoute("/api/v1/rules/{namespace}/{groupName}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.GetRuleGroup)), true, "GET")
+	a.RegisterRoute("/api/v1/rules/{namespace}/{groupName}/history", a.requireCapability(CapabilityRules, http.HandlerFunc(r.GetRuleGroupEvaluationHistory)), true, "GET")
+	a.RegisterRoute("/api/v1/rules/{namespace}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.CreateRuleGroup)), true, "POST")
+	a.RegisterRoute("/api/v1/rules/{namespace}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.ReplaceNamespace)), true, "PUT")
+	a.RegisterRoute("/api/v1/rules/{namespace}/validate", a.requireCapability(CapabilityRules, http.HandlerFunc(r.ValidateRuleGroup)), true, "POST")
+	a.RegisterRoute("/api/v1/rules/{namespace}/{groupName}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.DeleteRuleGroup)), true, "DELETE")
+	a.RegisterRoute("/api/v1/rules/{namespace}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.DeleteNamespace)), true, "DELETE")
 
 	// Legacy Prometheus Rule API Routes
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/rules", http.HandlerFunc(r.PrometheusRules), true, "GET")
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/alerts", http.HandlerFunc(r.PrometheusAlerts), true, "GET")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/rules", a.requireCapability(CapabilityRules, http.HandlerFunc(r.PrometheusRules)), true, "GET")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/alerts", a.requireCapability(CapabilityRules, http.HandlerFunc(r.PrometheusAlerts)), true, "GET")
 
 	// Legacy Ruler API Routes
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules", http.HandlerFunc(r.ListRules), true, "GET")
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules/{namespace}", http.HandlerFunc(r.ListRules), true, "GET")
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules/{namespace}/{groupName}", http.HandlerFunc(r.GetRuleGroup), true, "GET")
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules/{namespace}", http.HandlerFunc(r.CreateRuleGroup), true, "POST")
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules/{namespace}/{groupName}", http.HandlerFunc(r.DeleteRuleGroup), true, "DELETE")
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules/{namespace}", http.HandlerFunc(r.DeleteNamespace), true, "DELETE")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules", a.requireCapability(CapabilityRules, http.HandlerFunc(r.ListRules)), true, "GET")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules/{namespace}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.ListRules)), true, "GET")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules/{namespace}/{groupName}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.GetRuleGroup)), true, "GET")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules/{namespace}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.CreateRuleGroup)), true, "POST")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules/{namespace}/{groupName}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.DeleteRuleGroup)), true, "DELETE")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/rules/{namespace}", a.requireCapability(CapabilityRules, http.HandlerFunc(r.DeleteNamespace)), true, "DELETE")
 }
 
 // RegisterRing registers the ring UI page associated with the distributor for writes.
@@ -317,18 +338,23 @@ func (a *API) RegisterQueryable(
 	distributor *distributor.Distributor,
 ) {
 	// these routes are always registered to the default server
-	a.RegisterRoute("/api/v1/user_stats", http.HandlerFunc(distributor.UserStatsHandler), true, "GET")
-	a.RegisterRoute("/api/v1/chunks", querier.ChunksHandler(queryable), true, "GET")
+	a.RegisterRoute("/api/v1/user_stats", a.requireCapability(CapabilityQuery, http.HandlerFunc(distributor.UserStatsHandler)), true, "GET")
+	a.RegisterRoute("/api/v1/cardinality/label_names", a.requireCapability(CapabilityQuery, http.HandlerFunc(distributor.LabelNamesCardinalityHandler)), true, "GET", "POST")
+	a.RegisterRoute("/api/v1/cardinality/label_values", a.requireCapability(CapabilityQuery, http.HandlerFunc(distributor.LabelValuesCardinalityHandler)), true, "GET", "POST")
+	a.RegisterRoute("/api/v1/chunks", a.requireCapability(CapabilityQuery, querier.ChunksHandler(queryable)), true, "GET")
 
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/user_stats", http.HandlerFunc(distributor.UserStatsHandler), true, "GET")
-	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/chunks", querier.ChunksHandler(queryable), true, "GET")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/user_stats", a.requireCapability(CapabilityQuery, http.HandlerFunc(distributor.UserStatsHandler)), true, "GET")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/chunks", a.requireCapability(CapabilityQuery, querier.ChunksHandler(queryable)), true, "GET")
 }
 
 // RegisterQueryAPI registers the Prometheus API routes with the provided handler.
 func (a *API) RegisterQueryAPI(handler http.Handler) {
+	handler = a.requireCapability(CapabilityQuery, handler)
+
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/read", handler, true, "POST")
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/query", handler, true, "GET", "POST")
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/query_range", handler, true, "GET", "POST")
+	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/query_exemplars", handler, true, "GET", "POST")
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/labels", handler, true, "GET", "POST")
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/label/{name}/values", handler, true, "GET")
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/series", handler, true, "GET", "POST", "DELETE")
@@ -338,6 +364,7 @@ func (a *API) RegisterQueryAPI(handler http.Handler) {
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/read", handler, true, "POST")
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/query", handler, true, "GET", "POST")
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/query_range", handler, true, "GET", "POST")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/query_exemplars", handler, true, "GET", "POST")
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/labels", handler, true, "GET", "POST")
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/label/{name}/values", handler, true, "GET")
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/series", handler, true, "GET", "POST", "DELETE")
@@ -353,15 +380,25 @@ func (a *API) RegisterQueryFrontendHandler(h http.Handler) {
 
 func (a *API) RegisterQueryFrontend1(f *frontendv1.Frontend) {
 	frontendv1pb.RegisterFrontendServer(a.server.GRPC, f)
+	a.registerActiveQueries(f)
 }
 
 func (a *API) RegisterQueryFrontend2(f *frontendv2.Frontend) {
 	frontendv2pb.RegisterFrontendForQuerierServer(a.server.GRPC, f)
+	a.registerActiveQueries(f)
 }
 
 func (a *API) RegisterQueryScheduler(f *scheduler.Scheduler) {
 	schedulerpb.RegisterSchedulerForFrontendServer(a.server.GRPC, f)
 	schedulerpb.RegisterSchedulerForQuerierServer(a.server.GRPC, f)
+	a.registerActiveQueries(f)
+}
+
+// registerActiveQueries registers the endpoints to list and cancel the queries in flight of the
+// authenticated tenant in the query-frontend or the query-scheduler.
+func (a *API) registerActiveQueries(t activequeries.Tracker) {
+	a.RegisterRoute("/api/v1/queries/active", a.requireCapability(CapabilityQuery, activequeries.ListHandler(t, a.logger)), true, "GET")
+	a.RegisterRoute("/api/v1/queries/{id}", a.requireCapability(CapabilityQuery, activequeries.CancelHandler(t, a.logger)), true, "DELETE")
 }
 
 // RegisterServiceMapHandler registers the Cortex structs service handler
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/weaveworks/common/httpgrpc"
+	"google.golang.org/grpc"
+
+	"github.com/cortexproject/cortex/pkg/tenant"
+)
+
+// Capability is a group of API endpoints which can be allowed or denied to a tenant.
+type Capability string
+
+const (
+	CapabilityPush         Capability = "push"
+	CapabilityQuery        Capability = "query"
+	CapabilityRules        Capability = "rules"
+	CapabilityAlertmanager Capability = "alertmanager"
+)
+
+// pushOnlyIngesterPushMethod is the gRPC method used by clients to push series to the distributor.
+const pushOnlyIngesterPushMethod = "/cortex.PushOnlyIngester/Push"
+
+// TenantCapabilities is the interface used to check the capabilities allowed to a tenant.
+type TenantCapabilities interface {
+	AllowPush(userID string) bool
+	AllowQuery(userID string) bool
+	AllowRules(userID string) bool
+	AllowAlertmanager(userID string) bool
+}
+
+func allowed(capabilities TenantCapabilities, capability Capability, userID string) bool {
+	switch capability {
+	case CapabilityPush:
+		return capabilities.AllowPush(userID)
+	case CapabilityQuery:
+		return capabilities.AllowQuery(userID)
+	case CapabilityRules:
+		return capabilities.AllowRules(userID)
+	case CapabilityAlertmanager:
+		return capabilities.AllowAlertmanager(userID)
+	default:
+		return false
+	}
+}
+
+// SetTenantCapabilities sets the per-tenant capabilities enforced on the API endpoints
+// requiring a capability. Until set, all capabilities are allowed to every tenant.
+func (a *API) SetTenantCapabilities(capabilities TenantCapabilities) {
+	a.capabilitiesMtx.Lock()
+	defer a.capabilitiesMtx.Unlock()
+
+	a.capabilities = capabilities
+}
+
+func (a *API) getTenantCapabilities() TenantCapabilities {
+	a.capabilitiesMtx.RLock()
+	defer a.capabilitiesMtx.RUnlock()
+
+	return a.capabilities
+}
+
+// requireCapability wraps the input handler to reject with 403 the requests of tenants not
+// allowed to use the capability. When a request is made on behalf of multiple tenants, all of
+// them must be allowed. The handler is expected to be wrapped by the authentication middleware.
+func (a *API) requireCapability(capability Capability, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status, err := checkCapability(r.Context(), a.getTenantCapabilities(), capability); err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// PushCapabilityUnaryServerInterceptor returns a gRPC interceptor rejecting with 403 the requests
+// to the push-only gRPC service of the distributor from tenants not allowed to push. The input
+// function is called on each request to get the tenant capabilities, and all capabilities are
+// allowed while it returns nil.
+func PushCapabilityUnaryServerInterceptor(getCapabilities func() TenantCapabilities) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod != pushOnlyIngesterPushMethod {
+			return handler(ctx, req)
+		}
+
+		if status, err := checkCapability(ctx, getCapabilities(), CapabilityPush); err != nil {
+			return nil, httpgrpc.Errorf(status, err.Error())
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// checkCapability returns an error, along with the HTTP status code, if any of the tenants in
+// the context is not allowed to use the capability.
+func checkCapability(ctx context.Context, capabilities TenantCapabilities, capability Capability) (int, error) {
+	if capabilities == nil {
+		return http.StatusOK, nil
+	}
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return http.StatusUnauthorized, err
+	}
+
+	for _, tenantID := range tenantIDs {
+		if !allowed(capabilities, capability, tenantID) {
+			return http.StatusForbidden, fmt.Errorf("tenant %s is not allowed to access the %s API endpoints", tenantID, capability)
+		}
+	}
+
+	return http.StatusOK, nil
+}
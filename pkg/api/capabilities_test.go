@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/server"
+	"github.com/weaveworks/common/user"
+	"google.golang.org/grpc"
+
+	"github.com/cortexproject/cortex/pkg/frontend/activequeries"
+	"github.com/cortexproject/cortex/pkg/tenant"
+	"github.com/cortexproject/cortex/pkg/util/flagext"
+	"github.com/cortexproject/cortex/pkg/util/validation"
+)
+
+func TestAPI_RequireCapability(t *testing.T) {
+	// Enable multi-tenant requests, like tenant federation does.
+	tenant.WithDefaultResolver(tenant.NewMultiResolver())
+	t.Cleanup(func() { tenant.WithDefaultResolver(tenant.NewSingleResolver()) })
+
+	defaults := validation.Limits{}
+	flagext.DefaultValues(&defaults)
+
+	queryOnly := defaults
+	queryOnly.AllowPush = false
+	queryOnly.AllowRules = false
+	queryOnly.AllowAlertmanager = false
+
+	overrides, err := validation.NewOverrides(defaults, func(userID string) *validation.Limits {
+		if userID == "query-only" {
+			return &queryOnly
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		capabilities   TenantCapabilities
+		capability     Capability
+		orgID          string
+		expectedStatus int
+	}{
+		"should allow any capability if the tenant capabilities are not set": {
+			capability:     CapabilityPush,
+			orgID:          "query-only",
+			expectedStatus: http.StatusOK,
+		},
+		"should allow a capability enabled by default": {
+			capabilities:   overrides,
+			capability:     CapabilityPush,
+			orgID:          "user-1",
+			expectedStatus: http.StatusOK,
+		},
+		"should allow a capability enabled for the tenant": {
+			capabilities:   overrides,
+			capability:     CapabilityQuery,
+			orgID:          "query-only",
+			expectedStatus: http.StatusOK,
+		},
+		"should reject push if disabled for the tenant": {
+			capabilities:   overrides,
+			capability:     CapabilityPush,
+			orgID:          "query-only",
+			expectedStatus: http.StatusForbidden,
+		},
+		"should reject rules if disabled for the tenant": {
+			capabilities:   overrides,
+			capability:     CapabilityRules,
+			orgID:          "query-only",
+			expectedStatus: http.StatusForbidden,
+		},
+		"should reject alertmanager if disabled for the tenant": {
+			capabilities:   overrides,
+			capability:     CapabilityAlertmanager,
+			orgID:          "query-only",
+			expectedStatus: http.StatusForbidden,
+		},
+		"should allow a capability if enabled for all the tenants of a multi-tenant request": {
+			capabilities:   overrides,
+			capability:     CapabilityQuery,
+			orgID:          "user-1|query-only",
+			expectedStatus: http.StatusOK,
+		},
+		"should reject a capability if disabled for any tenant of a multi-tenant request": {
+			capabilities:   overrides,
+			capability:     CapabilityPush,
+			orgID:          "user-1|query-only",
+			expectedStatus: http.StatusForbidden,
+		},
+		"should reject a request without a tenant ID": {
+			capabilities:   overrides,
+			capability:     CapabilityQuery,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			a := &API{}
+			if testData.capabilities != nil {
+				a.SetTenantCapabilities(testData.capabilities)
+			}
+
+			handler := a.requireCapability(testData.capability, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			ctx := context.Background()
+			if testData.orgID != "" {
+				ctx = user.InjectOrgID(ctx, testData.orgID)
+			}
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+			assert.Equal(t, testData.expectedStatus, resp.Code)
+		})
+	}
+}
+
+type fakeActiveQueriesTracker struct{}
+
+func (fakeActiveQueriesTracker) ActiveQueries(_ string) []activequeries.Query {
+	return nil
+}
+
+func (fakeActiveQueriesTracker) CancelQuery(_ context.Context, _, _ string) bool {
+	return true
+}
+
+func TestAPI_ActiveQueriesRequireQueryCapability(t *testing.T) {
+	defaults := validation.Limits{}
+	flagext.DefaultValues(&defaults)
+
+	noQuery := defaults
+	noQuery.AllowQuery = false
+
+	overrides, err := validation.NewOverrides(defaults, func(userID string) *validation.Limits {
+		if userID == "no-query" {
+			return &noQuery
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	a, err := New(Config{}, server.Config{}, &server.Server{HTTP: mux.NewRouter()}, log.NewNopLogger())
+	require.NoError(t, err)
+	a.SetTenantCapabilities(overrides)
+	a.registerActiveQueries(fakeActiveQueriesTracker{})
+
+	for _, req := range []struct {
+		method string
+		path   string
+	}{
+		{method: "GET", path: "/api/v1/queries/active"},
+		{method: "DELETE", path: "/api/v1/queries/query-1"},
+	} {
+		for orgID, expectedStatus := range map[string]int{
+			"user-1":   http.StatusOK,
+			"no-query": http.StatusForbidden,
+		} {
+			t.Run(req.method+" "+orgID, func(t *testing.T) {
+				r := httptest.NewRequest(req.method, req.path, nil)
+				r.Header.Set(user.OrgIDHeaderName, orgID)
+
+				resp := httptest.NewRecorder()
+				a.server.HTTP.ServeHTTP(resp, r)
+				assert.Equal(t, expectedStatus, resp.Code)
+			})
+		}
+	}
+}
+
+func TestPushCapabilityUnaryServerInterceptor(t *testing.T) {
+	defaults := validation.Limits{}
+	flagext.DefaultValues(&defaults)
+
+	queryOnly := defaults
+	queryOnly.AllowPush = false
+
+	overrides, err := validation.NewOverrides(defaults, func(userID string) *validation.Limits {
+		if userID == "query-only" {
+			return &queryOnly
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		capabilities TenantCapabilities
+		method       string
+		orgID        string
+		expectedErr  bool
+	}{
+		"should allow push if the tenant capabilities are not set": {
+			method: pushOnlyIngesterPushMethod,
+			orgID:  "query-only",
+		},
+		"should allow push if enabled for the tenant": {
+			capabilities: overrides,
+			method:       pushOnlyIngesterPushMethod,
+			orgID:        "user-1",
+		},
+		"should reject push if disabled for the tenant": {
+			capabilities: overrides,
+			method:       pushOnlyIngesterPushMethod,
+			orgID:        "query-only",
+			expectedErr:  true,
+		},
+		"should not check the push capability on other methods": {
+			capabilities: overrides,
+			method:       "/cortex.Ingester/Push",
+			orgID:        "query-only",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			interceptor := PushCapabilityUnaryServerInterceptor(func() TenantCapabilities {
+				return testData.capabilities
+			})
+
+			ctx := user.InjectOrgID(context.Background(), testData.orgID)
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: testData.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+
+			if testData.expectedErr {
+				resp, ok := httpgrpc.HTTPResponseFromError(err)
+				require.True(t, ok)
+				assert.Equal(t, int32(http.StatusForbidden), resp.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
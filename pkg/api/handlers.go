@@ -241,6 +241,7 @@ func NewQuerierHandler(
 	router.Path(prefix + "/api/v1/read").Methods("POST").Handler(promRouter)
 	router.Path(prefix+"/api/v1/query").Methods("GET", "POST").Handler(promRouter)
 	router.Path(prefix+"/api/v1/query_range").Methods("GET", "POST").Handler(promRouter)
+	router.Path(prefix+"/api/v1/query_exemplars").Methods("GET", "POST").Handler(querier.ExemplarsHandler(distributor))
 	router.Path(prefix+"/api/v1/labels").Methods("GET", "POST").Handler(promRouter)
 	router.Path(prefix + "/api/v1/label/{name}/values").Methods("GET").Handler(promRouter)
 	router.Path(prefix+"/api/v1/series").Methods("GET", "POST", "DELETE").Handler(promRouter)
@@ -253,6 +254,7 @@ func NewQuerierHandler(
 	router.Path(legacyPrefix + "/api/v1/read").Methods("POST").Handler(legacyPromRouter)
 	router.Path(legacyPrefix+"/api/v1/query").Methods("GET", "POST").Handler(legacyPromRouter)
 	router.Path(legacyPrefix+"/api/v1/query_range").Methods("GET", "POST").Handler(legacyPromRouter)
+	router.Path(legacyPrefix+"/api/v1/query_exemplars").Methods("GET", "POST").Handler(querier.ExemplarsHandler(distributor))
 	router.Path(legacyPrefix+"/api/v1/labels").Methods("GET", "POST").Handler(legacyPromRouter)
 	router.Path(legacyPrefix + "/api/v1/label/{name}/values").Methods("GET").Handler(legacyPromRouter)
 	router.Path(legacyPrefix+"/api/v1/series").Methods("GET", "POST", "DELETE").Handler(legacyPromRouter)
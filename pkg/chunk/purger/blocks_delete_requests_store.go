@@ -0,0 +1,298 @@
+package purger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/cortexproject/cortex/pkg/storage/bucket"
+	cortex_tsdb "github.com/cortexproject/cortex/pkg/storage/tsdb"
+)
+
+const (
+	// BlocksDeleteRequestsPrefix is the location, relative to the tenant's bucket prefix,
+	// where delete requests for the blocks storage are stored.
+	BlocksDeleteRequestsPrefix = "tombstones"
+
+	// blocksCacheGenNumbersFilename is the name of the file, within the delete requests
+	// location, holding the tenant's cache generation numbers.
+	blocksCacheGenNumbersFilename = "cache-gen-numbers.json"
+
+	blocksDeleteRequestExtension = ".json"
+)
+
+// blocksCacheGenNumbers is the representation of cacheGenNumbers stored in the bucket.
+type blocksCacheGenNumbers struct {
+	Store   string `json:"store"`
+	Results string `json:"results"`
+}
+
+// BlocksDeleteStore provides all the methods required to manage the lifecycle of delete requests
+// when running the blocks storage. Delete requests are stored in the bucket, one object per request,
+// within the tenant's BlocksDeleteRequestsPrefix location.
+type BlocksDeleteStore struct {
+	bucketClient objstore.Bucket
+}
+
+// NewBlocksDeleteStoreFromConfig creates a BlocksDeleteStore with a new bucket client created from config.
+func NewBlocksDeleteStoreFromConfig(storageCfg cortex_tsdb.BlocksStorageConfig, logger log.Logger, reg prometheus.Registerer) (*BlocksDeleteStore, error) {
+	bucketClient, err := bucket.NewClient(context.Background(), storageCfg.Bucket, "delete-requests-store", logger, reg)
+	if err != nil {
+		return nil, errors.Wrap(err, "create bucket client")
+	}
+
+	return NewBlocksDeleteStore(bucketClient), nil
+}
+
+// NewBlocksDeleteStore creates a store for managing delete requests in the blocks storage bucket.
+func NewBlocksDeleteStore(bucketClient objstore.Bucket) *BlocksDeleteStore {
+	return &BlocksDeleteStore{bucketClient: bucketClient}
+}
+
+// AddDeleteRequest creates a new delete request.
+func (s *BlocksDeleteStore) AddDeleteRequest(ctx context.Context, userID string, startTime, endTime model.Time, selectors []string) error {
+	return s.addDeleteRequest(ctx, userID, model.Now(), startTime, endTime, selectors)
+}
+
+// addDeleteRequest is also used for tests to create delete requests with different createdAt time.
+func (s *BlocksDeleteStore) addDeleteRequest(ctx context.Context, userID string, createdAt, startTime, endTime model.Time, selectors []string) error {
+	userBucket := bucket.NewUserBucketClient(userID, s.bucketClient)
+	requestID := string(generateUniqueID(userID, selectors))
+
+	for {
+		exists, err := userBucket.Exists(ctx, blocksDeleteRequestPath(requestID))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			break
+		}
+
+		// we have a collision here, lets recreate a new requestID and check for collision
+		time.Sleep(time.Millisecond)
+		requestID = string(generateUniqueID(userID, selectors))
+	}
+
+	req := DeleteRequest{
+		RequestID: requestID,
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Selectors: selectors,
+		Status:    StatusReceived,
+		CreatedAt: createdAt,
+	}
+
+	if err := s.writeDeleteRequest(ctx, userBucket, req); err != nil {
+		return err
+	}
+
+	// we update only cache gen number because only query responses are changing at this stage.
+	// we still have to query data from store for doing query time filtering and we don't want to invalidate its results now.
+	return s.updateCacheGenNumbers(ctx, userBucket, CacheKindResults)
+}
+
+// GetAllDeleteRequestsForUser returns all delete requests for a user, sorted by creation time.
+func (s *BlocksDeleteStore) GetAllDeleteRequestsForUser(ctx context.Context, userID string) ([]DeleteRequest, error) {
+	userBucket := bucket.NewUserBucketClient(userID, s.bucketClient)
+
+	var requestIDs []string
+	err := userBucket.Iter(ctx, BlocksDeleteRequestsPrefix+"/", func(name string) error {
+		filename := path.Base(name)
+		if filename == blocksCacheGenNumbersFilename || !strings.HasSuffix(filename, blocksDeleteRequestExtension) {
+			return nil
+		}
+
+		requestIDs = append(requestIDs, strings.TrimSuffix(filename, blocksDeleteRequestExtension))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list delete requests")
+	}
+
+	deleteRequests := make([]DeleteRequest, 0, len(requestIDs))
+	for _, requestID := range requestIDs {
+		req, err := s.readDeleteRequest(ctx, userBucket, userID, requestID)
+		if errors.Is(err, ErrDeleteRequestNotFound) {
+			// The request has been removed in the meanwhile.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		deleteRequests = append(deleteRequests, *req)
+	}
+
+	sort.Slice(deleteRequests, func(i, j int) bool {
+		return deleteRequests[i].CreatedAt < deleteRequests[j].CreatedAt
+	})
+
+	return deleteRequests, nil
+}
+
+// GetPendingDeleteRequestsForUser returns all delete requests for a user which should be applied
+// at query time. Differently from the chunks storage, processed requests are returned too, because
+// blocks rewritten by the compactor replace the original ones only after the deletion delay and,
+// until then, queriers and store-gateways may still read the original blocks. Processed requests
+// are removed by the compactor once no longer needed.
+func (s *BlocksDeleteStore) GetPendingDeleteRequestsForUser(ctx context.Context, userID string) ([]DeleteRequest, error) {
+	return s.GetAllDeleteRequestsForUser(ctx, userID)
+}
+
+// GetDeleteRequest returns delete request with given requestID.
+func (s *BlocksDeleteStore) GetDeleteRequest(ctx context.Context, userID, requestID string) (*DeleteRequest, error) {
+	return s.readDeleteRequest(ctx, bucket.NewUserBucketClient(userID, s.bucketClient), userID, requestID)
+}
+
+// UpdateStatus updates status of a delete request.
+func (s *BlocksDeleteStore) UpdateStatus(ctx context.Context, userID, requestID string, newStatus DeleteRequestStatus) error {
+	userBucket := bucket.NewUserBucketClient(userID, s.bucketClient)
+
+	req, err := s.readDeleteRequest(ctx, userBucket, userID, requestID)
+	if err != nil {
+		return err
+	}
+
+	req.Status = newStatus
+	if newStatus == StatusProcessed {
+		req.ProcessedAt = model.Now()
+	}
+	if err := s.writeDeleteRequest(ctx, userBucket, *req); err != nil {
+		return err
+	}
+
+	if newStatus == StatusProcessed {
+		// we have deleted data from store so invalidate cache only for store.
+		return s.updateCacheGenNumbers(ctx, userBucket, CacheKindStore)
+	}
+
+	return nil
+}
+
+// RemoveDeleteRequest removes a delete request and increments cache gen number. The time range
+// arguments are ignored and only accepted to offer the same interface of the DeleteStore.
+func (s *BlocksDeleteStore) RemoveDeleteRequest(ctx context.Context, userID, requestID string, _, _, _ model.Time) error {
+	userBucket := bucket.NewUserBucketClient(userID, s.bucketClient)
+
+	if err := userBucket.Delete(ctx, blocksDeleteRequestPath(requestID)); err != nil && !userBucket.IsObjNotFoundErr(err) {
+		return errors.Wrapf(err, "delete request %s", requestID)
+	}
+
+	// we need to invalidate results cache since removal of delete request would cause query results to change
+	return s.updateCacheGenNumbers(ctx, userBucket, CacheKindResults)
+}
+
+// RemoveProcessedDeleteRequest removes a processed delete request. Differently from RemoveDeleteRequest,
+// the cache gen numbers are not updated, because the deleted series have already been removed from
+// the storage and query results don't change.
+func (s *BlocksDeleteStore) RemoveProcessedDeleteRequest(ctx context.Context, userID, requestID string) error {
+	userBucket := bucket.NewUserBucketClient(userID, s.bucketClient)
+
+	if err := userBucket.Delete(ctx, blocksDeleteRequestPath(requestID)); err != nil && !userBucket.IsObjNotFoundErr(err) {
+		return errors.Wrapf(err, "delete request %s", requestID)
+	}
+
+	return nil
+}
+
+// getCacheGenerationNumbers returns cache gen numbers for a user.
+func (s *BlocksDeleteStore) getCacheGenerationNumbers(ctx context.Context, userID string) (*cacheGenNumbers, error) {
+	numbers, err := s.readCacheGenNumbers(ctx, bucket.NewUserBucketClient(userID, s.bucketClient))
+	if err != nil {
+		return nil, err
+	}
+
+	return &cacheGenNumbers{store: numbers.Store, results: numbers.Results}, nil
+}
+
+func (s *BlocksDeleteStore) readDeleteRequest(ctx context.Context, userBucket objstore.Bucket, userID, requestID string) (*DeleteRequest, error) {
+	r, err := userBucket.Get(ctx, blocksDeleteRequestPath(requestID))
+	if userBucket.IsObjNotFoundErr(err) {
+		return nil, ErrDeleteRequestNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "read delete request %s", requestID)
+	}
+	defer r.Close()
+
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read delete request %s", requestID)
+	}
+
+	req := DeleteRequest{}
+	if err := json.Unmarshal(content, &req); err != nil {
+		return nil, errors.Wrapf(err, "decode delete request %s", requestID)
+	}
+
+	req.UserID = userID
+	return &req, nil
+}
+
+func (s *BlocksDeleteStore) writeDeleteRequest(ctx context.Context, userBucket objstore.Bucket, req DeleteRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return errors.Wrap(err, "serialize delete request")
+	}
+
+	return errors.Wrapf(userBucket.Upload(ctx, blocksDeleteRequestPath(req.RequestID), bytes.NewReader(data)), "upload delete request %s", req.RequestID)
+}
+
+func (s *BlocksDeleteStore) readCacheGenNumbers(ctx context.Context, userBucket objstore.Bucket) (blocksCacheGenNumbers, error) {
+	numbers := blocksCacheGenNumbers{}
+
+	r, err := userBucket.Get(ctx, path.Join(BlocksDeleteRequestsPrefix, blocksCacheGenNumbersFilename))
+	if userBucket.IsObjNotFoundErr(err) {
+		return numbers, nil
+	}
+	if err != nil {
+		return numbers, errors.Wrap(err, "read cache generation numbers")
+	}
+	defer r.Close()
+
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return numbers, errors.Wrap(err, "read cache generation numbers")
+	}
+
+	return numbers, errors.Wrap(json.Unmarshal(content, &numbers), "decode cache generation numbers")
+}
+
+func (s *BlocksDeleteStore) updateCacheGenNumbers(ctx context.Context, userBucket objstore.Bucket, kind CacheKind) error {
+	numbers, err := s.readCacheGenNumbers(ctx, userBucket)
+	if err != nil {
+		return err
+	}
+
+	genNumber := strconv.FormatInt(time.Now().Unix(), 10)
+	switch kind {
+	case CacheKindStore:
+		numbers.Store = genNumber
+	case CacheKindResults:
+		numbers.Results = genNumber
+	}
+
+	data, err := json.Marshal(numbers)
+	if err != nil {
+		return errors.Wrap(err, "serialize cache generation numbers")
+	}
+
+	return errors.Wrap(userBucket.Upload(ctx, path.Join(BlocksDeleteRequestsPrefix, blocksCacheGenNumbersFilename), bytes.NewReader(data)), "upload cache generation numbers")
+}
+
+func blocksDeleteRequestPath(requestID string) string {
+	return path.Join(BlocksDeleteRequestsPrefix, requestID+blocksDeleteRequestExtension)
+}
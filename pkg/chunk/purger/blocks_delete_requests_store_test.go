@@ -0,0 +1,120 @@
+package purger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+func TestBlocksDeleteStore(t *testing.T) {
+	const userID = "user-1"
+
+	ctx := context.Background()
+	bkt := objstore.NewInMemBucket()
+	store := NewBlocksDeleteStore(bkt)
+
+	// No delete requests and no cache gen numbers at the beginning.
+	requests, err := store.GetAllDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+
+	genNumbers, err := store.getCacheGenerationNumbers(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, cacheGenNumbers{}, *genNumbers)
+
+	// Add delete requests.
+	now := model.Now()
+	require.NoError(t, store.addDeleteRequest(ctx, userID, now.Add(-time.Hour), 10, 20, []string{`{foo="bar"}`}))
+	require.NoError(t, store.addDeleteRequest(ctx, userID, now, 30, 40, []string{`{foo="baz"}`, `{foo="qux"}`}))
+	require.NoError(t, store.addDeleteRequest(ctx, "user-2", now, 30, 40, []string{`{foo="bar"}`}))
+
+	requests, err = store.GetAllDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+
+	assert.Equal(t, userID, requests[0].UserID)
+	assert.Equal(t, StatusReceived, requests[0].Status)
+	assert.Equal(t, model.Time(10), requests[0].StartTime)
+	assert.Equal(t, model.Time(20), requests[0].EndTime)
+	assert.Equal(t, []string{`{foo="bar"}`}, requests[0].Selectors)
+	assert.Equal(t, []string{`{foo="baz"}`, `{foo="qux"}`}, requests[1].Selectors)
+
+	// Adding a delete request should update the results cache gen number only.
+	genNumbers, err = store.getCacheGenerationNumbers(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, genNumbers.store)
+	assert.NotEmpty(t, genNumbers.results)
+
+	// Get a single delete request.
+	req, err := store.GetDeleteRequest(ctx, userID, requests[0].RequestID)
+	require.NoError(t, err)
+	assert.Equal(t, requests[0], *req)
+
+	_, err = store.GetDeleteRequest(ctx, userID, "unknown")
+	assert.Equal(t, ErrDeleteRequestNotFound, err)
+
+	// Update the status to processed.
+	require.NoError(t, store.UpdateStatus(ctx, userID, requests[0].RequestID, StatusProcessed))
+
+	req, err = store.GetDeleteRequest(ctx, userID, requests[0].RequestID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusProcessed, req.Status)
+	assert.NotZero(t, req.ProcessedAt)
+
+	genNumbers, err = store.getCacheGenerationNumbers(ctx, userID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, genNumbers.store)
+
+	// Processed requests are still returned as pending, because they need to be applied at query time.
+	pending, err := store.GetPendingDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	// Remove a delete request.
+	require.NoError(t, store.RemoveDeleteRequest(ctx, userID, requests[1].RequestID, 0, 0, 0))
+
+	requests, err = store.GetAllDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, StatusProcessed, requests[0].Status)
+
+	// Remove the processed delete request.
+	require.NoError(t, store.RemoveProcessedDeleteRequest(ctx, userID, requests[0].RequestID))
+
+	requests, err = store.GetAllDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+
+	// Requests of other tenants are not affected.
+	requests, err = store.GetAllDeleteRequestsForUser(ctx, "user-2")
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+}
+
+func TestBlocksDeleteStore_ShouldBeUsableByTombstonesLoader(t *testing.T) {
+	const userID = "user-1"
+
+	ctx := context.Background()
+	store := NewBlocksDeleteStore(objstore.NewInMemBucket())
+	require.NoError(t, store.addDeleteRequest(ctx, userID, model.Now(), 10, 20, []string{`{foo="bar"}`}))
+
+	loader := NewTombstonesLoader(store, nil)
+
+	tombstones, err := loader.GetPendingTombstonesForInterval(userID, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 1, tombstones.Len())
+	assert.Equal(t, []model.Interval{{Start: 10, End: 20}}, tombstones.GetDeletedIntervals(labels.Labels{{Name: "foo", Value: "bar"}}, 0, 30))
+	assert.Empty(t, tombstones.GetDeletedIntervals(labels.Labels{{Name: "foo", Value: "baz"}}, 0, 30))
+
+	tombstones, err = loader.GetPendingTombstonesForInterval(userID, 21, 30)
+	require.NoError(t, err)
+	assert.Equal(t, 0, tombstones.Len())
+
+	assert.NotEmpty(t, loader.GetResultsCacheGenNumber([]string{userID}))
+}
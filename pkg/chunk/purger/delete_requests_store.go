@@ -58,6 +58,9 @@ type DeleteRequest struct {
 	Status    DeleteRequestStatus `json:"status"`
 	Matchers  [][]*labels.Matcher `json:"-"`
 	CreatedAt model.Time          `json:"created_at"`
+
+	// ProcessedAt is the time the request has been processed. Only tracked by the blocks storage.
+	ProcessedAt model.Time `json:"processed_at,omitempty"`
 }
 
 // cacheGenNumbers holds store and results cache gen numbers for a user.
@@ -2,6 +2,7 @@ package compactor
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-kit/kit/log"
@@ -38,6 +39,7 @@ type BlocksCleaner struct {
 	logger       log.Logger
 	bucketClient objstore.Bucket
 	usersScanner *cortex_tsdb.UsersScanner
+	cfgProvider  ConfigProvider
 
 	// Keep track of the last owned users.
 	lastOwnedUsers []string
@@ -49,17 +51,19 @@ type BlocksCleaner struct {
 	runsLastSuccess             prometheus.Gauge
 	blocksCleanedTotal          prometheus.Counter
 	blocksFailedTotal           prometheus.Counter
+	blocksMarkedForDeletion     *prometheus.CounterVec
 	tenantBlocks                *prometheus.GaugeVec
 	tenantMarkedBlocks          *prometheus.GaugeVec
 	tenantPartialBlocks         *prometheus.GaugeVec
 	tenantBucketIndexLastUpdate *prometheus.GaugeVec
 }
 
-func NewBlocksCleaner(cfg BlocksCleanerConfig, bucketClient objstore.Bucket, usersScanner *cortex_tsdb.UsersScanner, logger log.Logger, reg prometheus.Registerer) *BlocksCleaner {
+func NewBlocksCleaner(cfg BlocksCleanerConfig, bucketClient objstore.Bucket, usersScanner *cortex_tsdb.UsersScanner, cfgProvider ConfigProvider, logger log.Logger, reg prometheus.Registerer) *BlocksCleaner {
 	c := &BlocksCleaner{
 		cfg:          cfg,
 		bucketClient: bucketClient,
 		usersScanner: usersScanner,
+		cfgProvider:  cfgProvider,
 		logger:       log.With(logger, "component", "cleaner"),
 		runsStarted: promauto.With(reg).NewCounter(prometheus.CounterOpts{
 			Name: "cortex_compactor_block_cleanup_started_total",
@@ -85,6 +89,10 @@ func NewBlocksCleaner(cfg BlocksCleanerConfig, bucketClient objstore.Bucket, use
 			Name: "cortex_compactor_block_cleanup_failures_total",
 			Help: "Total number of blocks failed to be deleted.",
 		}),
+		blocksMarkedForDeletion: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_compactor_retention_blocks_marked_for_deletion_total",
+			Help: "Total number of blocks marked for deletion because exceeding the tenant's retention period.",
+		}, []string{"user"}),
 
 		// The following metrics don't have the "cortex_compactor" prefix because not strictly related to
 		// the compactor. They're just tracked by the compactor because it's the most logical place where these
@@ -162,6 +170,7 @@ func (c *BlocksCleaner) cleanUsers(ctx context.Context, firstRun bool) error {
 			c.tenantMarkedBlocks.DeleteLabelValues(userID)
 			c.tenantPartialBlocks.DeleteLabelValues(userID)
 			c.tenantBucketIndexLastUpdate.DeleteLabelValues(userID)
+			c.blocksMarkedForDeletion.DeleteLabelValues(userID)
 		}
 	}
 	c.lastOwnedUsers = allUsers
@@ -308,6 +317,14 @@ func (c *BlocksCleaner) cleanUser(ctx context.Context, userID string, firstRun b
 		return err
 	}
 
+	// Mark blocks for deletion based on the tenant's retention period. This is done before
+	// updating the bucket index, so that the new deletion marks are picked up by the updater.
+	// The trade-off is that the retention is not applied if the index has to be rebuilt from
+	// scratch, but it will be applied in the next cleanup run.
+	if idx != nil {
+		c.applyUserRetentionPeriod(ctx, userID, idx, c.cfgProvider.CompactorBlocksRetentionPeriod(userID), userBucket, userLogger)
+	}
+
 	// Generate an updated in-memory version of the bucket index.
 	w := bucketindex.NewUpdater(c.bucketClient, userID, c.logger)
 	idx, partials, err := w.UpdateIndex(ctx, idx)
@@ -389,3 +406,49 @@ func (c *BlocksCleaner) cleanUserPartialBlocks(ctx context.Context, partials map
 		level.Info(userLogger).Log("msg", "deleted partial block marked for deletion", "block", blockID)
 	}
 }
+
+// applyUserRetentionPeriod marks for deletion all blocks whose max time is older than the
+// retention period. Failures are logged but not returned, because the retention will be
+// applied again in the next cleanup run.
+func (c *BlocksCleaner) applyUserRetentionPeriod(ctx context.Context, userID string, idx *bucketindex.Index, retention time.Duration, userBucket *bucket.UserBucketClient, userLogger log.Logger) {
+	// A retention period of 0 disables the retention.
+	if retention <= 0 {
+		return
+	}
+
+	blocks := listBlocksOutsideRetentionPeriod(idx, time.Now().Add(-retention))
+
+	for _, b := range blocks {
+		if err := ctx.Err(); err != nil {
+			return
+		}
+
+		level.Info(userLogger).Log("msg", "applying retention: marking block for deletion", "block", b.ID, "maxTime", b.MaxTime, "retention", retention)
+		if err := block.MarkForDeletion(ctx, userLogger, userBucket, b.ID, fmt.Sprintf("block exceeding retention of %v", retention), c.blocksMarkedForDeletion.WithLabelValues(userID)); err != nil {
+			level.Warn(userLogger).Log("msg", "failed to mark block for deletion", "block", b.ID, "err", err)
+		}
+	}
+}
+
+// listBlocksOutsideRetentionPeriod returns the blocks whose max time is older than the
+// input threshold and which are not already marked for deletion.
+func listBlocksOutsideRetentionPeriod(idx *bucketindex.Index, threshold time.Time) (result bucketindex.Blocks) {
+	// Re-marking a block is not harmful but generates a warning, so we skip
+	// blocks we already know have been marked for deletion.
+	marked := make(map[ulid.ULID]struct{}, len(idx.BlockDeletionMarks))
+	for _, m := range idx.BlockDeletionMarks {
+		marked[m.ID] = struct{}{}
+	}
+
+	for _, b := range idx.Blocks {
+		if _, ok := marked[b.ID]; ok {
+			continue
+		}
+
+		if util.TimeFromMillis(b.MaxTime).Before(threshold) {
+			result = append(result, b)
+		}
+	}
+
+	return
+}
@@ -116,7 +116,7 @@ func testBlocksCleanerWithOptions(t *testing.T, options testBlocksCleanerOptions
 	logger := log.NewNopLogger()
 	scanner := tsdb.NewUsersScanner(bucketClient, tsdb.AllUsers, logger)
 
-	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, logger, reg)
+	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, newMockConfigProvider(), logger, reg)
 	require.NoError(t, services.StartAndAwaitRunning(ctx, cleaner))
 	defer services.StopAndAwaitTerminated(ctx, cleaner) //nolint:errcheck
 
@@ -249,7 +249,7 @@ func TestBlocksCleaner_ShouldContinueOnBlockDeletionFailure(t *testing.T) {
 	logger := log.NewNopLogger()
 	scanner := tsdb.NewUsersScanner(bucketClient, tsdb.AllUsers, logger)
 
-	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, logger, nil)
+	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, newMockConfigProvider(), logger, nil)
 	require.NoError(t, services.StartAndAwaitRunning(ctx, cleaner))
 	defer services.StopAndAwaitTerminated(ctx, cleaner) //nolint:errcheck
 
@@ -308,7 +308,7 @@ func TestBlocksCleaner_ShouldRebuildBucketIndexOnCorruptedOne(t *testing.T) {
 	logger := log.NewNopLogger()
 	scanner := tsdb.NewUsersScanner(bucketClient, tsdb.AllUsers, logger)
 
-	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, logger, nil)
+	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, newMockConfigProvider(), logger, nil)
 	require.NoError(t, services.StartAndAwaitRunning(ctx, cleaner))
 	defer services.StopAndAwaitTerminated(ctx, cleaner) //nolint:errcheck
 
@@ -358,7 +358,7 @@ func TestBlocksCleaner_ShouldRemoveMetricsForTenantsNotBelongingAnymoreToTheShar
 	reg := prometheus.NewPedanticRegistry()
 	scanner := tsdb.NewUsersScanner(bucketClient, tsdb.AllUsers, logger)
 
-	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, logger, reg)
+	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, newMockConfigProvider(), logger, reg)
 	require.NoError(t, cleaner.cleanUsers(ctx, true))
 
 	assert.NoError(t, prom_testutil.GatherAndCompare(reg, strings.NewReader(`
@@ -406,6 +406,84 @@ func TestBlocksCleaner_ShouldRemoveMetricsForTenantsNotBelongingAnymoreToTheShar
 	))
 }
 
+func TestBlocksCleaner_ShouldMarkBlocksOutsideRetentionPeriodForDeletion(t *testing.T) {
+	const userID = "user-1"
+
+	bucketClient, _ := cortex_testutil.PrepareFilesystemBucket(t)
+	bucketClient = bucketindex.BucketWithGlobalMarkers(bucketClient)
+
+	// Create blocks.
+	ctx := context.Background()
+	now := time.Now()
+	deletionDelay := 12 * time.Hour
+	block1 := createTSDBBlock(t, bucketClient, userID, now.Add(-5*time.Hour).Unix()*1000, now.Add(-4*time.Hour).Unix()*1000, nil)
+	block2 := createTSDBBlock(t, bucketClient, userID, now.Add(-3*time.Hour).Unix()*1000, now.Add(-2*time.Hour).Unix()*1000, nil)
+	block3 := createTSDBBlock(t, bucketClient, userID, now.Add(-time.Hour).Unix()*1000, now.Unix()*1000, nil)
+	block4 := createTSDBBlock(t, bucketClient, "user-2", now.Add(-5*time.Hour).Unix()*1000, now.Add(-4*time.Hour).Unix()*1000, nil)
+
+	cfg := BlocksCleanerConfig{
+		DeletionDelay:      deletionDelay,
+		CleanupInterval:    time.Minute,
+		CleanupConcurrency: 1,
+	}
+
+	logger := log.NewNopLogger()
+	reg := prometheus.NewPedanticRegistry()
+	scanner := tsdb.NewUsersScanner(bucketClient, tsdb.AllUsers, logger)
+	cfgProvider := newMockConfigProvider()
+
+	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, cfgProvider, logger, reg)
+
+	// The first run builds the bucket index, with no retention configured.
+	require.NoError(t, cleaner.cleanUsers(ctx, true))
+
+	idx, err := bucketindex.ReadIndex(ctx, bucketClient, userID, logger)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ulid.ULID{block1, block2, block3}, idx.Blocks.GetULIDs())
+	assert.Empty(t, idx.BlockDeletionMarks)
+
+	// Configure a retention period only for user-1 and run the cleanup again.
+	cfgProvider.userRetentionPeriods[userID] = 3 * time.Hour
+	require.NoError(t, cleaner.cleanUsers(ctx, false))
+
+	for _, tc := range []struct {
+		path           string
+		expectedExists bool
+	}{
+		{path: path.Join(userID, block1.String(), metadata.DeletionMarkFilename), expectedExists: true},
+		{path: path.Join(userID, bucketindex.BlockDeletionMarkFilepath(block1)), expectedExists: true},
+		{path: path.Join(userID, block2.String(), metadata.DeletionMarkFilename), expectedExists: false},
+		{path: path.Join(userID, block3.String(), metadata.DeletionMarkFilename), expectedExists: false},
+		{path: path.Join("user-2", block4.String(), metadata.DeletionMarkFilename), expectedExists: false},
+	} {
+		exists, err := bucketClient.Exists(ctx, tc.path)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expectedExists, exists, tc.path)
+	}
+
+	// Check the updated bucket index: the block is marked, but not yet deleted.
+	idx, err = bucketindex.ReadIndex(ctx, bucketClient, userID, logger)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ulid.ULID{block1, block2, block3}, idx.Blocks.GetULIDs())
+	assert.ElementsMatch(t, []ulid.ULID{block1}, idx.BlockDeletionMarks.GetULIDs())
+
+	// Running the cleanup again should not mark the same block twice.
+	require.NoError(t, cleaner.cleanUsers(ctx, false))
+
+	assert.NoError(t, prom_testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_bucket_blocks_marked_for_deletion_count Total number of blocks marked for deletion in the bucket.
+		# TYPE cortex_bucket_blocks_marked_for_deletion_count gauge
+		cortex_bucket_blocks_marked_for_deletion_count{user="user-1"} 1
+		cortex_bucket_blocks_marked_for_deletion_count{user="user-2"} 0
+		# HELP cortex_compactor_retention_blocks_marked_for_deletion_total Total number of blocks marked for deletion because exceeding the tenant's retention period.
+		# TYPE cortex_compactor_retention_blocks_marked_for_deletion_total counter
+		cortex_compactor_retention_blocks_marked_for_deletion_total{user="user-1"} 1
+	`),
+		"cortex_bucket_blocks_marked_for_deletion_count",
+		"cortex_compactor_retention_blocks_marked_for_deletion_total",
+	))
+}
+
 type mockBucketFailure struct {
 	objstore.Bucket
 
@@ -418,3 +496,20 @@ func (m *mockBucketFailure) Delete(ctx context.Context, name string) error {
 	}
 	return m.Bucket.Delete(ctx, name)
 }
+
+type mockConfigProvider struct {
+	userRetentionPeriods map[string]time.Duration
+}
+
+func newMockConfigProvider() *mockConfigProvider {
+	return &mockConfigProvider{
+		userRetentionPeriods: make(map[string]time.Duration),
+	}
+}
+
+func (m *mockConfigProvider) CompactorBlocksRetentionPeriod(user string) time.Duration {
+	if result, ok := m.userRetentionPeriods[user]; ok {
+		return result
+	}
+	return 0
+}
@@ -0,0 +1,343 @@
+package compactor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/oklog/ulid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/thanos-io/thanos/pkg/block"
+	"github.com/thanos-io/thanos/pkg/block/metadata"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/cortexproject/cortex/pkg/chunk/purger"
+	"github.com/cortexproject/cortex/pkg/storage/bucket"
+	"github.com/cortexproject/cortex/pkg/storage/tsdb/bucketindex"
+	util_log "github.com/cortexproject/cortex/pkg/util/log"
+)
+
+// BlocksSeriesDeleterConfig holds the config of the BlocksSeriesDeleter.
+type BlocksSeriesDeleterConfig struct {
+	DataDir                   string
+	DeleteRequestCancelPeriod time.Duration
+
+	// ProcessingDelay is the time, after a delete request's end time, before the request is marked
+	// as processed. Until then, new blocks overlapping the request may still be uploaded by ingesters,
+	// so the request is applied again to the newly uploaded blocks on each run.
+	ProcessingDelay time.Duration
+
+	// TombstonesRetention is the time, after a delete request has been processed, before the request
+	// is removed. Until then, the request is applied at query time, because queriers and store-gateways
+	// may still read the original blocks marked for deletion.
+	TombstonesRetention time.Duration
+}
+
+// BlocksSeriesDeleter physically removes from the storage the series matching the tenant's
+// delete requests, rewriting each affected block without the deleted series and marking the
+// original block for deletion.
+type BlocksSeriesDeleter struct {
+	cfg          BlocksSeriesDeleterConfig
+	bucketClient objstore.Bucket
+	deleteStore  *purger.BlocksDeleteStore
+	logger       log.Logger
+
+	// Compactor used to rewrite blocks. Rewriting a block doesn't depend on the
+	// configured block ranges, so we don't need to share it with the compactor.
+	tsdbCompactor tsdb.Compactor
+
+	// Metrics.
+	requestsProcessed       prometheus.Counter
+	requestsFailed          prometheus.Counter
+	requestsRemoved         prometheus.Counter
+	blocksRewritten         prometheus.Counter
+	blocksMarkedForDeletion prometheus.Counter
+}
+
+// NewBlocksSeriesDeleter makes a new BlocksSeriesDeleter.
+func NewBlocksSeriesDeleter(cfg BlocksSeriesDeleterConfig, bucketClient objstore.Bucket, logger log.Logger, reg prometheus.Registerer) (*BlocksSeriesDeleter, error) {
+	// The block ranges are not used when writing a single block.
+	tsdbCompactor, err := tsdb.NewLeveledCompactor(context.Background(), nil, logger, []int64{1}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlocksSeriesDeleter{
+		cfg:           cfg,
+		bucketClient:  bucketClient,
+		deleteStore:   purger.NewBlocksDeleteStore(bucketClient),
+		logger:        logger,
+		tsdbCompactor: tsdbCompactor,
+		requestsProcessed: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_compactor_series_delete_requests_processed_total",
+			Help: "Total number of series delete requests successfully processed.",
+		}),
+		requestsFailed: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_compactor_series_delete_requests_failed_total",
+			Help: "Total number of series delete requests failed to be processed.",
+		}),
+		requestsRemoved: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_compactor_series_delete_requests_removed_total",
+			Help: "Total number of processed series delete requests removed after the tombstones retention.",
+		}),
+		blocksRewritten: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_compactor_series_deletion_blocks_rewritten_total",
+			Help: "Total number of blocks rewritten to remove series matching delete requests.",
+		}),
+		blocksMarkedForDeletion: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_compactor_series_deletion_blocks_marked_for_deletion_total",
+			Help: "Total number of blocks marked for deletion because replaced by a rewritten block without the deleted series.",
+		}),
+	}, nil
+}
+
+// DeleteSeries processes all the tenant's delete requests whose cancellation period is over.
+// Each block overlapping with at least one of such requests is rewritten without the deleted
+// series. Delete requests are marked as processed once the processing delay after their end time
+// is over, because until then new blocks overlapping the request may be uploaded. Processed
+// delete requests are removed once the tombstones retention is over.
+func (d *BlocksSeriesDeleter) DeleteSeries(ctx context.Context, userID string) error {
+	userLogger := util_log.WithUserID(userID, d.logger)
+
+	all, err := d.deleteStore.GetAllDeleteRequestsForUser(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to read delete requests")
+	}
+
+	if err := d.removeExpiredRequests(ctx, userID, all, userLogger); err != nil {
+		return err
+	}
+
+	requests, err := d.getRequestsToProcess(all)
+	if err != nil {
+		return err
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	// Blocks are listed from the bucket, instead of relying on the bucket index only, because the bucket
+	// index may be stale and miss blocks recently uploaded. The existing bucket index, if any, is only
+	// used to avoid reading again the meta of the blocks already known.
+	old, err := bucketindex.ReadIndex(ctx, d.bucketClient, userID, userLogger)
+	if err != nil && !errors.Is(err, bucketindex.ErrIndexNotFound) {
+		level.Warn(userLogger).Log("msg", "failed to read bucket index, listing all blocks from the bucket", "err", err)
+	}
+
+	idx, _, err := bucketindex.NewUpdater(d.bucketClient, userID, userLogger).UpdateIndex(ctx, old)
+	if err != nil {
+		return errors.Wrap(err, "failed to list blocks")
+	}
+
+	for _, req := range requests {
+		if req.Status == purger.StatusDeleting {
+			continue
+		}
+
+		if err := d.deleteStore.UpdateStatus(ctx, userID, req.RequestID, purger.StatusDeleting); err != nil {
+			return errors.Wrapf(err, "failed to update status of delete request %s", req.RequestID)
+		}
+	}
+
+	if err := d.rewriteBlocks(ctx, userID, idx, requests, userLogger); err != nil {
+		d.requestsFailed.Add(float64(len(requests)))
+		return err
+	}
+
+	processedBefore := model.Now().Add(-d.cfg.ProcessingDelay)
+
+	for _, req := range requests {
+		// New blocks overlapping the request may still be uploaded, so it will be applied again in the next run.
+		if req.EndTime.After(processedBefore) {
+			continue
+		}
+
+		if err := d.deleteStore.UpdateStatus(ctx, userID, req.RequestID, purger.StatusProcessed); err != nil {
+			d.requestsFailed.Inc()
+			return errors.Wrapf(err, "failed to update status of delete request %s", req.RequestID)
+		}
+
+		d.requestsProcessed.Inc()
+		level.Info(userLogger).Log("msg", "processed delete request", "request_id", req.RequestID)
+	}
+
+	return nil
+}
+
+// removeExpiredRequests removes the processed delete requests whose tombstones retention is over.
+func (d *BlocksSeriesDeleter) removeExpiredRequests(ctx context.Context, userID string, requests []purger.DeleteRequest, userLogger log.Logger) error {
+	removeBefore := model.Now().Add(-d.cfg.TombstonesRetention)
+
+	for _, req := range requests {
+		if req.Status != purger.StatusProcessed || req.ProcessedAt.After(removeBefore) {
+			continue
+		}
+
+		if err := d.deleteStore.RemoveProcessedDeleteRequest(ctx, userID, req.RequestID); err != nil {
+			return errors.Wrapf(err, "failed to remove processed delete request %s", req.RequestID)
+		}
+
+		d.requestsRemoved.Inc()
+		level.Info(userLogger).Log("msg", "removed processed delete request", "request_id", req.RequestID)
+	}
+
+	return nil
+}
+
+// getRequestsToProcess returns the delete requests not processed yet whose cancellation period
+// is over, with matchers parsed.
+func (d *BlocksSeriesDeleter) getRequestsToProcess(all []purger.DeleteRequest) ([]purger.DeleteRequest, error) {
+	var result []purger.DeleteRequest
+	for _, req := range all {
+		if req.Status == purger.StatusProcessed {
+			continue
+		}
+
+		// The request can still be cancelled.
+		if req.CreatedAt.Add(d.cfg.DeleteRequestCancelPeriod).After(model.Now()) {
+			continue
+		}
+
+		req.Matchers = make([][]*labels.Matcher, 0, len(req.Selectors))
+		for _, selector := range req.Selectors {
+			matchers, err := parser.ParseMetricSelector(selector)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse selector of delete request %s", req.RequestID)
+			}
+			req.Matchers = append(req.Matchers, matchers)
+		}
+
+		result = append(result, req)
+	}
+
+	return result, nil
+}
+
+func (d *BlocksSeriesDeleter) rewriteBlocks(ctx context.Context, userID string, idx *bucketindex.Index, requests []purger.DeleteRequest, userLogger log.Logger) error {
+	userBucket := bucket.NewUserBucketClient(userID, d.bucketClient)
+
+	// Blocks already marked for deletion will be deleted anyway, so there's no need to rewrite them.
+	marked := make(map[ulid.ULID]struct{}, len(idx.BlockDeletionMarks))
+	for _, m := range idx.BlockDeletionMarks {
+		marked[m.ID] = struct{}{}
+	}
+
+	for _, b := range idx.Blocks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if _, ok := marked[b.ID]; ok {
+			continue
+		}
+
+		var overlapping []purger.DeleteRequest
+		for _, req := range requests {
+			if b.Within(int64(req.StartTime), int64(req.EndTime)) {
+				overlapping = append(overlapping, req)
+			}
+		}
+		if len(overlapping) == 0 {
+			continue
+		}
+
+		if err := d.rewriteBlock(ctx, userID, userBucket, b.ID, overlapping, userLogger); err != nil {
+			return errors.Wrapf(err, "failed to delete series from block %s", b.ID.String())
+		}
+	}
+
+	return nil
+}
+
+// rewriteBlock downloads the block, rewrites it without the series matching the input delete requests,
+// uploads the new block (if not empty) and marks the original one for deletion. If the block doesn't
+// contain any series to delete, it's left untouched.
+func (d *BlocksSeriesDeleter) rewriteBlock(ctx context.Context, userID string, userBucket objstore.Bucket, blockID ulid.ULID, requests []purger.DeleteRequest, userLogger log.Logger) error {
+	workDir := filepath.Join(d.cfg.DataDir, "series-deletion", userID)
+	if err := os.MkdirAll(workDir, 0750); err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(workDir); err != nil {
+			level.Warn(userLogger).Log("msg", "failed to remove series deletion working directory", "dir", workDir, "err", err)
+		}
+	}()
+
+	srcDir := filepath.Join(workDir, blockID.String())
+	if err := block.Download(ctx, userLogger, userBucket, blockID, srcDir); err != nil {
+		return errors.Wrap(err, "download block")
+	}
+
+	srcMeta, err := metadata.ReadFromDir(srcDir)
+	if err != nil {
+		return errors.Wrap(err, "read block meta")
+	}
+
+	srcBlock, err := tsdb.OpenBlock(userLogger, srcDir, nil)
+	if err != nil {
+		return errors.Wrap(err, "open block")
+	}
+	defer srcBlock.Close() //nolint:errcheck
+
+	// Tombstones are written locally to the downloaded block and then
+	// applied when the block is rewritten.
+	for _, req := range requests {
+		for _, matchers := range req.Matchers {
+			if err := srcBlock.Delete(int64(req.StartTime), int64(req.EndTime), matchers...); err != nil {
+				return errors.Wrap(err, "add tombstones to block")
+			}
+		}
+	}
+
+	newID, err := srcBlock.CleanTombstones(workDir, d.tsdbCompactor)
+	if err != nil {
+		return errors.Wrap(err, "rewrite block")
+	}
+	if newID == nil {
+		level.Debug(userLogger).Log("msg", "block doesn't contain any series to delete", "block", blockID)
+		return nil
+	}
+
+	// If all series have been deleted, the rewritten block is empty and there's nothing to upload.
+	if *newID != (ulid.ULID{}) {
+		if err := d.uploadRewrittenBlock(ctx, userBucket, filepath.Join(workDir, newID.String()), srcMeta, *newID, userLogger); err != nil {
+			return err
+		}
+
+		d.blocksRewritten.Inc()
+		level.Info(userLogger).Log("msg", "rewritten block without deleted series", "block", blockID, "new_block", newID.String())
+	}
+
+	return block.MarkForDeletion(ctx, userLogger, userBucket, blockID, "replaced by a block without the deleted series", d.blocksMarkedForDeletion)
+}
+
+func (d *BlocksSeriesDeleter) uploadRewrittenBlock(ctx context.Context, userBucket objstore.Bucket, dir string, srcMeta *metadata.Meta, newID ulid.ULID, userLogger log.Logger) error {
+	newMeta, err := metadata.ReadFromDir(dir)
+	if err != nil {
+		return errors.Wrap(err, "read rewritten block meta")
+	}
+
+	// The rewritten block replaces the original one, so it keeps the same compaction level and
+	// external labels. Its sources are a superset of the original block's sources, so that the
+	// deduplication filter (used both by the compactor and store-gateway) discards the original
+	// block as soon as the rewritten one is discovered, even before the original block is deleted.
+	newMeta.Thanos = srcMeta.Thanos
+	newMeta.Thanos.Files = nil
+	newMeta.Compaction.Level = srcMeta.Compaction.Level
+	newMeta.Compaction.Sources = append(append([]ulid.ULID{}, srcMeta.Compaction.Sources...), newID)
+
+	if err := newMeta.WriteToDir(userLogger, dir); err != nil {
+		return errors.Wrap(err, "write rewritten block meta")
+	}
+
+	return errors.Wrap(block.Upload(ctx, userLogger, userBucket, dir), "upload rewritten block")
+}
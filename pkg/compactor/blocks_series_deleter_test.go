@@ -0,0 +1,234 @@
+package compactor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/oklog/ulid"
+	"github.com/prometheus/client_golang/prometheus"
+	prom_testutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+	"github.com/prometheus/prometheus/tsdb/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/block"
+	"github.com/thanos-io/thanos/pkg/block/metadata"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/cortexproject/cortex/pkg/chunk/purger"
+	"github.com/cortexproject/cortex/pkg/storage/bucket"
+	"github.com/cortexproject/cortex/pkg/storage/tsdb/bucketindex"
+	cortex_testutil "github.com/cortexproject/cortex/pkg/storage/tsdb/testutil"
+)
+
+func TestBlocksSeriesDeleter_DeleteSeries(t *testing.T) {
+	const userID = "user-1"
+
+	bucketClient, _ := cortex_testutil.PrepareFilesystemBucket(t)
+	bucketClient = bucketindex.BucketWithGlobalMarkers(bucketClient)
+
+	dataDir, err := ioutil.TempDir(os.TempDir(), "series-deleter-test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dataDir)) })
+
+	// Create blocks. Each block contains two series: series_id="0" and series_id="1".
+	ctx := context.Background()
+	externalLabels := map[string]string{"__org_id__": userID}
+	block1 := createTSDBBlock(t, bucketClient, userID, 10, 20, externalLabels)
+	block2 := createTSDBBlock(t, bucketClient, userID, 20, 30, externalLabels)
+	block3 := createTSDBBlock(t, bucketClient, userID, 30, 40, externalLabels)
+	block4 := createTSDBBlock(t, bucketClient, userID, 40, 50, externalLabels)
+	createDeletionMark(t, bucketClient, userID, block4, time.Now())
+
+	// Create delete requests:
+	// - one overlapping block1 and block2, deleting series_id="0"
+	// - one overlapping block2, deleting series_id="1"
+	deleteStore := purger.NewBlocksDeleteStore(bucketClient)
+	require.NoError(t, deleteStore.AddDeleteRequest(ctx, userID, 0, 25, []string{`{series_id="0"}`}))
+	require.NoError(t, deleteStore.AddDeleteRequest(ctx, userID, 20, 30, []string{`{series_id="1"}`}))
+
+	// Build the bucket index.
+	idx, _, err := bucketindex.NewUpdater(bucketClient, userID, log.NewNopLogger()).UpdateIndex(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, bucketindex.WriteIndex(ctx, bucketClient, userID, idx))
+
+	// Requests whose cancellation period is not over yet should not be processed.
+	cfg := BlocksSeriesDeleterConfig{DataDir: dataDir, DeleteRequestCancelPeriod: time.Hour, ProcessingDelay: time.Hour, TombstonesRetention: time.Hour}
+	deleter, err := NewBlocksSeriesDeleter(cfg, bucketClient, log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	require.NoError(t, deleter.DeleteSeries(ctx, userID))
+
+	requests, err := deleteStore.GetAllDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	for _, req := range requests {
+		assert.Equal(t, purger.StatusReceived, req.Status)
+	}
+
+	// Requests whose cancellation period is over should be processed.
+	cfg.DeleteRequestCancelPeriod = 0
+	deleter, err = NewBlocksSeriesDeleter(cfg, bucketClient, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+	require.NoError(t, deleter.DeleteSeries(ctx, userID))
+
+	// Check the delete requests status.
+	requests, err = deleteStore.GetAllDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	for _, req := range requests {
+		assert.Equal(t, purger.StatusProcessed, req.Status)
+	}
+
+	// Original blocks affected by the processed requests should be marked for deletion.
+	for _, tc := range []struct {
+		blockID        ulid.ULID
+		expectedMarked bool
+	}{
+		{blockID: block1, expectedMarked: true},
+		{blockID: block2, expectedMarked: true},
+		{blockID: block3, expectedMarked: false},
+		{blockID: block4, expectedMarked: true},
+	} {
+		exists, err := bucketClient.Exists(ctx, path.Join(userID, tc.blockID.String(), metadata.DeletionMarkFilename))
+		require.NoError(t, err)
+		assert.Equal(t, tc.expectedMarked, exists, tc.blockID.String())
+	}
+
+	// Only block1 should have been rewritten, because all series of block2 have been deleted.
+	idx, _, err = bucketindex.NewUpdater(bucketClient, userID, log.NewNopLogger()).UpdateIndex(ctx, nil)
+	require.NoError(t, err)
+
+	var rewritten []*bucketindex.Block
+	for _, b := range idx.Blocks {
+		if b.ID != block1 && b.ID != block2 && b.ID != block3 && b.ID != block4 {
+			rewritten = append(rewritten, b)
+		}
+	}
+	require.Len(t, rewritten, 1)
+	assert.Equal(t, int64(10), rewritten[0].MinTime)
+	assert.Equal(t, int64(20), rewritten[0].MaxTime)
+
+	userBucket := bucket.NewUserBucketClient(userID, bucketClient)
+	meta, err := block.DownloadMeta(ctx, log.NewNopLogger(), userBucket, rewritten[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, externalLabels, meta.Thanos.Labels)
+	assert.ElementsMatch(t, []ulid.ULID{block1, rewritten[0].ID}, meta.Compaction.Sources)
+
+	assert.Equal(t, []labels.Labels{{{Name: "series_id", Value: "1"}}}, readBlockSeries(t, userBucket, dataDir, rewritten[0].ID))
+
+	assert.Equal(t, float64(2), prom_testutil.ToFloat64(deleter.requestsProcessed))
+	assert.Equal(t, float64(0), prom_testutil.ToFloat64(deleter.requestsFailed))
+	assert.Equal(t, float64(1), prom_testutil.ToFloat64(deleter.blocksRewritten))
+	assert.Equal(t, float64(2), prom_testutil.ToFloat64(deleter.blocksMarkedForDeletion))
+
+	// Running it again should be a no-op, because there are no more requests to process.
+	require.NoError(t, deleter.DeleteSeries(ctx, userID))
+	assert.Equal(t, float64(2), prom_testutil.ToFloat64(deleter.requestsProcessed))
+	assert.Equal(t, float64(1), prom_testutil.ToFloat64(deleter.blocksRewritten))
+
+	// Processed requests should be removed once the tombstones retention is over.
+	cfg.TombstonesRetention = 0
+	deleter, err = NewBlocksSeriesDeleter(cfg, bucketClient, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+	require.NoError(t, deleter.DeleteSeries(ctx, userID))
+
+	requests, err = deleteStore.GetAllDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+	assert.Equal(t, float64(2), prom_testutil.ToFloat64(deleter.requestsRemoved))
+}
+
+func TestBlocksSeriesDeleter_ShouldApplyRequestToBlocksUploadedBeforeProcessingDelay(t *testing.T) {
+	const userID = "user-1"
+
+	bucketClient, _ := cortex_testutil.PrepareFilesystemBucket(t)
+	bucketClient = bucketindex.BucketWithGlobalMarkers(bucketClient)
+
+	dataDir, err := ioutil.TempDir(os.TempDir(), "series-deleter-test")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dataDir)) })
+
+	ctx := context.Background()
+	externalLabels := map[string]string{"__org_id__": userID}
+	now := time.Now()
+	nowMillis := now.UnixNano() / int64(time.Millisecond)
+
+	// The request ends now, so the processing delay is not over yet.
+	deleteStore := purger.NewBlocksDeleteStore(bucketClient)
+	require.NoError(t, deleteStore.AddDeleteRequest(ctx, userID, 0, model.TimeFromUnixNano(now.UnixNano()), []string{`{series_id="0"}`}))
+
+	// The bucket index doesn't exist, so blocks are listed from the bucket.
+	block1 := createTSDBBlock(t, bucketClient, userID, nowMillis-20, nowMillis-10, externalLabels)
+
+	cfg := BlocksSeriesDeleterConfig{DataDir: dataDir, ProcessingDelay: time.Hour, TombstonesRetention: time.Hour}
+	deleter, err := NewBlocksSeriesDeleter(cfg, bucketClient, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+	require.NoError(t, deleter.DeleteSeries(ctx, userID))
+
+	requests, err := deleteStore.GetAllDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, purger.StatusDeleting, requests[0].Status)
+	assert.Equal(t, float64(1), prom_testutil.ToFloat64(deleter.blocksMarkedForDeletion))
+
+	// A block overlapping the request is uploaded after the first run.
+	block2 := createTSDBBlock(t, bucketClient, userID, nowMillis-10, nowMillis, externalLabels)
+	require.NoError(t, deleter.DeleteSeries(ctx, userID))
+
+	for _, blockID := range []ulid.ULID{block1, block2} {
+		exists, err := bucketClient.Exists(ctx, path.Join(userID, blockID.String(), metadata.DeletionMarkFilename))
+		require.NoError(t, err)
+		assert.True(t, exists, blockID.String())
+	}
+	assert.Equal(t, float64(2), prom_testutil.ToFloat64(deleter.blocksMarkedForDeletion))
+	assert.Equal(t, float64(0), prom_testutil.ToFloat64(deleter.requestsProcessed))
+
+	// Once the processing delay is over, the request is marked as processed.
+	cfg.ProcessingDelay = 0
+	deleter, err = NewBlocksSeriesDeleter(cfg, bucketClient, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+	require.NoError(t, deleter.DeleteSeries(ctx, userID))
+
+	requests, err = deleteStore.GetAllDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, purger.StatusProcessed, requests[0].Status)
+	assert.Equal(t, float64(1), prom_testutil.ToFloat64(deleter.requestsProcessed))
+}
+
+func readBlockSeries(t *testing.T, bkt objstore.Bucket, dataDir string, blockID ulid.ULID) []labels.Labels {
+	dir := filepath.Join(dataDir, "read", blockID.String())
+	require.NoError(t, block.Download(context.Background(), log.NewNopLogger(), bkt, blockID, dir))
+
+	b, err := tsdb.OpenBlock(log.NewNopLogger(), dir, nil)
+	require.NoError(t, err)
+	defer b.Close() //nolint:errcheck
+
+	idxReader, err := b.Index()
+	require.NoError(t, err)
+	defer idxReader.Close() //nolint:errcheck
+
+	name, value := index.AllPostingsKey()
+	postings, err := idxReader.Postings(name, value)
+	require.NoError(t, err)
+
+	var result []labels.Labels
+	for postings.Next() {
+		var lbls labels.Labels
+		var chks []chunks.Meta
+		require.NoError(t, idxReader.Series(postings.At(), &lbls, &chks))
+		result = append(result, lbls)
+	}
+	require.NoError(t, postings.Err())
+
+	return result
+}
@@ -61,6 +61,11 @@ type Config struct {
 	ShardingEnabled bool       `yaml:"sharding_enabled"`
 	ShardingRing    RingConfig `yaml:"sharding_ring"`
 
+	// Series deletion. These options are not exposed as compactor's config, but set
+	// from the purger config, because shared with the API managing delete requests.
+	SeriesDeletionEnabled     bool          `yaml:"-"`
+	DeleteRequestCancelPeriod time.Duration `yaml:"-"`
+
 	// No need to add options to customize the retry backoff,
 	// given the defaults should be fine, but allow to override
 	// it in tests.
@@ -108,12 +113,19 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+// ConfigProvider defines the per-tenant config provider for the Compactor.
+type ConfigProvider interface {
+	// CompactorBlocksRetentionPeriod returns the retention period for a given user.
+	CompactorBlocksRetentionPeriod(user string) time.Duration
+}
+
 // Compactor is a multi-tenant TSDB blocks compactor based on Thanos.
 type Compactor struct {
 	services.Service
 
 	compactorCfg Config
 	storageCfg   cortex_tsdb.BlocksStorageConfig
+	cfgProvider  ConfigProvider
 	logger       log.Logger
 	parentLogger log.Logger
 	registerer   prometheus.Registerer
@@ -134,6 +146,10 @@ type Compactor struct {
 	// Blocks cleaner is responsible to hard delete blocks marked for deletion.
 	blocksCleaner *BlocksCleaner
 
+	// Series deleter is responsible to rewrite blocks without the series matching
+	// delete requests. Nil if series deletion is disabled.
+	seriesDeleter *BlocksSeriesDeleter
+
 	// Underlying compactor and planner used to compact TSDB blocks.
 	tsdbCompactor tsdb.Compactor
 	tsdbPlanner   compact.Planner
@@ -164,7 +180,7 @@ type Compactor struct {
 }
 
 // NewCompactor makes a new Compactor.
-func NewCompactor(compactorCfg Config, storageCfg cortex_tsdb.BlocksStorageConfig, logger log.Logger, registerer prometheus.Registerer) (*Compactor, error) {
+func NewCompactor(compactorCfg Config, storageCfg cortex_tsdb.BlocksStorageConfig, cfgProvider ConfigProvider, logger log.Logger, registerer prometheus.Registerer) (*Compactor, error) {
 	createDependencies := func(ctx context.Context) (objstore.Bucket, tsdb.Compactor, compact.Planner, error) {
 		bucketClient, err := bucket.NewClient(ctx, storageCfg.Bucket, "compactor", logger, registerer)
 		if err != nil {
@@ -180,7 +196,7 @@ func NewCompactor(compactorCfg Config, storageCfg cortex_tsdb.BlocksStorageConfi
 		return bucketClient, compactor, planner, nil
 	}
 
-	cortexCompactor, err := newCompactor(compactorCfg, storageCfg, logger, registerer, createDependencies)
+	cortexCompactor, err := newCompactor(compactorCfg, storageCfg, cfgProvider, logger, registerer, createDependencies)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create Cortex blocks compactor")
 	}
@@ -191,6 +207,7 @@ func NewCompactor(compactorCfg Config, storageCfg cortex_tsdb.BlocksStorageConfi
 func newCompactor(
 	compactorCfg Config,
 	storageCfg cortex_tsdb.BlocksStorageConfig,
+	cfgProvider ConfigProvider,
 	logger log.Logger,
 	registerer prometheus.Registerer,
 	createDependencies func(ctx context.Context) (objstore.Bucket, tsdb.Compactor, compact.Planner, error),
@@ -198,6 +215,7 @@ func newCompactor(
 	c := &Compactor{
 		compactorCfg:       compactorCfg,
 		storageCfg:         storageCfg,
+		cfgProvider:        cfgProvider,
 		parentLogger:       logger,
 		logger:             log.With(logger, "component", "compactor"),
 		registerer:         registerer,
@@ -345,7 +363,22 @@ func (c *Compactor) starting(ctx context.Context) error {
 		CleanupConcurrency:                 c.compactorCfg.CleanupConcurrency,
 		BlockDeletionMarksMigrationEnabled: c.compactorCfg.BlockDeletionMarksMigrationEnabled,
 		TenantCleanupDelay:                 c.compactorCfg.TenantCleanupDelay,
-	}, c.bucketClient, c.usersScanner, c.parentLogger, c.registerer)
+	}, c.bucketClient, c.usersScanner, c.cfgProvider, c.parentLogger, c.registerer)
+
+	// Create the series deleter, if enabled.
+	if c.compactorCfg.SeriesDeletionEnabled {
+		c.seriesDeleter, err = NewBlocksSeriesDeleter(BlocksSeriesDeleterConfig{
+			DataDir:                   c.compactorCfg.DataDir,
+			DeleteRequestCancelPeriod: c.compactorCfg.DeleteRequestCancelPeriod,
+			ProcessingDelay:           seriesDeletionProcessingDelay(c.storageCfg),
+			// Original blocks are deleted after the deletion delay, and queriers and store-gateways
+			// discover it with the next bucket index update.
+			TombstonesRetention: c.compactorCfg.DeletionDelay + c.compactorCfg.CleanupInterval,
+		}, c.bucketClient, c.parentLogger, c.registerer)
+		if err != nil {
+			return errors.Wrap(err, "failed to create the series deleter")
+		}
+	}
 
 	// Ensure an initial cleanup occurred before starting the compactor.
 	if err := services.StartAndAwaitRunning(ctx, c.blocksCleaner); err != nil {
@@ -486,7 +519,31 @@ func (c *Compactor) compactUserWithRetries(ctx context.Context, userID string) e
 	return lastErr
 }
 
+// seriesDeletionProcessingDelay returns the time, after a delete request's end time, after which no more
+// blocks overlapping the request are expected to be uploaded by the ingesters. Ingesters upload a block
+// once its range has been compacted from the head, and keep it for the retention period.
+func seriesDeletionProcessingDelay(storageCfg cortex_tsdb.BlocksStorageConfig) time.Duration {
+	delay := storageCfg.TSDB.Retention + storageCfg.TSDB.ShipInterval
+
+	maxRange := time.Duration(0)
+	for _, r := range storageCfg.TSDB.BlockRanges {
+		if r > maxRange {
+			maxRange = r
+		}
+	}
+
+	return delay + maxRange
+}
+
 func (c *Compactor) compactUser(ctx context.Context, userID string) error {
+	// Physically delete series matching the tenant's delete requests before compacting
+	// blocks, so that the compaction doesn't run on blocks which are going to be replaced.
+	if c.seriesDeleter != nil {
+		if err := c.seriesDeleter.DeleteSeries(ctx, userID); err != nil {
+			return errors.Wrap(err, "series deletion")
+		}
+	}
+
 	bucket := bucket.NewUserBucketClient(userID, c.bucketClient)
 
 	reg := prometheus.NewRegistry()
@@ -221,6 +221,9 @@ func (c *Config) Validate(log log.Logger) error {
 	if err := c.Alertmanager.Validate(); err != nil {
 		return errors.Wrap(err, "invalid alertmanager config")
 	}
+	if err := c.QueryScheduler.Validate(); err != nil {
+		return errors.Wrap(err, "invalid query-scheduler config")
+	}
 
 	if c.Storage.Engine == storage.StorageEngineBlocks && c.Querier.SecondStoreEngine != storage.StorageEngineChunks && len(c.Schema.Configs) > 0 {
 		level.Warn(log).Log("schema configuration is not used by the blocks storage engine, and will have no effect")
@@ -277,6 +280,7 @@ type Cortex struct {
 	Flusher                  *flusher.Flusher
 	Store                    chunk.Store
 	DeletesStore             *purger.DeleteStore
+	BlocksDeletesStore       *purger.BlocksDeleteStore
 	Frontend                 *frontendv1.Frontend
 	TableManager             *chunk.TableManager
 	RuntimeConfig            *runtimeconfig.Manager
@@ -332,6 +336,7 @@ func New(cfg Config) (*Cortex, error) {
 	}
 
 	cortex.setupThanosTracing()
+	cortex.setupPushCapability()
 
 	if err := cortex.setupModuleManager(); err != nil {
 		return nil, err
@@ -347,6 +352,18 @@ func (t *Cortex) setupThanosTracing() {
 	t.Cfg.Server.GRPCStreamMiddleware = append(t.Cfg.Server.GRPCStreamMiddleware, ThanosTracerStreamInterceptor)
 }
 
+// setupPushCapability appends a gRPC middleware used to enforce the per-tenant push capability
+// on the series pushed to the distributor via gRPC. Overrides are initialized after the server,
+// so they're looked up on each request.
+func (t *Cortex) setupPushCapability() {
+	t.Cfg.Server.GRPCMiddleware = append(t.Cfg.Server.GRPCMiddleware, api.PushCapabilityUnaryServerInterceptor(func() api.TenantCapabilities {
+		if t.Overrides == nil {
+			return nil
+		}
+		return t.Overrides
+	}))
+}
+
 // Run starts Cortex running, and blocks until a Cortex stops.
 func (t *Cortex) Run() error {
 	// Register custom process metrics.
@@ -3,6 +3,7 @@ package cortex
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -165,9 +166,16 @@ func (t *Cortex) initRuntimeConfig() (services.Service, error) {
 
 func (t *Cortex) initOverrides() (serv services.Service, err error) {
 	t.Overrides, err = validation.NewOverrides(t.Cfg.LimitsConfig, tenantLimitsFromRuntimeConfig(t.RuntimeConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	// Enforce the per-tenant capabilities on the API endpoints.
+	t.API.SetTenantCapabilities(t.Overrides)
+
 	// overrides don't have operational state, nor do they need to do anything more in starting/stopping phase,
 	// so there is no need to return any service.
-	return nil, err
+	return nil, nil
 }
 
 func (t *Cortex) initDistributorService() (serv services.Service, err error) {
@@ -443,6 +451,16 @@ func (t *Cortex) initChunkStore() (serv services.Service, err error) {
 }
 
 func (t *Cortex) initDeleteRequestsStore() (serv services.Service, err error) {
+	if t.Cfg.Storage.Engine == storage.StorageEngineBlocks && t.Cfg.PurgerConfig.Enable {
+		t.BlocksDeletesStore, err = purger.NewBlocksDeleteStoreFromConfig(t.Cfg.BlocksStorage, util_log.Logger, prometheus.DefaultRegisterer)
+		if err != nil {
+			return
+		}
+
+		t.TombstonesLoader = purger.NewTombstonesLoader(t.BlocksDeletesStore, prometheus.DefaultRegisterer)
+		return
+	}
+
 	if t.Cfg.Storage.Engine != storage.StorageEngineChunks || !t.Cfg.PurgerConfig.Enable {
 		// until we need to explicitly enable delete series support we need to do create TombstonesLoader without DeleteStore which acts as noop
 		t.TombstonesLoader = purger.NewTombstonesLoader(nil, nil)
@@ -471,8 +489,9 @@ func (t *Cortex) initDeleteRequestsStore() (serv services.Service, err error) {
 // initQueryFrontendTripperware instantiates the tripperware used by the query frontend
 // to optimize Prometheus query requests.
 func (t *Cortex) initQueryFrontendTripperware() (serv services.Service, err error) {
-	// Load the schema only if sharded queries is set.
-	if t.Cfg.QueryRange.ShardedQueries {
+	// Load the schema only if sharded queries is set and the queriers run the chunks storage.
+	t.Cfg.QueryRange.BlocksStorageEnabled = t.Cfg.Storage.Engine == storage.StorageEngineBlocks
+	if t.Cfg.QueryRange.ShardedQueries && !t.Cfg.QueryRange.BlocksStorageEnabled {
 		err := t.Cfg.Schema.Load()
 		if err != nil {
 			return nil, err
@@ -627,8 +646,19 @@ func (t *Cortex) initRuler() (serv services.Service, err error) {
 	rulerRegisterer := prometheus.WrapRegistererWith(prometheus.Labels{"engine": "ruler"}, prometheus.DefaultRegisterer)
 	queryable, engine := querier.New(t.Cfg.Querier, t.Overrides, t.Distributor, t.StoreQueryables, t.TombstonesLoader, rulerRegisterer)
 
-	managerFactory := ruler.DefaultTenantManagerFactory(t.Cfg.Ruler, t.Distributor, queryable, engine, t.Overrides)
-	manager, err := ruler.NewDefaultMultiTenantManager(t.Cfg.Ruler, managerFactory, prometheus.DefaultRegisterer, util_log.Logger)
+	var remoteQuerier *ruler.RemoteQuerier
+	var queryFrontendConn io.Closer
+	if t.Cfg.Ruler.QueryFrontend.Address != "" {
+		client, conn, err := ruler.DialQueryFrontend(t.Cfg.Ruler.QueryFrontend, prometheus.DefaultRegisterer)
+		if err != nil {
+			return nil, err
+		}
+		queryFrontendConn = conn
+		remoteQuerier = ruler.NewRemoteQuerier(client, t.Cfg.Ruler.QueryFrontend, t.Cfg.API.PrometheusHTTPPrefix, util_log.Logger)
+	}
+
+	managerFactory := ruler.DefaultTenantManagerFactory(t.Cfg.Ruler, t.Distributor, queryable, engine, remoteQuerier, t.Overrides)
+	manager, err := ruler.NewDefaultMultiTenantManager(t.Cfg.Ruler, managerFactory, t.Overrides, prometheus.DefaultRegisterer, util_log.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -640,6 +670,7 @@ func (t *Cortex) initRuler() (serv services.Service, err error) {
 		util_log.Logger,
 		t.RulerStorage,
 		t.Overrides,
+		queryFrontendConn,
 	)
 	if err != nil {
 		return
@@ -650,7 +681,7 @@ func (t *Cortex) initRuler() (serv services.Service, err error) {
 
 	// If the API is enabled, register the Ruler API
 	if t.Cfg.Ruler.EnableAPI {
-		t.API.RegisterRulerAPI(ruler.NewAPI(t.Ruler, t.RulerStorage))
+		t.API.RegisterRulerAPI(ruler.NewAPI(t.Ruler, t.RulerStorage, ruler.DefaultTenantQueryFunc(queryable, engine, remoteQuerier, t.Overrides)))
 	}
 
 	return t.Ruler, nil
@@ -673,7 +704,7 @@ func (t *Cortex) initConfig() (serv services.Service, err error) {
 func (t *Cortex) initAlertManager() (serv services.Service, err error) {
 	t.Cfg.Alertmanager.ShardingRing.ListenPort = t.Cfg.Server.HTTPListenPort
 
-	t.Alertmanager, err = alertmanager.NewMultitenantAlertmanager(&t.Cfg.Alertmanager, util_log.Logger, prometheus.DefaultRegisterer)
+	t.Alertmanager, err = alertmanager.NewMultitenantAlertmanager(&t.Cfg.Alertmanager, t.Overrides, util_log.Logger, prometheus.DefaultRegisterer)
 	if err != nil {
 		return
 	}
@@ -684,8 +715,10 @@ func (t *Cortex) initAlertManager() (serv services.Service, err error) {
 
 func (t *Cortex) initCompactor() (serv services.Service, err error) {
 	t.Cfg.Compactor.ShardingRing.ListenPort = t.Cfg.Server.GRPCListenPort
+	t.Cfg.Compactor.SeriesDeletionEnabled = t.Cfg.PurgerConfig.Enable
+	t.Cfg.Compactor.DeleteRequestCancelPeriod = t.Cfg.PurgerConfig.DeleteRequestCancelPeriod
 
-	t.Compactor, err = compactor.NewCompactor(t.Cfg.Compactor, t.Cfg.BlocksStorage, util_log.Logger, prometheus.DefaultRegisterer)
+	t.Compactor, err = compactor.NewCompactor(t.Cfg.Compactor, t.Cfg.BlocksStorage, t.Overrides, util_log.Logger, prometheus.DefaultRegisterer)
 	if err != nil {
 		return
 	}
@@ -766,6 +799,10 @@ func (t *Cortex) initBlocksPurger() (services.Service, error) {
 	}
 
 	t.API.RegisterBlocksPurger(purgerAPI)
+
+	if t.BlocksDeletesStore != nil {
+		t.API.RegisterBlocksSeriesDeletion(t.BlocksDeletesStore, t.Cfg.PurgerConfig.DeleteRequestCancelPeriod)
+	}
 	return nil, nil
 }
 
@@ -822,7 +859,7 @@ func (t *Cortex) setupModuleManager() error {
 		MemberlistKV:             {API},
 		RuntimeConfig:            {API},
 		Ring:                     {API, RuntimeConfig, MemberlistKV},
-		Overrides:                {RuntimeConfig},
+		Overrides:                {RuntimeConfig, API},
 		Distributor:              {DistributorService, API},
 		DistributorService:       {Ring, Overrides},
 		Store:                    {Overrides, DeleteRequestsStore},
@@ -838,11 +875,11 @@ func (t *Cortex) setupModuleManager() error {
 		TableManager:             {API},
 		Ruler:                    {Overrides, DistributorService, Store, StoreQueryable, RulerStorage},
 		Configs:                  {API},
-		AlertManager:             {API, MemberlistKV},
-		Compactor:                {API, MemberlistKV},
+		AlertManager:             {API, MemberlistKV, Overrides},
+		Compactor:                {API, MemberlistKV, Overrides},
 		StoreGateway:             {API, Overrides, MemberlistKV},
 		ChunksPurger:             {Store, DeleteRequestsStore, API},
-		BlocksPurger:             {Store, API},
+		BlocksPurger:             {Store, DeleteRequestsStore, API},
 		Purger:                   {ChunksPurger, BlocksPurger},
 		TenantFederation:         {Queryable},
 		All:                      {QueryFrontend, Querier, Ingester, Distributor, TableManager, Purger, StoreGateway, Ruler},
@@ -0,0 +1,407 @@
+package distributor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/common/model"
+
+	ingester_client "github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/ring"
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+const (
+	// Default and max number of items returned by the cardinality API.
+	defaultCardinalityLimit = 20
+	maxCardinalityLimit     = 500
+
+	// Max number of values returned by each ingester for a label name. The values of label names
+	// with more values are not returned, in order to bound the size of the ingesters responses.
+	cardinalityMaxLabelValues = 1000
+)
+
+// LabelNamesCardinality models the cardinality of the label names of a tenant.
+type LabelNamesCardinality struct {
+	NumSeries             uint64                 `json:"num_series"`
+	LabelNamesCount       uint64                 `json:"label_names_count"`
+	LabelValuesCountTotal uint64                 `json:"label_values_count_total"`
+	LabelNames            []LabelNameCardinality `json:"label_names"`
+	MetricNames           []MetricNameSeries     `json:"metric_names"`
+}
+
+// LabelNameCardinality models the number of distinct values of a label name.
+type LabelNameCardinality struct {
+	LabelName        string `json:"label_name"`
+	LabelValuesCount uint64 `json:"label_values_count"`
+}
+
+// MetricNameSeries models the number of series of a metric name.
+type MetricNameSeries struct {
+	MetricName  string `json:"metric_name"`
+	SeriesCount uint64 `json:"series_count"`
+}
+
+// LabelValuesCardinality models the number of series for each value of some label names.
+type LabelValuesCardinality struct {
+	LabelNames []LabelNameSeries `json:"label_names"`
+}
+
+// LabelNameSeries models the number of series for each value of a label name.
+type LabelNameSeries struct {
+	LabelName        string             `json:"label_name"`
+	LabelValuesCount uint64             `json:"label_values_count"`
+	SeriesCount      uint64             `json:"series_count"`
+	Cardinality      []LabelValueSeries `json:"cardinality"`
+}
+
+// LabelValueSeries models the number of series of a label value.
+type LabelValueSeries struct {
+	LabelValue  string `json:"label_value"`
+	SeriesCount uint64 `json:"series_count"`
+}
+
+// LabelNamesCardinality returns the cardinality of the label names of the tenant's series in
+// the ingesters, with the top limit label names by number of distinct values and the top limit
+// metric names by number of series.
+func (d *Distributor) LabelNamesCardinality(ctx context.Context, limit int) (*LabelNamesCardinality, error) {
+	return d.labelNamesCardinality(ctx, limit, cardinalityMaxLabelValues)
+}
+
+func (d *Distributor) labelNamesCardinality(ctx context.Context, limit int, maxLabelValues uint64) (*LabelNamesCardinality, error) {
+	replicationSet, err := d.GetIngestersForMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ingester_client.LabelNamesCardinalityRequest{MaxLabelValues: maxLabelValues}
+	resps, err := d.forAllIngesters(ctx, replicationSet, func(ctx context.Context, client ingester_client.IngesterClient) (interface{}, error) {
+		return client.LabelNamesCardinality(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	numSeries := uint64(0)
+	labelValues := map[string]*labelNameValues{}
+	metricSeries := map[string]uint64{}
+
+	for _, resp := range resps {
+		r := resp.(*ingester_client.LabelNamesCardinalityResponse)
+		numSeries += r.NumSeries
+
+		for _, l := range r.LabelNames {
+			values, ok := labelValues[l.LabelName]
+			if !ok {
+				values = newLabelNameValues()
+				labelValues[l.LabelName] = values
+			}
+			values.add(l)
+		}
+
+		for _, m := range r.MetricNames {
+			metricSeries[m.LabelValue] += m.SeriesCount
+		}
+	}
+
+	// Series are replicated across ingesters, while distinct label values are not affected by the replication.
+	seriesCount := newSeriesCountEstimator(d.ingestersRing.ReplicationFactor(), len(replicationSet.Ingesters), len(resps))
+
+	result := &LabelNamesCardinality{
+		NumSeries:       seriesCount(numSeries),
+		LabelNamesCount: uint64(len(labelValues)),
+		LabelNames:      make([]LabelNameCardinality, 0, len(labelValues)),
+		MetricNames:     make([]MetricNameSeries, 0, len(metricSeries)),
+	}
+
+	for name, values := range labelValues {
+		count := values.count()
+		result.LabelValuesCountTotal += count
+		result.LabelNames = append(result.LabelNames, LabelNameCardinality{LabelName: name, LabelValuesCount: count})
+	}
+	sort.Slice(result.LabelNames, func(i, j int) bool {
+		if result.LabelNames[i].LabelValuesCount != result.LabelNames[j].LabelValuesCount {
+			return result.LabelNames[i].LabelValuesCount > result.LabelNames[j].LabelValuesCount
+		}
+		return result.LabelNames[i].LabelName < result.LabelNames[j].LabelName
+	})
+	if len(result.LabelNames) > limit {
+		result.LabelNames = result.LabelNames[:limit]
+	}
+
+	for name, count := range metricSeries {
+		result.MetricNames = append(result.MetricNames, MetricNameSeries{MetricName: name, SeriesCount: seriesCount(count)})
+	}
+	sort.Slice(result.MetricNames, func(i, j int) bool {
+		if result.MetricNames[i].SeriesCount != result.MetricNames[j].SeriesCount {
+			return result.MetricNames[i].SeriesCount > result.MetricNames[j].SeriesCount
+		}
+		return result.MetricNames[i].MetricName < result.MetricNames[j].MetricName
+	})
+	if len(result.MetricNames) > limit {
+		result.MetricNames = result.MetricNames[:limit]
+	}
+
+	return result, nil
+}
+
+// LabelValuesCardinality returns, for each input label name, the top limit values by number of
+// series of the tenant in the ingesters. Each ingester counts the series of the first
+// cardinalityMaxLabelValues values of each label name only, in sorted order.
+func (d *Distributor) LabelValuesCardinality(ctx context.Context, labelNames []string, limit int) (*LabelValuesCardinality, error) {
+	return d.labelValuesCardinality(ctx, labelNames, limit, cardinalityMaxLabelValues)
+}
+
+func (d *Distributor) labelValuesCardinality(ctx context.Context, labelNames []string, limit int, maxLabelValues uint64) (*LabelValuesCardinality, error) {
+	replicationSet, err := d.GetIngestersForMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ingester_client.LabelValuesCardinalityRequest{LabelNames: labelNames, MaxLabelValues: maxLabelValues}
+	resps, err := d.forAllIngesters(ctx, replicationSet, func(ctx context.Context, client ingester_client.IngesterClient) (interface{}, error) {
+		return client.LabelValuesCardinality(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	valuesSeries := make(map[string]map[string]uint64, len(labelNames))
+	valuesCount := make(map[string]uint64, len(labelNames))
+	for _, name := range labelNames {
+		valuesSeries[name] = map[string]uint64{}
+	}
+
+	for _, resp := range resps {
+		for _, l := range resp.(*ingester_client.LabelValuesCardinalityResponse).LabelNames {
+			series, ok := valuesSeries[l.LabelName]
+			if !ok {
+				continue
+			}
+			for _, v := range l.Values {
+				series[v.LabelValue] += v.SeriesCount
+			}
+			if l.ValuesCount > valuesCount[l.LabelName] {
+				valuesCount[l.LabelName] = l.ValuesCount
+			}
+		}
+	}
+
+	seriesCount := newSeriesCountEstimator(d.ingestersRing.ReplicationFactor(), len(replicationSet.Ingesters), len(resps))
+	result := &LabelValuesCardinality{LabelNames: make([]LabelNameSeries, 0, len(labelNames))}
+
+	for _, name := range labelNames {
+		nameSeries := LabelNameSeries{
+			LabelName:        name,
+			LabelValuesCount: uint64(len(valuesSeries[name])),
+			Cardinality:      make([]LabelValueSeries, 0, len(valuesSeries[name])),
+		}
+		// The values not counted by the ingesters because above the max are not in the union, so
+		// the number of values is at least the max number of values reported by any ingester.
+		if valuesCount[name] > nameSeries.LabelValuesCount {
+			nameSeries.LabelValuesCount = valuesCount[name]
+		}
+
+		for value, count := range valuesSeries[name] {
+			nameSeries.SeriesCount += count
+			nameSeries.Cardinality = append(nameSeries.Cardinality, LabelValueSeries{LabelValue: value, SeriesCount: seriesCount(count)})
+		}
+		nameSeries.SeriesCount = seriesCount(nameSeries.SeriesCount)
+
+		sort.Slice(nameSeries.Cardinality, func(i, j int) bool {
+			if nameSeries.Cardinality[i].SeriesCount != nameSeries.Cardinality[j].SeriesCount {
+				return nameSeries.Cardinality[i].SeriesCount > nameSeries.Cardinality[j].SeriesCount
+			}
+			return nameSeries.Cardinality[i].LabelValue < nameSeries.Cardinality[j].LabelValue
+		})
+		if len(nameSeries.Cardinality) > limit {
+			nameSeries.Cardinality = nameSeries.Cardinality[:limit]
+		}
+
+		result.LabelNames = append(result.LabelNames, nameSeries)
+	}
+
+	return result, nil
+}
+
+// forAllIngesters runs f on all ingesters of the replication set and returns the responses of the
+// successful ones. Differently from ForReplicationSet, it doesn't return as soon as the quorum is
+// reached, because the cardinality is computed summing up the responses of all ingesters, but it
+// tolerates the same failures as the replication set.
+func (d *Distributor) forAllIngesters(ctx context.Context, replicationSet ring.ReplicationSet, f func(context.Context, ingester_client.IngesterClient) (interface{}, error)) ([]interface{}, error) {
+	type ingesterResult struct {
+		res  interface{}
+		err  error
+		zone string
+	}
+
+	results := make([]ingesterResult, len(replicationSet.Ingesters))
+	wg := sync.WaitGroup{}
+	wg.Add(len(replicationSet.Ingesters))
+
+	for i := range replicationSet.Ingesters {
+		go func(i int, ing *ring.IngesterDesc) {
+			defer wg.Done()
+
+			results[i].zone = ing.Zone
+			client, err := d.ingesterPool.GetClientFor(ing.Addr)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+
+			results[i].res, results[i].err = f(ctx, client.(ingester_client.IngesterClient))
+		}(i, &replicationSet.Ingesters[i])
+	}
+	wg.Wait()
+
+	var (
+		resps       = make([]interface{}, 0, len(results))
+		lastErr     error
+		numErrors   int
+		failedZones = map[string]struct{}{}
+	)
+
+	for _, r := range results {
+		if r.err != nil {
+			lastErr = r.err
+			numErrors++
+			failedZones[r.zone] = struct{}{}
+			continue
+		}
+		resps = append(resps, r.res)
+	}
+
+	if replicationSet.MaxUnavailableZones > 0 {
+		if len(failedZones) > replicationSet.MaxUnavailableZones {
+			return nil, lastErr
+		}
+	} else if numErrors > replicationSet.MaxErrors {
+		return nil, lastErr
+	}
+
+	return resps, nil
+}
+
+// newSeriesCountEstimator returns a function converting the number of series summed up across the
+// responding ingesters into the number of distinct series. If some ingesters failed, the count is
+// scaled by the ratio of responding ingesters, so the returned value is an estimate.
+func newSeriesCountEstimator(replicationFactor, numIngesters, numResponses int) func(uint64) uint64 {
+	return func(count uint64) uint64 {
+		if numResponses == 0 {
+			return 0
+		}
+		if numResponses < numIngesters {
+			count = count * uint64(numIngesters) / uint64(numResponses)
+		}
+		return count / uint64(replicationFactor)
+	}
+}
+
+// labelNameValues merges the values of a label name returned by the ingesters.
+type labelNameValues struct {
+	values map[string]struct{}
+
+	// Max number of values of the label name in a single ingester.
+	maxCount uint64
+
+	// Whether all ingesters returned all the values of the label name.
+	complete bool
+}
+
+func newLabelNameValues() *labelNameValues {
+	return &labelNameValues{values: map[string]struct{}{}, complete: true}
+}
+
+func (v *labelNameValues) add(l ingester_client.LabelNameValues) {
+	for _, value := range l.Values {
+		v.values[value] = struct{}{}
+	}
+
+	if l.ValuesCount > v.maxCount {
+		v.maxCount = l.ValuesCount
+	}
+	if l.ValuesCount > uint64(len(l.Values)) {
+		v.complete = false
+	}
+}
+
+// count returns the number of distinct values of the label name. If some ingesters didn't return
+// all the values, because above the max number of values per ingester, the returned count is a
+// lower bound of the actual one.
+func (v *labelNameValues) count() uint64 {
+	count := uint64(len(v.values))
+	if !v.complete && v.maxCount > count {
+		count = v.maxCount
+	}
+	return count
+}
+
+// LabelNamesCardinalityHandler returns the label names cardinality of the tenant.
+func (d *Distributor) LabelNamesCardinalityHandler(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseCardinalityLimit(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := d.LabelNamesCardinality(r.Context(), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	util.WriteJSONResponse(w, result)
+}
+
+// LabelValuesCardinalityHandler returns the number of series for each value of the label
+// names requested via the label_names[] parameter.
+func (d *Distributor) LabelValuesCardinalityHandler(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseCardinalityLimit(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	labelNames := r.Form["label_names[]"]
+	if len(labelNames) == 0 {
+		http.Error(w, "at least one label name must be specified via the label_names[] parameter", http.StatusBadRequest)
+		return
+	}
+	for _, name := range labelNames {
+		if !model.LabelName(name).IsValid() {
+			http.Error(w, fmt.Sprintf("invalid label name %q", name), http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := d.LabelValuesCardinality(r.Context(), labelNames, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	util.WriteJSONResponse(w, result)
+}
+
+// parseCardinalityLimit parses the request form and returns the limit parameter.
+func parseCardinalityLimit(r *http.Request) (int, error) {
+	if err := r.ParseForm(); err != nil {
+		return 0, err
+	}
+
+	value := r.Form.Get("limit")
+	if value == "" {
+		return defaultCardinalityLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 || limit > maxCardinalityLimit {
+		return 0, fmt.Errorf("invalid limit %q: must be an integer between 1 and %d", value, maxCardinalityLimit)
+	}
+	return limit, nil
+}
@@ -382,10 +382,22 @@ func (d *Distributor) validateSeries(ts ingester_client.PreallocTimeseries, user
 		samples = append(samples, s)
 	}
 
+	var exemplars []ingester_client.Exemplar
+	if len(ts.Exemplars) > 0 {
+		exemplars = make([]ingester_client.Exemplar, 0, len(ts.Exemplars))
+		for _, e := range ts.Exemplars {
+			if err := validation.ValidateExemplar(userID, ts.Labels, e); err != nil {
+				return emptyPreallocSeries, err
+			}
+			exemplars = append(exemplars, e)
+		}
+	}
+
 	return ingester_client.PreallocTimeseries{
 			TimeSeries: &ingester_client.TimeSeries{
-				Labels:  ts.Labels,
-				Samples: samples,
+				Labels:    ts.Labels,
+				Samples:   samples,
+				Exemplars: exemplars,
 			},
 		},
 		nil
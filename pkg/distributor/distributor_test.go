@@ -7,6 +7,7 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"net/http/httptest"
 	"sort"
 	"strconv"
 	"strings"
@@ -38,6 +39,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/chunkcompat"
 	"github.com/cortexproject/cortex/pkg/util/flagext"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
 	util_math "github.com/cortexproject/cortex/pkg/util/math"
 	"github.com/cortexproject/cortex/pkg/util/services"
 	"github.com/cortexproject/cortex/pkg/util/test"
@@ -636,6 +638,115 @@ func TestDistributor_PushQuery(t *testing.T) {
 	}
 }
 
+func TestDistributor_QueryStream_ShouldReturnErrorIfMaxSeriesPerQueryLimitIsReached(t *testing.T) {
+	const maxSeriesLimit = 10
+
+	limits := &validation.Limits{}
+	flagext.DefaultValues(limits)
+	ctx := user.InjectOrgID(context.Background(), "user")
+	ctx = limiter.AddQueryLimiterToContext(ctx, limiter.NewQueryLimiter(maxSeriesLimit, 0))
+
+	// Prepare distributors.
+	ds, _, r := prepare(t, prepConfig{
+		numIngesters:     3,
+		happyIngesters:   3,
+		numDistributors:  1,
+		shardByAllLabels: true,
+		limits:           limits,
+	})
+	defer stopAll(ds, r)
+
+	// Push a number of series below the max series limit.
+	initialSeries := maxSeriesLimit
+	writeReq := makeWriteRequest(0, initialSeries, 0)
+	writeRes, err := ds[0].Push(ctx, writeReq)
+	assert.Equal(t, &client.WriteResponse{}, writeRes)
+	assert.Nil(t, err)
+
+	allSeriesMatchers := []*labels.Matcher{
+		labels.MustNewMatcher(labels.MatchRegexp, model.MetricNameLabel, ".+"),
+	}
+
+	// Since the number of series is equal to the limit (but doesn't
+	// exceed it), we expect a query running on all series to succeed.
+	queryRes, err := ds[0].QueryStream(ctx, math.MinInt32, math.MaxInt32, allSeriesMatchers...)
+	require.NoError(t, err)
+	assert.Len(t, queryRes.Chunkseries, initialSeries)
+
+	// Push more series to exceed the limit once we'll query back all series.
+	writeReq = &client.WriteRequest{
+		Timeseries: []client.PreallocTimeseries{{
+			TimeSeries: &client.TimeSeries{
+				Labels:  []client.LabelAdapter{{Name: model.MetricNameLabel, Value: "another_series"}},
+				Samples: []client.Sample{{Value: 1, TimestampMs: 0}},
+			},
+		}},
+	}
+
+	writeRes, err = ds[0].Push(ctx, writeReq)
+	assert.Equal(t, &client.WriteResponse{}, writeRes)
+	assert.Nil(t, err)
+
+	// Since the number of series is exceeding the limit, we expect
+	// a query running on all series to fail, using a new limiter for the new query.
+	ctx = limiter.AddQueryLimiterToContext(ctx, limiter.NewQueryLimiter(maxSeriesLimit, 0))
+	_, err = ds[0].QueryStream(ctx, math.MinInt32, math.MaxInt32, allSeriesMatchers...)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the query hit the max number of fetched series limit")
+	assert.IsType(t, validation.LimitError(""), err)
+}
+
+func TestDistributor_QueryStream_ShouldReturnErrorIfMaxChunkBytesPerQueryLimitIsReached(t *testing.T) {
+	const seriesToAdd = 10
+
+	limits := &validation.Limits{}
+	flagext.DefaultValues(limits)
+	ctx := user.InjectOrgID(context.Background(), "user")
+
+	// Prepare distributors.
+	ds, _, r := prepare(t, prepConfig{
+		numIngesters:     3,
+		happyIngesters:   3,
+		numDistributors:  1,
+		shardByAllLabels: true,
+		limits:           limits,
+	})
+	defer stopAll(ds, r)
+
+	allSeriesMatchers := []*labels.Matcher{
+		labels.MustNewMatcher(labels.MatchRegexp, model.MetricNameLabel, ".+"),
+	}
+
+	writeReq := makeWriteRequest(0, seriesToAdd, 0)
+	writeRes, err := ds[0].Push(ctx, writeReq)
+	assert.Equal(t, &client.WriteResponse{}, writeRes)
+	assert.Nil(t, err)
+
+	// Query back the series without any limit, to know the size of the fetched chunks.
+	queryRes, err := ds[0].QueryStream(ctx, math.MinInt32, math.MaxInt32, allSeriesMatchers...)
+	require.NoError(t, err)
+	require.Len(t, queryRes.Chunkseries, seriesToAdd)
+
+	// The chunks are fetched from all the ingesters of the replication set, but the limit is
+	// enforced on the size of the chunks of a single replica, which are all the same in the mock.
+	chunkBytes := 0
+	for _, series := range queryRes.Chunkseries {
+		require.NotEmpty(t, series.Chunks)
+		chunkBytes += client.ChunksSize(series.Chunks[:1])
+	}
+
+	limitCtx := limiter.AddQueryLimiterToContext(ctx, limiter.NewQueryLimiter(0, chunkBytes))
+	_, err = ds[0].QueryStream(limitCtx, math.MinInt32, math.MaxInt32, allSeriesMatchers...)
+	require.NoError(t, err)
+
+	// A limit lower than the size of the chunks makes the query fail.
+	limitCtx = limiter.AddQueryLimiterToContext(ctx, limiter.NewQueryLimiter(0, chunkBytes-1))
+	_, err = ds[0].QueryStream(limitCtx, math.MinInt32, math.MaxInt32, allSeriesMatchers...)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the query hit the max size of fetched chunks limit")
+	assert.IsType(t, validation.LimitError(""), err)
+}
+
 func TestDistributor_Push_LabelRemoval(t *testing.T) {
 	ctx = user.InjectOrgID(context.Background(), "user")
 
@@ -1096,6 +1207,204 @@ func TestDistributor_MetricsMetadata(t *testing.T) {
 	}
 }
 
+func TestDistributor_Cardinality(t *testing.T) {
+	ds, ingesters, r := prepare(t, prepConfig{
+		numIngesters:     5,
+		happyIngesters:   5,
+		numDistributors:  1,
+		shardByAllLabels: true,
+	})
+	defer stopAll(ds, r)
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+
+	for _, series := range []labels.Labels{
+		labels.FromStrings(labels.MetricName, "http_requests_total", "job", "api", "status", "200"),
+		labels.FromStrings(labels.MetricName, "http_requests_total", "job", "api", "status", "500"),
+		labels.FromStrings(labels.MetricName, "http_requests_total", "job", "web", "status", "200"),
+		labels.FromStrings(labels.MetricName, "up", "job", "api"),
+	} {
+		_, err := ds[0].Push(ctx, mockWriteRequest(series, 1, 1))
+		require.NoError(t, err)
+	}
+
+	// The push returns once the quorum is reached, so we poll until all replicas are written.
+	test.Poll(t, time.Second, 12, func() interface{} {
+		return countMockIngestersSeries(ingesters)
+	})
+
+	t.Run("label names", func(t *testing.T) {
+		res, err := ds[0].LabelNamesCardinality(ctx, 2)
+		require.NoError(t, err)
+
+		assert.Equal(t, &LabelNamesCardinality{
+			NumSeries:             4,
+			LabelNamesCount:       3,
+			LabelValuesCountTotal: 6,
+			LabelNames: []LabelNameCardinality{
+				{LabelName: labels.MetricName, LabelValuesCount: 2},
+				{LabelName: "job", LabelValuesCount: 2},
+			},
+			MetricNames: []MetricNameSeries{
+				{MetricName: "http_requests_total", SeriesCount: 3},
+				{MetricName: "up", SeriesCount: 1},
+			},
+		}, res)
+		assert.Equal(t, 5, countMockIngestersCalls(ingesters, "LabelNamesCardinality"))
+	})
+
+	t.Run("label names with values above the max per ingester", func(t *testing.T) {
+		res, err := ds[0].labelNamesCardinality(ctx, 3, 1)
+		require.NoError(t, err)
+
+		// The values count is a lower bound, given by the max number of values in a single ingester.
+		for _, l := range res.LabelNames {
+			assert.GreaterOrEqual(t, l.LabelValuesCount, uint64(1))
+			assert.LessOrEqual(t, l.LabelValuesCount, uint64(2))
+		}
+		assert.Equal(t, uint64(3), res.LabelNamesCount)
+	})
+
+	t.Run("label values", func(t *testing.T) {
+		res, err := ds[0].LabelValuesCardinality(ctx, []string{"job", "status", "missing"}, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, &LabelValuesCardinality{
+			LabelNames: []LabelNameSeries{
+				{LabelName: "job", LabelValuesCount: 2, SeriesCount: 4, Cardinality: []LabelValueSeries{{LabelValue: "api", SeriesCount: 3}}},
+				{LabelName: "status", LabelValuesCount: 2, SeriesCount: 3, Cardinality: []LabelValueSeries{{LabelValue: "200", SeriesCount: 2}}},
+				{LabelName: "missing", LabelValuesCount: 0, SeriesCount: 0, Cardinality: []LabelValueSeries{}},
+			},
+		}, res)
+	})
+
+	t.Run("label values above the max per ingester", func(t *testing.T) {
+		res, err := ds[0].labelValuesCardinality(ctx, []string{"job"}, 10, 1)
+		require.NoError(t, err)
+
+		// Only the first value in sorted order is counted, but the values count still includes all values.
+		assert.Equal(t, &LabelValuesCardinality{
+			LabelNames: []LabelNameSeries{
+				{LabelName: "job", LabelValuesCount: 2, SeriesCount: 3, Cardinality: []LabelValueSeries{{LabelValue: "api", SeriesCount: 3}}},
+			},
+		}, res)
+	})
+}
+
+func TestDistributor_CardinalityWithFailingIngester(t *testing.T) {
+	ds, ingesters, r := prepare(t, prepConfig{
+		numIngesters:     3,
+		happyIngesters:   2,
+		numDistributors:  1,
+		shardByAllLabels: true,
+	})
+	defer stopAll(ds, r)
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+	_, err := ds[0].Push(ctx, mockWriteRequest(labels.FromStrings(labels.MetricName, "up", "job", "api"), 1, 1))
+	require.NoError(t, err)
+
+	test.Poll(t, time.Second, 2, func() interface{} {
+		return countMockIngestersSeries(ingesters)
+	})
+
+	// A single failing ingester is tolerated, like for the other read paths, and the
+	// series count is estimated from the responding ingesters.
+	namesRes, err := ds[0].LabelNamesCardinality(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), namesRes.LabelNamesCount)
+	assert.Equal(t, uint64(1), namesRes.NumSeries)
+
+	valuesRes, err := ds[0].LabelValuesCardinality(ctx, []string{"job"}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), valuesRes.LabelNames[0].LabelValuesCount)
+	assert.Equal(t, uint64(1), valuesRes.LabelNames[0].SeriesCount)
+}
+
+func TestDistributor_CardinalityWithTooManyFailingIngesters(t *testing.T) {
+	ds, _, r := prepare(t, prepConfig{
+		numIngesters:     3,
+		happyIngesters:   1,
+		numDistributors:  1,
+		shardByAllLabels: true,
+	})
+	defer stopAll(ds, r)
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+
+	_, err := ds[0].LabelNamesCardinality(ctx, 10)
+	require.Error(t, err)
+
+	_, err = ds[0].LabelValuesCardinality(ctx, []string{"job"}, 10)
+	require.Error(t, err)
+}
+
+func TestDistributor_CardinalityHandlers(t *testing.T) {
+	ds, ingesters, r := prepare(t, prepConfig{
+		numIngesters:     3,
+		happyIngesters:   3,
+		numDistributors:  1,
+		shardByAllLabels: true,
+	})
+	defer stopAll(ds, r)
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+	_, err := ds[0].Push(ctx, mockWriteRequest(labels.FromStrings(labels.MetricName, "up", "job", "api"), 1, 1))
+	require.NoError(t, err)
+
+	test.Poll(t, time.Second, 3, func() interface{} {
+		return countMockIngestersSeries(ingesters)
+	})
+
+	tests := map[string]struct {
+		handler        http.HandlerFunc
+		url            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		"label names": {
+			handler:        ds[0].LabelNamesCardinalityHandler,
+			url:            "/api/v1/cardinality/label_names",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"num_series":1,"label_names_count":2,"label_values_count_total":2,"label_names":[{"label_name":"__name__","label_values_count":1},{"label_name":"job","label_values_count":1}],"metric_names":[{"metric_name":"up","series_count":1}]}`,
+		},
+		"label names with invalid limit": {
+			handler:        ds[0].LabelNamesCardinalityHandler,
+			url:            "/api/v1/cardinality/label_names?limit=0",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"label values": {
+			handler:        ds[0].LabelValuesCardinalityHandler,
+			url:            "/api/v1/cardinality/label_values?label_names[]=job&limit=10",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"label_names":[{"label_name":"job","label_values_count":1,"series_count":1,"cardinality":[{"label_value":"api","series_count":1}]}]}`,
+		},
+		"label values without label names": {
+			handler:        ds[0].LabelValuesCardinalityHandler,
+			url:            "/api/v1/cardinality/label_values",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"label values with invalid label name": {
+			handler:        ds[0].LabelValuesCardinalityHandler,
+			url:            "/api/v1/cardinality/label_values?label_names[]=1invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			req := httptest.NewRequest("GET", testData.url, nil).WithContext(ctx)
+			resp := httptest.NewRecorder()
+			testData.handler(resp, req)
+
+			assert.Equal(t, testData.expectedStatus, resp.Code)
+			if testData.expectedBody != "" {
+				assert.JSONEq(t, testData.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
 func mustNewMatcher(t labels.MatchType, n, v string) *labels.Matcher {
 	m, err := labels.NewMatcher(t, n, v)
 	if err != nil {
@@ -1558,6 +1867,82 @@ func (i *mockIngester) MetricsMetadata(ctx context.Context, req *client.MetricsM
 	return resp, nil
 }
 
+func (i *mockIngester) LabelNamesCardinality(ctx context.Context, req *client.LabelNamesCardinalityRequest, opts ...grpc.CallOption) (*client.LabelNamesCardinalityResponse, error) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.trackCall("LabelNamesCardinality")
+
+	if !i.happy {
+		return nil, errFail
+	}
+
+	values := map[string]map[string]struct{}{}
+	for _, ts := range i.timeseries {
+		for _, l := range ts.Labels {
+			if values[l.Name] == nil {
+				values[l.Name] = map[string]struct{}{}
+			}
+			values[l.Name][l.Value] = struct{}{}
+		}
+	}
+
+	resp := &client.LabelNamesCardinalityResponse{NumSeries: uint64(len(i.timeseries))}
+	for name, nameValues := range values {
+		l := client.LabelNameValues{LabelName: name, ValuesCount: uint64(len(nameValues))}
+		if l.ValuesCount <= req.MaxLabelValues {
+			for v := range nameValues {
+				l.Values = append(l.Values, v)
+			}
+		}
+		resp.LabelNames = append(resp.LabelNames, l)
+	}
+	resp.MetricNames = i.labelValuesSeriesCount(labels.MetricName)
+
+	return resp, nil
+}
+
+func (i *mockIngester) LabelValuesCardinality(ctx context.Context, req *client.LabelValuesCardinalityRequest, opts ...grpc.CallOption) (*client.LabelValuesCardinalityResponse, error) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.trackCall("LabelValuesCardinality")
+
+	if !i.happy {
+		return nil, errFail
+	}
+
+	resp := &client.LabelValuesCardinalityResponse{}
+	for _, name := range req.LabelNames {
+		values := i.labelValuesSeriesCount(name)
+		valuesCount := uint64(len(values))
+		if req.MaxLabelValues > 0 && valuesCount > req.MaxLabelValues {
+			sort.Slice(values, func(i, j int) bool { return values[i].LabelValue < values[j].LabelValue })
+			values = values[:req.MaxLabelValues]
+		}
+		resp.LabelNames = append(resp.LabelNames, client.LabelNameSeriesCount{LabelName: name, Values: values, ValuesCount: valuesCount})
+	}
+
+	return resp, nil
+}
+
+func (i *mockIngester) labelValuesSeriesCount(name string) []client.LabelValueSeriesCount {
+	counts := map[string]uint64{}
+	for _, ts := range i.timeseries {
+		for _, l := range ts.Labels {
+			if l.Name == name {
+				counts[l.Value]++
+			}
+		}
+	}
+
+	var result []client.LabelValueSeriesCount
+	for value, count := range counts {
+		result = append(result, client.LabelValueSeriesCount{LabelValue: value, SeriesCount: count})
+	}
+	return result
+}
+
 func (i *mockIngester) trackCall(name string) {
 	if i.calls == nil {
 		i.calls = map[string]int{}
@@ -1867,6 +2252,16 @@ func TestDistributor_Push_Relabel(t *testing.T) {
 	}
 }
 
+func countMockIngestersSeries(ingesters []mockIngester) int {
+	count := 0
+	for i := 0; i < len(ingesters); i++ {
+		ingesters[i].Lock()
+		count += len(ingesters[i].timeseries)
+		ingesters[i].Unlock()
+	}
+	return count
+}
+
 func countMockIngestersCalls(ingesters []mockIngester, name string) int {
 	count := 0
 	for i := 0; i < len(ingesters); i++ {
@@ -3,6 +3,8 @@ package distributor
 import (
 	"context"
 	"io"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/opentracing/opentracing-go"
@@ -16,6 +18,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/extract"
 	grpc_util "github.com/cortexproject/cortex/pkg/util/grpc"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
 )
 
 // Query multiple ingesters and returns a Matrix of samples.
@@ -72,6 +75,36 @@ func (d *Distributor) QueryStream(ctx context.Context, from, to model.Time, matc
 	return result, err
 }
 
+// QueryExemplars returns exemplars with timestamp between from and to, for the series matching
+// at least one of the input sets of matchers.
+func (d *Distributor) QueryExemplars(ctx context.Context, from, to model.Time, matchersSet ...[]*labels.Matcher) (*ingester_client.ExemplarQueryResponse, error) {
+	var result *ingester_client.ExemplarQueryResponse
+	err := instrument.CollectedRequest(ctx, "Distributor.QueryExemplars", queryDuration, instrument.ErrorCode, func(ctx context.Context) error {
+		req, err := ingester_client.ToExemplarQueryRequest(from, to, matchersSet...)
+		if err != nil {
+			return err
+		}
+
+		// We ask for all ingesters without passing matchers because exemplar queries take in an array of
+		// arrays of label matchers, so we can't shard by metric name.
+		replicationSet, err := d.GetIngestersForMetadata(ctx)
+		if err != nil {
+			return err
+		}
+
+		result, err = d.queryIngestersExemplars(ctx, replicationSet, req)
+		if err != nil {
+			return err
+		}
+
+		if s := opentracing.SpanFromContext(ctx); s != nil {
+			s.LogKV("series", len(result.Timeseries))
+		}
+		return nil
+	})
+	return result, err
+}
+
 // GetIngestersForQuery returns a replication set including all ingesters that should be queried
 // to fetch series matching input label matchers.
 func (d *Distributor) GetIngestersForQuery(ctx context.Context, matchers ...*labels.Matcher) (ring.ReplicationSet, error) {
@@ -173,6 +206,32 @@ func (d *Distributor) queryIngesters(ctx context.Context, replicationSet ring.Re
 
 // queryIngesterStream queries the ingesters using the new streaming API.
 func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ring.ReplicationSet, req *ingester_client.QueryRequest) (*ingester_client.QueryStreamResponse, error) {
+	queryLimiter := limiter.QueryLimiterFromContextWithFallback(ctx)
+
+	// The same series is fetched from all the ingesters of the replication set, so the chunk bytes
+	// are counted only once for each series, taking the biggest size among the replicas, in order
+	// to enforce the limit on the size of the deduplicated chunks while still receiving them.
+	var (
+		seriesChunkBytesMx sync.Mutex
+		seriesChunkBytes   = map[string]int{}
+	)
+	addChunkBytes := func(series ingester_client.TimeSeriesChunk) error {
+		key := ingester_client.LabelsToKeyString(ingester_client.FromLabelAdaptersToLabels(series.Labels))
+		size := ingester_client.ChunksSize(series.Chunks)
+
+		seriesChunkBytesMx.Lock()
+		delta := size - seriesChunkBytes[key]
+		if delta > 0 {
+			seriesChunkBytes[key] = size
+		} else {
+			delta = 0
+		}
+		seriesChunkBytesMx.Unlock()
+
+		// Adding zero bytes still fails if the limit has already been exceeded.
+		return queryLimiter.AddChunkBytes(delta)
+	}
+
 	// Fetch samples from multiple ingesters
 	results, err := replicationSet.Do(ctx, d.cfg.ExtraQueryDelay, func(ctx context.Context, ing *ring.IngesterDesc) (interface{}, error) {
 		client, err := d.ingesterPool.GetClientFor(ing.Addr)
@@ -202,6 +261,21 @@ func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ri
 				return nil, err
 			}
 
+			// Enforce the max series and chunk bytes limits while receiving the series.
+			for _, series := range resp.Chunkseries {
+				if limitErr := queryLimiter.AddSeries(ingester_client.FromLabelAdaptersToLabels(series.Labels)); limitErr != nil {
+					return nil, limitErr
+				}
+				if limitErr := addChunkBytes(series); limitErr != nil {
+					return nil, limitErr
+				}
+			}
+			for _, series := range resp.Timeseries {
+				if limitErr := queryLimiter.AddSeries(ingester_client.FromLabelAdaptersToLabels(series.Labels)); limitErr != nil {
+					return nil, limitErr
+				}
+			}
+
 			result.Chunkseries = append(result.Chunkseries, resp.Chunkseries...)
 			result.Timeseries = append(result.Timeseries, resp.Timeseries...)
 		}
@@ -211,6 +285,12 @@ func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ri
 		return nil, err
 	}
 
+	// The limit could have been exceeded by fewer ingesters than the tolerated failures,
+	// so it's checked again once all the responses have been received.
+	if limitErr := queryLimiter.AddChunkBytes(0); limitErr != nil {
+		return nil, limitErr
+	}
+
 	hashToChunkseries := map[string]ingester_client.TimeSeriesChunk{}
 	hashToTimeSeries := map[string]ingester_client.TimeSeries{}
 
@@ -254,6 +334,86 @@ func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ri
 	return resp, nil
 }
 
+// queryIngestersExemplars queries the ingesters for exemplars.
+func (d *Distributor) queryIngestersExemplars(ctx context.Context, replicationSet ring.ReplicationSet, req *ingester_client.ExemplarQueryRequest) (*ingester_client.ExemplarQueryResponse, error) {
+	// Fetch exemplars from multiple ingesters in parallel, using the replicationSet
+	// to deal with consistency.
+	results, err := replicationSet.Do(ctx, d.cfg.ExtraQueryDelay, func(ctx context.Context, ing *ring.IngesterDesc) (interface{}, error) {
+		client, err := d.ingesterPool.GetClientFor(ing.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.(ingester_client.IngesterClient).QueryExemplars(ctx, req)
+		ingesterQueries.WithLabelValues(ing.Addr).Inc()
+		if err != nil {
+			ingesterQueryFailures.WithLabelValues(ing.Addr).Inc()
+			return nil, err
+		}
+
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeExemplarQueryResponses(results), nil
+}
+
+func mergeExemplarQueryResponses(results []interface{}) *ingester_client.ExemplarQueryResponse {
+	var keys []string
+	exemplarResults := make(map[string]ingester_client.TimeSeries)
+	for _, result := range results {
+		r := result.(*ingester_client.ExemplarQueryResponse)
+		for _, ts := range r.Timeseries {
+			lbls := ingester_client.LabelsToKeyString(ingester_client.FromLabelAdaptersToLabels(ts.Labels))
+			e, ok := exemplarResults[lbls]
+			if !ok {
+				exemplarResults[lbls] = ts
+				keys = append(keys, lbls)
+				continue
+			}
+
+			// Merge in any missing values from another ingester's exemplars for this series.
+			e.Exemplars = mergeExemplarSets(e.Exemplars, ts.Exemplars)
+			exemplarResults[lbls] = e
+		}
+	}
+
+	// Query results from each ingester were sorted, but are not necessarily still sorted after merging.
+	sort.Strings(keys)
+
+	result := make([]ingester_client.TimeSeries, len(exemplarResults))
+	for i, k := range keys {
+		result[i] = exemplarResults[k]
+	}
+
+	return &ingester_client.ExemplarQueryResponse{Timeseries: result}
+}
+
+// mergeExemplarSets merges and dedupes two sets of already sorted exemplars.
+func mergeExemplarSets(a, b []ingester_client.Exemplar) []ingester_client.Exemplar {
+	result := make([]ingester_client.Exemplar, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i].TimestampMs < b[j].TimestampMs {
+			result = append(result, a[i])
+			i++
+		} else if a[i].TimestampMs > b[j].TimestampMs {
+			result = append(result, b[j])
+			j++
+		} else {
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	// Add the rest of a or b. One of them is empty now.
+	result = append(result, a[i:]...)
+	result = append(result, b[j:]...)
+	return result
+}
+
 // Merges and dedupes two sorted slices with samples together.
 func mergeSamples(a, b []ingester_client.Sample) []ingester_client.Sample {
 	if sameSamples(a, b) {
@@ -106,3 +106,27 @@ func TestMergeSamplesIntoFirstNilB(t *testing.T) {
 
 	require.Equal(t, b, a)
 }
+
+func TestMergeExemplarQueryResponses(t *testing.T) {
+	seriesA := []ingester_client.LabelAdapter{{Name: "__name__", Value: "a"}}
+	seriesB := []ingester_client.LabelAdapter{{Name: "__name__", Value: "b"}}
+	traceA := []ingester_client.LabelAdapter{{Name: "traceID", Value: "a"}}
+	traceB := []ingester_client.LabelAdapter{{Name: "traceID", Value: "b"}}
+
+	results := []interface{}{
+		&ingester_client.ExemplarQueryResponse{Timeseries: []ingester_client.TimeSeries{
+			{Labels: seriesB, Exemplars: []ingester_client.Exemplar{{Labels: traceA, Value: 1, TimestampMs: 10}, {Labels: traceB, Value: 2, TimestampMs: 20}}},
+			{Labels: seriesA, Exemplars: []ingester_client.Exemplar{{Labels: traceA, Value: 3, TimestampMs: 30}}},
+		}},
+		&ingester_client.ExemplarQueryResponse{Timeseries: []ingester_client.TimeSeries{
+			{Labels: seriesB, Exemplars: []ingester_client.Exemplar{{Labels: traceB, Value: 2, TimestampMs: 20}, {Labels: traceA, Value: 4, TimestampMs: 40}}},
+		}},
+	}
+
+	expected := &ingester_client.ExemplarQueryResponse{Timeseries: []ingester_client.TimeSeries{
+		{Labels: seriesA, Exemplars: []ingester_client.Exemplar{{Labels: traceA, Value: 3, TimestampMs: 30}}},
+		{Labels: seriesB, Exemplars: []ingester_client.Exemplar{{Labels: traceA, Value: 1, TimestampMs: 10}, {Labels: traceB, Value: 2, TimestampMs: 20}, {Labels: traceA, Value: 4, TimestampMs: 40}}},
+	}}
+
+	require.Equal(t, expected, mergeExemplarQueryResponses(results))
+}
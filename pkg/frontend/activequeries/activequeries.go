@@ -0,0 +1,166 @@
+// Package activequeries provides the HTTP API used by tenants to list and cancel
+// their queries in flight in the query-frontend and the query-scheduler.
+package activequeries
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/gorilla/mux"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/tenant"
+)
+
+const (
+	statusSuccess = "success"
+	statusError   = "error"
+
+	errorTypeBadData  = "bad_data"
+	errorTypeNotFound = "not_found"
+)
+
+// ErrCancelled is the error returned for the queries cancelled via the API. The status code is
+// the same Prometheus returns for cancelled queries.
+var ErrCancelled = httpgrpc.Errorf(http.StatusServiceUnavailable, "query cancelled via the active queries API")
+
+// Query is a query in flight.
+type Query struct {
+	ID     string `json:"id"`
+	Tenant string `json:"tenant"`
+	Path   string `json:"path"`
+	Query  string `json:"query"`
+
+	// StartTime is the time the query has been received.
+	StartTime time.Time `json:"startTime"`
+
+	// Querier is the ID of the querier the query has been dispatched to, if known.
+	// Empty if the query is still queued.
+	Querier string `json:"querier,omitempty"`
+
+	// Frontend is the address of the query-frontend which sent the query, when listed by the query-scheduler.
+	Frontend string `json:"frontend,omitempty"`
+
+	ElapsedSeconds float64 `json:"elapsedSeconds"`
+}
+
+// NewQuery returns a Query for the given HTTP request. The path and the query are read from the request.
+func NewQuery(id, tenant string, req *httpgrpc.HTTPRequest, startTime time.Time, querier string) Query {
+	q := Query{
+		ID:             id,
+		Tenant:         tenant,
+		StartTime:      startTime,
+		Querier:        querier,
+		ElapsedSeconds: time.Since(startTime).Seconds(),
+	}
+
+	r, err := http.NewRequest(req.Method, req.Url, ioutil.NopCloser(bytes.NewReader(req.Body)))
+	if err != nil {
+		return q
+	}
+	for _, h := range req.Headers {
+		for _, v := range h.Values {
+			r.Header.Add(h.Key, v)
+		}
+	}
+
+	q.Path = r.URL.Path
+	if err := r.ParseForm(); err == nil {
+		q.Query = r.Form.Get("query")
+	}
+	return q
+}
+
+// Tracker tracks the queries in flight, and allows to cancel them. The tenant ID is the one
+// of the request, joined with tenant.JoinTenantIDs for cross-tenant queries.
+type Tracker interface {
+	// ActiveQueries returns the queries in flight of the given tenant.
+	ActiveQueries(tenantID string) []Query
+
+	// CancelQuery cancels the query in flight of the given tenant with the given ID. The query fails
+	// with ErrCancelled. Returns false if the tenant has no query in flight with the given ID.
+	CancelQuery(ctx context.Context, tenantID, id string) bool
+}
+
+type response struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// ListHandler returns the HTTP handler listing the queries in flight, sorted by start time.
+func ListHandler(t Tracker, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID, ok := requestTenantID(w, r)
+		if !ok {
+			return
+		}
+
+		queries := t.ActiveQueries(tenantID)
+		if queries == nil {
+			queries = []Query{}
+		}
+		sort.Slice(queries, func(i, j int) bool {
+			return queries[i].StartTime.Before(queries[j].StartTime)
+		})
+
+		respond(logger, w, http.StatusOK, response{Status: statusSuccess, Data: queries})
+	})
+}
+
+// CancelHandler returns the HTTP handler cancelling the query in flight with the ID in the "id" URL variable.
+func CancelHandler(t Tracker, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID, ok := requestTenantID(w, r)
+		if !ok {
+			return
+		}
+
+		id := mux.Vars(r)["id"]
+		if id == "" {
+			respond(logger, w, http.StatusBadRequest, response{Status: statusError, ErrorType: errorTypeBadData, Error: "query ID is missing"})
+			return
+		}
+
+		if !t.CancelQuery(r.Context(), tenantID, id) {
+			respond(logger, w, http.StatusNotFound, response{Status: statusError, ErrorType: errorTypeNotFound, Error: "no query in flight with ID " + id})
+			return
+		}
+
+		level.Info(logger).Log("msg", "query cancelled", "id", id, "user", tenantID)
+		respond(logger, w, http.StatusOK, response{Status: statusSuccess})
+	})
+}
+
+// requestTenantID returns the tenant ID of the request. If missing, the error response is written and false is returned.
+func requestTenantID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	tenantIDs, err := tenant.TenantIDs(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return "", false
+	}
+	return tenant.JoinTenantIDs(tenantIDs), true
+}
+
+func respond(logger log.Logger, w http.ResponseWriter, status int, resp response) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		level.Error(logger).Log("msg", "error marshaling json response", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if n, err := w.Write(b); err != nil {
+		level.Error(logger).Log("msg", "error writing response", "bytesWritten", n, "err", err)
+	}
+}
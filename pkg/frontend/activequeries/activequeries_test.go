@@ -0,0 +1,153 @@
+package activequeries
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+)
+
+type mockTracker struct {
+	queries   []Query
+	cancelled []string
+}
+
+func (m *mockTracker) ActiveQueries(tenantID string) []Query {
+	var result []Query
+	for _, q := range m.queries {
+		if q.Tenant == tenantID {
+			result = append(result, q)
+		}
+	}
+	return result
+}
+
+func (m *mockTracker) CancelQuery(_ context.Context, tenantID, id string) bool {
+	for _, q := range m.queries {
+		if q.Tenant == tenantID && q.ID == id {
+			m.cancelled = append(m.cancelled, id)
+			return true
+		}
+	}
+	return false
+}
+
+func TestNewQuery(t *testing.T) {
+	startTime := time.Now().Add(-time.Minute)
+
+	tests := map[string]struct {
+		req           *httpgrpc.HTTPRequest
+		expectedPath  string
+		expectedQuery string
+	}{
+		"GET request": {
+			req:           &httpgrpc.HTTPRequest{Method: "GET", Url: "/prometheus/api/v1/query?query=up&time=1"},
+			expectedPath:  "/prometheus/api/v1/query",
+			expectedQuery: "up",
+		},
+		"POST request": {
+			req: &httpgrpc.HTTPRequest{
+				Method:  "POST",
+				Url:     "/prometheus/api/v1/query_range",
+				Headers: []*httpgrpc.Header{{Key: "Content-Type", Values: []string{"application/x-www-form-urlencoded"}}},
+				Body:    []byte("query=sum(rate(foo[1m]))&start=0&end=10&step=1"),
+			},
+			expectedPath:  "/prometheus/api/v1/query_range",
+			expectedQuery: "sum(rate(foo[1m]))",
+		},
+		"request without query": {
+			req:          &httpgrpc.HTTPRequest{Method: "GET", Url: "/prometheus/api/v1/labels"},
+			expectedPath: "/prometheus/api/v1/labels",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			q := NewQuery("1", "user-1", testData.req, startTime, "querier-1")
+
+			assert.Equal(t, "1", q.ID)
+			assert.Equal(t, "user-1", q.Tenant)
+			assert.Equal(t, testData.expectedPath, q.Path)
+			assert.Equal(t, testData.expectedQuery, q.Query)
+			assert.Equal(t, startTime, q.StartTime)
+			assert.Equal(t, "querier-1", q.Querier)
+			assert.GreaterOrEqual(t, q.ElapsedSeconds, 60.0)
+		})
+	}
+}
+
+func TestListHandler(t *testing.T) {
+	now := time.Now()
+	tracker := &mockTracker{queries: []Query{
+		{ID: "3", Tenant: "user-1", StartTime: now},
+		{ID: "2", Tenant: "user-2", StartTime: now},
+		{ID: "1", Tenant: "user-1", StartTime: now.Add(-time.Second)},
+	}}
+
+	rec := httptest.NewRecorder()
+	ListHandler(tracker, log.NewNopLogger()).ServeHTTP(rec, newRequest("GET", "/api/v1/queries/active", "user-1"))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Regexp(t, `^{"status":"success","data":\[{"id":"1","tenant":"user-1".*},{"id":"3","tenant":"user-1".*}\]}$`, rec.Body.String())
+
+	// An empty list is returned when the tenant has no queries in flight.
+	rec = httptest.NewRecorder()
+	ListHandler(tracker, log.NewNopLogger()).ServeHTTP(rec, newRequest("GET", "/api/v1/queries/active", "user-3"))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, `{"status":"success","data":[]}`, rec.Body.String())
+
+	// The tenant ID is required.
+	rec = httptest.NewRecorder()
+	ListHandler(tracker, log.NewNopLogger()).ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/queries/active", nil))
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestCancelHandler(t *testing.T) {
+	tracker := &mockTracker{queries: []Query{{ID: "1", Tenant: "user-1"}}}
+
+	router := mux.NewRouter()
+	router.Path("/api/v1/queries/{id}").Methods("DELETE").Handler(CancelHandler(tracker, log.NewNopLogger()))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("DELETE", "/api/v1/queries/2", "user-1"))
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, `{"status":"error","errorType":"not_found","error":"no query in flight with ID 2"}`, rec.Body.String())
+	require.Empty(t, tracker.cancelled)
+
+	// A tenant can't cancel the queries of other tenants.
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("DELETE", "/api/v1/queries/1", "user-2"))
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Empty(t, tracker.cancelled)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("DELETE", "/api/v1/queries/1", nil))
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Empty(t, tracker.cancelled)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, newRequest("DELETE", "/api/v1/queries/1", "user-1"))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, `{"status":"success"}`, rec.Body.String())
+	require.Equal(t, []string{"1"}, tracker.cancelled)
+}
+
+func newRequest(method, target, tenantID string) *http.Request {
+	r := httptest.NewRequest(method, target, nil)
+	return r.WithContext(user.InjectOrgID(r.Context(), tenantID))
+}
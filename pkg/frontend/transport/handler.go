@@ -59,6 +59,10 @@ type Handler struct {
 
 	// Metrics.
 	querySeconds *prometheus.CounterVec
+	querySeries  *prometheus.CounterVec
+	queryBytes   *prometheus.CounterVec
+	queryBlocks  *prometheus.CounterVec
+	querySamples *prometheus.CounterVec
 }
 
 // New creates a new frontend handler.
@@ -74,6 +78,26 @@ func NewHandler(cfg HandlerConfig, roundTripper http.RoundTripper, log log.Logge
 			Name: "cortex_query_seconds_total",
 			Help: "Total amount of wall clock time spend processing queries.",
 		}, []string{"user"})
+
+		h.querySeries = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_query_fetched_series_total",
+			Help: "Number of series fetched to execute a query.",
+		}, []string{"user"})
+
+		h.queryBytes = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_query_fetched_chunks_bytes_total",
+			Help: "Size of all chunks fetched to execute a query in bytes.",
+		}, []string{"user"})
+
+		h.queryBlocks = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_query_fetched_blocks_total",
+			Help: "Number of blocks queried in the store-gateways to execute a query.",
+		}, []string{"user"})
+
+		h.querySamples = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_query_ingester_samples_total",
+			Help: "Number of samples returned by the ingesters to execute a query.",
+		}, []string{"user"})
 	}
 
 	return h
@@ -160,6 +184,10 @@ func (f *Handler) reportQueryStats(r *http.Request, queryString url.Values, quer
 
 	// Track stats.
 	f.querySeconds.WithLabelValues(userID).Add(stats.LoadWallTime().Seconds())
+	f.querySeries.WithLabelValues(userID).Add(float64(stats.LoadFetchedSeries()))
+	f.queryBytes.WithLabelValues(userID).Add(float64(stats.LoadFetchedChunkBytes()))
+	f.queryBlocks.WithLabelValues(userID).Add(float64(stats.LoadFetchedBlocks()))
+	f.querySamples.WithLabelValues(userID).Add(float64(stats.LoadIngesterSamples()))
 
 	// Log stats.
 	logMessage := append([]interface{}{
@@ -168,6 +196,10 @@ func (f *Handler) reportQueryStats(r *http.Request, queryString url.Values, quer
 		"path", r.URL.Path,
 		"response_time", queryResponseTime,
 		"query_wall_time_seconds", stats.LoadWallTime().Seconds(),
+		"fetched_series_count", stats.LoadFetchedSeries(),
+		"fetched_chunks_bytes", stats.LoadFetchedChunkBytes(),
+		"fetched_blocks_count", stats.LoadFetchedBlocks(),
+		"ingester_samples_count", stats.LoadIngesterSamples(),
 	}, formatQueryString(queryString)...)
 
 	level.Info(util_log.WithContext(r.Context(), f.log)).Log(logMessage...)
@@ -213,6 +245,10 @@ func writeServiceTimingHeader(queryResponseTime time.Duration, headers http.Head
 		parts := make([]string, 0)
 		parts = append(parts, statsValue("querier_wall_time", stats.LoadWallTime()))
 		parts = append(parts, statsValue("response_time", queryResponseTime))
+		parts = append(parts, statsCountValue("fetched_series", stats.LoadFetchedSeries()))
+		parts = append(parts, statsCountValue("fetched_chunks_bytes", stats.LoadFetchedChunkBytes()))
+		parts = append(parts, statsCountValue("fetched_blocks", stats.LoadFetchedBlocks()))
+		parts = append(parts, statsCountValue("ingester_samples", stats.LoadIngesterSamples()))
 		headers.Set(ServiceTimingHeaderName, strings.Join(parts, ", "))
 	}
 }
@@ -221,3 +257,8 @@ func statsValue(name string, d time.Duration) string {
 	durationInMs := strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64)
 	return name + ";dur=" + durationInMs
 }
+
+// statsCountValue formats a counter, which has no duration, using the description of the metric.
+func statsCountValue(name string, count uint64) string {
+	return name + ";desc=" + strconv.FormatUint(count, 10)
+}
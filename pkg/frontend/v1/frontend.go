@@ -5,7 +5,10 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-kit/kit/log"
@@ -14,7 +17,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/weaveworks/common/httpgrpc"
+	"go.uber.org/atomic"
 
+	"github.com/cortexproject/cortex/pkg/frontend/activequeries"
 	"github.com/cortexproject/cortex/pkg/frontend/v1/frontendv1pb"
 	"github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/scheduler/queue"
@@ -51,16 +56,31 @@ type Frontend struct {
 
 	requestQueue *queue.RequestQueue
 
+	lastQueryID atomic.Uint64
+
+	// Requests in flight, by query ID.
+	activeRequestsMu sync.Mutex
+	activeRequests   map[uint64]*request
+
 	// Metrics.
 	numClients    prometheus.GaugeFunc
 	queueDuration prometheus.Histogram
 }
 
 type request struct {
+	queryID     uint64
+	userID      string
+	startTime   time.Time
 	enqueueTime time.Time
 	queueSpan   opentracing.Span
 	originalCtx context.Context
 
+	cancel context.CancelFunc
+	// Set when the request is cancelled via the active queries API.
+	cancelledViaAPI atomic.Bool
+	// ID of the querier the request has been dispatched to.
+	querierID atomic.String
+
 	request  *httpgrpc.HTTPRequest
 	err      chan error
 	response chan *httpgrpc.HTTPResponse
@@ -74,10 +94,11 @@ func New(cfg Config, limits Limits, log log.Logger, registerer prometheus.Regist
 	}, []string{"user"})
 
 	f := &Frontend{
-		cfg:          cfg,
-		log:          log,
-		limits:       limits,
-		requestQueue: queue.NewRequestQueue(cfg.MaxOutstandingPerTenant, queueLength),
+		cfg:            cfg,
+		log:            log,
+		limits:         limits,
+		requestQueue:   queue.NewRequestQueue(cfg.MaxOutstandingPerTenant, queue.PriorityConfig{Policy: queue.PriorityPolicyStrict}, queueLength, nil),
+		activeRequests: map[uint64]*request{},
 		queueDuration: promauto.With(registerer).NewHistogram(prometheus.HistogramOpts{
 			Name:    "cortex_query_frontend_queue_duration_seconds",
 			Help:    "Time spend by requests queued.",
@@ -90,6 +111,9 @@ func New(cfg Config, limits Limits, log log.Logger, registerer prometheus.Regist
 		Help: "Number of worker clients currently connected to the frontend.",
 	}, f.requestQueue.GetConnectedQuerierWorkersMetric)
 
+	// Randomize to make the query IDs unlikely to be the same of other frontends, and across restarts.
+	f.lastQueryID.Store(rand.Uint64())
+
 	return f, nil
 }
 
@@ -110,9 +134,15 @@ func (f *Frontend) RoundTripGRPC(ctx context.Context, req *httpgrpc.HTTPRequest)
 		}
 	}
 
-	request := request{
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	request := &request{
+		queryID:     f.lastQueryID.Inc(),
+		startTime:   time.Now(),
 		request:     req,
 		originalCtx: ctx,
+		cancel:      cancel,
 
 		// Buffer of 1 to ensure response can be written by the server side
 		// of the Process stream, even if this goroutine goes away due to
@@ -121,12 +151,25 @@ func (f *Frontend) RoundTripGRPC(ctx context.Context, req *httpgrpc.HTTPRequest)
 		response: make(chan *httpgrpc.HTTPResponse, 1),
 	}
 
-	if err := f.queueRequest(ctx, &request); err != nil {
+	if err := f.queueRequest(ctx, request); err != nil {
 		return nil, err
 	}
 
+	f.activeRequestsMu.Lock()
+	f.activeRequests[request.queryID] = request
+	f.activeRequestsMu.Unlock()
+
+	defer func() {
+		f.activeRequestsMu.Lock()
+		delete(f.activeRequests, request.queryID)
+		f.activeRequestsMu.Unlock()
+	}()
+
 	select {
 	case <-ctx.Done():
+		if request.cancelledViaAPI.Load() {
+			return nil, activequeries.ErrCancelled
+		}
 		return nil, ctx.Err()
 
 	case resp := <-request.response:
@@ -185,6 +228,8 @@ func (f *Frontend) Process(server frontendv1pb.Frontend_ProcessServer) error {
 			continue
 		}
 
+		req.querierID.Store(querierID)
+
 		// Handle the stream sending & receiving on a goroutine so we can
 		// monitoring the contexts in a select and cancel things appropriately.
 		resps := make(chan *frontendv1pb.ClientToFrontend, 1)
@@ -263,19 +308,58 @@ func (f *Frontend) queueRequest(ctx context.Context, req *request) error {
 		return err
 	}
 
+	req.userID = tenant.JoinTenantIDs(tenantIDs)
 	req.enqueueTime = time.Now()
 	req.queueSpan, _ = opentracing.StartSpanFromContext(ctx, "queued")
 
 	// aggregate the max queriers limit in the case of a multi tenant query
 	maxQueriers := validation.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.MaxQueriersPerUser)
 
-	err = f.requestQueue.EnqueueRequest(tenant.JoinTenantIDs(tenantIDs), req, maxQueriers, nil)
+	// Query priorities and tenant weights are only supported by the query-scheduler, so all the requests
+	// have the same priority and all the tenants have the same weight.
+	err = f.requestQueue.EnqueueRequest(req.userID, req, queue.PriorityNormal, maxQueriers, 1, nil)
 	if err == queue.ErrTooManyRequests {
 		return errTooManyRequest
 	}
 	return err
 }
 
+// ActiveQueries implements activequeries.Tracker.
+func (f *Frontend) ActiveQueries(tenantID string) []activequeries.Query {
+	f.activeRequestsMu.Lock()
+	defer f.activeRequestsMu.Unlock()
+
+	var result []activequeries.Query
+	for _, req := range f.activeRequests {
+		if req.userID != tenantID {
+			continue
+		}
+		result = append(result, activequeries.NewQuery(strconv.FormatUint(req.queryID, 10), req.userID, req.request, req.startTime, req.querierID.Load()))
+	}
+	return result
+}
+
+// CancelQuery implements activequeries.Tracker. If the query has been dispatched to a querier,
+// the stream to the querier is closed, which cancels the query in the querier.
+func (f *Frontend) CancelQuery(_ context.Context, tenantID, id string) bool {
+	queryID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	f.activeRequestsMu.Lock()
+	req := f.activeRequests[queryID]
+	f.activeRequestsMu.Unlock()
+
+	if req == nil || req.userID != tenantID {
+		return false
+	}
+
+	req.cancelledViaAPI.Store(true)
+	req.cancel()
+	return true
+}
+
 // CheckReady determines if the query frontend is ready.  Function parameters/return
 // chosen to match the same method in the ingester
 func (f *Frontend) CheckReady(_ context.Context) error {
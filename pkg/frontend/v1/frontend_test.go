@@ -126,7 +126,7 @@ func TestFrontendCheckReady(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			f := &Frontend{
 				log:          log.NewNopLogger(),
-				requestQueue: queue.NewRequestQueue(5, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"})),
+				requestQueue: queue.NewRequestQueue(5, queue.PriorityConfig{Policy: queue.PriorityPolicyStrict}, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil),
 			}
 			for i := 0; i < tt.connectedClients; i++ {
 				f.requestQueue.RegisterQuerierConnection("test")
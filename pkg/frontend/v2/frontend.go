@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/weaveworks/common/httpgrpc"
 	"go.uber.org/atomic"
 
+	"github.com/cortexproject/cortex/pkg/frontend/activequeries"
 	"github.com/cortexproject/cortex/pkg/frontend/v2/frontendv2pb"
 	"github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/tenant"
@@ -77,8 +79,11 @@ type frontendRequest struct {
 	request      *httpgrpc.HTTPRequest
 	userID       string
 	statsEnabled bool
+	startTime    time.Time
 
 	cancel context.CancelFunc
+	// Set when the request is cancelled via the active queries API.
+	cancelledViaAPI atomic.Bool
 
 	enqueue  chan enqueueResult
 	response chan *frontendv2pb.QueryResultRequest
@@ -176,6 +181,7 @@ func (f *Frontend) RoundTripGRPC(ctx context.Context, req *httpgrpc.HTTPRequest)
 		request:      req,
 		userID:       userID,
 		statsEnabled: stats.IsEnabled(ctx),
+		startTime:    time.Now(),
 
 		cancel: cancel,
 
@@ -193,7 +199,7 @@ func (f *Frontend) RoundTripGRPC(ctx context.Context, req *httpgrpc.HTTPRequest)
 enqueueAgain:
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return nil, freq.contextErr(ctx)
 
 	case f.requestsCh <- freq:
 		// Enqueued, let's wait for response.
@@ -203,7 +209,7 @@ enqueueAgain:
 
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return nil, freq.contextErr(ctx)
 
 	case enqRes := <-freq.enqueue:
 		if enqRes.status == waitForResponse {
@@ -229,7 +235,7 @@ enqueueAgain:
 				// failed to cancel, ignore.
 			}
 		}
-		return nil, ctx.Err()
+		return nil, freq.contextErr(ctx)
 
 	case resp := <-freq.response:
 		if stats.ShouldTrackHTTPGRPCResponse(resp.HttpResponse) {
@@ -241,6 +247,14 @@ enqueueAgain:
 	}
 }
 
+// contextErr returns the error of the request whose context is done.
+func (r *frontendRequest) contextErr(ctx context.Context) error {
+	if r.cancelledViaAPI.Load() {
+		return activequeries.ErrCancelled
+	}
+	return ctx.Err()
+}
+
 func (f *Frontend) QueryResult(ctx context.Context, qrReq *frontendv2pb.QueryResultRequest) (*frontendv2pb.QueryResultResponse, error) {
 	tenantIDs, err := tenant.TenantIDs(ctx)
 	if err != nil {
@@ -264,6 +278,37 @@ func (f *Frontend) QueryResult(ctx context.Context, qrReq *frontendv2pb.QueryRes
 	return &frontendv2pb.QueryResultResponse{}, nil
 }
 
+// ActiveQueries implements activequeries.Tracker. The querier a query has been dispatched to is only
+// known by the query-scheduler.
+func (f *Frontend) ActiveQueries(tenantID string) []activequeries.Query {
+	var result []activequeries.Query
+	for _, req := range f.requests.list() {
+		if req.userID != tenantID {
+			continue
+		}
+		result = append(result, activequeries.NewQuery(strconv.FormatUint(req.queryID, 10), req.userID, req.request, req.startTime, ""))
+	}
+	return result
+}
+
+// CancelQuery implements activequeries.Tracker. The cancellation is sent to the query-scheduler,
+// which cancels the query in the querier it has been dispatched to.
+func (f *Frontend) CancelQuery(_ context.Context, tenantID, id string) bool {
+	queryID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	req := f.requests.get(queryID)
+	if req == nil || req.userID != tenantID {
+		return false
+	}
+
+	req.cancelledViaAPI.Store(true)
+	req.cancel()
+	return true
+}
+
 // CheckReady determines if the query frontend is ready.  Function parameters/return
 // chosen to match the same method in the ingester
 func (f *Frontend) CheckReady(_ context.Context) error {
@@ -297,6 +342,17 @@ func (r *requestsInProgress) count() int {
 	return len(r.requests)
 }
 
+func (r *requestsInProgress) list() []*frontendRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*frontendRequest, 0, len(r.requests))
+	for _, req := range r.requests {
+		result = append(result, req)
+	}
+	return result
+}
+
 func (r *requestsInProgress) put(req *frontendRequest) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
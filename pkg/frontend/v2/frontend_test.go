@@ -16,6 +16,7 @@ import (
 	"go.uber.org/atomic"
 	"google.golang.org/grpc"
 
+	"github.com/cortexproject/cortex/pkg/frontend/activequeries"
 	"github.com/cortexproject/cortex/pkg/frontend/v2/frontendv2pb"
 	"github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/scheduler/schedulerpb"
@@ -215,6 +216,53 @@ func TestFrontendFailedCancellation(t *testing.T) {
 	})
 }
 
+func TestFrontendCancelActiveQuery(t *testing.T) {
+	f, ms := setupFrontend(t, nil)
+
+	go func() {
+		// Wait until the request has been enqueued in the scheduler.
+		test.Poll(t, time.Second, 1, func() interface{} {
+			ms.mu.Lock()
+			defer ms.mu.Unlock()
+
+			return len(ms.msgs)
+		})
+
+		// Add a little sleep to make sure that frontend receives the enqueue result.
+		time.Sleep(100 * time.Millisecond)
+
+		require.Empty(t, f.ActiveQueries("other"))
+
+		queries := f.ActiveQueries("test")
+		require.Len(t, queries, 1)
+		require.Equal(t, "test", queries[0].Tenant)
+		require.Equal(t, "/api/v1/query", queries[0].Path)
+		require.Equal(t, "up", queries[0].Query)
+
+		require.False(t, f.CancelQuery(context.Background(), "test", "not-a-query-id"))
+		require.False(t, f.CancelQuery(context.Background(), "other", queries[0].ID))
+		require.True(t, f.CancelQuery(context.Background(), "test", queries[0].ID))
+	}()
+
+	resp, err := f.RoundTripGRPC(user.InjectOrgID(context.Background(), "test"), &httpgrpc.HTTPRequest{Method: "GET", Url: "/api/v1/query?query=up"})
+	require.Equal(t, activequeries.ErrCancelled, err)
+	require.Nil(t, resp)
+	require.Empty(t, f.ActiveQueries("test"))
+
+	// The cancellation is sent to the scheduler.
+	test.Poll(t, time.Second, 2, func() interface{} {
+		ms.mu.Lock()
+		defer ms.mu.Unlock()
+
+		return len(ms.msgs)
+	})
+
+	ms.checkWithLock(func() {
+		require.True(t, ms.msgs[1].Type == schedulerpb.CANCEL)
+		require.True(t, ms.msgs[0].QueryID == ms.msgs[1].QueryID)
+	})
+}
+
 type mockScheduler struct {
 	t *testing.T
 	f *Frontend
@@ -1,17 +1,24 @@
 package ingester
 
 import (
+	"fmt"
 	"hash"
 	"math"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/cespare/xxhash"
+	"github.com/go-kit/kit/log/level"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
 	"go.uber.org/atomic"
 
 	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/log"
+	"github.com/cortexproject/cortex/pkg/util/validation"
 )
 
 const (
@@ -20,9 +27,118 @@ const (
 
 // ActiveSeries is keeping track of recently active series for a single tenant.
 type ActiveSeries struct {
+	mtx      sync.RWMutex // Protects matchers.
+	matchers *ActiveSeriesMatchers
+
 	stripes [numActiveSeriesStripes]activeSeriesStripe
 }
 
+// updateActiveSeriesMatchers reloads the custom trackers matchers of the input user's active series
+// if the configured trackers have changed, removing the metrics of the previous trackers.
+func updateActiveSeriesMatchers(userID string, activeSeries *ActiveSeries, limits *validation.Overrides, metrics *ingesterMetrics) {
+	// Limits are not set when running the flusher, which doesn't track active series.
+	if limits == nil {
+		return
+	}
+
+	trackers := limits.ActiveSeriesCustomTrackers(userID)
+	current := activeSeries.CurrentMatchers()
+	if current.Matches(trackers) {
+		return
+	}
+
+	asm, err := NewActiveSeriesMatchers(trackers)
+	if err != nil {
+		level.Warn(log.Logger).Log("msg", "failed to reload active series custom trackers", "user", userID, "err", err)
+		return
+	}
+
+	activeSeries.ReloadMatchers(asm)
+	metrics.deleteActiveSeriesCustomTrackers(userID, current)
+}
+
+// ActiveSeriesMatchers holds the named label matchers used to track the number of
+// active series matching each of them (custom trackers).
+type ActiveSeriesMatchers struct {
+	key      string
+	names    []string
+	matchers [][]*labels.Matcher
+}
+
+// NewActiveSeriesMatchers parses the input custom trackers, a map of tracker name to series
+// selector (ie. `{team="a"}`), and returns the matchers sorted by name.
+func NewActiveSeriesMatchers(trackers map[string]string) (*ActiveSeriesMatchers, error) {
+	asm := &ActiveSeriesMatchers{key: activeSeriesMatchersKey(trackers)}
+
+	for name := range trackers {
+		asm.names = append(asm.names, name)
+	}
+	sort.Strings(asm.names)
+
+	for _, name := range asm.names {
+		matchers, err := parser.ParseMetricSelector(trackers[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid matchers for active series custom tracker %q: %w", name, err)
+		}
+		asm.matchers = append(asm.matchers, matchers)
+	}
+
+	return asm, nil
+}
+
+// Names returns the names of the custom trackers, sorted.
+func (asm *ActiveSeriesMatchers) Names() []string {
+	if asm == nil {
+		return nil
+	}
+	return asm.names
+}
+
+// Matches returns whether the input trackers config is the one these matchers have been built from.
+func (asm *ActiveSeriesMatchers) Matches(trackers map[string]string) bool {
+	if asm == nil {
+		return len(trackers) == 0
+	}
+	return asm.key == activeSeriesMatchersKey(trackers)
+}
+
+// matches returns, for each custom tracker, whether the input series matches it.
+func (asm *ActiveSeriesMatchers) matches(series labels.Labels) []bool {
+	if asm == nil || len(asm.matchers) == 0 {
+		return nil
+	}
+
+	result := make([]bool, len(asm.matchers))
+	for i, matchers := range asm.matchers {
+		result[i] = true
+		for _, m := range matchers {
+			if !m.Matches(series.Get(m.Name)) {
+				result[i] = false
+				break
+			}
+		}
+	}
+	return result
+}
+
+// activeSeriesMatchersKey returns a string uniquely identifying the input trackers config.
+func activeSeriesMatchersKey(trackers map[string]string) string {
+	names := make([]string, 0, len(trackers))
+	for name := range trackers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sb := strings.Builder{}
+	for _, name := range names {
+		sb.WriteString(name)
+		sb.WriteByte(0)
+		sb.WriteString(trackers[name])
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
 // activeSeriesStripe holds a subset of the series timestamps for a single tenant.
 type activeSeriesStripe struct {
 	// Unix nanoseconds. Only used by purge. Zero = unknown.
@@ -30,15 +146,18 @@ type activeSeriesStripe struct {
 	// without holding the lock -- hence the atomic).
 	oldestEntryTs atomic.Int64
 
-	mu     sync.RWMutex
-	refs   map[uint64][]activeSeriesEntry
-	active int // Number of active entries in this stripe. Only decreased during purge or clear.
+	mu             sync.RWMutex
+	refs           map[uint64][]activeSeriesEntry
+	matchers       *ActiveSeriesMatchers
+	active         int   // Number of active entries in this stripe. Only decreased during purge or clear.
+	activeMatching []int // Number of active entries in this stripe matching each custom tracker.
 }
 
 // activeSeriesEntry holds a timestamp for single series.
 type activeSeriesEntry struct {
-	lbs   labels.Labels
-	nanos *atomic.Int64 // Unix timestamp in nanoseconds. Needs to be a pointer because we don't store pointers to entries in the stripe.
+	lbs     labels.Labels
+	nanos   *atomic.Int64 // Unix timestamp in nanoseconds. Needs to be a pointer because we don't store pointers to entries in the stripe.
+	matches []bool        // Whether the series matches each custom tracker.
 }
 
 func NewActiveSeries() *ActiveSeries {
@@ -94,6 +213,26 @@ func (c *ActiveSeries) clear() {
 	}
 }
 
+// ReloadMatchers replaces the custom trackers matchers. Since the series already tracked have been
+// matched against the previous matchers, they're all cleared and will be tracked again once updated.
+func (c *ActiveSeries) ReloadMatchers(asm *ActiveSeriesMatchers) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for s := 0; s < numActiveSeriesStripes; s++ {
+		c.stripes[s].reinitialize(asm)
+	}
+	c.matchers = asm
+}
+
+// CurrentMatchers returns the custom trackers matchers currently in use, or nil if none has been configured.
+func (c *ActiveSeries) CurrentMatchers() *ActiveSeriesMatchers {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.matchers
+}
+
 func (c *ActiveSeries) Active() int {
 	total := 0
 	for s := 0; s < numActiveSeriesStripes; s++ {
@@ -102,6 +241,20 @@ func (c *ActiveSeries) Active() int {
 	return total
 }
 
+// ActiveWithMatchers returns the total number of active series, along with the number of active
+// series matching each custom tracker, in the same order of the current matchers names.
+func (c *ActiveSeries) ActiveWithMatchers() (int, []int) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	total := 0
+	totalMatching := make([]int, len(c.matchers.Names()))
+	for s := 0; s < numActiveSeriesStripes; s++ {
+		total += c.stripes[s].getActiveWithMatchers(totalMatching)
+	}
+	return total, totalMatching
+}
+
 func (s *activeSeriesStripe) updateSeriesTimestamp(now time.Time, series labels.Labels, fingerprint uint64, labelsCopy func(labels.Labels) labels.Labels) {
 	nowNanos := now.UnixNano()
 
@@ -155,8 +308,14 @@ func (s *activeSeriesStripe) findOrCreateEntryForSeries(fingerprint uint64, seri
 
 	s.active++
 	e := activeSeriesEntry{
-		lbs:   labelsCopy(series),
-		nanos: atomic.NewInt64(nowNanos),
+		lbs:     labelsCopy(series),
+		nanos:   atomic.NewInt64(nowNanos),
+		matches: s.matchers.matches(series),
+	}
+	for i, match := range e.matches {
+		if match {
+			s.activeMatching[i]++
+		}
 	}
 
 	s.refs[fingerprint] = append(s.refs[fingerprint], e)
@@ -172,6 +331,21 @@ func (s *activeSeriesStripe) clear() {
 	s.oldestEntryTs.Store(0)
 	s.refs = map[uint64][]activeSeriesEntry{}
 	s.active = 0
+	for i := range s.activeMatching {
+		s.activeMatching[i] = 0
+	}
+}
+
+// reinitialize clears the stripe and sets the custom trackers matchers.
+func (s *activeSeriesStripe) reinitialize(asm *ActiveSeriesMatchers) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.oldestEntryTs.Store(0)
+	s.refs = map[uint64][]activeSeriesEntry{}
+	s.active = 0
+	s.matchers = asm
+	s.activeMatching = make([]int, len(asm.Names()))
 }
 
 func (s *activeSeriesStripe) purge(keepUntil time.Time) {
@@ -185,6 +359,7 @@ func (s *activeSeriesStripe) purge(keepUntil time.Time) {
 	defer s.mu.Unlock()
 
 	active := 0
+	activeMatching := make([]int, len(s.activeMatching))
 
 	oldest := int64(math.MaxInt64)
 	for fp, entries := range s.refs {
@@ -198,6 +373,7 @@ func (s *activeSeriesStripe) purge(keepUntil time.Time) {
 			}
 
 			active++
+			countMatching(activeMatching, entries[0].matches)
 			if ts < oldest {
 				oldest = ts
 			}
@@ -215,6 +391,7 @@ func (s *activeSeriesStripe) purge(keepUntil time.Time) {
 					oldest = ts
 				}
 
+				countMatching(activeMatching, entries[i].matches)
 				i++
 			}
 		}
@@ -234,6 +411,15 @@ func (s *activeSeriesStripe) purge(keepUntil time.Time) {
 		s.oldestEntryTs.Store(oldest)
 	}
 	s.active = active
+	s.activeMatching = activeMatching
+}
+
+func countMatching(activeMatching []int, matches []bool) {
+	for i, match := range matches {
+		if match {
+			activeMatching[i]++
+		}
+	}
 }
 
 func (s *activeSeriesStripe) getActive() int {
@@ -242,3 +428,15 @@ func (s *activeSeriesStripe) getActive() int {
 
 	return s.active
 }
+
+// getActiveWithMatchers adds the number of active entries matching each custom tracker to
+// activeMatching and returns the number of active entries in this stripe.
+func (s *activeSeriesStripe) getActiveWithMatchers(activeMatching []int) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i, count := range s.activeMatching {
+		activeMatching[i] += count
+	}
+	return s.active
+}
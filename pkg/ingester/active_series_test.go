@@ -102,6 +102,90 @@ func TestActiveSeries_PurgeOpt(t *testing.T) {
 	assert.Equal(t, 1, c.Active())
 }
 
+func TestActiveSeries_UpdateSeriesWithMatchers(t *testing.T) {
+	ls1 := labels.FromStrings(labels.MetricName, "up", "team", "a")
+	ls2 := labels.FromStrings(labels.MetricName, "up", "team", "b")
+	ls3 := labels.FromStrings(labels.MetricName, "requests_total", "team", "a")
+
+	asm, err := NewActiveSeriesMatchers(map[string]string{
+		"team_a": `{team="a"}`,
+		"up":     `up{team=~"a|b"}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team_a", "up"}, asm.Names())
+
+	c := NewActiveSeries()
+	c.ReloadMatchers(asm)
+
+	now := time.Now()
+	c.UpdateSeries(ls1, now, copyFn)
+	c.UpdateSeries(ls2, now, copyFn)
+	c.UpdateSeries(ls3, now.Add(-time.Minute), copyFn)
+
+	total, totalMatching := c.ActiveWithMatchers()
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []int{2, 2}, totalMatching)
+
+	// Purging the series should update the number of matching series too.
+	c.Purge(now)
+	total, totalMatching = c.ActiveWithMatchers()
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []int{1, 2}, totalMatching)
+}
+
+func TestActiveSeries_ReloadMatchers(t *testing.T) {
+	ls1 := labels.FromStrings(labels.MetricName, "up", "team", "a")
+	ls2 := labels.FromStrings(labels.MetricName, "up", "team", "b")
+
+	asm, err := NewActiveSeriesMatchers(map[string]string{"team_a": `{team="a"}`})
+	require.NoError(t, err)
+
+	c := NewActiveSeries()
+	c.ReloadMatchers(asm)
+	c.UpdateSeries(ls1, time.Now(), copyFn)
+	c.UpdateSeries(ls2, time.Now(), copyFn)
+
+	total, totalMatching := c.ActiveWithMatchers()
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []int{1}, totalMatching)
+
+	// Reloading the matchers should clear the tracked series.
+	asm, err = NewActiveSeriesMatchers(map[string]string{"team_a": `{team="a"}`, "team_b": `{team="b"}`})
+	require.NoError(t, err)
+	c.ReloadMatchers(asm)
+
+	total, totalMatching = c.ActiveWithMatchers()
+	assert.Equal(t, 0, total)
+	assert.Equal(t, []int{0, 0}, totalMatching)
+
+	c.UpdateSeries(ls1, time.Now(), copyFn)
+	c.UpdateSeries(ls2, time.Now(), copyFn)
+
+	total, totalMatching = c.ActiveWithMatchers()
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []int{1, 1}, totalMatching)
+}
+
+func TestActiveSeriesMatchers(t *testing.T) {
+	trackers := map[string]string{"team_a": `{team="a"}`, "team_b": `{team="b"}`}
+
+	asm, err := NewActiveSeriesMatchers(trackers)
+	require.NoError(t, err)
+	assert.True(t, asm.Matches(trackers))
+	assert.True(t, asm.Matches(map[string]string{"team_b": `{team="b"}`, "team_a": `{team="a"}`}))
+	assert.False(t, asm.Matches(map[string]string{"team_a": `{team="a"}`}))
+	assert.False(t, asm.Matches(nil))
+
+	// Nil matchers are the ones of an active series without custom trackers.
+	var empty *ActiveSeriesMatchers
+	assert.True(t, empty.Matches(nil))
+	assert.True(t, empty.Matches(map[string]string{}))
+	assert.False(t, empty.Matches(trackers))
+
+	_, err = NewActiveSeriesMatchers(map[string]string{"invalid": `{team=}`})
+	require.Error(t, err)
+}
+
 var activeSeriesTestGoroutines = []int{50, 100, 500}
 
 func BenchmarkActiveSeriesTest_single_series(b *testing.B) {
@@ -130,6 +130,37 @@ func FromMetricsForLabelMatchersRequest(req *MetricsForLabelMatchersRequest) (mo
 	return from, to, matchersSet, nil
 }
 
+// ToExemplarQueryRequest builds an ExemplarQueryRequest proto.
+func ToExemplarQueryRequest(from, to model.Time, matchersSet ...[]*labels.Matcher) (*ExemplarQueryRequest, error) {
+	reqMatchers := make([]*LabelMatchers, 0, len(matchersSet))
+	for _, matchers := range matchersSet {
+		ms, err := toLabelMatchers(matchers)
+		if err != nil {
+			return nil, err
+		}
+		reqMatchers = append(reqMatchers, &LabelMatchers{Matchers: ms})
+	}
+
+	return &ExemplarQueryRequest{
+		StartTimestampMs: int64(from),
+		EndTimestampMs:   int64(to),
+		Matchers:         reqMatchers,
+	}, nil
+}
+
+// FromExemplarQueryRequest unpacks an ExemplarQueryRequest proto.
+func FromExemplarQueryRequest(req *ExemplarQueryRequest) (int64, int64, [][]*labels.Matcher, error) {
+	matchersSet := make([][]*labels.Matcher, 0, len(req.Matchers))
+	for _, matchers := range req.Matchers {
+		matchers, err := fromLabelMatchers(matchers.Matchers)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		matchersSet = append(matchersSet, matchers)
+	}
+	return req.StartTimestampMs, req.EndTimestampMs, matchersSet, nil
+}
+
 // FromMetricsForLabelMatchersResponse unpacks a MetricsForLabelMatchersResponse proto
 func FromMetricsForLabelMatchersResponse(resp *MetricsForLabelMatchersResponse) []model.Metric {
 	metrics := []model.Metric{}
@@ -338,6 +369,15 @@ func LabelsToKeyString(l labels.Labels) string {
 	return string(l.Bytes(b))
 }
 
+// ChunksSize returns the size of the data of the chunks, in bytes.
+func ChunksSize(chunks []Chunk) int {
+	size := 0
+	for _, c := range chunks {
+		size += len(c.Data)
+	}
+	return size
+}
+
 // MarshalJSON implements json.Marshaler.
 func (s Sample) MarshalJSON() ([]byte, error) {
 	t, err := json.Marshal(model.Time(s.TimestampMs))
@@ -63,6 +63,28 @@ func TestQueryRequest(t *testing.T) {
 	}
 }
 
+func TestExemplarQueryRequest(t *testing.T) {
+	from, to := model.Time(int64(0)), model.Time(int64(10))
+	matchersSet := [][]*labels.Matcher{
+		{
+			labels.MustNewMatcher(labels.MatchEqual, "foo", "1"),
+			labels.MustNewMatcher(labels.MatchRegexp, "bar", "2"),
+		},
+		{
+			labels.MustNewMatcher(labels.MatchNotEqual, "baz", "3"),
+		},
+	}
+
+	req, err := ToExemplarQueryRequest(from, to, matchersSet...)
+	assert.NoError(t, err)
+
+	haveFrom, haveTo, haveMatchersSet, err := FromExemplarQueryRequest(req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(from), haveFrom)
+	assert.Equal(t, int64(to), haveTo)
+	assert.Equal(t, matchersSet, haveMatchersSet)
+}
+
 func buildTestMatrix(numSeries int, samplesPerSeries int, offset int) model.Matrix {
 	m := make(model.Matrix, 0, numSeries)
 	for i := 0; i < numSeries; i++ {
@@ -116,7 +116,7 @@ var MetricMetadata_MetricType_value = map[string]int32{
 }
 
 func (MetricMetadata_MetricType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{26, 0}
+	return fileDescriptor_893a47d0a749d749, []int{36, 0}
 }
 
 type WriteRequest struct {
@@ -454,6 +454,109 @@ func (m *QueryStreamResponse) GetTimeseries() []TimeSeries {
 	return nil
 }
 
+type ExemplarQueryRequest struct {
+	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
+}
+
+func (m *ExemplarQueryRequest) Reset()      { *m = ExemplarQueryRequest{} }
+func (*ExemplarQueryRequest) ProtoMessage() {}
+func (*ExemplarQueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{7}
+}
+func (m *ExemplarQueryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExemplarQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExemplarQueryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExemplarQueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExemplarQueryRequest.Merge(m, src)
+}
+func (m *ExemplarQueryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExemplarQueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExemplarQueryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExemplarQueryRequest proto.InternalMessageInfo
+
+func (m *ExemplarQueryRequest) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
+	}
+	return 0
+}
+
+func (m *ExemplarQueryRequest) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
+	}
+	return 0
+}
+
+func (m *ExemplarQueryRequest) GetMatchers() []*LabelMatchers {
+	if m != nil {
+		return m.Matchers
+	}
+	return nil
+}
+
+// ExemplarQueryResponse contains the series matching the query, each one holding its exemplars only.
+type ExemplarQueryResponse struct {
+	Timeseries []TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries"`
+}
+
+func (m *ExemplarQueryResponse) Reset()      { *m = ExemplarQueryResponse{} }
+func (*ExemplarQueryResponse) ProtoMessage() {}
+func (*ExemplarQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{8}
+}
+func (m *ExemplarQueryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExemplarQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExemplarQueryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExemplarQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExemplarQueryResponse.Merge(m, src)
+}
+func (m *ExemplarQueryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExemplarQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExemplarQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExemplarQueryResponse proto.InternalMessageInfo
+
+func (m *ExemplarQueryResponse) GetTimeseries() []TimeSeries {
+	if m != nil {
+		return m.Timeseries
+	}
+	return nil
+}
+
 type LabelValuesRequest struct {
 	LabelName        string `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
 	StartTimestampMs int64  `protobuf:"varint,2,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
@@ -463,7 +566,7 @@ type LabelValuesRequest struct {
 func (m *LabelValuesRequest) Reset()      { *m = LabelValuesRequest{} }
 func (*LabelValuesRequest) ProtoMessage() {}
 func (*LabelValuesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{7}
+	return fileDescriptor_893a47d0a749d749, []int{9}
 }
 func (m *LabelValuesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -520,7 +623,7 @@ type LabelValuesResponse struct {
 func (m *LabelValuesResponse) Reset()      { *m = LabelValuesResponse{} }
 func (*LabelValuesResponse) ProtoMessage() {}
 func (*LabelValuesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{8}
+	return fileDescriptor_893a47d0a749d749, []int{10}
 }
 func (m *LabelValuesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -564,7 +667,7 @@ type LabelNamesRequest struct {
 func (m *LabelNamesRequest) Reset()      { *m = LabelNamesRequest{} }
 func (*LabelNamesRequest) ProtoMessage() {}
 func (*LabelNamesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{9}
+	return fileDescriptor_893a47d0a749d749, []int{11}
 }
 func (m *LabelNamesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -614,7 +717,7 @@ type LabelNamesResponse struct {
 func (m *LabelNamesResponse) Reset()      { *m = LabelNamesResponse{} }
 func (*LabelNamesResponse) ProtoMessage() {}
 func (*LabelNamesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{10}
+	return fileDescriptor_893a47d0a749d749, []int{12}
 }
 func (m *LabelNamesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -656,7 +759,7 @@ type UserStatsRequest struct {
 func (m *UserStatsRequest) Reset()      { *m = UserStatsRequest{} }
 func (*UserStatsRequest) ProtoMessage() {}
 func (*UserStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{11}
+	return fileDescriptor_893a47d0a749d749, []int{13}
 }
 func (m *UserStatsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -695,7 +798,7 @@ type UserStatsResponse struct {
 func (m *UserStatsResponse) Reset()      { *m = UserStatsResponse{} }
 func (*UserStatsResponse) ProtoMessage() {}
 func (*UserStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{12}
+	return fileDescriptor_893a47d0a749d749, []int{14}
 }
 func (m *UserStatsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -760,7 +863,7 @@ type UserIDStatsResponse struct {
 func (m *UserIDStatsResponse) Reset()      { *m = UserIDStatsResponse{} }
 func (*UserIDStatsResponse) ProtoMessage() {}
 func (*UserIDStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{13}
+	return fileDescriptor_893a47d0a749d749, []int{15}
 }
 func (m *UserIDStatsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -810,7 +913,7 @@ type UsersStatsResponse struct {
 func (m *UsersStatsResponse) Reset()      { *m = UsersStatsResponse{} }
 func (*UsersStatsResponse) ProtoMessage() {}
 func (*UsersStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{14}
+	return fileDescriptor_893a47d0a749d749, []int{16}
 }
 func (m *UsersStatsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -846,23 +949,22 @@ func (m *UsersStatsResponse) GetStats() []*UserIDStatsResponse {
 	return nil
 }
 
-type MetricsForLabelMatchersRequest struct {
-	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	MatchersSet      []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers_set,json=matchersSet,proto3" json:"matchers_set,omitempty"`
+type LabelNamesCardinalityRequest struct {
+	// Max number of values returned for each label name. The values of label names with more values are not returned.
+	MaxLabelValues uint64 `protobuf:"varint,1,opt,name=max_label_values,json=maxLabelValues,proto3" json:"max_label_values,omitempty"`
 }
 
-func (m *MetricsForLabelMatchersRequest) Reset()      { *m = MetricsForLabelMatchersRequest{} }
-func (*MetricsForLabelMatchersRequest) ProtoMessage() {}
-func (*MetricsForLabelMatchersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{15}
+func (m *LabelNamesCardinalityRequest) Reset()      { *m = LabelNamesCardinalityRequest{} }
+func (*LabelNamesCardinalityRequest) ProtoMessage() {}
+func (*LabelNamesCardinalityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{17}
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Unmarshal(b []byte) error {
+func (m *LabelNamesCardinalityRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelNamesCardinalityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsForLabelMatchersRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelNamesCardinalityRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -872,54 +974,104 @@ func (m *MetricsForLabelMatchersRequest) XXX_Marshal(b []byte, deterministic boo
 		return b[:n], nil
 	}
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsForLabelMatchersRequest.Merge(m, src)
+func (m *LabelNamesCardinalityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelNamesCardinalityRequest.Merge(m, src)
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Size() int {
+func (m *LabelNamesCardinalityRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsForLabelMatchersRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsForLabelMatchersRequest.DiscardUnknown(m)
+func (m *LabelNamesCardinalityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelNamesCardinalityRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsForLabelMatchersRequest proto.InternalMessageInfo
+var xxx_messageInfo_LabelNamesCardinalityRequest proto.InternalMessageInfo
 
-func (m *MetricsForLabelMatchersRequest) GetStartTimestampMs() int64 {
+func (m *LabelNamesCardinalityRequest) GetMaxLabelValues() uint64 {
 	if m != nil {
-		return m.StartTimestampMs
+		return m.MaxLabelValues
 	}
 	return 0
 }
 
-func (m *MetricsForLabelMatchersRequest) GetEndTimestampMs() int64 {
+// LabelNamesCardinalityResponse contains the cardinality of the in-memory series of a single tenant.
+type LabelNamesCardinalityResponse struct {
+	NumSeries uint64 `protobuf:"varint,1,opt,name=num_series,json=numSeries,proto3" json:"num_series,omitempty"`
+	// All label names along with their values.
+	LabelNames []LabelNameValues `protobuf:"bytes,2,rep,name=label_names,json=labelNames,proto3" json:"label_names"`
+	// Number of series for each metric name.
+	MetricNames []LabelValueSeriesCount `protobuf:"bytes,3,rep,name=metric_names,json=metricNames,proto3" json:"metric_names"`
+}
+
+func (m *LabelNamesCardinalityResponse) Reset()      { *m = LabelNamesCardinalityResponse{} }
+func (*LabelNamesCardinalityResponse) ProtoMessage() {}
+func (*LabelNamesCardinalityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{18}
+}
+func (m *LabelNamesCardinalityResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelNamesCardinalityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelNamesCardinalityResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LabelNamesCardinalityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelNamesCardinalityResponse.Merge(m, src)
+}
+func (m *LabelNamesCardinalityResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelNamesCardinalityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelNamesCardinalityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelNamesCardinalityResponse proto.InternalMessageInfo
+
+func (m *LabelNamesCardinalityResponse) GetNumSeries() uint64 {
 	if m != nil {
-		return m.EndTimestampMs
+		return m.NumSeries
 	}
 	return 0
 }
 
-func (m *MetricsForLabelMatchersRequest) GetMatchersSet() []*LabelMatchers {
+func (m *LabelNamesCardinalityResponse) GetLabelNames() []LabelNameValues {
 	if m != nil {
-		return m.MatchersSet
+		return m.LabelNames
 	}
 	return nil
 }
 
-type MetricsForLabelMatchersResponse struct {
-	Metric []*Metric `protobuf:"bytes,1,rep,name=metric,proto3" json:"metric,omitempty"`
+func (m *LabelNamesCardinalityResponse) GetMetricNames() []LabelValueSeriesCount {
+	if m != nil {
+		return m.MetricNames
+	}
+	return nil
 }
 
-func (m *MetricsForLabelMatchersResponse) Reset()      { *m = MetricsForLabelMatchersResponse{} }
-func (*MetricsForLabelMatchersResponse) ProtoMessage() {}
-func (*MetricsForLabelMatchersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{16}
+type LabelNameValues struct {
+	LabelName   string   `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
+	Values      []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	ValuesCount uint64   `protobuf:"varint,3,opt,name=values_count,json=valuesCount,proto3" json:"values_count,omitempty"`
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *LabelNameValues) Reset()      { *m = LabelNameValues{} }
+func (*LabelNameValues) ProtoMessage() {}
+func (*LabelNameValues) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{19}
+}
+func (m *LabelNameValues) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelNameValues) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsForLabelMatchersResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelNameValues.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -929,39 +1081,55 @@ func (m *MetricsForLabelMatchersResponse) XXX_Marshal(b []byte, deterministic bo
 		return b[:n], nil
 	}
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsForLabelMatchersResponse.Merge(m, src)
+func (m *LabelNameValues) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelNameValues.Merge(m, src)
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Size() int {
+func (m *LabelNameValues) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsForLabelMatchersResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsForLabelMatchersResponse.DiscardUnknown(m)
+func (m *LabelNameValues) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelNameValues.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsForLabelMatchersResponse proto.InternalMessageInfo
+var xxx_messageInfo_LabelNameValues proto.InternalMessageInfo
 
-func (m *MetricsForLabelMatchersResponse) GetMetric() []*Metric {
+func (m *LabelNameValues) GetLabelName() string {
 	if m != nil {
-		return m.Metric
+		return m.LabelName
+	}
+	return ""
+}
+
+func (m *LabelNameValues) GetValues() []string {
+	if m != nil {
+		return m.Values
 	}
 	return nil
 }
 
-type MetricsMetadataRequest struct {
+func (m *LabelNameValues) GetValuesCount() uint64 {
+	if m != nil {
+		return m.ValuesCount
+	}
+	return 0
 }
 
-func (m *MetricsMetadataRequest) Reset()      { *m = MetricsMetadataRequest{} }
-func (*MetricsMetadataRequest) ProtoMessage() {}
-func (*MetricsMetadataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{17}
+type LabelValuesCardinalityRequest struct {
+	LabelNames     []string `protobuf:"bytes,1,rep,name=label_names,json=labelNames,proto3" json:"label_names,omitempty"`
+	MaxLabelValues uint64   `protobuf:"varint,2,opt,name=max_label_values,json=maxLabelValues,proto3" json:"max_label_values,omitempty"`
 }
-func (m *MetricsMetadataRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *LabelValuesCardinalityRequest) Reset()      { *m = LabelValuesCardinalityRequest{} }
+func (*LabelValuesCardinalityRequest) ProtoMessage() {}
+func (*LabelValuesCardinalityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{20}
+}
+func (m *LabelValuesCardinalityRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesCardinalityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsMetadataRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesCardinalityRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -971,33 +1139,47 @@ func (m *MetricsMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *MetricsMetadataRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsMetadataRequest.Merge(m, src)
+func (m *LabelValuesCardinalityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesCardinalityRequest.Merge(m, src)
 }
-func (m *MetricsMetadataRequest) XXX_Size() int {
+func (m *LabelValuesCardinalityRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsMetadataRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsMetadataRequest.DiscardUnknown(m)
+func (m *LabelValuesCardinalityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesCardinalityRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsMetadataRequest proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesCardinalityRequest proto.InternalMessageInfo
 
-type MetricsMetadataResponse struct {
-	Metadata []*MetricMetadata `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty"`
+func (m *LabelValuesCardinalityRequest) GetLabelNames() []string {
+	if m != nil {
+		return m.LabelNames
+	}
+	return nil
 }
 
-func (m *MetricsMetadataResponse) Reset()      { *m = MetricsMetadataResponse{} }
-func (*MetricsMetadataResponse) ProtoMessage() {}
-func (*MetricsMetadataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{18}
+func (m *LabelValuesCardinalityRequest) GetMaxLabelValues() uint64 {
+	if m != nil {
+		return m.MaxLabelValues
+	}
+	return 0
 }
-func (m *MetricsMetadataResponse) XXX_Unmarshal(b []byte) error {
+
+type LabelValuesCardinalityResponse struct {
+	LabelNames []LabelNameSeriesCount `protobuf:"bytes,1,rep,name=label_names,json=labelNames,proto3" json:"label_names"`
+}
+
+func (m *LabelValuesCardinalityResponse) Reset()      { *m = LabelValuesCardinalityResponse{} }
+func (*LabelValuesCardinalityResponse) ProtoMessage() {}
+func (*LabelValuesCardinalityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{21}
+}
+func (m *LabelValuesCardinalityResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesCardinalityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsMetadataResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesCardinalityResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1007,43 +1189,43 @@ func (m *MetricsMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]b
 		return b[:n], nil
 	}
 }
-func (m *MetricsMetadataResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsMetadataResponse.Merge(m, src)
+func (m *LabelValuesCardinalityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesCardinalityResponse.Merge(m, src)
 }
-func (m *MetricsMetadataResponse) XXX_Size() int {
+func (m *LabelValuesCardinalityResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsMetadataResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsMetadataResponse.DiscardUnknown(m)
+func (m *LabelValuesCardinalityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesCardinalityResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsMetadataResponse proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesCardinalityResponse proto.InternalMessageInfo
 
-func (m *MetricsMetadataResponse) GetMetadata() []*MetricMetadata {
+func (m *LabelValuesCardinalityResponse) GetLabelNames() []LabelNameSeriesCount {
 	if m != nil {
-		return m.Metadata
+		return m.LabelNames
 	}
 	return nil
 }
 
-type TimeSeriesChunk struct {
-	FromIngesterId string         `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
-	UserId         string         `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Labels         []LabelAdapter `protobuf:"bytes,3,rep,name=labels,proto3,customtype=LabelAdapter" json:"labels"`
-	Chunks         []Chunk        `protobuf:"bytes,4,rep,name=chunks,proto3" json:"chunks"`
+// LabelNameSeriesCount contains the number of series for each value of a label name.
+type LabelNameSeriesCount struct {
+	LabelName   string                  `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
+	Values      []LabelValueSeriesCount `protobuf:"bytes,2,rep,name=values,proto3" json:"values"`
+	ValuesCount uint64                  `protobuf:"varint,3,opt,name=values_count,json=valuesCount,proto3" json:"values_count,omitempty"`
 }
 
-func (m *TimeSeriesChunk) Reset()      { *m = TimeSeriesChunk{} }
-func (*TimeSeriesChunk) ProtoMessage() {}
-func (*TimeSeriesChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{19}
+func (m *LabelNameSeriesCount) Reset()      { *m = LabelNameSeriesCount{} }
+func (*LabelNameSeriesCount) ProtoMessage() {}
+func (*LabelNameSeriesCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{22}
 }
-func (m *TimeSeriesChunk) XXX_Unmarshal(b []byte) error {
+func (m *LabelNameSeriesCount) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TimeSeriesChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelNameSeriesCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TimeSeriesChunk.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelNameSeriesCount.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1053,57 +1235,55 @@ func (m *TimeSeriesChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *TimeSeriesChunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TimeSeriesChunk.Merge(m, src)
+func (m *LabelNameSeriesCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelNameSeriesCount.Merge(m, src)
 }
-func (m *TimeSeriesChunk) XXX_Size() int {
+func (m *LabelNameSeriesCount) XXX_Size() int {
 	return m.Size()
 }
-func (m *TimeSeriesChunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_TimeSeriesChunk.DiscardUnknown(m)
+func (m *LabelNameSeriesCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelNameSeriesCount.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TimeSeriesChunk proto.InternalMessageInfo
+var xxx_messageInfo_LabelNameSeriesCount proto.InternalMessageInfo
 
-func (m *TimeSeriesChunk) GetFromIngesterId() string {
+func (m *LabelNameSeriesCount) GetLabelName() string {
 	if m != nil {
-		return m.FromIngesterId
+		return m.LabelName
 	}
 	return ""
 }
 
-func (m *TimeSeriesChunk) GetUserId() string {
+func (m *LabelNameSeriesCount) GetValues() []LabelValueSeriesCount {
 	if m != nil {
-		return m.UserId
+		return m.Values
 	}
-	return ""
+	return nil
 }
 
-func (m *TimeSeriesChunk) GetChunks() []Chunk {
+func (m *LabelNameSeriesCount) GetValuesCount() uint64 {
 	if m != nil {
-		return m.Chunks
+		return m.ValuesCount
 	}
-	return nil
+	return 0
 }
 
-type Chunk struct {
-	StartTimestampMs int64  `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64  `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Encoding         int32  `protobuf:"varint,3,opt,name=encoding,proto3" json:"encoding,omitempty"`
-	Data             []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+type LabelValueSeriesCount struct {
+	LabelValue  string `protobuf:"bytes,1,opt,name=label_value,json=labelValue,proto3" json:"label_value,omitempty"`
+	SeriesCount uint64 `protobuf:"varint,2,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
 }
 
-func (m *Chunk) Reset()      { *m = Chunk{} }
-func (*Chunk) ProtoMessage() {}
-func (*Chunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{20}
+func (m *LabelValueSeriesCount) Reset()      { *m = LabelValueSeriesCount{} }
+func (*LabelValueSeriesCount) ProtoMessage() {}
+func (*LabelValueSeriesCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{23}
 }
-func (m *Chunk) XXX_Unmarshal(b []byte) error {
+func (m *LabelValueSeriesCount) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValueSeriesCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Chunk.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValueSeriesCount.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1113,60 +1293,106 @@ func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Chunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Chunk.Merge(m, src)
+func (m *LabelValueSeriesCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValueSeriesCount.Merge(m, src)
 }
-func (m *Chunk) XXX_Size() int {
+func (m *LabelValueSeriesCount) XXX_Size() int {
 	return m.Size()
 }
-func (m *Chunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_Chunk.DiscardUnknown(m)
+func (m *LabelValueSeriesCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValueSeriesCount.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Chunk proto.InternalMessageInfo
+var xxx_messageInfo_LabelValueSeriesCount proto.InternalMessageInfo
 
-func (m *Chunk) GetStartTimestampMs() int64 {
+func (m *LabelValueSeriesCount) GetLabelValue() string {
 	if m != nil {
-		return m.StartTimestampMs
+		return m.LabelValue
+	}
+	return ""
+}
+
+func (m *LabelValueSeriesCount) GetSeriesCount() uint64 {
+	if m != nil {
+		return m.SeriesCount
 	}
 	return 0
 }
 
-func (m *Chunk) GetEndTimestampMs() int64 {
+type MetricsForLabelMatchersRequest struct {
+	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	MatchersSet      []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers_set,json=matchersSet,proto3" json:"matchers_set,omitempty"`
+}
+
+func (m *MetricsForLabelMatchersRequest) Reset()      { *m = MetricsForLabelMatchersRequest{} }
+func (*MetricsForLabelMatchersRequest) ProtoMessage() {}
+func (*MetricsForLabelMatchersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{24}
+}
+func (m *MetricsForLabelMatchersRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MetricsForLabelMatchersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricsForLabelMatchersRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MetricsForLabelMatchersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsForLabelMatchersRequest.Merge(m, src)
+}
+func (m *MetricsForLabelMatchersRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricsForLabelMatchersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsForLabelMatchersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricsForLabelMatchersRequest proto.InternalMessageInfo
+
+func (m *MetricsForLabelMatchersRequest) GetStartTimestampMs() int64 {
 	if m != nil {
-		return m.EndTimestampMs
+		return m.StartTimestampMs
 	}
 	return 0
 }
 
-func (m *Chunk) GetEncoding() int32 {
+func (m *MetricsForLabelMatchersRequest) GetEndTimestampMs() int64 {
 	if m != nil {
-		return m.Encoding
+		return m.EndTimestampMs
 	}
 	return 0
 }
 
-func (m *Chunk) GetData() []byte {
+func (m *MetricsForLabelMatchersRequest) GetMatchersSet() []*LabelMatchers {
 	if m != nil {
-		return m.Data
+		return m.MatchersSet
 	}
 	return nil
 }
 
-type TransferChunksResponse struct {
+type MetricsForLabelMatchersResponse struct {
+	Metric []*Metric `protobuf:"bytes,1,rep,name=metric,proto3" json:"metric,omitempty"`
 }
 
-func (m *TransferChunksResponse) Reset()      { *m = TransferChunksResponse{} }
-func (*TransferChunksResponse) ProtoMessage() {}
-func (*TransferChunksResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{21}
+func (m *MetricsForLabelMatchersResponse) Reset()      { *m = MetricsForLabelMatchersResponse{} }
+func (*MetricsForLabelMatchersResponse) ProtoMessage() {}
+func (*MetricsForLabelMatchersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{25}
 }
-func (m *TransferChunksResponse) XXX_Unmarshal(b []byte) error {
+func (m *MetricsForLabelMatchersResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TransferChunksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MetricsForLabelMatchersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TransferChunksResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MetricsForLabelMatchersResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1176,35 +1402,39 @@ func (m *TransferChunksResponse) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *TransferChunksResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TransferChunksResponse.Merge(m, src)
+func (m *MetricsForLabelMatchersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsForLabelMatchersResponse.Merge(m, src)
 }
-func (m *TransferChunksResponse) XXX_Size() int {
+func (m *MetricsForLabelMatchersResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *TransferChunksResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_TransferChunksResponse.DiscardUnknown(m)
+func (m *MetricsForLabelMatchersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsForLabelMatchersResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TransferChunksResponse proto.InternalMessageInfo
+var xxx_messageInfo_MetricsForLabelMatchersResponse proto.InternalMessageInfo
 
-type TimeSeries struct {
-	Labels []LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=LabelAdapter" json:"labels"`
-	// Sorted by time, oldest sample first.
-	Samples []Sample `protobuf:"bytes,2,rep,name=samples,proto3" json:"samples"`
+func (m *MetricsForLabelMatchersResponse) GetMetric() []*Metric {
+	if m != nil {
+		return m.Metric
+	}
+	return nil
 }
 
-func (m *TimeSeries) Reset()      { *m = TimeSeries{} }
-func (*TimeSeries) ProtoMessage() {}
-func (*TimeSeries) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{22}
+type MetricsMetadataRequest struct {
 }
-func (m *TimeSeries) XXX_Unmarshal(b []byte) error {
+
+func (m *MetricsMetadataRequest) Reset()      { *m = MetricsMetadataRequest{} }
+func (*MetricsMetadataRequest) ProtoMessage() {}
+func (*MetricsMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{26}
+}
+func (m *MetricsMetadataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TimeSeries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MetricsMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TimeSeries.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MetricsMetadataRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1214,41 +1444,33 @@ func (m *TimeSeries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *TimeSeries) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TimeSeries.Merge(m, src)
+func (m *MetricsMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsMetadataRequest.Merge(m, src)
 }
-func (m *TimeSeries) XXX_Size() int {
+func (m *MetricsMetadataRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *TimeSeries) XXX_DiscardUnknown() {
-	xxx_messageInfo_TimeSeries.DiscardUnknown(m)
+func (m *MetricsMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsMetadataRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TimeSeries proto.InternalMessageInfo
-
-func (m *TimeSeries) GetSamples() []Sample {
-	if m != nil {
-		return m.Samples
-	}
-	return nil
-}
+var xxx_messageInfo_MetricsMetadataRequest proto.InternalMessageInfo
 
-type LabelPair struct {
-	Name  []byte `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+type MetricsMetadataResponse struct {
+	Metadata []*MetricMetadata `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
-func (m *LabelPair) Reset()      { *m = LabelPair{} }
-func (*LabelPair) ProtoMessage() {}
-func (*LabelPair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{23}
+func (m *MetricsMetadataResponse) Reset()      { *m = MetricsMetadataResponse{} }
+func (*MetricsMetadataResponse) ProtoMessage() {}
+func (*MetricsMetadataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{27}
 }
-func (m *LabelPair) XXX_Unmarshal(b []byte) error {
+func (m *MetricsMetadataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MetricsMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelPair.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MetricsMetadataResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1258,48 +1480,43 @@ func (m *LabelPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *LabelPair) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelPair.Merge(m, src)
+func (m *MetricsMetadataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsMetadataResponse.Merge(m, src)
 }
-func (m *LabelPair) XXX_Size() int {
+func (m *MetricsMetadataResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelPair) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelPair.DiscardUnknown(m)
+func (m *MetricsMetadataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsMetadataResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelPair proto.InternalMessageInfo
-
-func (m *LabelPair) GetName() []byte {
-	if m != nil {
-		return m.Name
-	}
-	return nil
-}
+var xxx_messageInfo_MetricsMetadataResponse proto.InternalMessageInfo
 
-func (m *LabelPair) GetValue() []byte {
+func (m *MetricsMetadataResponse) GetMetadata() []*MetricMetadata {
 	if m != nil {
-		return m.Value
+		return m.Metadata
 	}
 	return nil
 }
 
-type Sample struct {
-	Value       float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
-	TimestampMs int64   `protobuf:"varint,2,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+type TimeSeriesChunk struct {
+	FromIngesterId string         `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
+	UserId         string         `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Labels         []LabelAdapter `protobuf:"bytes,3,rep,name=labels,proto3,customtype=LabelAdapter" json:"labels"`
+	Chunks         []Chunk        `protobuf:"bytes,4,rep,name=chunks,proto3" json:"chunks"`
 }
 
-func (m *Sample) Reset()      { *m = Sample{} }
-func (*Sample) ProtoMessage() {}
-func (*Sample) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{24}
+func (m *TimeSeriesChunk) Reset()      { *m = TimeSeriesChunk{} }
+func (*TimeSeriesChunk) ProtoMessage() {}
+func (*TimeSeriesChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{28}
 }
-func (m *Sample) XXX_Unmarshal(b []byte) error {
+func (m *TimeSeriesChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Sample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TimeSeriesChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Sample.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TimeSeriesChunk.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1309,47 +1526,57 @@ func (m *Sample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Sample) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Sample.Merge(m, src)
+func (m *TimeSeriesChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeSeriesChunk.Merge(m, src)
 }
-func (m *Sample) XXX_Size() int {
+func (m *TimeSeriesChunk) XXX_Size() int {
 	return m.Size()
 }
-func (m *Sample) XXX_DiscardUnknown() {
-	xxx_messageInfo_Sample.DiscardUnknown(m)
+func (m *TimeSeriesChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeSeriesChunk.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Sample proto.InternalMessageInfo
+var xxx_messageInfo_TimeSeriesChunk proto.InternalMessageInfo
 
-func (m *Sample) GetValue() float64 {
+func (m *TimeSeriesChunk) GetFromIngesterId() string {
 	if m != nil {
-		return m.Value
+		return m.FromIngesterId
 	}
-	return 0
+	return ""
 }
 
-func (m *Sample) GetTimestampMs() int64 {
+func (m *TimeSeriesChunk) GetUserId() string {
 	if m != nil {
-		return m.TimestampMs
+		return m.UserId
 	}
-	return 0
+	return ""
 }
 
-type LabelMatchers struct {
-	Matchers []*LabelMatcher `protobuf:"bytes,1,rep,name=matchers,proto3" json:"matchers,omitempty"`
+func (m *TimeSeriesChunk) GetChunks() []Chunk {
+	if m != nil {
+		return m.Chunks
+	}
+	return nil
 }
 
-func (m *LabelMatchers) Reset()      { *m = LabelMatchers{} }
-func (*LabelMatchers) ProtoMessage() {}
-func (*LabelMatchers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{25}
+type Chunk struct {
+	StartTimestampMs int64  `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64  `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Encoding         int32  `protobuf:"varint,3,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	Data             []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
 }
-func (m *LabelMatchers) XXX_Unmarshal(b []byte) error {
+
+func (m *Chunk) Reset()      { *m = Chunk{} }
+func (*Chunk) ProtoMessage() {}
+func (*Chunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{29}
+}
+func (m *Chunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelMatchers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelMatchers.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Chunk.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1359,43 +1586,60 @@ func (m *LabelMatchers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *LabelMatchers) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelMatchers.Merge(m, src)
+func (m *Chunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Chunk.Merge(m, src)
 }
-func (m *LabelMatchers) XXX_Size() int {
+func (m *Chunk) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelMatchers) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelMatchers.DiscardUnknown(m)
+func (m *Chunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_Chunk.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelMatchers proto.InternalMessageInfo
+var xxx_messageInfo_Chunk proto.InternalMessageInfo
 
-func (m *LabelMatchers) GetMatchers() []*LabelMatcher {
+func (m *Chunk) GetStartTimestampMs() int64 {
 	if m != nil {
-		return m.Matchers
+		return m.StartTimestampMs
+	}
+	return 0
+}
+
+func (m *Chunk) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
+	}
+	return 0
+}
+
+func (m *Chunk) GetEncoding() int32 {
+	if m != nil {
+		return m.Encoding
+	}
+	return 0
+}
+
+func (m *Chunk) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
 	return nil
 }
 
-type MetricMetadata struct {
-	Type             MetricMetadata_MetricType `protobuf:"varint,1,opt,name=type,proto3,enum=cortex.MetricMetadata_MetricType" json:"type,omitempty"`
-	MetricFamilyName string                    `protobuf:"bytes,2,opt,name=metric_family_name,json=metricFamilyName,proto3" json:"metric_family_name,omitempty"`
-	Help             string                    `protobuf:"bytes,4,opt,name=help,proto3" json:"help,omitempty"`
-	Unit             string                    `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+type TransferChunksResponse struct {
 }
 
-func (m *MetricMetadata) Reset()      { *m = MetricMetadata{} }
-func (*MetricMetadata) ProtoMessage() {}
-func (*MetricMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{26}
+func (m *TransferChunksResponse) Reset()      { *m = TransferChunksResponse{} }
+func (*TransferChunksResponse) ProtoMessage() {}
+func (*TransferChunksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{30}
 }
-func (m *MetricMetadata) XXX_Unmarshal(b []byte) error {
+func (m *TransferChunksResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TransferChunksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricMetadata.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TransferChunksResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1405,61 +1649,88 @@ func (m *MetricMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *MetricMetadata) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricMetadata.Merge(m, src)
+func (m *TransferChunksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferChunksResponse.Merge(m, src)
 }
-func (m *MetricMetadata) XXX_Size() int {
+func (m *TransferChunksResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricMetadata) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricMetadata.DiscardUnknown(m)
+func (m *TransferChunksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferChunksResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricMetadata proto.InternalMessageInfo
+var xxx_messageInfo_TransferChunksResponse proto.InternalMessageInfo
 
-func (m *MetricMetadata) GetType() MetricMetadata_MetricType {
-	if m != nil {
-		return m.Type
-	}
-	return UNKNOWN
+type TimeSeries struct {
+	Labels []LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=LabelAdapter" json:"labels"`
+	// Sorted by time, oldest sample first.
+	Samples []Sample `protobuf:"bytes,2,rep,name=samples,proto3" json:"samples"`
+	// Sorted by time, oldest exemplar first.
+	Exemplars []Exemplar `protobuf:"bytes,3,rep,name=exemplars,proto3" json:"exemplars"`
 }
 
-func (m *MetricMetadata) GetMetricFamilyName() string {
-	if m != nil {
-		return m.MetricFamilyName
+func (m *TimeSeries) Reset()      { *m = TimeSeries{} }
+func (*TimeSeries) ProtoMessage() {}
+func (*TimeSeries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{31}
+}
+func (m *TimeSeries) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeSeries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeSeries.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return ""
+}
+func (m *TimeSeries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeSeries.Merge(m, src)
+}
+func (m *TimeSeries) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeSeries) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeSeries.DiscardUnknown(m)
 }
 
-func (m *MetricMetadata) GetHelp() string {
+var xxx_messageInfo_TimeSeries proto.InternalMessageInfo
+
+func (m *TimeSeries) GetSamples() []Sample {
 	if m != nil {
-		return m.Help
+		return m.Samples
 	}
-	return ""
+	return nil
 }
 
-func (m *MetricMetadata) GetUnit() string {
+func (m *TimeSeries) GetExemplars() []Exemplar {
 	if m != nil {
-		return m.Unit
+		return m.Exemplars
 	}
-	return ""
+	return nil
 }
 
-type Metric struct {
-	Labels []LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=LabelAdapter" json:"labels"`
+type LabelPair struct {
+	Name  []byte `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (m *Metric) Reset()      { *m = Metric{} }
-func (*Metric) ProtoMessage() {}
-func (*Metric) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{27}
+func (m *LabelPair) Reset()      { *m = LabelPair{} }
+func (*LabelPair) ProtoMessage() {}
+func (*LabelPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{32}
 }
-func (m *Metric) XXX_Unmarshal(b []byte) error {
+func (m *LabelPair) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Metric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Metric.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelPair.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1469,35 +1740,48 @@ func (m *Metric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Metric) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Metric.Merge(m, src)
+func (m *LabelPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelPair.Merge(m, src)
 }
-func (m *Metric) XXX_Size() int {
+func (m *LabelPair) XXX_Size() int {
 	return m.Size()
 }
-func (m *Metric) XXX_DiscardUnknown() {
-	xxx_messageInfo_Metric.DiscardUnknown(m)
+func (m *LabelPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelPair.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Metric proto.InternalMessageInfo
+var xxx_messageInfo_LabelPair proto.InternalMessageInfo
 
-type LabelMatcher struct {
-	Type  MatchType `protobuf:"varint,1,opt,name=type,proto3,enum=cortex.MatchType" json:"type,omitempty"`
-	Name  string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Value string    `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+func (m *LabelPair) GetName() []byte {
+	if m != nil {
+		return m.Name
+	}
+	return nil
 }
 
-func (m *LabelMatcher) Reset()      { *m = LabelMatcher{} }
-func (*LabelMatcher) ProtoMessage() {}
-func (*LabelMatcher) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{28}
+func (m *LabelPair) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
 }
-func (m *LabelMatcher) XXX_Unmarshal(b []byte) error {
+
+type Sample struct {
+	Value       float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	TimestampMs int64   `protobuf:"varint,2,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+}
+
+func (m *Sample) Reset()      { *m = Sample{} }
+func (*Sample) ProtoMessage() {}
+func (*Sample) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{33}
+}
+func (m *Sample) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelMatcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Sample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelMatcher.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Sample.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1507,57 +1791,50 @@ func (m *LabelMatcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *LabelMatcher) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelMatcher.Merge(m, src)
+func (m *Sample) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sample.Merge(m, src)
 }
-func (m *LabelMatcher) XXX_Size() int {
+func (m *Sample) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelMatcher) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelMatcher.DiscardUnknown(m)
+func (m *Sample) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sample.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelMatcher proto.InternalMessageInfo
-
-func (m *LabelMatcher) GetType() MatchType {
-	if m != nil {
-		return m.Type
-	}
-	return EQUAL
-}
+var xxx_messageInfo_Sample proto.InternalMessageInfo
 
-func (m *LabelMatcher) GetName() string {
+func (m *Sample) GetValue() float64 {
 	if m != nil {
-		return m.Name
+		return m.Value
 	}
-	return ""
+	return 0
 }
 
-func (m *LabelMatcher) GetValue() string {
+func (m *Sample) GetTimestampMs() int64 {
 	if m != nil {
-		return m.Value
+		return m.TimestampMs
 	}
-	return ""
+	return 0
 }
 
-type TimeSeriesFile struct {
-	FromIngesterId string `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
-	UserId         string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Filename       string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
-	Data           []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+type Exemplar struct {
+	// Exemplar labels, different than series labels.
+	Labels      []LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=LabelAdapter" json:"labels"`
+	Value       float64        `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	TimestampMs int64          `protobuf:"varint,3,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
 }
 
-func (m *TimeSeriesFile) Reset()      { *m = TimeSeriesFile{} }
-func (*TimeSeriesFile) ProtoMessage() {}
-func (*TimeSeriesFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{29}
+func (m *Exemplar) Reset()      { *m = Exemplar{} }
+func (*Exemplar) ProtoMessage() {}
+func (*Exemplar) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{34}
 }
-func (m *TimeSeriesFile) XXX_Unmarshal(b []byte) error {
+func (m *Exemplar) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TimeSeriesFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Exemplar) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TimeSeriesFile.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Exemplar.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1567,370 +1844,501 @@ func (m *TimeSeriesFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *TimeSeriesFile) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TimeSeriesFile.Merge(m, src)
+func (m *Exemplar) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Exemplar.Merge(m, src)
 }
-func (m *TimeSeriesFile) XXX_Size() int {
+func (m *Exemplar) XXX_Size() int {
 	return m.Size()
 }
-func (m *TimeSeriesFile) XXX_DiscardUnknown() {
-	xxx_messageInfo_TimeSeriesFile.DiscardUnknown(m)
+func (m *Exemplar) XXX_DiscardUnknown() {
+	xxx_messageInfo_Exemplar.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TimeSeriesFile proto.InternalMessageInfo
+var xxx_messageInfo_Exemplar proto.InternalMessageInfo
 
-func (m *TimeSeriesFile) GetFromIngesterId() string {
+func (m *Exemplar) GetValue() float64 {
 	if m != nil {
-		return m.FromIngesterId
+		return m.Value
 	}
-	return ""
+	return 0
 }
 
-func (m *TimeSeriesFile) GetUserId() string {
+func (m *Exemplar) GetTimestampMs() int64 {
 	if m != nil {
-		return m.UserId
+		return m.TimestampMs
 	}
-	return ""
+	return 0
 }
 
-func (m *TimeSeriesFile) GetFilename() string {
-	if m != nil {
-		return m.Filename
-	}
-	return ""
+type LabelMatchers struct {
+	Matchers []*LabelMatcher `protobuf:"bytes,1,rep,name=matchers,proto3" json:"matchers,omitempty"`
 }
 
-func (m *TimeSeriesFile) GetData() []byte {
-	if m != nil {
-		return m.Data
-	}
-	return nil
+func (m *LabelMatchers) Reset()      { *m = LabelMatchers{} }
+func (*LabelMatchers) ProtoMessage() {}
+func (*LabelMatchers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{35}
 }
-
-func init() {
-	proto.RegisterEnum("cortex.MatchType", MatchType_name, MatchType_value)
-	proto.RegisterEnum("cortex.WriteRequest_SourceEnum", WriteRequest_SourceEnum_name, WriteRequest_SourceEnum_value)
-	proto.RegisterEnum("cortex.MetricMetadata_MetricType", MetricMetadata_MetricType_name, MetricMetadata_MetricType_value)
-	proto.RegisterType((*WriteRequest)(nil), "cortex.WriteRequest")
-	proto.RegisterType((*WriteResponse)(nil), "cortex.WriteResponse")
-	proto.RegisterType((*ReadRequest)(nil), "cortex.ReadRequest")
-	proto.RegisterType((*ReadResponse)(nil), "cortex.ReadResponse")
-	proto.RegisterType((*QueryRequest)(nil), "cortex.QueryRequest")
-	proto.RegisterType((*QueryResponse)(nil), "cortex.QueryResponse")
-	proto.RegisterType((*QueryStreamResponse)(nil), "cortex.QueryStreamResponse")
-	proto.RegisterType((*LabelValuesRequest)(nil), "cortex.LabelValuesRequest")
-	proto.RegisterType((*LabelValuesResponse)(nil), "cortex.LabelValuesResponse")
-	proto.RegisterType((*LabelNamesRequest)(nil), "cortex.LabelNamesRequest")
-	proto.RegisterType((*LabelNamesResponse)(nil), "cortex.LabelNamesResponse")
-	proto.RegisterType((*UserStatsRequest)(nil), "cortex.UserStatsRequest")
-	proto.RegisterType((*UserStatsResponse)(nil), "cortex.UserStatsResponse")
-	proto.RegisterType((*UserIDStatsResponse)(nil), "cortex.UserIDStatsResponse")
-	proto.RegisterType((*UsersStatsResponse)(nil), "cortex.UsersStatsResponse")
-	proto.RegisterType((*MetricsForLabelMatchersRequest)(nil), "cortex.MetricsForLabelMatchersRequest")
-	proto.RegisterType((*MetricsForLabelMatchersResponse)(nil), "cortex.MetricsForLabelMatchersResponse")
-	proto.RegisterType((*MetricsMetadataRequest)(nil), "cortex.MetricsMetadataRequest")
-	proto.RegisterType((*MetricsMetadataResponse)(nil), "cortex.MetricsMetadataResponse")
-	proto.RegisterType((*TimeSeriesChunk)(nil), "cortex.TimeSeriesChunk")
-	proto.RegisterType((*Chunk)(nil), "cortex.Chunk")
-	proto.RegisterType((*TransferChunksResponse)(nil), "cortex.TransferChunksResponse")
-	proto.RegisterType((*TimeSeries)(nil), "cortex.TimeSeries")
-	proto.RegisterType((*LabelPair)(nil), "cortex.LabelPair")
-	proto.RegisterType((*Sample)(nil), "cortex.Sample")
-	proto.RegisterType((*LabelMatchers)(nil), "cortex.LabelMatchers")
-	proto.RegisterType((*MetricMetadata)(nil), "cortex.MetricMetadata")
-	proto.RegisterType((*Metric)(nil), "cortex.Metric")
-	proto.RegisterType((*LabelMatcher)(nil), "cortex.LabelMatcher")
-	proto.RegisterType((*TimeSeriesFile)(nil), "cortex.TimeSeriesFile")
+func (m *LabelMatchers) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelMatchers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelMatchers.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LabelMatchers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelMatchers.Merge(m, src)
+}
+func (m *LabelMatchers) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelMatchers) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelMatchers.DiscardUnknown(m)
 }
 
-func init() { proto.RegisterFile("cortex.proto", fileDescriptor_893a47d0a749d749) }
+var xxx_messageInfo_LabelMatchers proto.InternalMessageInfo
 
-var fileDescriptor_893a47d0a749d749 = []byte{
-	// 1526 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcf, 0x6f, 0x13, 0xc7,
-	0x17, 0xdf, 0xf5, 0x6f, 0x3f, 0x3b, 0xce, 0x66, 0x12, 0x88, 0x31, 0xfa, 0xda, 0x30, 0x12, 0x7c,
-	0xa3, 0xb6, 0x04, 0x9a, 0x8a, 0x36, 0x07, 0x2a, 0xe4, 0x80, 0x13, 0xdc, 0x62, 0x27, 0x8c, 0x1d,
-	0x68, 0x2b, 0x55, 0xd6, 0xc6, 0x9e, 0x24, 0x2b, 0x76, 0xd7, 0x66, 0x7f, 0xa0, 0xe6, 0x50, 0xa9,
-	0x52, 0x8f, 0x3d, 0x94, 0x23, 0x7f, 0x42, 0x8f, 0x55, 0x2f, 0xbd, 0xf7, 0xc4, 0x91, 0x23, 0xea,
-	0x01, 0x95, 0x70, 0xe1, 0x56, 0xfe, 0x84, 0x6a, 0x7e, 0xec, 0x7a, 0xd7, 0xd8, 0x2d, 0x94, 0x72,
-	0xf3, 0xbc, 0xf7, 0x99, 0xcf, 0xbc, 0x7d, 0xf3, 0x99, 0xf7, 0x5e, 0x02, 0xc5, 0xfe, 0xd0, 0xf1,
-	0xe8, 0x37, 0xab, 0x23, 0x67, 0xe8, 0x0d, 0x51, 0x46, 0xac, 0x2a, 0x17, 0x0e, 0x0c, 0xef, 0xd0,
-	0xdf, 0x5b, 0xed, 0x0f, 0xad, 0x8b, 0x07, 0xc3, 0x83, 0xe1, 0x45, 0xee, 0xde, 0xf3, 0xf7, 0xf9,
-	0x8a, 0x2f, 0xf8, 0x2f, 0xb1, 0x0d, 0xff, 0x9c, 0x80, 0xe2, 0x1d, 0xc7, 0xf0, 0x28, 0xa1, 0xf7,
-	0x7c, 0xea, 0x7a, 0xa8, 0x0d, 0xe0, 0x19, 0x16, 0x75, 0xa9, 0x63, 0x50, 0xb7, 0xac, 0x9e, 0x49,
-	0xae, 0x14, 0xd6, 0xd0, 0xaa, 0x3c, 0xaa, 0x6b, 0x58, 0xb4, 0xc3, 0x3d, 0x1b, 0x95, 0x47, 0x4f,
-	0x6b, 0xca, 0xef, 0x4f, 0x6b, 0x68, 0xc7, 0xa1, 0xba, 0x69, 0x0e, 0xfb, 0xdd, 0x70, 0x17, 0x89,
-	0x30, 0xa0, 0x4f, 0x20, 0xd3, 0x19, 0xfa, 0x4e, 0x9f, 0x96, 0x13, 0x67, 0xd4, 0x95, 0xd2, 0x5a,
-	0x2d, 0xe0, 0x8a, 0x9e, 0xba, 0x2a, 0x20, 0x0d, 0xdb, 0xb7, 0x88, 0x84, 0xa3, 0x75, 0xc8, 0x59,
-	0xd4, 0xd3, 0x07, 0xba, 0xa7, 0x97, 0x93, 0x3c, 0x8c, 0x93, 0xc1, 0xd6, 0x16, 0xf5, 0x1c, 0xa3,
-	0xdf, 0x92, 0xde, 0x8d, 0xd4, 0xa3, 0xa7, 0x35, 0x95, 0x84, 0x68, 0x74, 0x05, 0x2a, 0xee, 0x5d,
-	0x63, 0xd4, 0x33, 0xf5, 0x3d, 0x6a, 0xf6, 0x6c, 0xdd, 0xa2, 0xbd, 0xfb, 0xba, 0x69, 0x0c, 0x74,
-	0xcf, 0x18, 0xda, 0xe5, 0x17, 0xd9, 0x33, 0xea, 0x4a, 0x8e, 0x2c, 0x33, 0xc8, 0x4d, 0x86, 0x68,
-	0xeb, 0x16, 0xbd, 0x1d, 0xfa, 0x71, 0x0d, 0x60, 0x1c, 0x0d, 0xca, 0x42, 0xb2, 0xbe, 0xd3, 0xd4,
-	0x14, 0x94, 0x83, 0x14, 0xd9, 0xbd, 0xd9, 0xd0, 0x54, 0x3c, 0x0f, 0x73, 0x32, 0x76, 0x77, 0x34,
-	0xb4, 0x5d, 0x8a, 0x3f, 0x85, 0x02, 0xa1, 0xfa, 0x20, 0xc8, 0xe0, 0x2a, 0x64, 0xef, 0xf9, 0xd1,
-	0xf4, 0x2d, 0x05, 0x71, 0xdf, 0xf2, 0xa9, 0x73, 0x24, 0x61, 0x24, 0x00, 0xe1, 0xab, 0x50, 0x14,
-	0xdb, 0x05, 0x1d, 0xba, 0x08, 0x59, 0x87, 0xba, 0xbe, 0xe9, 0x05, 0xfb, 0x4f, 0x4c, 0xec, 0x17,
-	0x38, 0x12, 0xa0, 0xf0, 0x43, 0x15, 0x8a, 0x51, 0x6a, 0xf4, 0x01, 0x20, 0xd7, 0xd3, 0x1d, 0xaf,
-	0xc7, 0xef, 0xc1, 0xd3, 0xad, 0x51, 0xcf, 0x62, 0x64, 0xea, 0x4a, 0x92, 0x68, 0xdc, 0xd3, 0x0d,
-	0x1c, 0x2d, 0x17, 0xad, 0x80, 0x46, 0xed, 0x41, 0x1c, 0x9b, 0xe0, 0xd8, 0x12, 0xb5, 0x07, 0x51,
-	0xe4, 0x25, 0xc8, 0x59, 0xba, 0xd7, 0x3f, 0xa4, 0x8e, 0x2b, 0xaf, 0x24, 0xfc, 0x34, 0x9e, 0xc9,
-	0x96, 0x70, 0x92, 0x10, 0x85, 0x9b, 0x30, 0x17, 0x0b, 0x1a, 0xad, 0xbf, 0xa6, 0xbc, 0xd8, 0x9d,
-	0x2a, 0x51, 0x21, 0xe1, 0x07, 0x2a, 0x2c, 0x72, 0xae, 0x8e, 0xe7, 0x50, 0xdd, 0x0a, 0x19, 0xaf,
-	0x42, 0xa1, 0x7f, 0xe8, 0xdb, 0x77, 0x63, 0x94, 0xcb, 0xaf, 0x52, 0x5e, 0x63, 0x20, 0xc9, 0x1b,
-	0xdd, 0x31, 0x11, 0x52, 0xe2, 0x0d, 0x42, 0xfa, 0x41, 0x05, 0xc4, 0x3f, 0xfc, 0xb6, 0x6e, 0xfa,
-	0xd4, 0x0d, 0xd2, 0xff, 0x3f, 0x80, 0xb1, 0xf4, 0x78, 0xda, 0xf3, 0x24, 0x6f, 0x06, 0x52, 0x9b,
-	0x71, 0x3b, 0x89, 0x37, 0xb8, 0x9d, 0xe4, 0xb4, 0xdb, 0xc1, 0xeb, 0xb0, 0x18, 0x0b, 0x46, 0xe6,
-	0xe7, 0x2c, 0x14, 0x45, 0x34, 0xf7, 0xb9, 0x9d, 0x27, 0x28, 0x4f, 0x0a, 0xe6, 0x18, 0x8a, 0xef,
-	0xc2, 0x42, 0xf8, 0x12, 0xdc, 0x77, 0x2c, 0x22, 0x7c, 0x59, 0xe6, 0x4c, 0x1e, 0x26, 0xa3, 0xac,
-	0x41, 0x61, 0x9c, 0xb3, 0x20, 0x48, 0x08, 0x93, 0xe6, 0x62, 0x04, 0xda, 0xae, 0x4b, 0x9d, 0x8e,
-	0xa7, 0x7b, 0x41, 0x88, 0xf8, 0x57, 0x15, 0x16, 0x22, 0x46, 0x49, 0x75, 0x0e, 0x4a, 0x86, 0x7d,
-	0x40, 0x5d, 0xf6, 0x9a, 0x7b, 0x8e, 0xee, 0x89, 0x2b, 0x50, 0xc9, 0x5c, 0x68, 0x25, 0xba, 0x47,
-	0xd9, 0x2d, 0xd9, 0xbe, 0xd5, 0x0b, 0xaf, 0x5d, 0x5d, 0x49, 0x91, 0xbc, 0xed, 0x5b, 0xe2, 0xb6,
-	0xd9, 0xe7, 0xeb, 0x23, 0xa3, 0x37, 0xc1, 0x94, 0xe4, 0x4c, 0x9a, 0x3e, 0x32, 0x9a, 0x31, 0xb2,
-	0x55, 0x58, 0x74, 0x7c, 0x93, 0x4e, 0xc2, 0x53, 0x1c, 0xbe, 0xc0, 0x5c, 0x31, 0x3c, 0xfe, 0x1a,
-	0x16, 0x59, 0xe0, 0xcd, 0xeb, 0xf1, 0xd0, 0x97, 0x21, 0xeb, 0xbb, 0xd4, 0xe9, 0x19, 0x03, 0x29,
-	0x9b, 0x0c, 0x5b, 0x36, 0x07, 0xe8, 0x02, 0xa4, 0x78, 0x21, 0x64, 0x61, 0x16, 0xd6, 0x4e, 0x05,
-	0xea, 0x7c, 0xe5, 0xe3, 0x09, 0x87, 0xe1, 0x2d, 0x40, 0xcc, 0xe5, 0xc6, 0xd9, 0x3f, 0x84, 0xb4,
-	0xcb, 0x0c, 0xf2, 0x8d, 0x9c, 0x8e, 0xb2, 0x4c, 0x44, 0x42, 0x04, 0x12, 0xff, 0xa2, 0x42, 0x55,
-	0x54, 0x5b, 0x77, 0x73, 0xe8, 0x44, 0x1f, 0xf9, 0xbb, 0xd6, 0x09, 0x5a, 0x87, 0x62, 0x50, 0x46,
-	0x7a, 0x2e, 0xf5, 0x64, 0xc1, 0x39, 0x31, 0xad, 0xe0, 0xb8, 0xa4, 0x10, 0x40, 0x3b, 0xd4, 0xc3,
-	0x4d, 0xa8, 0xcd, 0x8c, 0x59, 0xa6, 0xe2, 0x3c, 0x64, 0x2c, 0x0e, 0x91, 0xb9, 0x28, 0xc5, 0x5b,
-	0x0b, 0x91, 0x5e, 0x5c, 0x86, 0x93, 0x92, 0x2a, 0xe8, 0x36, 0x81, 0xf6, 0x5a, 0xb0, 0xfc, 0x8a,
-	0x47, 0x92, 0xaf, 0x45, 0x3a, 0x97, 0xfa, 0x77, 0x9d, 0x6b, 0xdc, 0xb3, 0xf0, 0x6f, 0x2a, 0xcc,
-	0x4f, 0xd4, 0x2a, 0x96, 0xab, 0x7d, 0x67, 0x68, 0x49, 0x51, 0x45, 0x65, 0x51, 0x62, 0xf6, 0xa6,
-	0x34, 0x37, 0x07, 0x51, 0xdd, 0x24, 0x62, 0xba, 0xb9, 0x0a, 0x19, 0xfe, 0x86, 0x82, 0x7a, 0xbd,
-	0x10, 0x4b, 0xdf, 0x8e, 0x6e, 0x38, 0x1b, 0x4b, 0xb2, 0x91, 0x17, 0xb9, 0xa9, 0x3e, 0xd0, 0x47,
-	0x1e, 0x75, 0x88, 0xdc, 0x86, 0xde, 0x87, 0x8c, 0xa8, 0x95, 0xe5, 0x14, 0x27, 0x98, 0x0b, 0x08,
-	0xa2, 0xe5, 0x54, 0x42, 0xf0, 0x8f, 0x2a, 0xa4, 0x45, 0xe8, 0xef, 0x4a, 0x14, 0x15, 0xc8, 0x51,
-	0xbb, 0x3f, 0x1c, 0x18, 0xf6, 0x01, 0x7f, 0x8b, 0x69, 0x12, 0xae, 0x11, 0x92, 0x6f, 0x84, 0x3d,
-	0xba, 0xa2, 0x7c, 0x08, 0x65, 0x38, 0xd9, 0x75, 0x74, 0xdb, 0xdd, 0xa7, 0x0e, 0x0f, 0x2c, 0x54,
-	0x00, 0xfe, 0x16, 0x60, 0x9c, 0xef, 0x48, 0x9e, 0xd4, 0x7f, 0x97, 0xa7, 0x55, 0xc8, 0xba, 0xba,
-	0x35, 0x32, 0xc3, 0x0e, 0x12, 0x2a, 0xaa, 0xc3, 0xcd, 0x32, 0x53, 0x01, 0x08, 0x5f, 0x86, 0x7c,
-	0x48, 0xcd, 0x22, 0x0f, 0x5b, 0x45, 0x91, 0xf0, 0xdf, 0x68, 0x09, 0xd2, 0xbc, 0x60, 0xf3, 0x44,
-	0x14, 0x89, 0x58, 0xe0, 0x3a, 0x64, 0x04, 0xdf, 0xd8, 0x2f, 0x8a, 0x9b, 0x58, 0xb0, 0x62, 0x3f,
-	0x25, 0x8b, 0x05, 0x2f, 0x52, 0x7f, 0xeb, 0x30, 0x17, 0x7b, 0x13, 0xb1, 0xae, 0xae, 0xbe, 0x56,
-	0x57, 0x7f, 0x98, 0x80, 0x52, 0x5c, 0xc9, 0xe8, 0x32, 0xa4, 0xbc, 0xa3, 0x91, 0x88, 0xa6, 0xb4,
-	0x76, 0x76, 0xba, 0xde, 0xe5, 0xb2, 0x7b, 0x34, 0xa2, 0x84, 0xc3, 0x99, 0x4e, 0xc4, 0x4b, 0xeb,
-	0xed, 0xeb, 0x96, 0x61, 0x1e, 0x89, 0x96, 0x29, 0x34, 0xac, 0x09, 0xcf, 0x26, 0x77, 0xf0, 0xce,
-	0x89, 0x20, 0x75, 0x48, 0xcd, 0x11, 0xbf, 0xe1, 0x3c, 0xe1, 0xbf, 0x99, 0xcd, 0xb7, 0x0d, 0xaf,
-	0x9c, 0x16, 0x36, 0xf6, 0x1b, 0x1f, 0x01, 0x8c, 0x4f, 0x42, 0x05, 0xc8, 0xee, 0xb6, 0x3f, 0x6f,
-	0x6f, 0xdf, 0x69, 0x6b, 0x0a, 0x5b, 0x5c, 0xdb, 0xde, 0x6d, 0x77, 0x1b, 0x44, 0x53, 0x51, 0x1e,
-	0xd2, 0x5b, 0xf5, 0xdd, 0xad, 0x86, 0x96, 0x40, 0x73, 0x90, 0xbf, 0xd1, 0xec, 0x74, 0xb7, 0xb7,
-	0x48, 0xbd, 0xa5, 0x25, 0x11, 0x82, 0x12, 0xf7, 0x8c, 0x6d, 0x29, 0xb6, 0xb5, 0xb3, 0xdb, 0x6a,
-	0xd5, 0xc9, 0x97, 0x5a, 0x9a, 0x8d, 0x83, 0xcd, 0xf6, 0xe6, 0xb6, 0x96, 0x41, 0x45, 0xc8, 0x75,
-	0xba, 0xf5, 0x6e, 0xa3, 0xd3, 0xe8, 0x6a, 0x59, 0xdc, 0x84, 0x8c, 0x38, 0xfa, 0xad, 0x25, 0x85,
-	0x7b, 0x50, 0x8c, 0xe6, 0x1f, 0x9d, 0x8b, 0xa5, 0x38, 0xa4, 0xe3, 0xee, 0x48, 0x4a, 0x03, 0x31,
-	0x89, 0x24, 0x4e, 0x88, 0x29, 0xc9, 0x8d, 0x52, 0x4c, 0xdf, 0xab, 0x50, 0x1a, 0xbf, 0x81, 0x4d,
-	0xc3, 0xa4, 0xff, 0x45, 0xc9, 0xa9, 0x40, 0x6e, 0xdf, 0x30, 0x29, 0x8f, 0x41, 0x1c, 0x17, 0xae,
-	0xa7, 0x3d, 0xd1, 0xf7, 0x3e, 0x83, 0x7c, 0xf8, 0x09, 0xec, 0x46, 0x1a, 0xb7, 0x76, 0xeb, 0x37,
-	0x35, 0x85, 0xdd, 0x48, 0x7b, 0xbb, 0xdb, 0x13, 0x4b, 0x15, 0xcd, 0x43, 0x81, 0x34, 0xb6, 0x1a,
-	0x5f, 0xf4, 0x5a, 0xf5, 0xee, 0xb5, 0x1b, 0x5a, 0x82, 0x5d, 0x91, 0x30, 0xb4, 0xb7, 0xa5, 0x2d,
-	0xb9, 0xf6, 0x67, 0x1a, 0x72, 0x41, 0x8c, 0x4c, 0x92, 0x3b, 0xbe, 0x7b, 0x88, 0x96, 0xa6, 0xfd,
-	0xc5, 0x51, 0x39, 0x31, 0x61, 0x95, 0x65, 0x41, 0x41, 0x1f, 0x43, 0x9a, 0x8f, 0x99, 0x68, 0xea,
-	0xd8, 0x5e, 0x99, 0x3e, 0x8c, 0x63, 0x05, 0x5d, 0x87, 0x42, 0x64, 0x3c, 0x9d, 0xb1, 0xfb, 0x74,
-	0xcc, 0x1a, 0x9f, 0x64, 0xb1, 0x72, 0x49, 0x45, 0x37, 0xa0, 0x10, 0x19, 0xe2, 0x50, 0x25, 0x26,
-	0x9a, 0xd8, 0x98, 0x39, 0xe6, 0x9a, 0x32, 0xf5, 0x61, 0x05, 0x35, 0x00, 0xc6, 0x73, 0x16, 0x3a,
-	0x15, 0x03, 0x47, 0x07, 0xbd, 0x4a, 0x65, 0x9a, 0x2b, 0xa4, 0xd9, 0x80, 0x7c, 0x38, 0x65, 0xa0,
-	0xf2, 0x94, 0xc1, 0x43, 0x90, 0xcc, 0x1e, 0x49, 0xb0, 0x82, 0x36, 0xa1, 0x58, 0x37, 0xcd, 0xd7,
-	0xa1, 0xa9, 0x44, 0x3d, 0xee, 0x24, 0x8f, 0x19, 0xf6, 0xdc, 0xc9, 0xc6, 0x8e, 0xce, 0xc7, 0x2b,
-	0xce, 0xac, 0x69, 0xa5, 0xf2, 0xff, 0x7f, 0xc4, 0x85, 0xa7, 0x75, 0x61, 0x7e, 0xa2, 0xc3, 0xa3,
-	0xea, 0xc4, 0xee, 0x89, 0xa1, 0xa0, 0x52, 0x9b, 0xe9, 0x0f, 0x59, 0x5b, 0x50, 0x8a, 0x77, 0x24,
-	0x34, 0xeb, 0x6f, 0x95, 0x4a, 0x78, 0xda, 0x8c, 0x16, 0xa6, 0xac, 0xa8, 0x6b, 0x4d, 0xd0, 0x98,
-	0xc8, 0xb7, 0x6d, 0xf3, 0xe8, 0x2d, 0x85, 0xbf, 0x71, 0xe5, 0xf1, 0xb3, 0xaa, 0xf2, 0xe4, 0x59,
-	0x55, 0x79, 0xf9, 0xac, 0xaa, 0x7e, 0x77, 0x5c, 0x55, 0x7f, 0x3a, 0xae, 0xaa, 0x8f, 0x8e, 0xab,
-	0xea, 0xe3, 0xe3, 0xaa, 0xfa, 0xc7, 0x71, 0x55, 0x7d, 0x71, 0x5c, 0x55, 0x5e, 0x1e, 0x57, 0xd5,
-	0x07, 0xcf, 0xab, 0xca, 0xe3, 0xe7, 0x55, 0xe5, 0xc9, 0xf3, 0xaa, 0xf2, 0x55, 0xa6, 0x6f, 0x1a,
-	0xd4, 0xf6, 0xf6, 0x32, 0xfc, 0xff, 0x09, 0x1f, 0xfd, 0x15, 0x00, 0x00, 0xff, 0xff, 0x57, 0x48,
-	0x9d, 0xc0, 0x96, 0x10, 0x00, 0x00,
+func (m *LabelMatchers) GetMatchers() []*LabelMatcher {
+	if m != nil {
+		return m.Matchers
+	}
+	return nil
 }
 
-func (x MatchType) String() string {
-	s, ok := MatchType_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+type MetricMetadata struct {
+	Type             MetricMetadata_MetricType `protobuf:"varint,1,opt,name=type,proto3,enum=cortex.MetricMetadata_MetricType" json:"type,omitempty"`
+	MetricFamilyName string                    `protobuf:"bytes,2,opt,name=metric_family_name,json=metricFamilyName,proto3" json:"metric_family_name,omitempty"`
+	Help             string                    `protobuf:"bytes,4,opt,name=help,proto3" json:"help,omitempty"`
+	Unit             string                    `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
 }
-func (x WriteRequest_SourceEnum) String() string {
-	s, ok := WriteRequest_SourceEnum_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+
+func (m *MetricMetadata) Reset()      { *m = MetricMetadata{} }
+func (*MetricMetadata) ProtoMessage() {}
+func (*MetricMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{36}
 }
-func (x MetricMetadata_MetricType) String() string {
-	s, ok := MetricMetadata_MetricType_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+func (m *MetricMetadata) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (this *WriteRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*WriteRequest)
-	if !ok {
-		that2, ok := that.(WriteRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Timeseries) != len(that1.Timeseries) {
-		return false
-	}
-	for i := range this.Timeseries {
-		if !this.Timeseries[i].Equal(that1.Timeseries[i]) {
-			return false
-		}
-	}
-	if this.Source != that1.Source {
-		return false
-	}
-	if len(this.Metadata) != len(that1.Metadata) {
-		return false
-	}
-	for i := range this.Metadata {
-		if !this.Metadata[i].Equal(that1.Metadata[i]) {
-			return false
+func (m *MetricMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricMetadata.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if this.SkipLabelNameValidation != that1.SkipLabelNameValidation {
-		return false
-	}
-	return true
 }
-func (this *WriteResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (m *MetricMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricMetadata.Merge(m, src)
+}
+func (m *MetricMetadata) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricMetadata.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*WriteResponse)
-	if !ok {
-		that2, ok := that.(WriteResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+var xxx_messageInfo_MetricMetadata proto.InternalMessageInfo
+
+func (m *MetricMetadata) GetType() MetricMetadata_MetricType {
+	if m != nil {
+		return m.Type
 	}
-	return true
+	return UNKNOWN
 }
-func (this *ReadRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*ReadRequest)
-	if !ok {
-		that2, ok := that.(ReadRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Queries) != len(that1.Queries) {
-		return false
-	}
-	for i := range this.Queries {
-		if !this.Queries[i].Equal(that1.Queries[i]) {
-			return false
-		}
+func (m *MetricMetadata) GetMetricFamilyName() string {
+	if m != nil {
+		return m.MetricFamilyName
 	}
-	return true
+	return ""
 }
-func (this *ReadResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*ReadResponse)
-	if !ok {
-		that2, ok := that.(ReadResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Results) != len(that1.Results) {
-		return false
-	}
-	for i := range this.Results {
-		if !this.Results[i].Equal(that1.Results[i]) {
-			return false
-		}
+func (m *MetricMetadata) GetHelp() string {
+	if m != nil {
+		return m.Help
 	}
-	return true
+	return ""
 }
-func (this *QueryRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*QueryRequest)
-	if !ok {
-		that2, ok := that.(QueryRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+func (m *MetricMetadata) GetUnit() string {
+	if m != nil {
+		return m.Unit
 	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
+	return ""
+}
+
+type Metric struct {
+	Labels []LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=LabelAdapter" json:"labels"`
+}
+
+func (m *Metric) Reset()      { *m = Metric{} }
+func (*Metric) ProtoMessage() {}
+func (*Metric) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{37}
+}
+func (m *Metric) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Metric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Metric.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
-		return false
+}
+func (m *Metric) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metric.Merge(m, src)
+}
+func (m *Metric) XXX_Size() int {
+	return m.Size()
+}
+func (m *Metric) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metric.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metric proto.InternalMessageInfo
+
+type LabelMatcher struct {
+	Type  MatchType `protobuf:"varint,1,opt,name=type,proto3,enum=cortex.MatchType" json:"type,omitempty"`
+	Name  string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Value string    `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *LabelMatcher) Reset()      { *m = LabelMatcher{} }
+func (*LabelMatcher) ProtoMessage() {}
+func (*LabelMatcher) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{38}
+}
+func (m *LabelMatcher) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelMatcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelMatcher.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(this.Matchers) != len(that1.Matchers) {
-		return false
+}
+func (m *LabelMatcher) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelMatcher.Merge(m, src)
+}
+func (m *LabelMatcher) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelMatcher) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelMatcher.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelMatcher proto.InternalMessageInfo
+
+func (m *LabelMatcher) GetType() MatchType {
+	if m != nil {
+		return m.Type
 	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
-			return false
+	return EQUAL
+}
+
+func (m *LabelMatcher) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LabelMatcher) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type TimeSeriesFile struct {
+	FromIngesterId string `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
+	UserId         string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Filename       string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Data           []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *TimeSeriesFile) Reset()      { *m = TimeSeriesFile{} }
+func (*TimeSeriesFile) ProtoMessage() {}
+func (*TimeSeriesFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{39}
+}
+func (m *TimeSeriesFile) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeSeriesFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeSeriesFile.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *QueryResponse) Equal(that interface{}) bool {
+func (m *TimeSeriesFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeSeriesFile.Merge(m, src)
+}
+func (m *TimeSeriesFile) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeSeriesFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeSeriesFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeSeriesFile proto.InternalMessageInfo
+
+func (m *TimeSeriesFile) GetFromIngesterId() string {
+	if m != nil {
+		return m.FromIngesterId
+	}
+	return ""
+}
+
+func (m *TimeSeriesFile) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *TimeSeriesFile) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *TimeSeriesFile) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("cortex.MatchType", MatchType_name, MatchType_value)
+	proto.RegisterEnum("cortex.WriteRequest_SourceEnum", WriteRequest_SourceEnum_name, WriteRequest_SourceEnum_value)
+	proto.RegisterEnum("cortex.MetricMetadata_MetricType", MetricMetadata_MetricType_name, MetricMetadata_MetricType_value)
+	proto.RegisterType((*WriteRequest)(nil), "cortex.WriteRequest")
+	proto.RegisterType((*WriteResponse)(nil), "cortex.WriteResponse")
+	proto.RegisterType((*ReadRequest)(nil), "cortex.ReadRequest")
+	proto.RegisterType((*ReadResponse)(nil), "cortex.ReadResponse")
+	proto.RegisterType((*QueryRequest)(nil), "cortex.QueryRequest")
+	proto.RegisterType((*QueryResponse)(nil), "cortex.QueryResponse")
+	proto.RegisterType((*QueryStreamResponse)(nil), "cortex.QueryStreamResponse")
+	proto.RegisterType((*ExemplarQueryRequest)(nil), "cortex.ExemplarQueryRequest")
+	proto.RegisterType((*ExemplarQueryResponse)(nil), "cortex.ExemplarQueryResponse")
+	proto.RegisterType((*LabelValuesRequest)(nil), "cortex.LabelValuesRequest")
+	proto.RegisterType((*LabelValuesResponse)(nil), "cortex.LabelValuesResponse")
+	proto.RegisterType((*LabelNamesRequest)(nil), "cortex.LabelNamesRequest")
+	proto.RegisterType((*LabelNamesResponse)(nil), "cortex.LabelNamesResponse")
+	proto.RegisterType((*UserStatsRequest)(nil), "cortex.UserStatsRequest")
+	proto.RegisterType((*UserStatsResponse)(nil), "cortex.UserStatsResponse")
+	proto.RegisterType((*UserIDStatsResponse)(nil), "cortex.UserIDStatsResponse")
+	proto.RegisterType((*UsersStatsResponse)(nil), "cortex.UsersStatsResponse")
+	proto.RegisterType((*LabelNamesCardinalityRequest)(nil), "cortex.LabelNamesCardinalityRequest")
+	proto.RegisterType((*LabelNamesCardinalityResponse)(nil), "cortex.LabelNamesCardinalityResponse")
+	proto.RegisterType((*LabelNameValues)(nil), "cortex.LabelNameValues")
+	proto.RegisterType((*LabelValuesCardinalityRequest)(nil), "cortex.LabelValuesCardinalityRequest")
+	proto.RegisterType((*LabelValuesCardinalityResponse)(nil), "cortex.LabelValuesCardinalityResponse")
+	proto.RegisterType((*LabelNameSeriesCount)(nil), "cortex.LabelNameSeriesCount")
+	proto.RegisterType((*LabelValueSeriesCount)(nil), "cortex.LabelValueSeriesCount")
+	proto.RegisterType((*MetricsForLabelMatchersRequest)(nil), "cortex.MetricsForLabelMatchersRequest")
+	proto.RegisterType((*MetricsForLabelMatchersResponse)(nil), "cortex.MetricsForLabelMatchersResponse")
+	proto.RegisterType((*MetricsMetadataRequest)(nil), "cortex.MetricsMetadataRequest")
+	proto.RegisterType((*MetricsMetadataResponse)(nil), "cortex.MetricsMetadataResponse")
+	proto.RegisterType((*TimeSeriesChunk)(nil), "cortex.TimeSeriesChunk")
+	proto.RegisterType((*Chunk)(nil), "cortex.Chunk")
+	proto.RegisterType((*TransferChunksResponse)(nil), "cortex.TransferChunksResponse")
+	proto.RegisterType((*TimeSeries)(nil), "cortex.TimeSeries")
+	proto.RegisterType((*LabelPair)(nil), "cortex.LabelPair")
+	proto.RegisterType((*Sample)(nil), "cortex.Sample")
+	proto.RegisterType((*Exemplar)(nil), "cortex.Exemplar")
+	proto.RegisterType((*LabelMatchers)(nil), "cortex.LabelMatchers")
+	proto.RegisterType((*MetricMetadata)(nil), "cortex.MetricMetadata")
+	proto.RegisterType((*Metric)(nil), "cortex.Metric")
+	proto.RegisterType((*LabelMatcher)(nil), "cortex.LabelMatcher")
+	proto.RegisterType((*TimeSeriesFile)(nil), "cortex.TimeSeriesFile")
+}
+
+func init() { proto.RegisterFile("cortex.proto", fileDescriptor_893a47d0a749d749) }
+
+var fileDescriptor_893a47d0a749d749 = []byte{
+	// 1848 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcd, 0x8f, 0x1b, 0x49,
+	0x15, 0xef, 0xf2, 0xd7, 0xd8, 0xcf, 0x1e, 0xa7, 0x53, 0x99, 0x0f, 0xaf, 0x37, 0xf1, 0x64, 0x4b,
+	0x24, 0x8c, 0x80, 0x9d, 0xec, 0x0e, 0x04, 0x22, 0xb1, 0x10, 0x79, 0x66, 0x3d, 0x13, 0x43, 0xec,
+	0x49, 0xda, 0x9e, 0x5d, 0x3e, 0x84, 0xac, 0x8e, 0x5d, 0x33, 0xd3, 0xa4, 0xbb, 0xed, 0xed, 0x8f,
+	0x28, 0x73, 0x43, 0x82, 0x1b, 0x07, 0x56, 0x42, 0x48, 0x7b, 0xe5, 0xc6, 0x11, 0xc1, 0x81, 0x3b,
+	0x07, 0x94, 0x63, 0x8e, 0x2b, 0x0e, 0x11, 0x99, 0x5c, 0xf6, 0xb8, 0x7f, 0x02, 0xaa, 0x8f, 0xfe,
+	0x74, 0x7b, 0x27, 0x21, 0x64, 0x6f, 0x5d, 0xef, 0xbd, 0xfa, 0xbd, 0x57, 0xef, 0xab, 0x5e, 0x35,
+	0xd4, 0xc6, 0x53, 0xc7, 0xa3, 0x8f, 0xb7, 0x66, 0xce, 0xd4, 0x9b, 0xe2, 0x92, 0x58, 0x35, 0xdf,
+	0x3d, 0x36, 0xbc, 0x13, 0xff, 0xc1, 0xd6, 0x78, 0x6a, 0xdd, 0x38, 0x9e, 0x1e, 0x4f, 0x6f, 0x70,
+	0xf6, 0x03, 0xff, 0x88, 0xaf, 0xf8, 0x82, 0x7f, 0x89, 0x6d, 0xe4, 0xaf, 0x39, 0xa8, 0x7d, 0xec,
+	0x18, 0x1e, 0xd5, 0xe8, 0x27, 0x3e, 0x75, 0x3d, 0xdc, 0x07, 0xf0, 0x0c, 0x8b, 0xba, 0xd4, 0x31,
+	0xa8, 0xdb, 0x40, 0x57, 0xf3, 0x9b, 0xd5, 0x6d, 0xbc, 0x25, 0x55, 0x0d, 0x0d, 0x8b, 0x0e, 0x38,
+	0x67, 0xa7, 0xf9, 0xe4, 0xd9, 0x86, 0xf2, 0xef, 0x67, 0x1b, 0xf8, 0x9e, 0x43, 0x75, 0xd3, 0x9c,
+	0x8e, 0x87, 0xe1, 0x2e, 0x2d, 0x86, 0x80, 0x7f, 0x00, 0xa5, 0xc1, 0xd4, 0x77, 0xc6, 0xb4, 0x91,
+	0xbb, 0x8a, 0x36, 0xeb, 0xdb, 0x1b, 0x01, 0x56, 0x5c, 0xeb, 0x96, 0x10, 0xe9, 0xd8, 0xbe, 0xa5,
+	0x49, 0x71, 0x7c, 0x0b, 0xca, 0x16, 0xf5, 0xf4, 0x89, 0xee, 0xe9, 0x8d, 0x3c, 0x37, 0x63, 0x2d,
+	0xd8, 0xda, 0xa3, 0x9e, 0x63, 0x8c, 0x7b, 0x92, 0xbb, 0x53, 0x78, 0xf2, 0x6c, 0x03, 0x69, 0xa1,
+	0x34, 0xfe, 0x00, 0x9a, 0xee, 0x43, 0x63, 0x36, 0x32, 0xf5, 0x07, 0xd4, 0x1c, 0xd9, 0xba, 0x45,
+	0x47, 0x8f, 0x74, 0xd3, 0x98, 0xe8, 0x9e, 0x31, 0xb5, 0x1b, 0x5f, 0x2c, 0x5d, 0x45, 0x9b, 0x65,
+	0x6d, 0x9d, 0x89, 0xdc, 0x65, 0x12, 0x7d, 0xdd, 0xa2, 0x1f, 0x85, 0x7c, 0xb2, 0x01, 0x10, 0x59,
+	0x83, 0x97, 0x20, 0xdf, 0xbe, 0xd7, 0x55, 0x15, 0x5c, 0x86, 0x82, 0x76, 0x78, 0xb7, 0xa3, 0x22,
+	0x72, 0x01, 0x96, 0xa5, 0xed, 0xee, 0x6c, 0x6a, 0xbb, 0x94, 0xfc, 0x08, 0xaa, 0x1a, 0xd5, 0x27,
+	0x81, 0x07, 0xb7, 0x60, 0xe9, 0x13, 0x3f, 0xee, 0xbe, 0x95, 0xc0, 0xee, 0xfb, 0x3e, 0x75, 0x4e,
+	0xa5, 0x98, 0x16, 0x08, 0x91, 0xdb, 0x50, 0x13, 0xdb, 0x05, 0x1c, 0xbe, 0x01, 0x4b, 0x0e, 0x75,
+	0x7d, 0xd3, 0x0b, 0xf6, 0xaf, 0xa6, 0xf6, 0x0b, 0x39, 0x2d, 0x90, 0x22, 0x9f, 0x21, 0xa8, 0xc5,
+	0xa1, 0xf1, 0x77, 0x00, 0xbb, 0x9e, 0xee, 0x78, 0x23, 0x1e, 0x07, 0x4f, 0xb7, 0x66, 0x23, 0x8b,
+	0x81, 0xa1, 0xcd, 0xbc, 0xa6, 0x72, 0xce, 0x30, 0x60, 0xf4, 0x5c, 0xbc, 0x09, 0x2a, 0xb5, 0x27,
+	0x49, 0xd9, 0x1c, 0x97, 0xad, 0x53, 0x7b, 0x12, 0x97, 0x7c, 0x0f, 0xca, 0x96, 0xee, 0x8d, 0x4f,
+	0xa8, 0xe3, 0x36, 0xf2, 0xc9, 0xa3, 0x71, 0x4f, 0xf6, 0x04, 0x53, 0x0b, 0xa5, 0x48, 0x17, 0x96,
+	0x13, 0x46, 0xe3, 0x5b, 0x2f, 0x99, 0x5e, 0x2c, 0xa6, 0x4a, 0x3c, 0x91, 0xc8, 0xa7, 0x08, 0x2e,
+	0x71, 0xac, 0x81, 0xe7, 0x50, 0xdd, 0x0a, 0x11, 0x6f, 0x43, 0x75, 0x7c, 0xe2, 0xdb, 0x0f, 0x13,
+	0x90, 0xeb, 0xf3, 0x90, 0xbb, 0x4c, 0x48, 0xe2, 0xc6, 0x77, 0xa4, 0x4c, 0xca, 0xbd, 0x82, 0x49,
+	0x7f, 0x46, 0xb0, 0xd2, 0x79, 0x4c, 0xad, 0x99, 0xa9, 0x3b, 0x5f, 0x4b, 0x00, 0xde, 0x9f, 0x0b,
+	0xc0, 0x6a, 0x56, 0x00, 0xdc, 0x58, 0x04, 0xee, 0xc3, 0x6a, 0xca, 0xc4, 0xd7, 0x8e, 0xc4, 0xef,
+	0x11, 0x60, 0xae, 0xee, 0x23, 0xdd, 0xf4, 0xa9, 0x1b, 0x1c, 0xfa, 0x0a, 0x40, 0x54, 0x71, 0xfc,
+	0xb0, 0x15, 0xad, 0x62, 0x06, 0x15, 0xb6, 0xc0, 0x27, 0xb9, 0x57, 0xf0, 0x49, 0x3e, 0xcb, 0x27,
+	0xe4, 0x16, 0x5c, 0x4a, 0x18, 0x23, 0x8f, 0xf7, 0x0e, 0xd4, 0x84, 0x35, 0x8f, 0x38, 0x9d, 0x1f,
+	0xb0, 0xa2, 0x55, 0xcd, 0x48, 0x94, 0x3c, 0x84, 0x8b, 0x61, 0x03, 0x70, 0xdf, 0x70, 0xe8, 0xc8,
+	0x4d, 0xc0, 0x71, 0x65, 0xd2, 0xca, 0x0d, 0xa8, 0x46, 0x3e, 0x0b, 0x8c, 0x84, 0xd0, 0x69, 0x2e,
+	0xc1, 0xa0, 0x1e, 0xba, 0xd4, 0x19, 0x78, 0xba, 0x17, 0x98, 0x48, 0xfe, 0x81, 0xe0, 0x62, 0x8c,
+	0x28, 0xa1, 0xae, 0x41, 0xdd, 0xb0, 0x8f, 0xa9, 0xcb, 0x9a, 0xd8, 0xc8, 0xd1, 0x3d, 0x11, 0x02,
+	0xa4, 0x2d, 0x87, 0x54, 0x4d, 0xf7, 0x28, 0x8b, 0x92, 0xed, 0x5b, 0xa3, 0x30, 0xdb, 0xd1, 0x66,
+	0x41, 0xab, 0xd8, 0xbe, 0x25, 0xa2, 0xcd, 0x8e, 0xaf, 0xcf, 0x8c, 0x51, 0x0a, 0x29, 0xcf, 0x91,
+	0x54, 0x7d, 0x66, 0x74, 0x13, 0x60, 0x5b, 0x70, 0xc9, 0xf1, 0x4d, 0x9a, 0x16, 0x2f, 0x70, 0xf1,
+	0x8b, 0x8c, 0x95, 0x90, 0x27, 0xbf, 0x82, 0x4b, 0xcc, 0xf0, 0xee, 0x87, 0x49, 0xd3, 0xd7, 0x61,
+	0xc9, 0x77, 0xa9, 0x33, 0x32, 0x26, 0x32, 0x6d, 0x4a, 0x6c, 0xd9, 0x9d, 0xe0, 0x77, 0xa1, 0xc0,
+	0xfb, 0x3f, 0x33, 0xb3, 0xba, 0xfd, 0x56, 0x90, 0x9d, 0x73, 0x87, 0xd7, 0xb8, 0x18, 0xd9, 0x07,
+	0xcc, 0x58, 0x6e, 0x12, 0xfd, 0x7d, 0x28, 0xba, 0x8c, 0x20, 0x73, 0xfc, 0xed, 0x38, 0x4a, 0xca,
+	0x12, 0x4d, 0x48, 0x92, 0x3b, 0x70, 0x39, 0x0a, 0xd6, 0xae, 0xee, 0x4c, 0x0c, 0x5b, 0x37, 0x0d,
+	0x2f, 0xac, 0xef, 0x4d, 0x50, 0x2d, 0xfd, 0xf1, 0x28, 0x95, 0x60, 0xcc, 0x95, 0x75, 0x4b, 0x7f,
+	0x1c, 0x4b, 0x47, 0xf2, 0x2f, 0x04, 0x57, 0x16, 0x40, 0x49, 0xf3, 0x92, 0x01, 0x41, 0xe9, 0x80,
+	0xfc, 0x38, 0x99, 0x21, 0xb9, 0x64, 0x7b, 0x8b, 0x5f, 0x60, 0x7e, 0x54, 0xac, 0x51, 0x02, 0xe1,
+	0x3d, 0xa8, 0x59, 0xfc, 0xba, 0x94, 0x00, 0xa2, 0x6d, 0x5c, 0x49, 0x00, 0xf0, 0xcd, 0xb2, 0x4b,
+	0x4e, 0x7d, 0xdb, 0x0b, 0xba, 0xa4, 0xd8, 0x28, 0x12, 0xf1, 0x21, 0x5c, 0x48, 0x29, 0x3b, 0xaf,
+	0xe0, 0xd7, 0xa0, 0x24, 0x5d, 0x93, 0xe3, 0x69, 0x2d, 0x57, 0xac, 0x32, 0xc5, 0xd7, 0x68, 0xcc,
+	0x94, 0xf1, 0xe4, 0x2a, 0x68, 0x55, 0x41, 0xe3, 0xfa, 0xc9, 0xaf, 0xa5, 0xd3, 0x84, 0xa2, 0x8c,
+	0x00, 0x9c, 0x57, 0x37, 0x99, 0x11, 0xca, 0x65, 0x46, 0x88, 0x42, 0x6b, 0x91, 0x2e, 0x19, 0xa1,
+	0xdd, 0x79, 0x65, 0xd5, 0xed, 0xcb, 0x73, 0x21, 0x98, 0x77, 0x60, 0xbc, 0x90, 0xff, 0x84, 0x60,
+	0x25, 0x4b, 0xf4, 0x3c, 0x2f, 0xfe, 0x30, 0xe1, 0xc5, 0x97, 0x8c, 0xdc, 0x2b, 0xb8, 0xfa, 0x97,
+	0xb0, 0x9a, 0x89, 0x14, 0xb9, 0x98, 0x4b, 0x4b, 0xc3, 0x20, 0xea, 0x9f, 0x0c, 0x5c, 0x24, 0xad,
+	0x04, 0x17, 0xee, 0xad, 0xba, 0x11, 0x06, 0xf9, 0x1b, 0x82, 0x96, 0x18, 0xd6, 0xdc, 0xbd, 0xa9,
+	0x93, 0xbc, 0xa2, 0xde, 0xf0, 0x55, 0x79, 0x0b, 0x6a, 0xc1, 0x1d, 0x38, 0x72, 0xa9, 0xf7, 0xd5,
+	0xd7, 0x65, 0x35, 0x10, 0x1d, 0x50, 0x8f, 0x74, 0x61, 0x63, 0xa1, 0xcd, 0x32, 0x23, 0xae, 0x43,
+	0x49, 0xd4, 0x86, 0x4c, 0x86, 0x7a, 0x72, 0x32, 0xd5, 0x24, 0x97, 0x34, 0x60, 0x4d, 0x42, 0x05,
+	0xc3, 0x6a, 0xd0, 0xc3, 0x7b, 0xb0, 0x3e, 0xc7, 0x91, 0xe0, 0xdb, 0xb1, 0xc1, 0x17, 0x7d, 0xd5,
+	0xe0, 0x1b, 0x8d, 0xbc, 0xe4, 0x9f, 0x08, 0x2e, 0xa4, 0x46, 0x1d, 0xe6, 0xab, 0x23, 0x67, 0x6a,
+	0xc9, 0xe6, 0x1c, 0x6f, 0xaf, 0x75, 0x46, 0xef, 0x4a, 0x72, 0x77, 0x12, 0xef, 0xbf, 0xb9, 0x44,
+	0xff, 0xbd, 0x0d, 0x25, 0x1e, 0xf0, 0xa0, 0x6d, 0x5c, 0x4c, 0xb8, 0xef, 0x9e, 0x6e, 0x38, 0x3b,
+	0x2b, 0xf2, 0x1d, 0x50, 0xe3, 0xa4, 0xf6, 0x44, 0x9f, 0x79, 0xd4, 0xd1, 0xe4, 0x36, 0xfc, 0x6d,
+	0x28, 0x89, 0x51, 0xab, 0x51, 0xe0, 0x00, 0xcb, 0x01, 0x40, 0x7c, 0x1a, 0x93, 0x22, 0xe4, 0x0f,
+	0x08, 0x8a, 0xc2, 0xf4, 0x37, 0x95, 0x14, 0x4d, 0x28, 0x53, 0x7b, 0x3c, 0x9d, 0x18, 0xf6, 0x31,
+	0xaf, 0x85, 0xa2, 0x16, 0xae, 0x31, 0x96, 0x77, 0x0d, 0xbb, 0xbc, 0x6a, 0xf2, 0x42, 0x69, 0xc0,
+	0xda, 0xd0, 0xd1, 0x6d, 0xf7, 0x88, 0x3a, 0xdc, 0xb0, 0x30, 0x03, 0xc8, 0xdf, 0x11, 0x40, 0xe4,
+	0xf0, 0x98, 0xa3, 0xd0, 0xff, 0xe6, 0xa8, 0x2d, 0x58, 0x72, 0x75, 0x6b, 0x66, 0x86, 0x75, 0x1e,
+	0xa6, 0xd4, 0x80, 0x93, 0xa5, 0xab, 0x02, 0x21, 0xfc, 0x3d, 0xa8, 0x50, 0x39, 0xd6, 0x05, 0xc1,
+	0x51, 0x83, 0x1d, 0xc1, 0xbc, 0x27, 0xf7, 0x44, 0x82, 0xe4, 0x26, 0x54, 0x42, 0x83, 0xd8, 0x81,
+	0xc3, 0x96, 0x53, 0xd3, 0xf8, 0x37, 0x5e, 0x81, 0xa2, 0x28, 0xf7, 0x1c, 0x27, 0x8a, 0x05, 0x69,
+	0x43, 0x49, 0x58, 0x11, 0xf1, 0xc5, 0x6c, 0x51, 0x7c, 0x14, 0x74, 0x82, 0x0c, 0xe7, 0x57, 0xbd,
+	0xd8, 0xf8, 0xf3, 0x3b, 0x04, 0xe5, 0xc0, 0xae, 0xd7, 0xf7, 0x56, 0xc2, 0xcc, 0x85, 0x66, 0xe4,
+	0xe7, 0xcd, 0x68, 0xc3, 0x72, 0xa2, 0xa2, 0x13, 0x4f, 0x1a, 0xf4, 0x52, 0x4f, 0x9a, 0xcf, 0x72,
+	0x50, 0x4f, 0xd6, 0x21, 0xbe, 0x09, 0x05, 0xef, 0x74, 0x26, 0x9c, 0x52, 0xdf, 0x7e, 0x27, 0xbb,
+	0x5a, 0xe5, 0x72, 0x78, 0x3a, 0xa3, 0x1a, 0x17, 0x67, 0x59, 0x2e, 0xaf, 0xe6, 0x23, 0xdd, 0x32,
+	0xcc, 0x53, 0x71, 0x03, 0x88, 0x0a, 0x54, 0x05, 0x67, 0x8f, 0x33, 0xf8, 0x45, 0x80, 0xa1, 0x70,
+	0x42, 0xcd, 0x19, 0xcf, 0xcf, 0x8a, 0xc6, 0xbf, 0x19, 0xcd, 0xb7, 0x0d, 0xaf, 0x51, 0x14, 0x34,
+	0xf6, 0x4d, 0x4e, 0x01, 0x22, 0x4d, 0xb8, 0x0a, 0x4b, 0x87, 0xfd, 0x9f, 0xf6, 0x0f, 0x3e, 0xee,
+	0xab, 0x0a, 0x5b, 0xec, 0x1e, 0x1c, 0xf6, 0x87, 0x1d, 0x4d, 0x45, 0xb8, 0x02, 0xc5, 0xfd, 0xf6,
+	0xe1, 0x7e, 0x47, 0xcd, 0xe1, 0x65, 0xa8, 0xdc, 0xe9, 0x0e, 0x86, 0x07, 0xfb, 0x5a, 0xbb, 0xa7,
+	0xe6, 0x31, 0x86, 0x3a, 0xe7, 0x44, 0xb4, 0x02, 0xdb, 0x3a, 0x38, 0xec, 0xf5, 0xda, 0xda, 0xcf,
+	0xd5, 0x22, 0x7b, 0x0b, 0x77, 0xfb, 0x7b, 0x07, 0x6a, 0x09, 0xd7, 0xa0, 0x3c, 0x18, 0xb6, 0x87,
+	0x9d, 0x41, 0x67, 0xa8, 0x2e, 0x91, 0x2e, 0x94, 0x84, 0xea, 0xd7, 0x8e, 0x30, 0x19, 0x41, 0x2d,
+	0xee, 0x7f, 0x7c, 0x2d, 0xe1, 0xe2, 0x10, 0x8e, 0xb3, 0x63, 0x2e, 0x0d, 0x72, 0x5a, 0x38, 0x31,
+	0x95, 0xd3, 0x79, 0x4e, 0x94, 0x39, 0xfd, 0x5b, 0x04, 0xf5, 0xa8, 0x80, 0xf7, 0x0c, 0x93, 0xfe,
+	0x3f, 0x1a, 0x66, 0x13, 0xca, 0x47, 0x86, 0x49, 0xb9, 0x0d, 0x42, 0x5d, 0xb8, 0xce, 0x6a, 0x30,
+	0xdf, 0xfa, 0x09, 0x54, 0xc2, 0x23, 0xb0, 0x88, 0x74, 0xee, 0x1f, 0xb6, 0xef, 0xaa, 0x0a, 0x8b,
+	0x48, 0xff, 0x60, 0x38, 0x12, 0x4b, 0x84, 0x2f, 0x40, 0x55, 0xeb, 0xec, 0x77, 0x7e, 0x36, 0xea,
+	0xb5, 0x87, 0xbb, 0x77, 0xd4, 0x1c, 0x0b, 0x91, 0x20, 0xf4, 0x0f, 0x24, 0x2d, 0xbf, 0xfd, 0xc7,
+	0x32, 0x94, 0x03, 0x1b, 0x59, 0x4a, 0xde, 0xf3, 0xdd, 0x13, 0xbc, 0x92, 0xf5, 0xbb, 0xa5, 0xb9,
+	0x9a, 0xa2, 0xca, 0xa6, 0xa6, 0xe0, 0xef, 0x43, 0x91, 0xbf, 0x12, 0x71, 0xe6, 0x3f, 0x8b, 0x66,
+	0xf6, 0x9f, 0x08, 0xa2, 0xe0, 0x0f, 0xa1, 0x1a, 0x7b, 0x9b, 0x2f, 0xd8, 0xfd, 0x76, 0x82, 0x9a,
+	0x7c, 0xc6, 0x13, 0xe5, 0x3d, 0x84, 0xef, 0x40, 0x35, 0x36, 0x8a, 0xe1, 0xe6, 0xfc, 0xa8, 0xe3,
+	0xce, 0x61, 0x65, 0xbc, 0xfd, 0x88, 0x82, 0x3b, 0x00, 0xd1, 0xd4, 0x8d, 0xdf, 0x9a, 0x9b, 0xd5,
+	0x42, 0x9c, 0x66, 0x16, 0x2b, 0x84, 0xd9, 0x81, 0x4a, 0xf8, 0xd6, 0xc0, 0x8d, 0x8c, 0xe7, 0x87,
+	0x00, 0x59, 0xfc, 0x30, 0x21, 0x0a, 0x1b, 0xc0, 0xdb, 0xa6, 0xf9, 0x32, 0x30, 0xcd, 0x38, 0xc7,
+	0x4d, 0xe3, 0x98, 0xb0, 0xbe, 0x60, 0x2c, 0xc1, 0xd7, 0x93, 0x1d, 0x67, 0xd1, 0xac, 0xd5, 0xfc,
+	0xe6, 0xb9, 0x72, 0xa1, 0xb6, 0x21, 0x5c, 0x48, 0xcd, 0x27, 0xb8, 0x95, 0xda, 0x9d, 0x1a, 0x69,
+	0x9a, 0x1b, 0x0b, 0xf9, 0x21, 0xea, 0x01, 0xd4, 0x79, 0xec, 0x83, 0x9b, 0xc0, 0xc5, 0x97, 0xd3,
+	0x97, 0x56, 0x22, 0x63, 0xae, 0x2c, 0xe0, 0x86, 0x80, 0x47, 0xb0, 0x1a, 0x05, 0x2e, 0x36, 0xbb,
+	0xe3, 0x6f, 0xcc, 0xc7, 0x75, 0xfe, 0x19, 0xd1, 0xbc, 0x76, 0x8e, 0x54, 0xa8, 0xc7, 0x80, 0xb5,
+	0xec, 0x47, 0x02, 0xbe, 0x96, 0x91, 0x88, 0x19, 0x9a, 0xae, 0x9f, 0x27, 0x16, 0xaa, 0xea, 0x41,
+	0x3d, 0x39, 0x73, 0xe0, 0x45, 0x3f, 0xb3, 0x9a, 0x61, 0x44, 0x16, 0x0c, 0x29, 0xca, 0x26, 0xda,
+	0xee, 0x82, 0xca, 0x1a, 0xc1, 0x81, 0x6d, 0x9e, 0xbe, 0x66, 0x73, 0xd8, 0xf9, 0xe0, 0xe9, 0xf3,
+	0x96, 0xf2, 0xf9, 0xf3, 0x96, 0xf2, 0xe5, 0xf3, 0x16, 0xfa, 0xcd, 0x59, 0x0b, 0xfd, 0xe5, 0xac,
+	0x85, 0x9e, 0x9c, 0xb5, 0xd0, 0xd3, 0xb3, 0x16, 0xfa, 0xcf, 0x59, 0x0b, 0x7d, 0x71, 0xd6, 0x52,
+	0xbe, 0x3c, 0x6b, 0xa1, 0x4f, 0x5f, 0xb4, 0x94, 0xa7, 0x2f, 0x5a, 0xca, 0xe7, 0x2f, 0x5a, 0xca,
+	0x2f, 0x4a, 0x63, 0xd3, 0xa0, 0xb6, 0xf7, 0xa0, 0xc4, 0x7f, 0x38, 0x7f, 0xf7, 0xbf, 0x03, 0x00,
+	0x64, 0x07, 0xd3, 0xed, 0xb7, 0x16, 0x00, 0x00,
+}
+
+func (x MatchType) String() string {
+	s, ok := MatchType_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x WriteRequest_SourceEnum) String() string {
+	s, ok := WriteRequest_SourceEnum_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x MetricMetadata_MetricType) String() string {
+	s, ok := MetricMetadata_MetricType_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *WriteRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*QueryResponse)
+	that1, ok := that.(*WriteRequest)
 	if !ok {
-		that2, ok := that.(QueryResponse)
+		that2, ok := that.(WriteRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1946,20 +2354,34 @@ func (this *QueryResponse) Equal(that interface{}) bool {
 		return false
 	}
 	for i := range this.Timeseries {
-		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+		if !this.Timeseries[i].Equal(that1.Timeseries[i]) {
+			return false
+		}
+	}
+	if this.Source != that1.Source {
+		return false
+	}
+	if len(this.Metadata) != len(that1.Metadata) {
+		return false
+	}
+	for i := range this.Metadata {
+		if !this.Metadata[i].Equal(that1.Metadata[i]) {
 			return false
 		}
 	}
+	if this.SkipLabelNameValidation != that1.SkipLabelNameValidation {
+		return false
+	}
 	return true
 }
-func (this *QueryStreamResponse) Equal(that interface{}) bool {
+func (this *WriteResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*QueryStreamResponse)
+	that1, ok := that.(*WriteResponse)
 	if !ok {
-		that2, ok := that.(QueryStreamResponse)
+		that2, ok := that.(WriteResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1971,32 +2393,16 @@ func (this *QueryStreamResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Chunkseries) != len(that1.Chunkseries) {
-		return false
-	}
-	for i := range this.Chunkseries {
-		if !this.Chunkseries[i].Equal(&that1.Chunkseries[i]) {
-			return false
-		}
-	}
-	if len(this.Timeseries) != len(that1.Timeseries) {
-		return false
-	}
-	for i := range this.Timeseries {
-		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
-			return false
-		}
-	}
 	return true
 }
-func (this *LabelValuesRequest) Equal(that interface{}) bool {
+func (this *ReadRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelValuesRequest)
+	that1, ok := that.(*ReadRequest)
 	if !ok {
-		that2, ok := that.(LabelValuesRequest)
+		that2, ok := that.(ReadRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2008,25 +2414,24 @@ func (this *LabelValuesRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.LabelName != that1.LabelName {
-		return false
-	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
+	if len(this.Queries) != len(that1.Queries) {
 		return false
 	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
-		return false
+	for i := range this.Queries {
+		if !this.Queries[i].Equal(that1.Queries[i]) {
+			return false
+		}
 	}
 	return true
 }
-func (this *LabelValuesResponse) Equal(that interface{}) bool {
+func (this *ReadResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelValuesResponse)
+	that1, ok := that.(*ReadResponse)
 	if !ok {
-		that2, ok := that.(LabelValuesResponse)
+		that2, ok := that.(ReadResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2038,24 +2443,24 @@ func (this *LabelValuesResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.LabelValues) != len(that1.LabelValues) {
+	if len(this.Results) != len(that1.Results) {
 		return false
 	}
-	for i := range this.LabelValues {
-		if this.LabelValues[i] != that1.LabelValues[i] {
+	for i := range this.Results {
+		if !this.Results[i].Equal(that1.Results[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *LabelNamesRequest) Equal(that interface{}) bool {
+func (this *QueryRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelNamesRequest)
+	that1, ok := that.(*QueryRequest)
 	if !ok {
-		that2, ok := that.(LabelNamesRequest)
+		that2, ok := that.(QueryRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2073,16 +2478,24 @@ func (this *LabelNamesRequest) Equal(that interface{}) bool {
 	if this.EndTimestampMs != that1.EndTimestampMs {
 		return false
 	}
+	if len(this.Matchers) != len(that1.Matchers) {
+		return false
+	}
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *LabelNamesResponse) Equal(that interface{}) bool {
+func (this *QueryResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelNamesResponse)
+	that1, ok := that.(*QueryResponse)
 	if !ok {
-		that2, ok := that.(LabelNamesResponse)
+		that2, ok := that.(QueryResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2094,24 +2507,24 @@ func (this *LabelNamesResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.LabelNames) != len(that1.LabelNames) {
+	if len(this.Timeseries) != len(that1.Timeseries) {
 		return false
 	}
-	for i := range this.LabelNames {
-		if this.LabelNames[i] != that1.LabelNames[i] {
+	for i := range this.Timeseries {
+		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *UserStatsRequest) Equal(that interface{}) bool {
+func (this *QueryStreamResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UserStatsRequest)
+	that1, ok := that.(*QueryStreamResponse)
 	if !ok {
-		that2, ok := that.(UserStatsRequest)
+		that2, ok := that.(QueryStreamResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2123,16 +2536,32 @@ func (this *UserStatsRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if len(this.Chunkseries) != len(that1.Chunkseries) {
+		return false
+	}
+	for i := range this.Chunkseries {
+		if !this.Chunkseries[i].Equal(&that1.Chunkseries[i]) {
+			return false
+		}
+	}
+	if len(this.Timeseries) != len(that1.Timeseries) {
+		return false
+	}
+	for i := range this.Timeseries {
+		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *UserStatsResponse) Equal(that interface{}) bool {
+func (this *ExemplarQueryRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UserStatsResponse)
+	that1, ok := that.(*ExemplarQueryRequest)
 	if !ok {
-		that2, ok := that.(UserStatsResponse)
+		that2, ok := that.(ExemplarQueryRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2144,28 +2573,30 @@ func (this *UserStatsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.IngestionRate != that1.IngestionRate {
+	if this.StartTimestampMs != that1.StartTimestampMs {
 		return false
 	}
-	if this.NumSeries != that1.NumSeries {
+	if this.EndTimestampMs != that1.EndTimestampMs {
 		return false
 	}
-	if this.ApiIngestionRate != that1.ApiIngestionRate {
+	if len(this.Matchers) != len(that1.Matchers) {
 		return false
 	}
-	if this.RuleIngestionRate != that1.RuleIngestionRate {
-		return false
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
 	}
 	return true
 }
-func (this *UserIDStatsResponse) Equal(that interface{}) bool {
+func (this *ExemplarQueryResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UserIDStatsResponse)
+	that1, ok := that.(*ExemplarQueryResponse)
 	if !ok {
-		that2, ok := that.(UserIDStatsResponse)
+		that2, ok := that.(ExemplarQueryResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2177,22 +2608,24 @@ func (this *UserIDStatsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.UserId != that1.UserId {
+	if len(this.Timeseries) != len(that1.Timeseries) {
 		return false
 	}
-	if !this.Data.Equal(that1.Data) {
-		return false
+	for i := range this.Timeseries {
+		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+			return false
+		}
 	}
 	return true
 }
-func (this *UsersStatsResponse) Equal(that interface{}) bool {
+func (this *LabelValuesRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UsersStatsResponse)
+	that1, ok := that.(*LabelValuesRequest)
 	if !ok {
-		that2, ok := that.(UsersStatsResponse)
+		that2, ok := that.(LabelValuesRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2204,24 +2637,25 @@ func (this *UsersStatsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Stats) != len(that1.Stats) {
+	if this.LabelName != that1.LabelName {
 		return false
 	}
-	for i := range this.Stats {
-		if !this.Stats[i].Equal(that1.Stats[i]) {
-			return false
-		}
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
+	}
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
 	}
 	return true
 }
-func (this *MetricsForLabelMatchersRequest) Equal(that interface{}) bool {
+func (this *LabelValuesResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricsForLabelMatchersRequest)
+	that1, ok := that.(*LabelValuesResponse)
 	if !ok {
-		that2, ok := that.(MetricsForLabelMatchersRequest)
+		that2, ok := that.(LabelValuesResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2233,30 +2667,24 @@ func (this *MetricsForLabelMatchersRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
-	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
-		return false
-	}
-	if len(this.MatchersSet) != len(that1.MatchersSet) {
+	if len(this.LabelValues) != len(that1.LabelValues) {
 		return false
 	}
-	for i := range this.MatchersSet {
-		if !this.MatchersSet[i].Equal(that1.MatchersSet[i]) {
+	for i := range this.LabelValues {
+		if this.LabelValues[i] != that1.LabelValues[i] {
 			return false
 		}
 	}
 	return true
 }
-func (this *MetricsForLabelMatchersResponse) Equal(that interface{}) bool {
+func (this *LabelNamesRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricsForLabelMatchersResponse)
+	that1, ok := that.(*LabelNamesRequest)
 	if !ok {
-		that2, ok := that.(MetricsForLabelMatchersResponse)
+		that2, ok := that.(LabelNamesRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2268,24 +2696,22 @@ func (this *MetricsForLabelMatchersResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Metric) != len(that1.Metric) {
+	if this.StartTimestampMs != that1.StartTimestampMs {
 		return false
 	}
-	for i := range this.Metric {
-		if !this.Metric[i].Equal(that1.Metric[i]) {
-			return false
-		}
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
 	}
 	return true
 }
-func (this *MetricsMetadataRequest) Equal(that interface{}) bool {
+func (this *LabelNamesResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricsMetadataRequest)
+	that1, ok := that.(*LabelNamesResponse)
 	if !ok {
-		that2, ok := that.(MetricsMetadataRequest)
+		that2, ok := that.(LabelNamesResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2297,16 +2723,24 @@ func (this *MetricsMetadataRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if len(this.LabelNames) != len(that1.LabelNames) {
+		return false
+	}
+	for i := range this.LabelNames {
+		if this.LabelNames[i] != that1.LabelNames[i] {
+			return false
+		}
+	}
 	return true
 }
-func (this *MetricsMetadataResponse) Equal(that interface{}) bool {
+func (this *UserStatsRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricsMetadataResponse)
+	that1, ok := that.(*UserStatsRequest)
 	if !ok {
-		that2, ok := that.(MetricsMetadataResponse)
+		that2, ok := that.(UserStatsRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2318,24 +2752,16 @@ func (this *MetricsMetadataResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Metadata) != len(that1.Metadata) {
-		return false
-	}
-	for i := range this.Metadata {
-		if !this.Metadata[i].Equal(that1.Metadata[i]) {
-			return false
-		}
-	}
 	return true
 }
-func (this *TimeSeriesChunk) Equal(that interface{}) bool {
+func (this *UserStatsResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TimeSeriesChunk)
+	that1, ok := that.(*UserStatsResponse)
 	if !ok {
-		that2, ok := that.(TimeSeriesChunk)
+		that2, ok := that.(UserStatsResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2347,38 +2773,28 @@ func (this *TimeSeriesChunk) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.FromIngesterId != that1.FromIngesterId {
+	if this.IngestionRate != that1.IngestionRate {
 		return false
 	}
-	if this.UserId != that1.UserId {
+	if this.NumSeries != that1.NumSeries {
 		return false
 	}
-	if len(this.Labels) != len(that1.Labels) {
+	if this.ApiIngestionRate != that1.ApiIngestionRate {
 		return false
 	}
-	for i := range this.Labels {
-		if !this.Labels[i].Equal(that1.Labels[i]) {
-			return false
-		}
-	}
-	if len(this.Chunks) != len(that1.Chunks) {
+	if this.RuleIngestionRate != that1.RuleIngestionRate {
 		return false
 	}
-	for i := range this.Chunks {
-		if !this.Chunks[i].Equal(&that1.Chunks[i]) {
-			return false
-		}
-	}
 	return true
 }
-func (this *Chunk) Equal(that interface{}) bool {
+func (this *UserIDStatsResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Chunk)
+	that1, ok := that.(*UserIDStatsResponse)
 	if !ok {
-		that2, ok := that.(Chunk)
+		that2, ok := that.(UserIDStatsResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2390,28 +2806,22 @@ func (this *Chunk) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
-	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
-		return false
-	}
-	if this.Encoding != that1.Encoding {
+	if this.UserId != that1.UserId {
 		return false
 	}
-	if !bytes.Equal(this.Data, that1.Data) {
+	if !this.Data.Equal(that1.Data) {
 		return false
 	}
 	return true
 }
-func (this *TransferChunksResponse) Equal(that interface{}) bool {
+func (this *UsersStatsResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TransferChunksResponse)
+	that1, ok := that.(*UsersStatsResponse)
 	if !ok {
-		that2, ok := that.(TransferChunksResponse)
+		that2, ok := that.(UsersStatsResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2423,16 +2833,24 @@ func (this *TransferChunksResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if len(this.Stats) != len(that1.Stats) {
+		return false
+	}
+	for i := range this.Stats {
+		if !this.Stats[i].Equal(that1.Stats[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *TimeSeries) Equal(that interface{}) bool {
+func (this *LabelNamesCardinalityRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TimeSeries)
+	that1, ok := that.(*LabelNamesCardinalityRequest)
 	if !ok {
-		that2, ok := that.(TimeSeries)
+		that2, ok := that.(LabelNamesCardinalityRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2444,32 +2862,59 @@ func (this *TimeSeries) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Labels) != len(that1.Labels) {
+	if this.MaxLabelValues != that1.MaxLabelValues {
 		return false
 	}
-	for i := range this.Labels {
-		if !this.Labels[i].Equal(that1.Labels[i]) {
+	return true
+}
+func (this *LabelNamesCardinalityResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LabelNamesCardinalityResponse)
+	if !ok {
+		that2, ok := that.(LabelNamesCardinalityResponse)
+		if ok {
+			that1 = &that2
+		} else {
 			return false
 		}
 	}
-	if len(this.Samples) != len(that1.Samples) {
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	for i := range this.Samples {
-		if !this.Samples[i].Equal(&that1.Samples[i]) {
+	if this.NumSeries != that1.NumSeries {
+		return false
+	}
+	if len(this.LabelNames) != len(that1.LabelNames) {
+		return false
+	}
+	for i := range this.LabelNames {
+		if !this.LabelNames[i].Equal(&that1.LabelNames[i]) {
+			return false
+		}
+	}
+	if len(this.MetricNames) != len(that1.MetricNames) {
+		return false
+	}
+	for i := range this.MetricNames {
+		if !this.MetricNames[i].Equal(&that1.MetricNames[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *LabelPair) Equal(that interface{}) bool {
+func (this *LabelNameValues) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelPair)
+	that1, ok := that.(*LabelNameValues)
 	if !ok {
-		that2, ok := that.(LabelPair)
+		that2, ok := that.(LabelNameValues)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2481,22 +2926,30 @@ func (this *LabelPair) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.Name, that1.Name) {
+	if this.LabelName != that1.LabelName {
 		return false
 	}
-	if !bytes.Equal(this.Value, that1.Value) {
+	if len(this.Values) != len(that1.Values) {
+		return false
+	}
+	for i := range this.Values {
+		if this.Values[i] != that1.Values[i] {
+			return false
+		}
+	}
+	if this.ValuesCount != that1.ValuesCount {
 		return false
 	}
 	return true
 }
-func (this *Sample) Equal(that interface{}) bool {
+func (this *LabelValuesCardinalityRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Sample)
+	that1, ok := that.(*LabelValuesCardinalityRequest)
 	if !ok {
-		that2, ok := that.(Sample)
+		that2, ok := that.(LabelValuesCardinalityRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2508,22 +2961,27 @@ func (this *Sample) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Value != that1.Value {
+	if len(this.LabelNames) != len(that1.LabelNames) {
 		return false
 	}
-	if this.TimestampMs != that1.TimestampMs {
+	for i := range this.LabelNames {
+		if this.LabelNames[i] != that1.LabelNames[i] {
+			return false
+		}
+	}
+	if this.MaxLabelValues != that1.MaxLabelValues {
 		return false
 	}
 	return true
 }
-func (this *LabelMatchers) Equal(that interface{}) bool {
+func (this *LabelValuesCardinalityResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelMatchers)
+	that1, ok := that.(*LabelValuesCardinalityResponse)
 	if !ok {
-		that2, ok := that.(LabelMatchers)
+		that2, ok := that.(LabelValuesCardinalityResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2535,24 +2993,24 @@ func (this *LabelMatchers) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Matchers) != len(that1.Matchers) {
+	if len(this.LabelNames) != len(that1.LabelNames) {
 		return false
 	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+	for i := range this.LabelNames {
+		if !this.LabelNames[i].Equal(&that1.LabelNames[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *MetricMetadata) Equal(that interface{}) bool {
+func (this *LabelNameSeriesCount) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricMetadata)
+	that1, ok := that.(*LabelNameSeriesCount)
 	if !ok {
-		that2, ok := that.(MetricMetadata)
+		that2, ok := that.(LabelNameSeriesCount)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2564,28 +3022,57 @@ func (this *MetricMetadata) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Type != that1.Type {
+	if this.LabelName != that1.LabelName {
 		return false
 	}
-	if this.MetricFamilyName != that1.MetricFamilyName {
+	if len(this.Values) != len(that1.Values) {
 		return false
 	}
-	if this.Help != that1.Help {
+	for i := range this.Values {
+		if !this.Values[i].Equal(&that1.Values[i]) {
+			return false
+		}
+	}
+	if this.ValuesCount != that1.ValuesCount {
 		return false
 	}
-	if this.Unit != that1.Unit {
+	return true
+}
+func (this *LabelValueSeriesCount) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LabelValueSeriesCount)
+	if !ok {
+		that2, ok := that.(LabelValueSeriesCount)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.LabelValue != that1.LabelValue {
+		return false
+	}
+	if this.SeriesCount != that1.SeriesCount {
 		return false
 	}
 	return true
 }
-func (this *Metric) Equal(that interface{}) bool {
+func (this *MetricsForLabelMatchersRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Metric)
+	that1, ok := that.(*MetricsForLabelMatchersRequest)
 	if !ok {
-		that2, ok := that.(Metric)
+		that2, ok := that.(MetricsForLabelMatchersRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2597,24 +3084,30 @@ func (this *Metric) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Labels) != len(that1.Labels) {
+	if this.StartTimestampMs != that1.StartTimestampMs {
 		return false
 	}
-	for i := range this.Labels {
-		if !this.Labels[i].Equal(that1.Labels[i]) {
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
+	}
+	if len(this.MatchersSet) != len(that1.MatchersSet) {
+		return false
+	}
+	for i := range this.MatchersSet {
+		if !this.MatchersSet[i].Equal(that1.MatchersSet[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *LabelMatcher) Equal(that interface{}) bool {
+func (this *MetricsForLabelMatchersResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelMatcher)
+	that1, ok := that.(*MetricsForLabelMatchersResponse)
 	if !ok {
-		that2, ok := that.(LabelMatcher)
+		that2, ok := that.(MetricsForLabelMatchersResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2626,25 +3119,74 @@ func (this *LabelMatcher) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Type != that1.Type {
+	if len(this.Metric) != len(that1.Metric) {
 		return false
 	}
-	if this.Name != that1.Name {
+	for i := range this.Metric {
+		if !this.Metric[i].Equal(that1.Metric[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *MetricsMetadataRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MetricsMetadataRequest)
+	if !ok {
+		that2, ok := that.(MetricsMetadataRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if this.Value != that1.Value {
+	return true
+}
+func (this *MetricsMetadataResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MetricsMetadataResponse)
+	if !ok {
+		that2, ok := that.(MetricsMetadataResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
+	if len(this.Metadata) != len(that1.Metadata) {
+		return false
+	}
+	for i := range this.Metadata {
+		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *TimeSeriesFile) Equal(that interface{}) bool {
+func (this *TimeSeriesChunk) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TimeSeriesFile)
+	that1, ok := that.(*TimeSeriesChunk)
 	if !ok {
-		that2, ok := that.(TimeSeriesFile)
+		that2, ok := that.(TimeSeriesChunk)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2662,1130 +3204,1588 @@ func (this *TimeSeriesFile) Equal(that interface{}) bool {
 	if this.UserId != that1.UserId {
 		return false
 	}
-	if this.Filename != that1.Filename {
+	if len(this.Labels) != len(that1.Labels) {
 		return false
 	}
-	if !bytes.Equal(this.Data, that1.Data) {
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
+		}
+	}
+	if len(this.Chunks) != len(that1.Chunks) {
 		return false
 	}
+	for i := range this.Chunks {
+		if !this.Chunks[i].Equal(&that1.Chunks[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *WriteRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *Chunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.WriteRequest{")
-	s = append(s, "Timeseries: "+fmt.Sprintf("%#v", this.Timeseries)+",\n")
-	s = append(s, "Source: "+fmt.Sprintf("%#v", this.Source)+",\n")
-	if this.Metadata != nil {
-		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+
+	that1, ok := that.(*Chunk)
+	if !ok {
+		that2, ok := that.(Chunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "SkipLabelNameValidation: "+fmt.Sprintf("%#v", this.SkipLabelNameValidation)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *WriteResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&client.WriteResponse{")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ReadRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.ReadRequest{")
-	if this.Queries != nil {
-		s = append(s, "Queries: "+fmt.Sprintf("%#v", this.Queries)+",\n")
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ReadResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Encoding != that1.Encoding {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.ReadResponse{")
-	if this.Results != nil {
-		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *QueryRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *TransferChunksResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.QueryRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+
+	that1, ok := that.(*TransferChunksResponse)
+	if !ok {
+		that2, ok := that.(TransferChunksResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
 }
-func (this *QueryResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *TimeSeries) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.QueryResponse{")
-	if this.Timeseries != nil {
-		vs := make([]*TimeSeries, len(this.Timeseries))
-		for i := range vs {
-			vs[i] = &this.Timeseries[i]
+
+	that1, ok := that.(*TimeSeries)
+	if !ok {
+		that2, ok := that.(TimeSeries)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *QueryStreamResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.QueryStreamResponse{")
-	if this.Chunkseries != nil {
-		vs := make([]*TimeSeriesChunk, len(this.Chunkseries))
-		for i := range vs {
-			vs[i] = &this.Chunkseries[i]
-		}
-		s = append(s, "Chunkseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	if len(this.Labels) != len(that1.Labels) {
+		return false
 	}
-	if this.Timeseries != nil {
-		vs := make([]*TimeSeries, len(this.Timeseries))
-		for i := range vs {
-			vs[i] = &this.Timeseries[i]
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
 		}
-		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
-	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelValuesRequest) GoString() string {
-	if this == nil {
-		return "nil"
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.LabelValuesRequest{")
-	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelValuesResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.Samples) != len(that1.Samples) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelValuesResponse{")
-	s = append(s, "LabelValues: "+fmt.Sprintf("%#v", this.LabelValues)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelNamesRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.Samples {
+		if !this.Samples[i].Equal(&that1.Samples[i]) {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.LabelNamesRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelNamesResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.Exemplars) != len(that1.Exemplars) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelNamesResponse{")
-	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *UserStatsRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.Exemplars {
+		if !this.Exemplars[i].Equal(&that1.Exemplars[i]) {
+			return false
+		}
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&client.UserStatsRequest{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *UserStatsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *LabelPair) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.UserStatsResponse{")
-	s = append(s, "IngestionRate: "+fmt.Sprintf("%#v", this.IngestionRate)+",\n")
-	s = append(s, "NumSeries: "+fmt.Sprintf("%#v", this.NumSeries)+",\n")
-	s = append(s, "ApiIngestionRate: "+fmt.Sprintf("%#v", this.ApiIngestionRate)+",\n")
-	s = append(s, "RuleIngestionRate: "+fmt.Sprintf("%#v", this.RuleIngestionRate)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *UserIDStatsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*LabelPair)
+	if !ok {
+		that2, ok := that.(LabelPair)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.UserIDStatsResponse{")
-	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
-	if this.Data != nil {
-		s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *UsersStatsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if !bytes.Equal(this.Name, that1.Name) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.UsersStatsResponse{")
-	if this.Stats != nil {
-		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
+	if !bytes.Equal(this.Value, that1.Value) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *MetricsForLabelMatchersRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *Sample) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.MetricsForLabelMatchersRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.MatchersSet != nil {
-		s = append(s, "MatchersSet: "+fmt.Sprintf("%#v", this.MatchersSet)+",\n")
+
+	that1, ok := that.(*Sample)
+	if !ok {
+		that2, ok := that.(Sample)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *MetricsForLabelMatchersResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.MetricsForLabelMatchersResponse{")
-	if this.Metric != nil {
-		s = append(s, "Metric: "+fmt.Sprintf("%#v", this.Metric)+",\n")
+	if this.Value != that1.Value {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *MetricsMetadataRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.TimestampMs != that1.TimestampMs {
+		return false
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&client.MetricsMetadataRequest{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *MetricsMetadataResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *Exemplar) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.MetricsMetadataResponse{")
-	if this.Metadata != nil {
-		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+
+	that1, ok := that.(*Exemplar)
+	if !ok {
+		that2, ok := that.(Exemplar)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Labels) != len(that1.Labels) {
+		return false
+	}
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
+		}
+	}
+	if this.Value != that1.Value {
+		return false
+	}
+	if this.TimestampMs != that1.TimestampMs {
+		return false
+	}
+	return true
 }
-func (this *TimeSeriesChunk) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *LabelMatchers) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.TimeSeriesChunk{")
-	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
-	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
-	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
-	if this.Chunks != nil {
-		vs := make([]*Chunk, len(this.Chunks))
-		for i := range vs {
-			vs[i] = &this.Chunks[i]
+
+	that1, ok := that.(*LabelMatchers)
+	if !ok {
+		that2, ok := that.(LabelMatchers)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		s = append(s, "Chunks: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Matchers) != len(that1.Matchers) {
+		return false
+	}
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
+	}
+	return true
 }
-func (this *Chunk) GoString() string {
+func (this *MetricMetadata) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MetricMetadata)
+	if !ok {
+		that2, ok := that.(MetricMetadata)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if this.MetricFamilyName != that1.MetricFamilyName {
+		return false
+	}
+	if this.Help != that1.Help {
+		return false
+	}
+	if this.Unit != that1.Unit {
+		return false
+	}
+	return true
+}
+func (this *Metric) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Metric)
+	if !ok {
+		that2, ok := that.(Metric)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Labels) != len(that1.Labels) {
+		return false
+	}
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *LabelMatcher) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LabelMatcher)
+	if !ok {
+		that2, ok := that.(LabelMatcher)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if this.Name != that1.Name {
+		return false
+	}
+	if this.Value != that1.Value {
+		return false
+	}
+	return true
+}
+func (this *TimeSeriesFile) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TimeSeriesFile)
+	if !ok {
+		that2, ok := that.(TimeSeriesFile)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.FromIngesterId != that1.FromIngesterId {
+		return false
+	}
+	if this.UserId != that1.UserId {
+		return false
+	}
+	if this.Filename != that1.Filename {
+		return false
+	}
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
+	}
+	return true
+}
+func (this *WriteRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 8)
-	s = append(s, "&client.Chunk{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	s = append(s, "Encoding: "+fmt.Sprintf("%#v", this.Encoding)+",\n")
-	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "&client.WriteRequest{")
+	s = append(s, "Timeseries: "+fmt.Sprintf("%#v", this.Timeseries)+",\n")
+	s = append(s, "Source: "+fmt.Sprintf("%#v", this.Source)+",\n")
+	if this.Metadata != nil {
+		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+	}
+	s = append(s, "SkipLabelNameValidation: "+fmt.Sprintf("%#v", this.SkipLabelNameValidation)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *TransferChunksResponse) GoString() string {
+func (this *WriteResponse) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 4)
-	s = append(s, "&client.TransferChunksResponse{")
+	s = append(s, "&client.WriteResponse{")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *TimeSeries) GoString() string {
+func (this *ReadRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.TimeSeries{")
-	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
-	if this.Samples != nil {
-		vs := make([]*Sample, len(this.Samples))
-		for i := range vs {
-			vs[i] = &this.Samples[i]
-		}
-		s = append(s, "Samples: "+fmt.Sprintf("%#v", vs)+",\n")
+	s := make([]string, 0, 5)
+	s = append(s, "&client.ReadRequest{")
+	if this.Queries != nil {
+		s = append(s, "Queries: "+fmt.Sprintf("%#v", this.Queries)+",\n")
 	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *LabelPair) GoString() string {
+func (this *ReadResponse) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.LabelPair{")
-	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
-	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s := make([]string, 0, 5)
+	s = append(s, "&client.ReadResponse{")
+	if this.Results != nil {
+		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *Sample) GoString() string {
+func (this *QueryRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.Sample{")
-	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
-	s = append(s, "TimestampMs: "+fmt.Sprintf("%#v", this.TimestampMs)+",\n")
+	s := make([]string, 0, 7)
+	s = append(s, "&client.QueryRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *LabelMatchers) GoString() string {
+func (this *QueryResponse) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelMatchers{")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	s = append(s, "&client.QueryResponse{")
+	if this.Timeseries != nil {
+		vs := make([]*TimeSeries, len(this.Timeseries))
+		for i := range vs {
+			vs[i] = &this.Timeseries[i]
+		}
+		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *MetricMetadata) GoString() string {
+func (this *QueryStreamResponse) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.MetricMetadata{")
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	s = append(s, "MetricFamilyName: "+fmt.Sprintf("%#v", this.MetricFamilyName)+",\n")
-	s = append(s, "Help: "+fmt.Sprintf("%#v", this.Help)+",\n")
-	s = append(s, "Unit: "+fmt.Sprintf("%#v", this.Unit)+",\n")
+	s := make([]string, 0, 6)
+	s = append(s, "&client.QueryStreamResponse{")
+	if this.Chunkseries != nil {
+		vs := make([]*TimeSeriesChunk, len(this.Chunkseries))
+		for i := range vs {
+			vs[i] = &this.Chunkseries[i]
+		}
+		s = append(s, "Chunkseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	if this.Timeseries != nil {
+		vs := make([]*TimeSeries, len(this.Timeseries))
+		for i := range vs {
+			vs[i] = &this.Timeseries[i]
+		}
+		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *Metric) GoString() string {
+func (this *ExemplarQueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&client.ExemplarQueryRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ExemplarQueryResponse) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 5)
-	s = append(s, "&client.Metric{")
-	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	s = append(s, "&client.ExemplarQueryResponse{")
+	if this.Timeseries != nil {
+		vs := make([]*TimeSeries, len(this.Timeseries))
+		for i := range vs {
+			vs[i] = &this.Timeseries[i]
+		}
+		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *LabelMatcher) GoString() string {
+func (this *LabelValuesRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 7)
-	s = append(s, "&client.LabelMatcher{")
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
-	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "&client.LabelValuesRequest{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *TimeSeriesFile) GoString() string {
+func (this *LabelValuesResponse) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.TimeSeriesFile{")
-	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
-	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
-	s = append(s, "Filename: "+fmt.Sprintf("%#v", this.Filename)+",\n")
-	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelValuesResponse{")
+	s = append(s, "LabelValues: "+fmt.Sprintf("%#v", this.LabelValues)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func valueToGoStringCortex(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
+func (this *LabelNamesRequest) GoString() string {
+	if this == nil {
 		return "nil"
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// IngesterClient is the client API for Ingester service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type IngesterClient interface {
-	Push(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error)
-	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
-	QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error)
-	LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error)
-	LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error)
-	UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error)
-	AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error)
-	MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error)
-	MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error)
-	// TransferChunks allows leaving ingester (client) to stream chunks directly to joining ingesters (server).
-	TransferChunks(ctx context.Context, opts ...grpc.CallOption) (Ingester_TransferChunksClient, error)
-}
-
-type ingesterClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewIngesterClient(cc *grpc.ClientConn) IngesterClient {
-	return &ingesterClient{cc}
+	s := make([]string, 0, 6)
+	s = append(s, "&client.LabelNamesRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *ingesterClient) Push(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error) {
-	out := new(WriteResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/Push", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *LabelNamesResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return out, nil
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelNamesResponse{")
+	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *ingesterClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error) {
-	out := new(QueryResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/Query", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *UserStatsRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return out, nil
+	s := make([]string, 0, 4)
+	s = append(s, "&client.UserStatsRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *ingesterClient) QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[0], "/cortex.Ingester/QueryStream", opts...)
-	if err != nil {
-		return nil, err
+func (this *UserStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	x := &ingesterQueryStreamClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	s := make([]string, 0, 8)
+	s = append(s, "&client.UserStatsResponse{")
+	s = append(s, "IngestionRate: "+fmt.Sprintf("%#v", this.IngestionRate)+",\n")
+	s = append(s, "NumSeries: "+fmt.Sprintf("%#v", this.NumSeries)+",\n")
+	s = append(s, "ApiIngestionRate: "+fmt.Sprintf("%#v", this.ApiIngestionRate)+",\n")
+	s = append(s, "RuleIngestionRate: "+fmt.Sprintf("%#v", this.RuleIngestionRate)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *UserIDStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	s := make([]string, 0, 6)
+	s = append(s, "&client.UserIDStatsResponse{")
+	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
+	if this.Data != nil {
+		s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
 	}
-	return x, nil
-}
-
-type Ingester_QueryStreamClient interface {
-	Recv() (*QueryStreamResponse, error)
-	grpc.ClientStream
-}
-
-type ingesterQueryStreamClient struct {
-	grpc.ClientStream
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (x *ingesterQueryStreamClient) Recv() (*QueryStreamResponse, error) {
-	m := new(QueryStreamResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (this *UsersStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return m, nil
-}
-
-func (c *ingesterClient) LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error) {
-	out := new(LabelValuesResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelValues", in, out, opts...)
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 5)
+	s = append(s, "&client.UsersStatsResponse{")
+	if this.Stats != nil {
+		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
 	}
-	return out, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *ingesterClient) LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error) {
-	out := new(LabelNamesResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelNames", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *LabelNamesCardinalityRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return out, nil
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelNamesCardinalityRequest{")
+	s = append(s, "MaxLabelValues: "+fmt.Sprintf("%#v", this.MaxLabelValues)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *ingesterClient) UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error) {
-	out := new(UserStatsResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/UserStats", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *LabelNamesCardinalityResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return out, nil
-}
-
-func (c *ingesterClient) AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error) {
-	out := new(UsersStatsResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/AllUserStats", in, out, opts...)
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelNamesCardinalityResponse{")
+	s = append(s, "NumSeries: "+fmt.Sprintf("%#v", this.NumSeries)+",\n")
+	if this.LabelNames != nil {
+		vs := make([]*LabelNameValues, len(this.LabelNames))
+		for i := range vs {
+			vs[i] = &this.LabelNames[i]
+		}
+		s = append(s, "LabelNames: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return out, nil
+	if this.MetricNames != nil {
+		vs := make([]*LabelValueSeriesCount, len(this.MetricNames))
+		for i := range vs {
+			vs[i] = &this.MetricNames[i]
+		}
+		s = append(s, "MetricNames: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *ingesterClient) MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error) {
-	out := new(MetricsForLabelMatchersResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsForLabelMatchers", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *LabelNameValues) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return out, nil
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelNameValues{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	s = append(s, "Values: "+fmt.Sprintf("%#v", this.Values)+",\n")
+	s = append(s, "ValuesCount: "+fmt.Sprintf("%#v", this.ValuesCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (c *ingesterClient) MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error) {
-	out := new(MetricsMetadataResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsMetadata", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *ingesterClient) TransferChunks(ctx context.Context, opts ...grpc.CallOption) (Ingester_TransferChunksClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[1], "/cortex.Ingester/TransferChunks", opts...)
-	if err != nil {
-		return nil, err
+func (this *LabelValuesCardinalityRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	x := &ingesterTransferChunksClient{stream}
-	return x, nil
-}
-
-type Ingester_TransferChunksClient interface {
-	Send(*TimeSeriesChunk) error
-	CloseAndRecv() (*TransferChunksResponse, error)
-	grpc.ClientStream
-}
-
-type ingesterTransferChunksClient struct {
-	grpc.ClientStream
-}
-
-func (x *ingesterTransferChunksClient) Send(m *TimeSeriesChunk) error {
-	return x.ClientStream.SendMsg(m)
+	s := make([]string, 0, 6)
+	s = append(s, "&client.LabelValuesCardinalityRequest{")
+	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
+	s = append(s, "MaxLabelValues: "+fmt.Sprintf("%#v", this.MaxLabelValues)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (x *ingesterTransferChunksClient) CloseAndRecv() (*TransferChunksResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (this *LabelValuesCardinalityResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	m := new(TransferChunksResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelValuesCardinalityResponse{")
+	if this.LabelNames != nil {
+		vs := make([]*LabelNameSeriesCount, len(this.LabelNames))
+		for i := range vs {
+			vs[i] = &this.LabelNames[i]
+		}
+		s = append(s, "LabelNames: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return m, nil
-}
-
-// IngesterServer is the server API for Ingester service.
-type IngesterServer interface {
-	Push(context.Context, *WriteRequest) (*WriteResponse, error)
-	Query(context.Context, *QueryRequest) (*QueryResponse, error)
-	QueryStream(*QueryRequest, Ingester_QueryStreamServer) error
-	LabelValues(context.Context, *LabelValuesRequest) (*LabelValuesResponse, error)
-	LabelNames(context.Context, *LabelNamesRequest) (*LabelNamesResponse, error)
-	UserStats(context.Context, *UserStatsRequest) (*UserStatsResponse, error)
-	AllUserStats(context.Context, *UserStatsRequest) (*UsersStatsResponse, error)
-	MetricsForLabelMatchers(context.Context, *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error)
-	MetricsMetadata(context.Context, *MetricsMetadataRequest) (*MetricsMetadataResponse, error)
-	// TransferChunks allows leaving ingester (client) to stream chunks directly to joining ingesters (server).
-	TransferChunks(Ingester_TransferChunksServer) error
-}
-
-// UnimplementedIngesterServer can be embedded to have forward compatible implementations.
-type UnimplementedIngesterServer struct {
-}
-
-func (*UnimplementedIngesterServer) Push(ctx context.Context, req *WriteRequest) (*WriteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
-}
-func (*UnimplementedIngesterServer) Query(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
-}
-func (*UnimplementedIngesterServer) QueryStream(req *QueryRequest, srv Ingester_QueryStreamServer) error {
-	return status.Errorf(codes.Unimplemented, "method QueryStream not implemented")
-}
-func (*UnimplementedIngesterServer) LabelValues(ctx context.Context, req *LabelValuesRequest) (*LabelValuesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LabelValues not implemented")
-}
-func (*UnimplementedIngesterServer) LabelNames(ctx context.Context, req *LabelNamesRequest) (*LabelNamesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LabelNames not implemented")
-}
-func (*UnimplementedIngesterServer) UserStats(ctx context.Context, req *UserStatsRequest) (*UserStatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UserStats not implemented")
-}
-func (*UnimplementedIngesterServer) AllUserStats(ctx context.Context, req *UserStatsRequest) (*UsersStatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AllUserStats not implemented")
-}
-func (*UnimplementedIngesterServer) MetricsForLabelMatchers(ctx context.Context, req *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MetricsForLabelMatchers not implemented")
-}
-func (*UnimplementedIngesterServer) MetricsMetadata(ctx context.Context, req *MetricsMetadataRequest) (*MetricsMetadataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MetricsMetadata not implemented")
-}
-func (*UnimplementedIngesterServer) TransferChunks(srv Ingester_TransferChunksServer) error {
-	return status.Errorf(codes.Unimplemented, "method TransferChunks not implemented")
-}
-
-func RegisterIngesterServer(s *grpc.Server, srv IngesterServer) {
-	s.RegisterService(&_Ingester_serviceDesc, srv)
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WriteRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IngesterServer).Push(ctx, in)
+func (this *LabelNameSeriesCount) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/Push",
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelNameSeriesCount{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	if this.Values != nil {
+		vs := make([]*LabelValueSeriesCount, len(this.Values))
+		for i := range vs {
+			vs[i] = &this.Values[i]
+		}
+		s = append(s, "Values: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).Push(ctx, req.(*WriteRequest))
+	s = append(s, "ValuesCount: "+fmt.Sprintf("%#v", this.ValuesCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelValueSeriesCount) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return interceptor(ctx, in, info, handler)
+	s := make([]string, 0, 6)
+	s = append(s, "&client.LabelValueSeriesCount{")
+	s = append(s, "LabelValue: "+fmt.Sprintf("%#v", this.LabelValue)+",\n")
+	s = append(s, "SeriesCount: "+fmt.Sprintf("%#v", this.SeriesCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *MetricsForLabelMatchersRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).Query(ctx, in)
+	s := make([]string, 0, 7)
+	s = append(s, "&client.MetricsForLabelMatchersRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.MatchersSet != nil {
+		s = append(s, "MatchersSet: "+fmt.Sprintf("%#v", this.MatchersSet)+",\n")
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/Query",
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *MetricsForLabelMatchersResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).Query(ctx, req.(*QueryRequest))
+	s := make([]string, 0, 5)
+	s = append(s, "&client.MetricsForLabelMatchersResponse{")
+	if this.Metric != nil {
+		s = append(s, "Metric: "+fmt.Sprintf("%#v", this.Metric)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_QueryStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(QueryRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (this *MetricsMetadataRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return srv.(IngesterServer).QueryStream(m, &ingesterQueryStreamServer{stream})
-}
-
-type Ingester_QueryStreamServer interface {
-	Send(*QueryStreamResponse) error
-	grpc.ServerStream
-}
-
-type ingesterQueryStreamServer struct {
-	grpc.ServerStream
-}
-
-func (x *ingesterQueryStreamServer) Send(m *QueryStreamResponse) error {
-	return x.ServerStream.SendMsg(m)
+	s := make([]string, 0, 4)
+	s = append(s, "&client.MetricsMetadataRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_LabelValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LabelValuesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IngesterServer).LabelValues(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/LabelValues",
+func (this *MetricsMetadataResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).LabelValues(ctx, req.(*LabelValuesRequest))
+	s := make([]string, 0, 5)
+	s = append(s, "&client.MetricsMetadataResponse{")
+	if this.Metadata != nil {
+		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_LabelNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LabelNamesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IngesterServer).LabelNames(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/LabelNames",
+func (this *TimeSeriesChunk) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).LabelNames(ctx, req.(*LabelNamesRequest))
+	s := make([]string, 0, 8)
+	s = append(s, "&client.TimeSeriesChunk{")
+	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
+	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if this.Chunks != nil {
+		vs := make([]*Chunk, len(this.Chunks))
+		for i := range vs {
+			vs[i] = &this.Chunks[i]
+		}
+		s = append(s, "Chunks: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_UserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserStatsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *Chunk) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).UserStats(ctx, in)
+	s := make([]string, 0, 8)
+	s = append(s, "&client.Chunk{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	s = append(s, "Encoding: "+fmt.Sprintf("%#v", this.Encoding)+",\n")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TransferChunksResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/UserStats",
+	s := make([]string, 0, 4)
+	s = append(s, "&client.TransferChunksResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TimeSeries) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).UserStats(ctx, req.(*UserStatsRequest))
+	s := make([]string, 0, 7)
+	s = append(s, "&client.TimeSeries{")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if this.Samples != nil {
+		vs := make([]*Sample, len(this.Samples))
+		for i := range vs {
+			vs[i] = &this.Samples[i]
+		}
+		s = append(s, "Samples: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Ingester_AllUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserStatsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if this.Exemplars != nil {
+		vs := make([]*Exemplar, len(this.Exemplars))
+		for i := range vs {
+			vs[i] = &this.Exemplars[i]
+		}
+		s = append(s, "Exemplars: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).AllUserStats(ctx, in)
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelPair) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/AllUserStats",
+	s := make([]string, 0, 6)
+	s = append(s, "&client.LabelPair{")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Sample) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).AllUserStats(ctx, req.(*UserStatsRequest))
+	s := make([]string, 0, 6)
+	s = append(s, "&client.Sample{")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "TimestampMs: "+fmt.Sprintf("%#v", this.TimestampMs)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Exemplar) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return interceptor(ctx, in, info, handler)
+	s := make([]string, 0, 7)
+	s = append(s, "&client.Exemplar{")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "TimestampMs: "+fmt.Sprintf("%#v", this.TimestampMs)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_MetricsForLabelMatchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MetricsForLabelMatchersRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *LabelMatchers) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, in)
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelMatchers{")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/MetricsForLabelMatchers",
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *MetricMetadata) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, req.(*MetricsForLabelMatchersRequest))
+	s := make([]string, 0, 8)
+	s = append(s, "&client.MetricMetadata{")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "MetricFamilyName: "+fmt.Sprintf("%#v", this.MetricFamilyName)+",\n")
+	s = append(s, "Help: "+fmt.Sprintf("%#v", this.Help)+",\n")
+	s = append(s, "Unit: "+fmt.Sprintf("%#v", this.Unit)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Metric) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return interceptor(ctx, in, info, handler)
+	s := make([]string, 0, 5)
+	s = append(s, "&client.Metric{")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_MetricsMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MetricsMetadataRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *LabelMatcher) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).MetricsMetadata(ctx, in)
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelMatcher{")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TimeSeriesFile) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/MetricsMetadata",
+	s := make([]string, 0, 8)
+	s = append(s, "&client.TimeSeriesFile{")
+	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
+	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
+	s = append(s, "Filename: "+fmt.Sprintf("%#v", this.Filename)+",\n")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringCortex(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).MetricsMetadata(ctx, req.(*MetricsMetadataRequest))
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// IngesterClient is the client API for Ingester service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IngesterClient interface {
+	Push(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error)
+	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
+	QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error)
+	LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error)
+	LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error)
+	UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error)
+	AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error)
+	MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error)
+	MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error)
+	QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error)
+	LabelNamesCardinality(ctx context.Context, in *LabelNamesCardinalityRequest, opts ...grpc.CallOption) (*LabelNamesCardinalityResponse, error)
+	LabelValuesCardinality(ctx context.Context, in *LabelValuesCardinalityRequest, opts ...grpc.CallOption) (*LabelValuesCardinalityResponse, error)
+	// TransferChunks allows leaving ingester (client) to stream chunks directly to joining ingesters (server).
+	TransferChunks(ctx context.Context, opts ...grpc.CallOption) (Ingester_TransferChunksClient, error)
+}
+
+type ingesterClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewIngesterClient(cc *grpc.ClientConn) IngesterClient {
+	return &ingesterClient{cc}
+}
+
+func (c *ingesterClient) Push(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error) {
+	out := new(WriteResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/Push", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Ingester_TransferChunks_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(IngesterServer).TransferChunks(&ingesterTransferChunksServer{stream})
+func (c *ingesterClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error) {
+	out := new(QueryResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type Ingester_TransferChunksServer interface {
-	SendAndClose(*TransferChunksResponse) error
-	Recv() (*TimeSeriesChunk, error)
-	grpc.ServerStream
+func (c *ingesterClient) QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[0], "/cortex.Ingester/QueryStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ingesterQueryStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-type ingesterTransferChunksServer struct {
-	grpc.ServerStream
+type Ingester_QueryStreamClient interface {
+	Recv() (*QueryStreamResponse, error)
+	grpc.ClientStream
 }
 
-func (x *ingesterTransferChunksServer) SendAndClose(m *TransferChunksResponse) error {
-	return x.ServerStream.SendMsg(m)
+type ingesterQueryStreamClient struct {
+	grpc.ClientStream
 }
 
-func (x *ingesterTransferChunksServer) Recv() (*TimeSeriesChunk, error) {
-	m := new(TimeSeriesChunk)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func (x *ingesterQueryStreamClient) Recv() (*QueryStreamResponse, error) {
+	m := new(QueryStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-var _Ingester_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "cortex.Ingester",
-	HandlerType: (*IngesterServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Push",
-			Handler:    _Ingester_Push_Handler,
-		},
-		{
-			MethodName: "Query",
-			Handler:    _Ingester_Query_Handler,
-		},
-		{
-			MethodName: "LabelValues",
-			Handler:    _Ingester_LabelValues_Handler,
-		},
-		{
-			MethodName: "LabelNames",
-			Handler:    _Ingester_LabelNames_Handler,
-		},
-		{
-			MethodName: "UserStats",
-			Handler:    _Ingester_UserStats_Handler,
-		},
-		{
-			MethodName: "AllUserStats",
-			Handler:    _Ingester_AllUserStats_Handler,
-		},
-		{
-			MethodName: "MetricsForLabelMatchers",
-			Handler:    _Ingester_MetricsForLabelMatchers_Handler,
-		},
-		{
-			MethodName: "MetricsMetadata",
-			Handler:    _Ingester_MetricsMetadata_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "QueryStream",
-			Handler:       _Ingester_QueryStream_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "TransferChunks",
-			Handler:       _Ingester_TransferChunks_Handler,
-			ClientStreams: true,
-		},
-	},
-	Metadata: "cortex.proto",
+func (c *ingesterClient) LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error) {
+	out := new(LabelValuesResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelValues", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// PushOnlyIngesterClient is the client API for PushOnlyIngester service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PushOnlyIngesterClient interface {
-	Push(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error)
+func (c *ingesterClient) LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error) {
+	out := new(LabelNamesResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelNames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type pushOnlyIngesterClient struct {
-	cc *grpc.ClientConn
+func (c *ingesterClient) UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error) {
+	out := new(UserStatsResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/UserStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewPushOnlyIngesterClient(cc *grpc.ClientConn) PushOnlyIngesterClient {
-	return &pushOnlyIngesterClient{cc}
+func (c *ingesterClient) AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error) {
+	out := new(UsersStatsResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/AllUserStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *pushOnlyIngesterClient) Push(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error) {
-	out := new(WriteResponse)
-	err := c.cc.Invoke(ctx, "/cortex.PushOnlyIngester/Push", in, out, opts...)
+func (c *ingesterClient) MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error) {
+	out := new(MetricsForLabelMatchersResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsForLabelMatchers", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// PushOnlyIngesterServer is the server API for PushOnlyIngester service.
-type PushOnlyIngesterServer interface {
+func (c *ingesterClient) MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error) {
+	out := new(MetricsMetadataResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingesterClient) QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error) {
+	out := new(ExemplarQueryResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/QueryExemplars", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingesterClient) LabelNamesCardinality(ctx context.Context, in *LabelNamesCardinalityRequest, opts ...grpc.CallOption) (*LabelNamesCardinalityResponse, error) {
+	out := new(LabelNamesCardinalityResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelNamesCardinality", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingesterClient) LabelValuesCardinality(ctx context.Context, in *LabelValuesCardinalityRequest, opts ...grpc.CallOption) (*LabelValuesCardinalityResponse, error) {
+	out := new(LabelValuesCardinalityResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelValuesCardinality", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingesterClient) TransferChunks(ctx context.Context, opts ...grpc.CallOption) (Ingester_TransferChunksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[1], "/cortex.Ingester/TransferChunks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ingesterTransferChunksClient{stream}
+	return x, nil
+}
+
+type Ingester_TransferChunksClient interface {
+	Send(*TimeSeriesChunk) error
+	CloseAndRecv() (*TransferChunksResponse, error)
+	grpc.ClientStream
+}
+
+type ingesterTransferChunksClient struct {
+	grpc.ClientStream
+}
+
+func (x *ingesterTransferChunksClient) Send(m *TimeSeriesChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *ingesterTransferChunksClient) CloseAndRecv() (*TransferChunksResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(TransferChunksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IngesterServer is the server API for Ingester service.
+type IngesterServer interface {
 	Push(context.Context, *WriteRequest) (*WriteResponse, error)
+	Query(context.Context, *QueryRequest) (*QueryResponse, error)
+	QueryStream(*QueryRequest, Ingester_QueryStreamServer) error
+	LabelValues(context.Context, *LabelValuesRequest) (*LabelValuesResponse, error)
+	LabelNames(context.Context, *LabelNamesRequest) (*LabelNamesResponse, error)
+	UserStats(context.Context, *UserStatsRequest) (*UserStatsResponse, error)
+	AllUserStats(context.Context, *UserStatsRequest) (*UsersStatsResponse, error)
+	MetricsForLabelMatchers(context.Context, *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error)
+	MetricsMetadata(context.Context, *MetricsMetadataRequest) (*MetricsMetadataResponse, error)
+	QueryExemplars(context.Context, *ExemplarQueryRequest) (*ExemplarQueryResponse, error)
+	LabelNamesCardinality(context.Context, *LabelNamesCardinalityRequest) (*LabelNamesCardinalityResponse, error)
+	LabelValuesCardinality(context.Context, *LabelValuesCardinalityRequest) (*LabelValuesCardinalityResponse, error)
+	// TransferChunks allows leaving ingester (client) to stream chunks directly to joining ingesters (server).
+	TransferChunks(Ingester_TransferChunksServer) error
 }
 
-// UnimplementedPushOnlyIngesterServer can be embedded to have forward compatible implementations.
-type UnimplementedPushOnlyIngesterServer struct {
+// UnimplementedIngesterServer can be embedded to have forward compatible implementations.
+type UnimplementedIngesterServer struct {
 }
 
-func (*UnimplementedPushOnlyIngesterServer) Push(ctx context.Context, req *WriteRequest) (*WriteResponse, error) {
+func (*UnimplementedIngesterServer) Push(ctx context.Context, req *WriteRequest) (*WriteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
 }
+func (*UnimplementedIngesterServer) Query(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
+}
+func (*UnimplementedIngesterServer) QueryStream(req *QueryRequest, srv Ingester_QueryStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method QueryStream not implemented")
+}
+func (*UnimplementedIngesterServer) LabelValues(ctx context.Context, req *LabelValuesRequest) (*LabelValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelValues not implemented")
+}
+func (*UnimplementedIngesterServer) LabelNames(ctx context.Context, req *LabelNamesRequest) (*LabelNamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelNames not implemented")
+}
+func (*UnimplementedIngesterServer) UserStats(ctx context.Context, req *UserStatsRequest) (*UserStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserStats not implemented")
+}
+func (*UnimplementedIngesterServer) AllUserStats(ctx context.Context, req *UserStatsRequest) (*UsersStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllUserStats not implemented")
+}
+func (*UnimplementedIngesterServer) MetricsForLabelMatchers(ctx context.Context, req *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MetricsForLabelMatchers not implemented")
+}
+func (*UnimplementedIngesterServer) MetricsMetadata(ctx context.Context, req *MetricsMetadataRequest) (*MetricsMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MetricsMetadata not implemented")
+}
+func (*UnimplementedIngesterServer) QueryExemplars(ctx context.Context, req *ExemplarQueryRequest) (*ExemplarQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryExemplars not implemented")
+}
+func (*UnimplementedIngesterServer) LabelNamesCardinality(ctx context.Context, req *LabelNamesCardinalityRequest) (*LabelNamesCardinalityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelNamesCardinality not implemented")
+}
+func (*UnimplementedIngesterServer) LabelValuesCardinality(ctx context.Context, req *LabelValuesCardinalityRequest) (*LabelValuesCardinalityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelValuesCardinality not implemented")
+}
+func (*UnimplementedIngesterServer) TransferChunks(srv Ingester_TransferChunksServer) error {
+	return status.Errorf(codes.Unimplemented, "method TransferChunks not implemented")
+}
 
-func RegisterPushOnlyIngesterServer(s *grpc.Server, srv PushOnlyIngesterServer) {
-	s.RegisterService(&_PushOnlyIngester_serviceDesc, srv)
+func RegisterIngesterServer(s *grpc.Server, srv IngesterServer) {
+	s.RegisterService(&_Ingester_serviceDesc, srv)
 }
 
-func _PushOnlyIngester_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Ingester_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WriteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PushOnlyIngesterServer).Push(ctx, in)
+		return srv.(IngesterServer).Push(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/cortex.PushOnlyIngester/Push",
+		FullMethod: "/cortex.Ingester/Push",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PushOnlyIngesterServer).Push(ctx, req.(*WriteRequest))
+		return srv.(IngesterServer).Push(ctx, req.(*WriteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _PushOnlyIngester_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "cortex.PushOnlyIngester",
-	HandlerType: (*PushOnlyIngesterServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Push",
-			Handler:    _PushOnlyIngester_Push_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "cortex.proto",
+func _Ingester_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/Query",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).Query(ctx, req.(*QueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *WriteRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func _Ingester_QueryStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return dAtA[:n], nil
+	return srv.(IngesterServer).QueryStream(m, &ingesterQueryStreamServer{stream})
 }
 
-func (m *WriteRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Ingester_QueryStreamServer interface {
+	Send(*QueryStreamResponse) error
+	grpc.ServerStream
 }
 
-func (m *WriteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.SkipLabelNameValidation {
-		i--
-		if m.SkipLabelNameValidation {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x3e
-		i--
-		dAtA[i] = 0xc0
+type ingesterQueryStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *ingesterQueryStreamServer) Send(m *QueryStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Ingester_LabelValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Metadata) > 0 {
-		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCortex(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+	if interceptor == nil {
+		return srv.(IngesterServer).LabelValues(ctx, in)
 	}
-	if m.Source != 0 {
-		i = encodeVarintCortex(dAtA, i, uint64(m.Source))
-		i--
-		dAtA[i] = 0x10
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/LabelValues",
 	}
-	if len(m.Timeseries) > 0 {
-		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size := m.Timeseries[iNdEx].Size()
-				i -= size
-				if _, err := m.Timeseries[iNdEx].MarshalTo(dAtA[i:]); err != nil {
-					return 0, err
-				}
-				i = encodeVarintCortex(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).LabelValues(ctx, req.(*LabelValuesRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *WriteResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _Ingester_LabelNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelNamesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *WriteResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *WriteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+	if interceptor == nil {
+		return srv.(IngesterServer).LabelNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/LabelNames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).LabelNames(ctx, req.(*LabelNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReadRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _Ingester_UserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IngesterServer).UserStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/UserStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).UserStats(ctx, req.(*UserStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _Ingester_AllUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).AllUserStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/AllUserStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).AllUserStats(ctx, req.(*UserStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Queries) > 0 {
-		for iNdEx := len(m.Queries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Queries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCortex(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func _Ingester_MetricsForLabelMatchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetricsForLabelMatchersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	if interceptor == nil {
+		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/MetricsForLabelMatchers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, req.(*MetricsForLabelMatchersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReadResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _Ingester_MetricsMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetricsMetadataRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IngesterServer).MetricsMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/MetricsMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).MetricsMetadata(ctx, req.(*MetricsMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _Ingester_QueryExemplars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExemplarQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).QueryExemplars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/QueryExemplars",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).QueryExemplars(ctx, req.(*ExemplarQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReadResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Results) > 0 {
-		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCortex(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func _Ingester_LabelNamesCardinality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelNamesCardinalityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	if interceptor == nil {
+		return srv.(IngesterServer).LabelNamesCardinality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/LabelNamesCardinality",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).LabelNamesCardinality(ctx, req.(*LabelNamesCardinalityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *QueryRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _Ingester_LabelValuesCardinality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelValuesCardinalityRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IngesterServer).LabelValuesCardinality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/LabelValuesCardinality",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).LabelValuesCardinality(ctx, req.(*LabelValuesCardinalityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _Ingester_TransferChunks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(IngesterServer).TransferChunks(&ingesterTransferChunksServer{stream})
 }
 
-func (m *QueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+type Ingester_TransferChunksServer interface {
+	SendAndClose(*TransferChunksResponse) error
+	Recv() (*TimeSeriesChunk, error)
+	grpc.ServerStream
+}
+
+type ingesterTransferChunksServer struct {
+	grpc.ServerStream
+}
+
+func (x *ingesterTransferChunksServer) SendAndClose(m *TransferChunksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *ingesterTransferChunksServer) Recv() (*TimeSeriesChunk, error) {
+	m := new(TimeSeriesChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Ingester_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cortex.Ingester",
+	HandlerType: (*IngesterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Push",
+			Handler:    _Ingester_Push_Handler,
+		},
+		{
+			MethodName: "Query",
+			Handler:    _Ingester_Query_Handler,
+		},
+		{
+			MethodName: "LabelValues",
+			Handler:    _Ingester_LabelValues_Handler,
+		},
+		{
+			MethodName: "LabelNames",
+			Handler:    _Ingester_LabelNames_Handler,
+		},
+		{
+			MethodName: "UserStats",
+			Handler:    _Ingester_UserStats_Handler,
+		},
+		{
+			MethodName: "AllUserStats",
+			Handler:    _Ingester_AllUserStats_Handler,
+		},
+		{
+			MethodName: "MetricsForLabelMatchers",
+			Handler:    _Ingester_MetricsForLabelMatchers_Handler,
+		},
+		{
+			MethodName: "MetricsMetadata",
+			Handler:    _Ingester_MetricsMetadata_Handler,
+		},
+		{
+			MethodName: "QueryExemplars",
+			Handler:    _Ingester_QueryExemplars_Handler,
+		},
+		{
+			MethodName: "LabelNamesCardinality",
+			Handler:    _Ingester_LabelNamesCardinality_Handler,
+		},
+		{
+			MethodName: "LabelValuesCardinality",
+			Handler:    _Ingester_LabelValuesCardinality_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "QueryStream",
+			Handler:       _Ingester_QueryStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TransferChunks",
+			Handler:       _Ingester_TransferChunks_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "cortex.proto",
+}
+
+// PushOnlyIngesterClient is the client API for PushOnlyIngester service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PushOnlyIngesterClient interface {
+	Push(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error)
+}
+
+type pushOnlyIngesterClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPushOnlyIngesterClient(cc *grpc.ClientConn) PushOnlyIngesterClient {
+	return &pushOnlyIngesterClient{cc}
+}
+
+func (c *pushOnlyIngesterClient) Push(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error) {
+	out := new(WriteResponse)
+	err := c.cc.Invoke(ctx, "/cortex.PushOnlyIngester/Push", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PushOnlyIngesterServer is the server API for PushOnlyIngester service.
+type PushOnlyIngesterServer interface {
+	Push(context.Context, *WriteRequest) (*WriteResponse, error)
+}
+
+// UnimplementedPushOnlyIngesterServer can be embedded to have forward compatible implementations.
+type UnimplementedPushOnlyIngesterServer struct {
+}
+
+func (*UnimplementedPushOnlyIngesterServer) Push(ctx context.Context, req *WriteRequest) (*WriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
+}
+
+func RegisterPushOnlyIngesterServer(s *grpc.Server, srv PushOnlyIngesterServer) {
+	s.RegisterService(&_PushOnlyIngester_serviceDesc, srv)
+}
+
+func _PushOnlyIngester_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushOnlyIngesterServer).Push(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.PushOnlyIngester/Push",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushOnlyIngesterServer).Push(ctx, req.(*WriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PushOnlyIngester_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cortex.PushOnlyIngester",
+	HandlerType: (*PushOnlyIngesterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Push",
+			Handler:    _PushOnlyIngester_Push_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cortex.proto",
+}
+
+func (m *WriteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WriteRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WriteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Matchers) > 0 {
-		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+	if m.SkipLabelNameValidation {
+		i--
+		if m.SkipLabelNameValidation {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xc0
+	}
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -3796,20 +4796,29 @@ func (m *QueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0x1a
 		}
 	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintCortex(dAtA, i, uint64(m.EndTimestampMs))
+	if m.Source != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.Source))
 		i--
 		dAtA[i] = 0x10
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintCortex(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Timeseries[iNdEx].Size()
+				i -= size
+				if _, err := m.Timeseries[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *QueryResponse) Marshal() (dAtA []byte, err error) {
+func (m *WriteResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3819,34 +4828,20 @@ func (m *QueryResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *WriteResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *QueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *WriteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Timeseries) > 0 {
-		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCortex(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *QueryStreamResponse) Marshal() (dAtA []byte, err error) {
+func (m *ReadRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3856,34 +4851,20 @@ func (m *QueryStreamResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *QueryStreamResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *QueryStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ReadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Timeseries) > 0 {
-		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintCortex(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if len(m.Chunkseries) > 0 {
-		for iNdEx := len(m.Chunkseries) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Queries) > 0 {
+		for iNdEx := len(m.Queries) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Chunkseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Queries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -3897,7 +4878,263 @@ func (m *QueryStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *LabelValuesRequest) Marshal() (dAtA []byte, err error) {
+func (m *ReadResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryStreamResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryStreamResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Chunkseries) > 0 {
+		for iNdEx := len(m.Chunkseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Chunkseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExemplarQueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExemplarQueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExemplarQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExemplarQueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExemplarQueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExemplarQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValuesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4182,7 +5419,7 @@ func (m *UsersStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *MetricsForLabelMatchersRequest) Marshal() (dAtA []byte, err error) {
+func (m *LabelNamesCardinalityRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4192,19 +5429,305 @@ func (m *MetricsForLabelMatchersRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MetricsForLabelMatchersRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelNamesCardinalityRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MetricsForLabelMatchersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LabelNamesCardinalityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.MatchersSet) > 0 {
-		for iNdEx := len(m.MatchersSet) - 1; iNdEx >= 0; iNdEx-- {
-			{
+	if m.MaxLabelValues != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.MaxLabelValues))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelNamesCardinalityResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelNamesCardinalityResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelNamesCardinalityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MetricNames) > 0 {
+		for iNdEx := len(m.MetricNames) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MetricNames[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.LabelNames) > 0 {
+		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LabelNames[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.NumSeries != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.NumSeries))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelNameValues) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelNameValues) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelNameValues) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ValuesCount != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.ValuesCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Values[iNdEx])
+			copy(dAtA[i:], m.Values[iNdEx])
+			i = encodeVarintCortex(dAtA, i, uint64(len(m.Values[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintCortex(dAtA, i, uint64(len(m.LabelName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValuesCardinalityRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValuesCardinalityRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesCardinalityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxLabelValues != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.MaxLabelValues))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.LabelNames) > 0 {
+		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LabelNames[iNdEx])
+			copy(dAtA[i:], m.LabelNames[iNdEx])
+			i = encodeVarintCortex(dAtA, i, uint64(len(m.LabelNames[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValuesCardinalityResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValuesCardinalityResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesCardinalityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LabelNames) > 0 {
+		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LabelNames[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelNameSeriesCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelNameSeriesCount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelNameSeriesCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ValuesCount != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.ValuesCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Values[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintCortex(dAtA, i, uint64(len(m.LabelName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValueSeriesCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValueSeriesCount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValueSeriesCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SeriesCount != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.SeriesCount))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.LabelValue) > 0 {
+		i -= len(m.LabelValue)
+		copy(dAtA[i:], m.LabelValue)
+		i = encodeVarintCortex(dAtA, i, uint64(len(m.LabelValue)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricsForLabelMatchersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricsForLabelMatchersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricsForLabelMatchersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MatchersSet) > 0 {
+		for iNdEx := len(m.MatchersSet) - 1; iNdEx >= 0; iNdEx-- {
+			{
 				size, err := m.MatchersSet[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
@@ -4479,6 +6002,20 @@ func (m *TimeSeries) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Exemplars) > 0 {
+		for iNdEx := len(m.Exemplars) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Exemplars[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.Samples) > 0 {
 		for iNdEx := len(m.Samples) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -4581,7 +6118,55 @@ func (m *Sample) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *LabelMatchers) Marshal() (dAtA []byte, err error) {
+func (m *Exemplar) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Exemplar) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Exemplar) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TimestampMs != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.TimestampMs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Value != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Value))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Labels[iNdEx].Size()
+				i -= size
+				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelMatchers) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4931,6 +6516,42 @@ func (m *QueryStreamResponse) Size() (n int) {
 	return n
 }
 
+func (m *ExemplarQueryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovCortex(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovCortex(uint64(m.EndTimestampMs))
+	}
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ExemplarQueryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for _, e := range m.Timeseries {
+			l = e.Size()
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *LabelValuesRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5057,6 +6678,135 @@ func (m *UsersStatsResponse) Size() (n int) {
 	return n
 }
 
+func (m *LabelNamesCardinalityRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxLabelValues != 0 {
+		n += 1 + sovCortex(uint64(m.MaxLabelValues))
+	}
+	return n
+}
+
+func (m *LabelNamesCardinalityResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NumSeries != 0 {
+		n += 1 + sovCortex(uint64(m.NumSeries))
+	}
+	if len(m.LabelNames) > 0 {
+		for _, e := range m.LabelNames {
+			l = e.Size()
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
+	if len(m.MetricNames) > 0 {
+		for _, e := range m.MetricNames {
+			l = e.Size()
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelNameValues) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovCortex(uint64(l))
+	}
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			l = len(s)
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
+	if m.ValuesCount != 0 {
+		n += 1 + sovCortex(uint64(m.ValuesCount))
+	}
+	return n
+}
+
+func (m *LabelValuesCardinalityRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelNames) > 0 {
+		for _, s := range m.LabelNames {
+			l = len(s)
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
+	if m.MaxLabelValues != 0 {
+		n += 1 + sovCortex(uint64(m.MaxLabelValues))
+	}
+	return n
+}
+
+func (m *LabelValuesCardinalityResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelNames) > 0 {
+		for _, e := range m.LabelNames {
+			l = e.Size()
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelNameSeriesCount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovCortex(uint64(l))
+	}
+	if len(m.Values) > 0 {
+		for _, e := range m.Values {
+			l = e.Size()
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
+	if m.ValuesCount != 0 {
+		n += 1 + sovCortex(uint64(m.ValuesCount))
+	}
+	return n
+}
+
+func (m *LabelValueSeriesCount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelValue)
+	if l > 0 {
+		n += 1 + l + sovCortex(uint64(l))
+	}
+	if m.SeriesCount != 0 {
+		n += 1 + sovCortex(uint64(m.SeriesCount))
+	}
+	return n
+}
+
 func (m *MetricsForLabelMatchersRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5195,6 +6945,12 @@ func (m *TimeSeries) Size() (n int) {
 			n += 1 + l + sovCortex(uint64(l))
 		}
 	}
+	if len(m.Exemplars) > 0 {
+		for _, e := range m.Exemplars {
+			l = e.Size()
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -5230,6 +6986,27 @@ func (m *Sample) Size() (n int) {
 	return n
 }
 
+func (m *Exemplar) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
+	if m.Value != 0 {
+		n += 9
+	}
+	if m.TimestampMs != 0 {
+		n += 1 + sovCortex(uint64(m.TimestampMs))
+	}
+	return n
+}
+
 func (m *LabelMatchers) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5445,6 +7222,38 @@ func (this *QueryStreamResponse) String() string {
 	}, "")
 	return s
 }
+func (this *ExemplarQueryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatchers{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&ExemplarQueryRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ExemplarQueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTimeseries := "[]TimeSeries{"
+	for _, f := range this.Timeseries {
+		repeatedStringForTimeseries += strings.Replace(strings.Replace(f.String(), "TimeSeries", "TimeSeries", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForTimeseries += "}"
+	s := strings.Join([]string{`&ExemplarQueryResponse{`,
+		`Timeseries:` + repeatedStringForTimeseries + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *LabelValuesRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -5536,52 +7345,150 @@ func (this *UsersStatsResponse) String() string {
 	}, "")
 	return s
 }
-func (this *MetricsForLabelMatchersRequest) String() string {
+func (this *LabelNamesCardinalityRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	repeatedStringForMatchersSet := "[]*LabelMatchers{"
-	for _, f := range this.MatchersSet {
-		repeatedStringForMatchersSet += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
-	}
-	repeatedStringForMatchersSet += "}"
-	s := strings.Join([]string{`&MetricsForLabelMatchersRequest{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`MatchersSet:` + repeatedStringForMatchersSet + `,`,
+	s := strings.Join([]string{`&LabelNamesCardinalityRequest{`,
+		`MaxLabelValues:` + fmt.Sprintf("%v", this.MaxLabelValues) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *MetricsForLabelMatchersResponse) String() string {
+func (this *LabelNamesCardinalityResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	repeatedStringForMetric := "[]*Metric{"
-	for _, f := range this.Metric {
-		repeatedStringForMetric += strings.Replace(f.String(), "Metric", "Metric", 1) + ","
+	repeatedStringForLabelNames := "[]LabelNameValues{"
+	for _, f := range this.LabelNames {
+		repeatedStringForLabelNames += strings.Replace(strings.Replace(f.String(), "LabelNameValues", "LabelNameValues", 1), `&`, ``, 1) + ","
 	}
-	repeatedStringForMetric += "}"
-	s := strings.Join([]string{`&MetricsForLabelMatchersResponse{`,
-		`Metric:` + repeatedStringForMetric + `,`,
+	repeatedStringForLabelNames += "}"
+	repeatedStringForMetricNames := "[]LabelValueSeriesCount{"
+	for _, f := range this.MetricNames {
+		repeatedStringForMetricNames += strings.Replace(strings.Replace(f.String(), "LabelValueSeriesCount", "LabelValueSeriesCount", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForMetricNames += "}"
+	s := strings.Join([]string{`&LabelNamesCardinalityResponse{`,
+		`NumSeries:` + fmt.Sprintf("%v", this.NumSeries) + `,`,
+		`LabelNames:` + repeatedStringForLabelNames + `,`,
+		`MetricNames:` + repeatedStringForMetricNames + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *MetricsMetadataRequest) String() string {
+func (this *LabelNameValues) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&MetricsMetadataRequest{`,
+	s := strings.Join([]string{`&LabelNameValues{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`Values:` + fmt.Sprintf("%v", this.Values) + `,`,
+		`ValuesCount:` + fmt.Sprintf("%v", this.ValuesCount) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *MetricsMetadataResponse) String() string {
+func (this *LabelValuesCardinalityRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	repeatedStringForMetadata := "[]*MetricMetadata{"
+	s := strings.Join([]string{`&LabelValuesCardinalityRequest{`,
+		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
+		`MaxLabelValues:` + fmt.Sprintf("%v", this.MaxLabelValues) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesCardinalityResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLabelNames := "[]LabelNameSeriesCount{"
+	for _, f := range this.LabelNames {
+		repeatedStringForLabelNames += strings.Replace(strings.Replace(f.String(), "LabelNameSeriesCount", "LabelNameSeriesCount", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForLabelNames += "}"
+	s := strings.Join([]string{`&LabelValuesCardinalityResponse{`,
+		`LabelNames:` + repeatedStringForLabelNames + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelNameSeriesCount) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForValues := "[]LabelValueSeriesCount{"
+	for _, f := range this.Values {
+		repeatedStringForValues += strings.Replace(strings.Replace(f.String(), "LabelValueSeriesCount", "LabelValueSeriesCount", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForValues += "}"
+	s := strings.Join([]string{`&LabelNameSeriesCount{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`Values:` + repeatedStringForValues + `,`,
+		`ValuesCount:` + fmt.Sprintf("%v", this.ValuesCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValueSeriesCount) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValueSeriesCount{`,
+		`LabelValue:` + fmt.Sprintf("%v", this.LabelValue) + `,`,
+		`SeriesCount:` + fmt.Sprintf("%v", this.SeriesCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsForLabelMatchersRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchersSet := "[]*LabelMatchers{"
+	for _, f := range this.MatchersSet {
+		repeatedStringForMatchersSet += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
+	}
+	repeatedStringForMatchersSet += "}"
+	s := strings.Join([]string{`&MetricsForLabelMatchersRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`MatchersSet:` + repeatedStringForMatchersSet + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsForLabelMatchersResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetric := "[]*Metric{"
+	for _, f := range this.Metric {
+		repeatedStringForMetric += strings.Replace(f.String(), "Metric", "Metric", 1) + ","
+	}
+	repeatedStringForMetric += "}"
+	s := strings.Join([]string{`&MetricsForLabelMatchersResponse{`,
+		`Metric:` + repeatedStringForMetric + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsMetadataRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MetricsMetadataRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsMetadataResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetadata := "[]*MetricMetadata{"
 	for _, f := range this.Metadata {
 		repeatedStringForMetadata += strings.Replace(f.String(), "MetricMetadata", "MetricMetadata", 1) + ","
 	}
@@ -5641,9 +7548,15 @@ func (this *TimeSeries) String() string {
 		repeatedStringForSamples += strings.Replace(strings.Replace(f.String(), "Sample", "Sample", 1), `&`, ``, 1) + ","
 	}
 	repeatedStringForSamples += "}"
+	repeatedStringForExemplars := "[]Exemplar{"
+	for _, f := range this.Exemplars {
+		repeatedStringForExemplars += strings.Replace(strings.Replace(f.String(), "Exemplar", "Exemplar", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForExemplars += "}"
 	s := strings.Join([]string{`&TimeSeries{`,
 		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
 		`Samples:` + repeatedStringForSamples + `,`,
+		`Exemplars:` + repeatedStringForExemplars + `,`,
 		`}`,
 	}, "")
 	return s
@@ -5670,6 +7583,18 @@ func (this *Sample) String() string {
 	}, "")
 	return s
 }
+func (this *Exemplar) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Exemplar{`,
+		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`TimestampMs:` + fmt.Sprintf("%v", this.TimestampMs) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *LabelMatchers) String() string {
 	if this == nil {
 		return "nil"
@@ -5724,24 +7649,744 @@ func (this *TimeSeriesFile) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&TimeSeriesFile{`,
-		`FromIngesterId:` + fmt.Sprintf("%v", this.FromIngesterId) + `,`,
-		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
-		`Filename:` + fmt.Sprintf("%v", this.Filename) + `,`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringCortex(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	s := strings.Join([]string{`&TimeSeriesFile{`,
+		`FromIngesterId:` + fmt.Sprintf("%v", this.FromIngesterId) + `,`,
+		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
+		`Filename:` + fmt.Sprintf("%v", this.Filename) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringCortex(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *WriteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timeseries = append(m.Timeseries, PreallocTimeseries{})
+			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			m.Source = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Source |= WriteRequest_SourceEnum(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata, &MetricMetadata{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1000:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SkipLabelNameValidation", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SkipLabelNameValidation = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WriteResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReadRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Queries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Queries = append(m.Queries, &QueryRequest{})
+			if err := m.Queries[len(m.Queries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReadResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Results = append(m.Results, &QueryResponse{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
+			}
+			m.StartTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
+			}
+			m.EndTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matchers = append(m.Matchers, &LabelMatcher{})
+			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timeseries = append(m.Timeseries, TimeSeries{})
+			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryStreamResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunkseries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chunkseries = append(m.Chunkseries, TimeSeriesChunk{})
+			if err := m.Chunkseries[len(m.Chunkseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timeseries = append(m.Timeseries, TimeSeries{})
+			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *WriteRequest) Unmarshal(dAtA []byte) error {
+func (m *ExemplarQueryRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5764,17 +8409,17 @@ func (m *WriteRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WriteRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExemplarQueryRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WriteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExemplarQueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
 			}
-			var msglen int
+			m.StartTimestampMs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -5784,31 +8429,16 @@ func (m *WriteRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.StartTimestampMs |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthCortex
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthCortex
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Timeseries = append(m.Timeseries, PreallocTimeseries{})
-			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
 			}
-			m.Source = 0
+			m.EndTimestampMs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -5818,14 +8448,14 @@ func (m *WriteRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Source |= WriteRequest_SourceEnum(b&0x7F) << shift
+				m.EndTimestampMs |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5852,16 +8482,69 @@ func (m *WriteRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Metadata = append(m.Metadata, &MetricMetadata{})
-			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Matchers = append(m.Matchers, &LabelMatchers{})
+			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1000:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SkipLabelNameValidation", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var v int
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExemplarQueryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExemplarQueryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExemplarQueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -5871,12 +8554,26 @@ func (m *WriteRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.SkipLabelNameValidation = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timeseries = append(m.Timeseries, TimeSeries{})
+			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -5901,7 +8598,7 @@ func (m *WriteRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WriteResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelValuesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5924,12 +8621,82 @@ func (m *WriteResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WriteResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelValuesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WriteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
+			}
+			m.StartTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
+			}
+			m.EndTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -5954,7 +8721,7 @@ func (m *WriteResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReadRequest) Unmarshal(dAtA []byte) error {
+func (m *LabelValuesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5977,17 +8744,17 @@ func (m *ReadRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReadRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelValuesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelValuesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Queries", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValues", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -5997,25 +8764,23 @@ func (m *ReadRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCortex
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCortex
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Queries = append(m.Queries, &QueryRequest{})
-			if err := m.Queries[len(m.Queries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.LabelValues = append(m.LabelValues, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -6041,7 +8806,7 @@ func (m *ReadRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReadResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6064,17 +8829,17 @@ func (m *ReadResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReadResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelNamesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReadResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelNamesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
 			}
-			var msglen int
+			m.StartTimestampMs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -6084,26 +8849,30 @@ func (m *ReadResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.StartTimestampMs |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthCortex
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthCortex
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
 			}
-			m.Results = append(m.Results, &QueryResponse{})
-			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.EndTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -6128,7 +8897,7 @@ func (m *ReadResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryRequest) Unmarshal(dAtA []byte) error {
+func (m *LabelNamesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6151,55 +8920,17 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelNamesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelNamesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
-			}
-			m.StartTimestampMs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCortex
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.StartTimestampMs |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
-			}
-			m.EndTimestampMs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCortex
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.EndTimestampMs |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -6209,26 +8940,77 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCortex
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCortex
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Matchers = append(m.Matchers, &LabelMatcher{})
-			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UserStatsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UserStatsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UserStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -6253,7 +9035,7 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryResponse) Unmarshal(dAtA []byte) error {
+func (m *UserStatsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6276,17 +9058,28 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: UserStatsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UserStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IngestionRate", wireType)
 			}
-			var msglen int
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.IngestionRate = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumSeries", wireType)
+			}
+			m.NumSeries = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -6296,26 +9089,33 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.NumSeries |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthCortex
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthCortex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApiIngestionRate", wireType)
 			}
-			if postIndex > l {
+			var v uint64
+			if (iNdEx + 8) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Timeseries = append(m.Timeseries, TimeSeries{})
-			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ApiIngestionRate = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RuleIngestionRate", wireType)
 			}
-			iNdEx = postIndex
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.RuleIngestionRate = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -6340,7 +9140,7 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
+func (m *UserIDStatsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6363,17 +9163,17 @@ func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryStreamResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: UserIDStatsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UserIDStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Chunkseries", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -6383,29 +9183,27 @@ func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCortex
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCortex
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Chunkseries = append(m.Chunkseries, TimeSeriesChunk{})
-			if err := m.Chunkseries[len(m.Chunkseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6432,8 +9230,10 @@ func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Timeseries = append(m.Timeseries, TimeSeries{})
-			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Data == nil {
+				m.Data = &UserStatsResponse{}
+			}
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -6461,7 +9261,7 @@ func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelValuesRequest) Unmarshal(dAtA []byte) error {
+func (m *UsersStatsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6484,17 +9284,17 @@ func (m *LabelValuesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValuesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UsersStatsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UsersStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -6504,62 +9304,26 @@ func (m *LabelValuesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthCortex
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthCortex
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
-			}
-			m.StartTimestampMs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCortex
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.StartTimestampMs |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
-			}
-			m.EndTimestampMs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCortex
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.EndTimestampMs |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Stats = append(m.Stats, &UserIDStatsResponse{})
+			if err := m.Stats[len(m.Stats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -6584,7 +9348,7 @@ func (m *LabelValuesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelValuesResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelNamesCardinalityRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6607,17 +9371,17 @@ func (m *LabelValuesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValuesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelNamesCardinalityRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValuesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelNamesCardinalityRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelValues", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLabelValues", wireType)
 			}
-			var stringLen uint64
+			m.MaxLabelValues = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -6627,24 +9391,11 @@ func (m *LabelValuesResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.MaxLabelValues |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthCortex
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthCortex
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.LabelValues = append(m.LabelValues, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -6669,7 +9420,7 @@ func (m *LabelValuesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
+func (m *LabelNamesCardinalityResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6692,17 +9443,17 @@ func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelNamesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelNamesCardinalityResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelNamesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelNamesCardinalityResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NumSeries", wireType)
 			}
-			m.StartTimestampMs = 0
+			m.NumSeries = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -6712,16 +9463,16 @@ func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartTimestampMs |= int64(b&0x7F) << shift
+				m.NumSeries |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
 			}
-			m.EndTimestampMs = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -6731,11 +9482,60 @@ func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EndTimestampMs |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelNames = append(m.LabelNames, LabelNameValues{})
+			if err := m.LabelNames[len(m.LabelNames)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetricNames", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MetricNames = append(m.MetricNames, LabelValueSeriesCount{})
+			if err := m.MetricNames[len(m.MetricNames)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -6760,7 +9560,7 @@ func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelNamesResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelNameValues) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6783,15 +9583,15 @@ func (m *LabelNamesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelNamesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelNameValues: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelNamesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelNameValues: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6819,8 +9619,59 @@ func (m *LabelNamesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
+			m.LabelName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValuesCount", wireType)
+			}
+			m.ValuesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValuesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -6845,7 +9696,7 @@ func (m *LabelNamesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UserStatsRequest) Unmarshal(dAtA []byte) error {
+func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6868,12 +9719,63 @@ func (m *UserStatsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UserStatsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelValuesCardinalityRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UserStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelValuesCardinalityRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLabelValues", wireType)
+			}
+			m.MaxLabelValues = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxLabelValues |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -6898,7 +9800,7 @@ func (m *UserStatsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UserStatsResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelValuesCardinalityResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6921,28 +9823,17 @@ func (m *UserStatsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UserStatsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelValuesCardinalityResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UserStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelValuesCardinalityResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IngestionRate", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.IngestionRate = float64(math.Float64frombits(v))
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NumSeries", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
 			}
-			m.NumSeries = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -6952,33 +9843,26 @@ func (m *UserStatsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NumSeries |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApiIngestionRate", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
 			}
-			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.ApiIngestionRate = float64(math.Float64frombits(v))
-		case 4:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RuleIngestionRate", wireType)
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
 			}
-			var v uint64
-			if (iNdEx + 8) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.RuleIngestionRate = float64(math.Float64frombits(v))
+			m.LabelNames = append(m.LabelNames, LabelNameSeriesCount{})
+			if err := m.LabelNames[len(m.LabelNames)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -7003,7 +9887,7 @@ func (m *UserStatsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UserIDStatsResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelNameSeriesCount) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7026,15 +9910,15 @@ func (m *UserIDStatsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UserIDStatsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelNameSeriesCount: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UserIDStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelNameSeriesCount: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7062,11 +9946,11 @@ func (m *UserIDStatsResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
+			m.LabelName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7093,13 +9977,30 @@ func (m *UserIDStatsResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Data == nil {
-				m.Data = &UserStatsResponse{}
-			}
-			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Values = append(m.Values, LabelValueSeriesCount{})
+			if err := m.Values[len(m.Values)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValuesCount", wireType)
+			}
+			m.ValuesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValuesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -7124,7 +10025,7 @@ func (m *UserIDStatsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UsersStatsResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelValueSeriesCount) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7147,17 +10048,17 @@ func (m *UsersStatsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UsersStatsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelValueSeriesCount: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UsersStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelValueSeriesCount: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValue", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCortex
@@ -7167,26 +10068,43 @@ func (m *UsersStatsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCortex
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCortex
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Stats = append(m.Stats, &UserIDStatsResponse{})
-			if err := m.Stats[len(m.Stats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.LabelValue = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCount", wireType)
+			}
+			m.SeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -8042,6 +10960,40 @@ func (m *TimeSeries) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exemplars", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Exemplars = append(m.Exemplars, Exemplar{})
+			if err := m.Exemplars[len(m.Exemplars)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -8270,6 +11222,123 @@ func (m *Sample) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Exemplar) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Exemplar: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Exemplar: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, LabelAdapter{})
+			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Value = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimestampMs", wireType)
+			}
+			m.TimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *LabelMatchers) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
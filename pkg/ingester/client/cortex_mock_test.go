@@ -55,6 +55,21 @@ func (m *IngesterServerMock) MetricsMetadata(ctx context.Context, r *MetricsMeta
 	return args.Get(0).(*MetricsMetadataResponse), args.Error(1)
 }
 
+func (m *IngesterServerMock) QueryExemplars(ctx context.Context, r *ExemplarQueryRequest) (*ExemplarQueryResponse, error) {
+	args := m.Called(ctx, r)
+	return args.Get(0).(*ExemplarQueryResponse), args.Error(1)
+}
+
+func (m *IngesterServerMock) LabelNamesCardinality(ctx context.Context, r *LabelNamesCardinalityRequest) (*LabelNamesCardinalityResponse, error) {
+	args := m.Called(ctx, r)
+	return args.Get(0).(*LabelNamesCardinalityResponse), args.Error(1)
+}
+
+func (m *IngesterServerMock) LabelValuesCardinality(ctx context.Context, r *LabelValuesCardinalityRequest) (*LabelValuesCardinalityResponse, error) {
+	args := m.Called(ctx, r)
+	return args.Get(0).(*LabelValuesCardinalityResponse), args.Error(1)
+}
+
 func (m *IngesterServerMock) TransferChunks(s Ingester_TransferChunksServer) error {
 	args := m.Called(s)
 	return args.Error(0)
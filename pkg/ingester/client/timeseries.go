@@ -283,5 +283,15 @@ func ReuseTimeseries(ts *TimeSeries) {
 	}
 	ts.Labels = ts.Labels[:0]
 	ts.Samples = ts.Samples[:0]
+
+	// Exemplar labels may point into a large gRPC buffer too.
+	for i := 0; i < len(ts.Exemplars); i++ {
+		for j := 0; j < len(ts.Exemplars[i].Labels); j++ {
+			ts.Exemplars[i].Labels[j].Name = ""
+			ts.Exemplars[i].Labels[j].Value = ""
+		}
+		ts.Exemplars[i].Labels = nil
+	}
+	ts.Exemplars = ts.Exemplars[:0]
 	timeSeriesPool.Put(ts)
 }
@@ -0,0 +1,231 @@
+package ingester
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/pkg/exemplar"
+	"github.com/prometheus/prometheus/pkg/labels"
+
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+)
+
+const (
+	// Discarded exemplars metric label.
+	exemplarOutOfOrder = "exemplar-out-of-order"
+)
+
+var (
+	errOutOfOrderExemplar  = errors.New("out of order exemplar")
+	errDuplicateExemplar   = errors.New("duplicate exemplar")
+	errExemplarsNotEnabled = errors.New("exemplars storage is disabled")
+)
+
+// exemplarStorage is an in-memory, fixed size, circular buffer holding the most
+// recent exemplars of a single tenant. When the buffer is full, the oldest exemplar
+// (across all series) is replaced by the new one.
+type exemplarStorage struct {
+	mtx sync.RWMutex
+
+	// Circular buffer of exemplars. The next exemplar is written at nextIndex.
+	exemplars []exemplarEntry
+	nextIndex int
+
+	// Map of series labels (as key string) to the series index entry.
+	index map[string]*exemplarIndexEntry
+}
+
+// exemplarIndexEntry keeps track, for a single series, of the position of its oldest and
+// newest exemplars in the circular buffer. Exemplars of the same series are linked together.
+type exemplarIndexEntry struct {
+	oldest       int
+	newest       int
+	seriesLabels labels.Labels
+}
+
+type exemplarEntry struct {
+	exemplar exemplar.Exemplar
+
+	// Position of the next exemplar of the same series, or -1 if this is the newest one.
+	next int
+
+	// Series this exemplar belongs to, or nil if the entry has never been used.
+	ref *exemplarIndexEntry
+}
+
+// seriesExemplar is an exemplar along with the labels of the series it belongs to.
+type seriesExemplar struct {
+	seriesLabels labels.Labels
+	exemplar     exemplar.Exemplar
+}
+
+func newExemplarStorage(size int) *exemplarStorage {
+	return &exemplarStorage{
+		exemplars: make([]exemplarEntry, size),
+		index:     make(map[string]*exemplarIndexEntry),
+	}
+}
+
+// size returns the max number of exemplars that can be stored.
+func (s *exemplarStorage) size() int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return len(s.exemplars)
+}
+
+// resize changes the max number of exemplars that can be stored, keeping the most recent ones.
+func (s *exemplarStorage) resize(size int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if size == len(s.exemplars) {
+		return
+	}
+
+	old := s.exemplars
+	oldNext := s.nextIndex
+
+	s.exemplars = make([]exemplarEntry, size)
+	s.nextIndex = 0
+	s.index = make(map[string]*exemplarIndexEntry)
+
+	if size == 0 {
+		return
+	}
+
+	// Re-add the old exemplars, from the oldest to the newest, skipping the ones which wouldn't fit anyway.
+	count := 0
+	for i := 0; i < len(old); i++ {
+		if old[(oldNext+i)%len(old)].ref != nil {
+			count++
+		}
+	}
+
+	skip := count - size
+	for i := 0; i < len(old); i++ {
+		entry := old[(oldNext+i)%len(old)]
+		if entry.ref == nil {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		s.appendLocked(entry.ref.seriesLabels, entry.exemplar)
+	}
+}
+
+// add stores the exemplar for the input series. The series labels are retained, so it's
+// the caller responsibility to pass a copy if they may be modified.
+func (s *exemplarStorage) add(seriesLabels labels.Labels, e exemplar.Exemplar) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if len(s.exemplars) == 0 {
+		return errExemplarsNotEnabled
+	}
+
+	if idx, ok := s.index[seriesLabels.String()]; ok {
+		newest := s.exemplars[idx.newest].exemplar
+
+		// Exemplars of the same series must be appended in order.
+		if e.Ts == newest.Ts && e.Value == newest.Value && labels.Equal(e.Labels, newest.Labels) {
+			return errDuplicateExemplar
+		}
+		if e.Ts <= newest.Ts {
+			return errOutOfOrderExemplar
+		}
+	}
+
+	s.appendLocked(seriesLabels, e)
+	return nil
+}
+
+// appendLocked appends the exemplar to the circular buffer, evicting the oldest one if the
+// buffer is full. The caller must hold the lock.
+func (s *exemplarStorage) appendLocked(seriesLabels labels.Labels, e exemplar.Exemplar) {
+	key := seriesLabels.String()
+
+	// Evict the oldest exemplar, if the slot is in use.
+	if prev := s.exemplars[s.nextIndex].ref; prev != nil {
+		if next := s.exemplars[s.nextIndex].next; next == -1 {
+			// It was the only exemplar of the series.
+			delete(s.index, prev.seriesLabels.String())
+		} else {
+			prev.oldest = next
+		}
+	}
+
+	idx, ok := s.index[key]
+	if !ok {
+		idx = &exemplarIndexEntry{oldest: s.nextIndex, seriesLabels: seriesLabels}
+		s.index[key] = idx
+	} else {
+		s.exemplars[idx.newest].next = s.nextIndex
+	}
+
+	idx.newest = s.nextIndex
+	s.exemplars[s.nextIndex] = exemplarEntry{exemplar: e, next: -1, ref: idx}
+	s.nextIndex = (s.nextIndex + 1) % len(s.exemplars)
+}
+
+// query returns the exemplars with timestamp within [start, end] for all series matching
+// at least one of the input sets of matchers.
+func (s *exemplarStorage) query(start, end int64, matchersSet ...[]*labels.Matcher) []client.TimeSeries {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	var result []client.TimeSeries
+
+	for _, idx := range s.index {
+		if !matchesAny(idx.seriesLabels, matchersSet) {
+			continue
+		}
+
+		var exemplars []client.Exemplar
+		for i := idx.oldest; i != -1; i = s.exemplars[i].next {
+			e := s.exemplars[i].exemplar
+			if e.Ts > end {
+				break
+			}
+			if e.Ts < start {
+				continue
+			}
+
+			exemplars = append(exemplars, client.Exemplar{
+				Labels:      client.FromLabelsToLabelAdapters(e.Labels),
+				Value:       e.Value,
+				TimestampMs: e.Ts,
+			})
+		}
+
+		if len(exemplars) > 0 {
+			result = append(result, client.TimeSeries{
+				Labels:    client.FromLabelsToLabelAdapters(idx.seriesLabels),
+				Exemplars: exemplars,
+			})
+		}
+	}
+
+	return result
+}
+
+func matchesAny(lbls labels.Labels, matchersSet [][]*labels.Matcher) bool {
+	for _, matchers := range matchersSet {
+		if matchesAll(lbls, matchers) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAll(lbls labels.Labels, matchers []*labels.Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(lbls.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
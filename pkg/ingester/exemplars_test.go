@@ -0,0 +1,137 @@
+package ingester
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/exemplar"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+)
+
+func TestExemplarStorage_Add(t *testing.T) {
+	series1 := labels.FromStrings(labels.MetricName, "test", "series", "1")
+	series2 := labels.FromStrings(labels.MetricName, "test", "series", "2")
+	traceID := func(id string) labels.Labels { return labels.FromStrings("traceID", id) }
+
+	t.Run("should fail if the storage is disabled", func(t *testing.T) {
+		s := newExemplarStorage(0)
+		assert.Equal(t, errExemplarsNotEnabled, s.add(series1, exemplar.Exemplar{Labels: traceID("a"), Value: 1, Ts: 10, HasTs: true}))
+	})
+
+	t.Run("should reject out of order and duplicate exemplars of the same series", func(t *testing.T) {
+		s := newExemplarStorage(5)
+		require.NoError(t, s.add(series1, exemplar.Exemplar{Labels: traceID("a"), Value: 1, Ts: 10, HasTs: true}))
+		assert.Equal(t, errDuplicateExemplar, s.add(series1, exemplar.Exemplar{Labels: traceID("a"), Value: 1, Ts: 10, HasTs: true}))
+		assert.Equal(t, errOutOfOrderExemplar, s.add(series1, exemplar.Exemplar{Labels: traceID("b"), Value: 2, Ts: 10, HasTs: true}))
+		assert.Equal(t, errOutOfOrderExemplar, s.add(series1, exemplar.Exemplar{Labels: traceID("b"), Value: 2, Ts: 5, HasTs: true}))
+
+		// Ordering is enforced per series.
+		require.NoError(t, s.add(series2, exemplar.Exemplar{Labels: traceID("c"), Value: 3, Ts: 5, HasTs: true}))
+	})
+
+	t.Run("should evict the oldest exemplars once the storage is full", func(t *testing.T) {
+		s := newExemplarStorage(3)
+		require.NoError(t, s.add(series1, exemplar.Exemplar{Labels: traceID("a"), Value: 1, Ts: 10, HasTs: true}))
+		require.NoError(t, s.add(series2, exemplar.Exemplar{Labels: traceID("b"), Value: 2, Ts: 15, HasTs: true}))
+		require.NoError(t, s.add(series1, exemplar.Exemplar{Labels: traceID("c"), Value: 3, Ts: 20, HasTs: true}))
+		require.NoError(t, s.add(series1, exemplar.Exemplar{Labels: traceID("d"), Value: 4, Ts: 30, HasTs: true}))
+		require.NoError(t, s.add(series1, exemplar.Exemplar{Labels: traceID("e"), Value: 5, Ts: 40, HasTs: true}))
+
+		// All exemplars of series2 have been evicted.
+		assert.Equal(t, []client.TimeSeries{{
+			Labels: client.FromLabelsToLabelAdapters(series1),
+			Exemplars: []client.Exemplar{
+				{Labels: client.FromLabelsToLabelAdapters(traceID("c")), Value: 3, TimestampMs: 20},
+				{Labels: client.FromLabelsToLabelAdapters(traceID("d")), Value: 4, TimestampMs: 30},
+				{Labels: client.FromLabelsToLabelAdapters(traceID("e")), Value: 5, TimestampMs: 40},
+			},
+		}}, s.query(0, 100, []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test")}))
+	})
+}
+
+func TestExemplarStorage_Resize(t *testing.T) {
+	series := labels.FromStrings(labels.MetricName, "test")
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test")}
+
+	s := newExemplarStorage(4)
+	for ts := int64(1); ts <= 6; ts++ {
+		require.NoError(t, s.add(series, exemplar.Exemplar{Value: float64(ts), Ts: ts, HasTs: true}))
+	}
+
+	// Shrinking the storage should keep the most recent exemplars.
+	s.resize(2)
+	assert.Equal(t, 2, s.size())
+	assert.Equal(t, []client.TimeSeries{{
+		Labels:    client.FromLabelsToLabelAdapters(series),
+		Exemplars: []client.Exemplar{{Value: 5, TimestampMs: 5}, {Value: 6, TimestampMs: 6}},
+	}}, s.query(0, 10, matchers))
+
+	// Growing the storage should keep all exemplars.
+	s.resize(3)
+	require.NoError(t, s.add(series, exemplar.Exemplar{Value: 7, Ts: 7, HasTs: true}))
+	assert.Equal(t, []client.TimeSeries{{
+		Labels:    client.FromLabelsToLabelAdapters(series),
+		Exemplars: []client.Exemplar{{Value: 5, TimestampMs: 5}, {Value: 6, TimestampMs: 6}, {Value: 7, TimestampMs: 7}},
+	}}, s.query(0, 10, matchers))
+
+	// Disabling the storage should drop all exemplars.
+	s.resize(0)
+	assert.Empty(t, s.query(0, 10, matchers))
+}
+
+func TestExemplarStorage_Query(t *testing.T) {
+	series1 := labels.FromStrings(labels.MetricName, "test", "series", "1")
+	series2 := labels.FromStrings(labels.MetricName, "test", "series", "2")
+
+	s := newExemplarStorage(10)
+	for ts := int64(10); ts <= 30; ts += 10 {
+		require.NoError(t, s.add(series1, exemplar.Exemplar{Value: 1, Ts: ts, HasTs: true}))
+		require.NoError(t, s.add(series2, exemplar.Exemplar{Value: 2, Ts: ts, HasTs: true}))
+	}
+
+	tests := map[string]struct {
+		start, end  int64
+		matchersSet [][]*labels.Matcher
+		expected    []client.TimeSeries
+	}{
+		"should filter by time range": {
+			start:       15,
+			end:         25,
+			matchersSet: [][]*labels.Matcher{{labels.MustNewMatcher(labels.MatchEqual, "series", "1")}},
+			expected: []client.TimeSeries{{
+				Labels:    client.FromLabelsToLabelAdapters(series1),
+				Exemplars: []client.Exemplar{{Value: 1, TimestampMs: 20}},
+			}},
+		},
+		"should return the series matching any set of matchers": {
+			start: 30,
+			end:   30,
+			matchersSet: [][]*labels.Matcher{
+				{labels.MustNewMatcher(labels.MatchEqual, "series", "1")},
+				{labels.MustNewMatcher(labels.MatchEqual, "series", "2")},
+			},
+			expected: []client.TimeSeries{{
+				Labels:    client.FromLabelsToLabelAdapters(series1),
+				Exemplars: []client.Exemplar{{Value: 1, TimestampMs: 30}},
+			}, {
+				Labels:    client.FromLabelsToLabelAdapters(series2),
+				Exemplars: []client.Exemplar{{Value: 2, TimestampMs: 30}},
+			}},
+		},
+		"should return nothing if no series match": {
+			start:       0,
+			end:         100,
+			matchersSet: [][]*labels.Matcher{{labels.MustNewMatcher(labels.MatchEqual, "series", "3")}},
+			expected:    nil,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.ElementsMatch(t, testData.expected, s.query(testData.start, testData.end, testData.matchersSet...))
+		})
+	}
+}
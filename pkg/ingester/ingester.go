@@ -945,6 +945,50 @@ func (i *Ingester) MetricsMetadata(ctx context.Context, req *client.MetricsMetad
 	return &client.MetricsMetadataResponse{Metadata: userMetadata.toClientMetadata()}, nil
 }
 
+// QueryExemplars returns the exemplars of the series matching the request. Exemplars are
+// supported only by the blocks storage, so an empty response is returned otherwise.
+func (i *Ingester) QueryExemplars(ctx context.Context, req *client.ExemplarQueryRequest) (*client.ExemplarQueryResponse, error) {
+	if err := i.checkRunningOrStopping(); err != nil {
+		return nil, err
+	}
+
+	if i.cfg.BlocksStorageEnabled {
+		return i.v2QueryExemplars(ctx, req)
+	}
+
+	return &client.ExemplarQueryResponse{}, nil
+}
+
+// LabelNamesCardinality returns the label names and the metric names cardinality of the in-memory
+// series of the current user. It's supported only by the blocks storage, so an empty response is
+// returned otherwise.
+func (i *Ingester) LabelNamesCardinality(ctx context.Context, req *client.LabelNamesCardinalityRequest) (*client.LabelNamesCardinalityResponse, error) {
+	if err := i.checkRunningOrStopping(); err != nil {
+		return nil, err
+	}
+
+	if i.cfg.BlocksStorageEnabled {
+		return i.v2LabelNamesCardinality(ctx, req)
+	}
+
+	return &client.LabelNamesCardinalityResponse{}, nil
+}
+
+// LabelValuesCardinality returns the number of in-memory series of the current user for each value
+// of the requested label names. It's supported only by the blocks storage, so an empty response is
+// returned otherwise.
+func (i *Ingester) LabelValuesCardinality(ctx context.Context, req *client.LabelValuesCardinalityRequest) (*client.LabelValuesCardinalityResponse, error) {
+	if err := i.checkRunningOrStopping(); err != nil {
+		return nil, err
+	}
+
+	if i.cfg.BlocksStorageEnabled {
+		return i.v2LabelValuesCardinality(ctx, req)
+	}
+
+	return &client.LabelValuesCardinalityResponse{}, nil
+}
+
 // UserStats returns ingestion statistics for the current user.
 func (i *Ingester) UserStats(ctx context.Context, req *client.UserStatsRequest) (*client.UserStatsResponse, error) {
 	if err := i.checkRunningOrStopping(); err != nil {
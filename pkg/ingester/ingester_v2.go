@@ -17,9 +17,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/exemplar"
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/record"
 	"github.com/thanos-io/thanos/pkg/block/metadata"
 	"github.com/thanos-io/thanos/pkg/objstore"
 	"github.com/thanos-io/thanos/pkg/shipper"
@@ -106,6 +108,12 @@ type userTSDB struct {
 	// Unix timestamp of last deletion mark check.
 	lastDeletionMarkCheck atomic.Int64
 
+	// In-memory storage of the most recent exemplars.
+	exemplars *exemplarStorage
+
+	// Storage of the samples ingested out of order.
+	outOfOrder *outOfOrderStorage
+
 	// for statistics
 	ingestedAPISamples  *ewmaRate
 	ingestedRuleSamples *ewmaRate
@@ -122,7 +130,22 @@ func (u *userTSDB) Appender(ctx context.Context) storage.Appender {
 }
 
 func (u *userTSDB) Querier(ctx context.Context, mint, maxt int64) (storage.Querier, error) {
-	return u.db.Querier(ctx, mint, maxt)
+	q, err := u.db.Querier(ctx, mint, maxt)
+	if err != nil || u.outOfOrder == nil {
+		return q, err
+	}
+
+	// Merge the out-of-order samples, if any.
+	outOfOrderQueriers, err := u.outOfOrder.queriers(mint, maxt)
+	if err != nil {
+		_ = q.Close()
+		return nil, err
+	}
+	if len(outOfOrderQueriers) == 0 {
+		return q, nil
+	}
+
+	return storage.NewMergeQuerier(append([]storage.Querier{q}, outOfOrderQueriers...), nil, storage.ChainedSeriesMerge), nil
 }
 
 func (u *userTSDB) Head() *tsdb.Head {
@@ -134,6 +157,11 @@ func (u *userTSDB) Blocks() []*tsdb.Block {
 }
 
 func (u *userTSDB) Close() error {
+	if u.outOfOrder != nil {
+		if err := u.outOfOrder.close(); err != nil {
+			return errors.Wrap(err, "close out-of-order blocks")
+		}
+	}
 	return u.db.Close()
 }
 
@@ -326,7 +354,7 @@ func (u *userTSDB) shouldCloseTSDB(idleTimeout time.Duration) (tsdbCloseCheckRes
 	}
 
 	// If head is not compacted, we cannot close this yet.
-	if u.Head().NumSeries() > 0 {
+	if u.Head().NumSeries() > 0 || (u.outOfOrder != nil && !u.outOfOrder.head.empty()) {
 		return tsdbNotCompacted, nil
 	}
 
@@ -335,6 +363,14 @@ func (u *userTSDB) shouldCloseTSDB(idleTimeout time.Duration) (tsdbCloseCheckRes
 		return tsdbNotShipped, nil
 	}
 
+	if u.outOfOrder != nil {
+		if unshipped, err := u.outOfOrder.hasUnshippedBlocks(); err != nil {
+			return tsdbCheckFailed, err
+		} else if unshipped {
+			return tsdbNotShipped, nil
+		}
+	}
+
 	return tsdbIdle, nil
 }
 
@@ -647,8 +683,9 @@ func (i *Ingester) v2UpdateActiveSeries() {
 			continue
 		}
 
+		updateActiveSeriesMatchers(userID, userDB.activeSeries, i.limits, i.metrics)
 		userDB.activeSeries.Purge(purgeTime)
-		i.metrics.activeSeriesPerUser.WithLabelValues(userID).Set(float64(userDB.activeSeries.Active()))
+		i.metrics.setActiveSeries(userID, userDB.activeSeries)
 	}
 }
 
@@ -691,8 +728,31 @@ func (i *Ingester) v2Push(ctx context.Context, req *client.WriteRequest) (*clien
 	// successfully committed
 	succeededSamplesCount := 0
 	failedSamplesCount := 0
+	outOfOrderSamplesCount := 0
 	startAppend := time.Now()
 
+	// Out-of-order series and samples to log to the out-of-order WAL.
+	var (
+		outOfOrderSeries  []record.RefSeries
+		outOfOrderSamples []record.RefSample
+	)
+
+	// Samples older than the latest one but within this window are stored in the out-of-order storage.
+	outOfOrderWindow := i.limits.OutOfOrderTimeWindow(userID).Milliseconds()
+
+	// Used to check whether the out-of-order samples are already in the TSDB head.
+	headLookup := newHeadSampleLookup(db.Head())
+	defer headLookup.close()
+
+	// The exemplars storage size may have changed since the TSDB was created.
+	maxExemplars := i.limits.MaxLocalExemplarsPerUser(userID)
+	if db.exemplars.size() != maxExemplars {
+		db.exemplars.resize(maxExemplars)
+	}
+
+	// Exemplars are added to the storage only once samples have been committed.
+	var exemplarsToAdd []seriesExemplar
+
 	// Walk the samples, appending them to the users database
 	app := db.Appender(ctx)
 	for _, ts := range req.Timeseries {
@@ -740,6 +800,37 @@ func (i *Ingester) v2Push(ctx context.Context, req *client.WriteRequest) (*clien
 					succeededSamplesCount++
 					continue
 				}
+
+				// The series reference is returned for out-of-order samples too, because the series
+				// exists in the head. It's used to look up the sample in the head.
+				if ref != 0 {
+					cachedRef = ref
+				}
+			}
+
+			// Out-of-order samples within the window are stored in the out-of-order storage. Out-of-bounds
+			// samples are accepted too, but only for series already in the head, so that series limits
+			// are honored.
+			if cause := errors.Cause(err); outOfOrderWindow > 0 && (cause == storage.ErrOutOfOrderSample || (cause == storage.ErrOutOfBounds && cachedRefExists)) {
+				if headMaxTime := db.Head().MaxTime(); headMaxTime != math.MinInt64 && s.TimestampMs >= headMaxTime-outOfOrderWindow {
+					if copiedLabels == nil {
+						copiedLabels = client.FromLabelAdaptersToLabelsWithCopy(ts.Labels)
+					}
+
+					ref, created, added, oooErr := db.appendOutOfOrder(headLookup, cachedRef, copiedLabels, s.TimestampMs, s.Value)
+					if oooErr == nil {
+						succeededSamplesCount++
+						if created {
+							outOfOrderSeries = append(outOfOrderSeries, record.RefSeries{Ref: ref, Labels: copiedLabels})
+						}
+						if added {
+							outOfOrderSamples = append(outOfOrderSamples, record.RefSample{Ref: ref, T: s.TimestampMs, V: s.Value})
+							outOfOrderSamplesCount++
+						}
+						continue
+					}
+					err = oooErr
+				}
 			}
 
 			failedSamplesCount++
@@ -784,6 +875,25 @@ func (i *Ingester) v2Push(ctx context.Context, req *client.WriteRequest) (*clien
 			return nil, wrapWithUser(err, userID)
 		}
 
+		// Exemplars are stored only for series existing in the TSDB.
+		if maxExemplars > 0 && len(ts.Exemplars) > 0 && cachedRefExists {
+			if copiedLabels == nil {
+				copiedLabels = client.FromLabelAdaptersToLabelsWithCopy(ts.Labels)
+			}
+
+			for _, e := range ts.Exemplars {
+				exemplarsToAdd = append(exemplarsToAdd, seriesExemplar{
+					seriesLabels: copiedLabels,
+					exemplar: exemplar.Exemplar{
+						Labels: client.FromLabelAdaptersToLabelsWithCopy(e.Labels),
+						Value:  e.Value,
+						Ts:     e.TimestampMs,
+						HasTs:  true,
+					},
+				})
+			}
+		}
+
 		if i.cfg.ActiveSeriesMetricsEnabled && succeededSamplesCount > oldSucceededSamplesCount {
 			db.activeSeries.UpdateSeries(client.FromLabelAdaptersToLabels(ts.Labels), startAppend, func(l labels.Labels) labels.Labels {
 				// If we have already made a copy during this push, no need to create new one.
@@ -804,6 +914,28 @@ func (i *Ingester) v2Push(ctx context.Context, req *client.WriteRequest) (*clien
 	}
 	i.TSDBState.appenderCommitDuration.Observe(time.Since(startCommit).Seconds())
 
+	// Out-of-order samples must be logged to the WAL before acknowledging the request.
+	if err := db.outOfOrder.logWAL(outOfOrderSeries, outOfOrderSamples); err != nil {
+		return nil, wrapWithUser(err, userID)
+	}
+
+	succeededExemplarsCount := 0
+	failedExemplarsCount := 0
+	for _, e := range exemplarsToAdd {
+		err := db.exemplars.add(e.seriesLabels, e.exemplar)
+		switch {
+		case err == nil:
+			succeededExemplarsCount++
+		case errors.Is(err, errDuplicateExemplar):
+			// Duplicate exemplars are silently ignored.
+		case errors.Is(err, errOutOfOrderExemplar):
+			failedExemplarsCount++
+			validation.DiscardedExemplars.WithLabelValues(exemplarOutOfOrder, userID).Inc()
+		default:
+			failedExemplarsCount++
+		}
+	}
+
 	// If only invalid samples are pushed, don't change "last update", as TSDB was not modified.
 	if succeededSamplesCount > 0 {
 		db.setLastUpdate(time.Now())
@@ -814,6 +946,9 @@ func (i *Ingester) v2Push(ctx context.Context, req *client.WriteRequest) (*clien
 	// which will be converted into an HTTP 5xx and the client should/will retry.
 	i.metrics.ingestedSamples.Add(float64(succeededSamplesCount))
 	i.metrics.ingestedSamplesFail.Add(float64(failedSamplesCount))
+	i.metrics.ingestedOutOfOrderSamples.Add(float64(outOfOrderSamplesCount))
+	i.metrics.ingestedExemplars.Add(float64(succeededExemplarsCount))
+	i.metrics.ingestedExemplarsFail.Add(float64(failedExemplarsCount))
 
 	switch req.Source {
 	case client.RULE:
@@ -948,6 +1083,35 @@ func (i *Ingester) v2LabelValues(ctx context.Context, req *client.LabelValuesReq
 	}, nil
 }
 
+func (i *Ingester) v2QueryExemplars(ctx context.Context, req *client.ExemplarQueryRequest) (*client.ExemplarQueryResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	from, through, matchersSet, err := client.FromExemplarQueryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	i.metrics.queries.Inc()
+
+	db := i.getTSDB(userID)
+	if db == nil {
+		return &client.ExemplarQueryResponse{}, nil
+	}
+
+	result := db.exemplars.query(from, through, matchersSet...)
+
+	numExemplars := 0
+	for _, ts := range result {
+		numExemplars += len(ts.Exemplars)
+	}
+	i.metrics.queriedExemplars.Observe(float64(numExemplars))
+
+	return &client.ExemplarQueryResponse{Timeseries: result}, nil
+}
+
 func (i *Ingester) v2LabelNames(ctx context.Context, req *client.LabelNamesRequest) (*client.LabelNamesResponse, error) {
 	userID, err := tenant.TenantID(ctx)
 	if err != nil {
@@ -980,6 +1144,140 @@ func (i *Ingester) v2LabelNames(ctx context.Context, req *client.LabelNamesReque
 	}, nil
 }
 
+func (i *Ingester) v2LabelNamesCardinality(ctx context.Context, req *client.LabelNamesCardinalityRequest) (*client.LabelNamesCardinalityResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := i.getTSDB(userID)
+	if db == nil {
+		return &client.LabelNamesCardinalityResponse{}, nil
+	}
+
+	idx, err := db.Head().Index()
+	if err != nil {
+		return nil, err
+	}
+	defer idx.Close()
+
+	names, err := idx.LabelNames()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &client.LabelNamesCardinalityResponse{
+		NumSeries:  db.Head().NumSeries(),
+		LabelNames: make([]client.LabelNameValues, 0, len(names)),
+	}
+
+	for _, name := range names {
+		values, err := idx.SortedLabelValues(name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Values are returned only for label names with few values, so that the response size is bounded.
+		// Label names with more values are only returned with their values count.
+		nameValues := client.LabelNameValues{LabelName: name, ValuesCount: uint64(len(values))}
+		if uint64(len(values)) <= req.MaxLabelValues {
+			nameValues.Values = values
+		}
+
+		resp.LabelNames = append(resp.LabelNames, nameValues)
+	}
+
+	// Each series has a single metric name, so counting the series of all metric names is bounded by the number of series.
+	resp.MetricNames, _, err = labelValuesSeriesCount(ctx, idx, labels.MetricName, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (i *Ingester) v2LabelValuesCardinality(ctx context.Context, req *client.LabelValuesCardinalityRequest) (*client.LabelValuesCardinalityResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := i.getTSDB(userID)
+	if db == nil {
+		return &client.LabelValuesCardinalityResponse{}, nil
+	}
+
+	idx, err := db.Head().Index()
+	if err != nil {
+		return nil, err
+	}
+	defer idx.Close()
+
+	resp := &client.LabelValuesCardinalityResponse{
+		LabelNames: make([]client.LabelNameSeriesCount, 0, len(req.LabelNames)),
+	}
+
+	for _, name := range req.LabelNames {
+		values, valuesCount, err := labelValuesSeriesCount(ctx, idx, name, req.MaxLabelValues)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.LabelNames = append(resp.LabelNames, client.LabelNameSeriesCount{LabelName: name, Values: values, ValuesCount: valuesCount})
+	}
+
+	return resp, nil
+}
+
+// labelValuesSeriesCountContextCheckInterval is the number of postings counted between two checks of the context.
+const labelValuesSeriesCountContextCheckInterval = 10000
+
+// labelValuesSeriesCount returns the number of series for each value of the input label name, counting
+// the postings of each value, and the number of values of the label name. If maxValues is greater than 0,
+// only the first maxValues values are counted. Counting stops as soon as the context is done.
+func labelValuesSeriesCount(ctx context.Context, idx tsdb.IndexReader, name string, maxValues uint64) ([]client.LabelValueSeriesCount, uint64, error) {
+	values, err := idx.SortedLabelValues(name)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	valuesCount := uint64(len(values))
+	if maxValues > 0 && uint64(len(values)) > maxValues {
+		values = values[:maxValues]
+	}
+
+	result := make([]client.LabelValueSeriesCount, 0, len(values))
+	for _, value := range values {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		p, err := idx.Postings(name, value)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		count := uint64(0)
+		for p.Next() {
+			count++
+
+			// A single value may have a lot of series, so the context is checked while counting too.
+			if count%labelValuesSeriesCountContextCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return nil, 0, err
+				}
+			}
+		}
+		if err := p.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		result = append(result, client.LabelValueSeriesCount{LabelValue: value, SeriesCount: count})
+	}
+
+	return result, valuesCount, nil
+}
+
 func (i *Ingester) v2MetricsForLabelMatchers(ctx context.Context, req *client.MetricsForLabelMatchersRequest) (*client.MetricsForLabelMatchersResponse, error) {
 	userID, err := tenant.TenantID(ctx)
 	if err != nil {
@@ -1248,14 +1546,22 @@ func (i *Ingester) createTSDB(userID string) (*userTSDB, error) {
 
 	blockRanges := i.cfg.BlocksStorageConfig.TSDB.BlockRanges.ToMilliseconds()
 
+	// Limits are not set when running the flusher, which doesn't need to store exemplars.
+	maxExemplars := 0
+	if i.limits != nil {
+		maxExemplars = i.limits.MaxLocalExemplarsPerUser(userID)
+	}
+
 	userDB := &userTSDB{
 		userID:              userID,
 		refCache:            cortex_tsdb.NewRefCache(),
 		activeSeries:        NewActiveSeries(),
 		seriesInMetric:      newMetricCounter(i.limiter),
+		exemplars:           newExemplarStorage(maxExemplars),
 		ingestedAPISamples:  newEWMARate(0.2, i.cfg.RateUpdatePeriod),
 		ingestedRuleSamples: newEWMARate(0.2, i.cfg.RateUpdatePeriod),
 	}
+	updateActiveSeriesMatchers(userID, userDB.activeSeries, i.limits, i.metrics)
 
 	// Create a new user database
 	db, err := tsdb.Open(udir, userLogger, tsdbPromReg, &tsdb.Options{
@@ -1285,6 +1591,13 @@ func (i *Ingester) createTSDB(userID string) (*userTSDB, error) {
 	}
 
 	userDB.db = db
+
+	userDB.outOfOrder, err = newOutOfOrderStorage(filepath.Join(udir, outOfOrderBlocksDir), i.cfg.BlocksStorageConfig.TSDB.WALSegmentSizeBytes, i.cfg.BlocksStorageConfig.TSDB.WALCompressionEnabled, userLogger)
+	if err != nil {
+		_ = db.Close()
+		return nil, errors.Wrapf(err, "failed to open out-of-order storage: %s", udir)
+	}
+
 	// We set the limiter here because we don't want to limit
 	// series during WAL replay.
 	userDB.limiter = i.limiter
@@ -1328,6 +1641,19 @@ func (i *Ingester) createTSDB(userID string) (*userTSDB, error) {
 		if err := userDB.updateCachedShippedBlocks(); err != nil {
 			level.Error(userLogger).Log("msg", "failed to update cached shipped blocks after shipper initialisation", "err", err)
 		}
+
+		// Out-of-order blocks are stored in a different directory, so they're shipped by a dedicated shipper.
+		// Its metrics are prefixed, otherwise they would clash with the ones of the TSDB shipper.
+		userDB.outOfOrder.shipper = shipper.New(
+			userLogger,
+			prometheus.WrapRegistererWithPrefix(outOfOrderShipperMetricsPrefix, tsdbPromReg),
+			userDB.outOfOrder.dir,
+			bucket.NewUserBucketClient(userID, i.TSDBState.bucket),
+			func() labels.Labels { return l },
+			metadata.ReceiveSource,
+			false, // No need to upload compacted blocks. Cortex compactor takes care of that.
+			true,  // Allow out of order uploads. It's fine in Cortex's context.
+		)
 	}
 
 	i.TSDBState.tsdbMetrics.setRegistryForUser(userID, tsdbPromReg)
@@ -1361,7 +1687,7 @@ func (i *Ingester) closeAllTSDB() {
 			i.userStatesMtx.Unlock()
 
 			i.metrics.memUsers.Dec()
-			i.metrics.activeSeriesPerUser.DeleteLabelValues(userID)
+			i.metrics.deleteActiveSeries(userID, db.activeSeries)
 		}(userDB)
 	}
 
@@ -1594,6 +1920,15 @@ func (i *Ingester) shipBlocks(ctx context.Context) {
 			}
 		}
 
+		if userDB.outOfOrder != nil && userDB.outOfOrder.shipper != nil {
+			uploaded, err := userDB.outOfOrder.shipper.Sync(ctx)
+			if err != nil {
+				level.Warn(userDB.outOfOrder.logger).Log("msg", "shipper failed to synchronize out-of-order TSDB blocks with the storage", "uploaded", uploaded, "err", err)
+			} else {
+				level.Debug(userDB.outOfOrder.logger).Log("msg", "shipper successfully synchronized out-of-order TSDB blocks with storage", "uploaded", uploaded)
+			}
+		}
+
 		return nil
 	})
 }
@@ -1640,6 +1975,10 @@ func (i *Ingester) compactBlocks(ctx context.Context, force bool) {
 			return nil
 		}
 
+		// Out-of-order samples are compacted after the head, because what to compact depends on the
+		// time range still covered by the head.
+		defer i.compactOutOfOrder(userDB, force)
+
 		// Don't do anything, if there is nothing to compact.
 		h := userDB.Head()
 		if h.NumSeries() == 0 {
@@ -1677,6 +2016,37 @@ func (i *Ingester) compactBlocks(ctx context.Context, force bool) {
 	})
 }
 
+// compactOutOfOrder compacts the out-of-order samples whose block range is not covered by the TSDB
+// head anymore (or all of them, if forced) and deletes the out-of-order blocks beyond the retention.
+func (i *Ingester) compactOutOfOrder(userDB *userTSDB, force bool) {
+	if userDB.outOfOrder == nil {
+		return
+	}
+
+	// Prevent the TSDB from being closed while compacting.
+	userDB.stateMtx.RLock()
+	defer userDB.stateMtx.RUnlock()
+
+	if userDB.state != active && userDB.state != activeShipping {
+		return
+	}
+
+	blockDuration := i.cfg.BlocksStorageConfig.TSDB.BlockRanges[0].Milliseconds()
+
+	maxt := int64(math.MaxInt64)
+	if headMinTime := userDB.Head().MinTime(); !force && headMinTime != math.MaxInt64 {
+		maxt = (headMinTime / blockDuration) * blockDuration
+	}
+
+	if err := userDB.outOfOrder.compact(maxt, blockDuration); err != nil {
+		level.Warn(userDB.outOfOrder.logger).Log("msg", "TSDB out-of-order samples compaction for user has failed", "err", err)
+	}
+
+	if err := userDB.outOfOrder.deleteBlocks(i.cfg.BlocksStorageConfig.TSDB.Retention, time.Now()); err != nil {
+		level.Warn(userDB.outOfOrder.logger).Log("msg", "failed to delete out-of-order TSDB blocks beyond the retention", "err", err)
+	}
+}
+
 func (i *Ingester) closeAndDeleteIdleUserTSDBs(ctx context.Context) error {
 	for _, userID := range i.getTSDBUsers() {
 		if ctx.Err() != nil {
@@ -1744,7 +2114,7 @@ func (i *Ingester) closeAndDeleteUserTSDBIfIdle(userID string) tsdbCloseCheckRes
 	i.userStatesMtx.Unlock()
 
 	i.metrics.memUsers.Dec()
-	i.metrics.activeSeriesPerUser.DeleteLabelValues(userID)
+	i.metrics.deleteActiveSeries(userID, userDB.activeSeries)
 	i.TSDBState.tsdbMetrics.removeRegistryForUser(userID)
 
 	// And delete local data.
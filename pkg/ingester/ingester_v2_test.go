@@ -669,6 +669,85 @@ func Benchmark_Ingester_v2PushOnOutOfBoundsSamplesWithHighConcurrency(b *testing
 	wg.Wait()
 }
 
+func TestIngester_v2ActiveSeriesCustomTrackers(t *testing.T) {
+	limits := defaultLimitsTestConfig()
+	limits.ActiveSeriesCustomTrackers = map[string]string{"team_a": `{team="a"}`}
+
+	registry := prometheus.NewRegistry()
+	cfg := defaultIngesterTestConfig()
+	cfg.ActiveSeriesMetricsEnabled = true
+
+	dataDir, err := ioutil.TempDir("", "ingester")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dataDir)) })
+
+	i, err := prepareIngesterWithBlocksStorageAndLimits(t, cfg, limits, dataDir, registry)
+	require.NoError(t, err)
+
+	// Override the tenant limits to track different series for the second tenant.
+	tenantLimits := defaultLimitsTestConfig()
+	tenantLimits.ActiveSeriesCustomTrackers = map[string]string{"team_b": `{team="b"}`, "up": `up`}
+	i.limits, err = validation.NewOverrides(limits, func(userID string) *validation.Limits {
+		if userID == "user-2" {
+			return &tenantLimits
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's ACTIVE
+	test.Poll(t, 1*time.Second, ring.ACTIVE, func() interface{} {
+		return i.lifecycler.GetState()
+	})
+
+	for _, userID := range []string{"user-1", "user-2"} {
+		ctx := user.InjectOrgID(context.Background(), userID)
+		for _, series := range []labels.Labels{
+			labels.FromStrings(labels.MetricName, "up", "team", "a"),
+			labels.FromStrings(labels.MetricName, "up", "team", "b"),
+			labels.FromStrings(labels.MetricName, "requests_total", "team", "b"),
+		} {
+			req, _, _ := mockWriteRequest(series, 1, 100000)
+			_, err := i.v2Push(ctx, req)
+			require.NoError(t, err)
+		}
+	}
+
+	i.v2UpdateActiveSeries()
+
+	expectedMetrics := `
+		# HELP cortex_ingester_active_series Number of currently active series per user.
+		# TYPE cortex_ingester_active_series gauge
+		cortex_ingester_active_series{user="user-1"} 3
+		cortex_ingester_active_series{user="user-2"} 3
+		# HELP cortex_ingester_active_series_custom_tracker Number of currently active series matching a pre-configured label matchers per user.
+		# TYPE cortex_ingester_active_series_custom_tracker gauge
+		cortex_ingester_active_series_custom_tracker{name="team_a",user="user-1"} 1
+		cortex_ingester_active_series_custom_tracker{name="team_b",user="user-2"} 2
+		cortex_ingester_active_series_custom_tracker{name="up",user="user-2"} 2
+	`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expectedMetrics), "cortex_ingester_active_series", "cortex_ingester_active_series_custom_tracker"))
+
+	// Changing the tenant trackers should reload them, removing the metrics of the previous ones.
+	tenantLimits.ActiveSeriesCustomTrackers = map[string]string{"team_a": `{team="a"}`}
+	i.v2UpdateActiveSeries()
+
+	expectedMetrics = `
+		# HELP cortex_ingester_active_series Number of currently active series per user.
+		# TYPE cortex_ingester_active_series gauge
+		cortex_ingester_active_series{user="user-1"} 3
+		cortex_ingester_active_series{user="user-2"} 0
+		# HELP cortex_ingester_active_series_custom_tracker Number of currently active series matching a pre-configured label matchers per user.
+		# TYPE cortex_ingester_active_series_custom_tracker gauge
+		cortex_ingester_active_series_custom_tracker{name="team_a",user="user-1"} 1
+		cortex_ingester_active_series_custom_tracker{name="team_a",user="user-2"} 0
+	`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expectedMetrics), "cortex_ingester_active_series", "cortex_ingester_active_series_custom_tracker"))
+}
+
 func Test_Ingester_v2LabelNames(t *testing.T) {
 	series := []struct {
 		lbls      labels.Labels
@@ -755,6 +834,93 @@ func Test_Ingester_v2LabelValues(t *testing.T) {
 	}
 }
 
+func Test_Ingester_v2Cardinality(t *testing.T) {
+	series := []struct {
+		lbls      labels.Labels
+		value     float64
+		timestamp int64
+	}{
+		{labels.Labels{{Name: labels.MetricName, Value: "test_1"}, {Name: "status", Value: "200"}, {Name: "route", Value: "get_user"}}, 1, 100000},
+		{labels.Labels{{Name: labels.MetricName, Value: "test_1"}, {Name: "status", Value: "500"}, {Name: "route", Value: "get_user"}}, 1, 110000},
+		{labels.Labels{{Name: labels.MetricName, Value: "test_2"}}, 2, 200000},
+	}
+
+	// Create ingester
+	i, err := prepareIngesterWithBlocksStorage(t, defaultIngesterTestConfig(), nil)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's ACTIVE
+	test.Poll(t, 1*time.Second, ring.ACTIVE, func() interface{} {
+		return i.lifecycler.GetState()
+	})
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+
+	// Should return an empty response if the TSDB doesn't exist yet.
+	namesRes, err := i.v2LabelNamesCardinality(ctx, &client.LabelNamesCardinalityRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &client.LabelNamesCardinalityResponse{}, namesRes)
+
+	// Push series
+	for _, series := range series {
+		req, _, _ := mockWriteRequest(series.lbls, series.value, series.timestamp)
+		_, err := i.v2Push(ctx, req)
+		require.NoError(t, err)
+	}
+
+	namesRes, err = i.v2LabelNamesCardinality(ctx, &client.LabelNamesCardinalityRequest{MaxLabelValues: 10})
+	require.NoError(t, err)
+	assert.Equal(t, &client.LabelNamesCardinalityResponse{
+		NumSeries: 3,
+		LabelNames: []client.LabelNameValues{
+			{LabelName: "__name__", Values: []string{"test_1", "test_2"}, ValuesCount: 2},
+			{LabelName: "route", Values: []string{"get_user"}, ValuesCount: 1},
+			{LabelName: "status", Values: []string{"200", "500"}, ValuesCount: 2},
+		},
+		MetricNames: []client.LabelValueSeriesCount{
+			{LabelValue: "test_1", SeriesCount: 2},
+			{LabelValue: "test_2", SeriesCount: 1},
+		},
+	}, namesRes)
+
+	// Should not return the values of label names with more values than the max.
+	namesRes, err = i.v2LabelNamesCardinality(ctx, &client.LabelNamesCardinalityRequest{MaxLabelValues: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []client.LabelNameValues{
+		{LabelName: "__name__", ValuesCount: 2},
+		{LabelName: "route", Values: []string{"get_user"}, ValuesCount: 1},
+		{LabelName: "status", ValuesCount: 2},
+	}, namesRes.LabelNames)
+
+	valuesRes, err := i.v2LabelValuesCardinality(ctx, &client.LabelValuesCardinalityRequest{LabelNames: []string{"status", "unknown"}})
+	require.NoError(t, err)
+	assert.Equal(t, &client.LabelValuesCardinalityResponse{
+		LabelNames: []client.LabelNameSeriesCount{
+			{LabelName: "status", Values: []client.LabelValueSeriesCount{{LabelValue: "200", SeriesCount: 1}, {LabelValue: "500", SeriesCount: 1}}, ValuesCount: 2},
+			{LabelName: "unknown", Values: []client.LabelValueSeriesCount{}},
+		},
+	}, valuesRes)
+
+	// Should count the series of the first values only, in sorted order, if there are more values than the max.
+	valuesRes, err = i.v2LabelValuesCardinality(ctx, &client.LabelValuesCardinalityRequest{LabelNames: []string{"status"}, MaxLabelValues: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []client.LabelNameSeriesCount{
+		{LabelName: "status", Values: []client.LabelValueSeriesCount{{LabelValue: "200", SeriesCount: 1}}, ValuesCount: 2},
+	}, valuesRes.LabelNames)
+
+	// Should stop counting once the context is canceled.
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err = i.v2LabelValuesCardinality(canceledCtx, &client.LabelValuesCardinalityRequest{LabelNames: []string{"status"}})
+	assert.Equal(t, context.Canceled, err)
+
+	_, err = i.v2LabelNamesCardinality(canceledCtx, &client.LabelNamesCardinalityRequest{MaxLabelValues: 10})
+	assert.Equal(t, context.Canceled, err)
+}
+
 func Test_Ingester_v2Query(t *testing.T) {
 	series := []struct {
 		lbls      labels.Labels
@@ -2834,3 +3000,75 @@ func TestIngesterNoFlushWithInFlightRequest(t *testing.T) {
 		cortex_ingester_tsdb_compactions_total 1
 	`), "cortex_ingester_tsdb_compactions_total"))
 }
+
+func TestIngester_v2QueryExemplars(t *testing.T) {
+	limits := defaultLimitsTestConfig()
+	limits.MaxLocalExemplarsPerUser = 2
+
+	dataDir, err := ioutil.TempDir("", "ingester")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dataDir)) })
+
+	registry := prometheus.NewRegistry()
+	i, err := prepareIngesterWithBlocksStorageAndLimits(t, defaultIngesterTestConfig(), limits, dataDir, registry)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's ACTIVE.
+	test.Poll(t, 1*time.Second, ring.ACTIVE, func() interface{} {
+		return i.lifecycler.GetState()
+	})
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+	// The labels slice is reused once the push request has been processed, so a new one is built each time.
+	series := func() []client.LabelAdapter { return []client.LabelAdapter{{Name: labels.MetricName, Value: "test"}} }
+
+	for ts := int64(1); ts <= 3; ts++ {
+		req := &client.WriteRequest{
+			Timeseries: []client.PreallocTimeseries{{TimeSeries: &client.TimeSeries{
+				Labels:    series(),
+				Samples:   []client.Sample{{Value: float64(ts), TimestampMs: ts * 1000}},
+				Exemplars: []client.Exemplar{{Labels: []client.LabelAdapter{{Name: "traceID", Value: "abc"}}, Value: float64(ts), TimestampMs: ts * 1000}},
+			}}},
+			Source: client.API,
+		}
+		_, err := i.v2Push(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// Pushing an out of order exemplar should not fail the request.
+	_, err = i.v2Push(ctx, &client.WriteRequest{
+		Timeseries: []client.PreallocTimeseries{{TimeSeries: &client.TimeSeries{
+			Labels:    series(),
+			Samples:   []client.Sample{{Value: 4, TimestampMs: 4000}},
+			Exemplars: []client.Exemplar{{Labels: []client.LabelAdapter{{Name: "traceID", Value: "def"}}, Value: 4, TimestampMs: 1500}},
+		}}},
+		Source: client.API,
+	})
+	require.NoError(t, err)
+
+	req, err := client.ToExemplarQueryRequest(0, 10000, []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test")})
+	require.NoError(t, err)
+
+	res, err := i.QueryExemplars(ctx, req)
+	require.NoError(t, err)
+
+	// Only the most recent exemplars are kept.
+	assert.Equal(t, &client.ExemplarQueryResponse{Timeseries: []client.TimeSeries{{
+		Labels: series(),
+		Exemplars: []client.Exemplar{
+			{Labels: []client.LabelAdapter{{Name: "traceID", Value: "abc"}}, Value: 2, TimestampMs: 2000},
+			{Labels: []client.LabelAdapter{{Name: "traceID", Value: "abc"}}, Value: 3, TimestampMs: 3000},
+		},
+	}}}, res)
+
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+		# HELP cortex_ingester_ingested_exemplars_total The total number of exemplars ingested.
+		# TYPE cortex_ingester_ingested_exemplars_total counter
+		cortex_ingester_ingested_exemplars_total 3
+		# HELP cortex_ingester_ingested_exemplars_failures_total The total number of exemplars that errored on ingestion.
+		# TYPE cortex_ingester_ingested_exemplars_failures_total counter
+		cortex_ingester_ingested_exemplars_failures_total 1
+	`), "cortex_ingester_ingested_exemplars_total", "cortex_ingester_ingested_exemplars_failures_total"))
+}
@@ -16,25 +16,29 @@ const (
 )
 
 type ingesterMetrics struct {
-	flushQueueLength        prometheus.Gauge
-	ingestedSamples         prometheus.Counter
-	ingestedMetadata        prometheus.Counter
-	ingestedSamplesFail     prometheus.Counter
-	ingestedMetadataFail    prometheus.Counter
-	queries                 prometheus.Counter
-	queriedSamples          prometheus.Histogram
-	queriedSeries           prometheus.Histogram
-	queriedChunks           prometheus.Histogram
-	memSeries               prometheus.Gauge
-	memMetadata             prometheus.Gauge
-	memUsers                prometheus.Gauge
-	memSeriesCreatedTotal   *prometheus.CounterVec
-	memMetadataCreatedTotal *prometheus.CounterVec
-	memSeriesRemovedTotal   *prometheus.CounterVec
-	memMetadataRemovedTotal *prometheus.CounterVec
-	createdChunks           prometheus.Counter
-	walReplayDuration       prometheus.Gauge
-	walCorruptionsTotal     prometheus.Counter
+	flushQueueLength          prometheus.Gauge
+	ingestedSamples           prometheus.Counter
+	ingestedMetadata          prometheus.Counter
+	ingestedSamplesFail       prometheus.Counter
+	ingestedMetadataFail      prometheus.Counter
+	ingestedExemplars         prometheus.Counter
+	ingestedExemplarsFail     prometheus.Counter
+	ingestedOutOfOrderSamples prometheus.Counter
+	queries                   prometheus.Counter
+	queriedSamples            prometheus.Histogram
+	queriedExemplars          prometheus.Histogram
+	queriedSeries             prometheus.Histogram
+	queriedChunks             prometheus.Histogram
+	memSeries                 prometheus.Gauge
+	memMetadata               prometheus.Gauge
+	memUsers                  prometheus.Gauge
+	memSeriesCreatedTotal     *prometheus.CounterVec
+	memMetadataCreatedTotal   *prometheus.CounterVec
+	memSeriesRemovedTotal     *prometheus.CounterVec
+	memMetadataRemovedTotal   *prometheus.CounterVec
+	createdChunks             prometheus.Counter
+	walReplayDuration         prometheus.Gauge
+	walCorruptionsTotal       prometheus.Counter
 
 	// Chunks transfer.
 	sentChunks     prometheus.Counter
@@ -52,7 +56,8 @@ type ingesterMetrics struct {
 	droppedChunks                 prometheus.Counter
 	oldestUnflushedChunkTimestamp prometheus.Gauge
 
-	activeSeriesPerUser *prometheus.GaugeVec
+	activeSeriesPerUser               *prometheus.GaugeVec
+	activeSeriesCustomTrackersPerUser *prometheus.GaugeVec
 }
 
 func newIngesterMetrics(r prometheus.Registerer, createMetricsConflictingWithTSDB bool, activeSeriesEnabled bool) *ingesterMetrics {
@@ -77,6 +82,18 @@ func newIngesterMetrics(r prometheus.Registerer, createMetricsConflictingWithTSD
 			Name: "cortex_ingester_ingested_metadata_failures_total",
 			Help: "The total number of metadata that errored on ingestion.",
 		}),
+		ingestedExemplars: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_ingester_ingested_exemplars_total",
+			Help: "The total number of exemplars ingested.",
+		}),
+		ingestedExemplarsFail: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_ingester_ingested_exemplars_failures_total",
+			Help: "The total number of exemplars that errored on ingestion.",
+		}),
+		ingestedOutOfOrderSamples: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_ingester_ingested_out_of_order_samples_total",
+			Help: "The total number of samples ingested out of order, within the out-of-order time window.",
+		}),
 		queries: promauto.With(r).NewCounter(prometheus.CounterOpts{
 			Name: "cortex_ingester_queries_total",
 			Help: "The total number of queries the ingester has handled.",
@@ -87,6 +104,12 @@ func newIngesterMetrics(r prometheus.Registerer, createMetricsConflictingWithTSD
 			// Could easily return 10m samples per query - 10*(8^(8-1)) = 20.9m.
 			Buckets: prometheus.ExponentialBuckets(10, 8, 8),
 		}),
+		queriedExemplars: promauto.With(r).NewHistogram(prometheus.HistogramOpts{
+			Name: "cortex_ingester_queried_exemplars",
+			Help: "The total number of exemplars returned from queries.",
+			// A reasonable upper bound is around 100k - 10*(5^(7-1)) = 156k.
+			Buckets: prometheus.ExponentialBuckets(10, 5, 7),
+		}),
 		queriedSeries: promauto.With(r).NewHistogram(prometheus.HistogramOpts{
 			Name: "cortex_ingester_queried_series",
 			Help: "The total number of series returned from queries.",
@@ -195,10 +218,15 @@ func newIngesterMetrics(r prometheus.Registerer, createMetricsConflictingWithTSD
 			Name: "cortex_ingester_active_series",
 			Help: "Number of currently active series per user.",
 		}, []string{"user"}),
+		activeSeriesCustomTrackersPerUser: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cortex_ingester_active_series_custom_tracker",
+			Help: "Number of currently active series matching a pre-configured label matchers per user.",
+		}, []string{"user", "name"}),
 	}
 
 	if activeSeriesEnabled && r != nil {
 		r.MustRegister(m.activeSeriesPerUser)
+		r.MustRegister(m.activeSeriesCustomTrackersPerUser)
 	}
 
 	if createMetricsConflictingWithTSDB {
@@ -216,6 +244,29 @@ func newIngesterMetrics(r prometheus.Registerer, createMetricsConflictingWithTSD
 	return m
 }
 
+// setActiveSeries updates the active series metrics of a user, including the custom trackers ones.
+func (m *ingesterMetrics) setActiveSeries(userID string, activeSeries *ActiveSeries) {
+	total, totalMatching := activeSeries.ActiveWithMatchers()
+	m.activeSeriesPerUser.WithLabelValues(userID).Set(float64(total))
+
+	for i, name := range activeSeries.CurrentMatchers().Names() {
+		m.activeSeriesCustomTrackersPerUser.WithLabelValues(userID, name).Set(float64(totalMatching[i]))
+	}
+}
+
+// deleteActiveSeries removes the active series metrics of a user.
+func (m *ingesterMetrics) deleteActiveSeries(userID string, activeSeries *ActiveSeries) {
+	m.activeSeriesPerUser.DeleteLabelValues(userID)
+	m.deleteActiveSeriesCustomTrackers(userID, activeSeries.CurrentMatchers())
+}
+
+// deleteActiveSeriesCustomTrackers removes the active series metrics of a user for the input custom trackers.
+func (m *ingesterMetrics) deleteActiveSeriesCustomTrackers(userID string, asm *ActiveSeriesMatchers) {
+	for _, name := range asm.Names() {
+		m.activeSeriesCustomTrackersPerUser.DeleteLabelValues(userID, name)
+	}
+}
+
 // TSDB metrics collector. Each tenant has its own registry, that TSDB code uses.
 type tsdbMetrics struct {
 	// Metrics aggregated from Thanos shipper.
@@ -224,6 +275,12 @@ type tsdbMetrics struct {
 	uploads         *prometheus.Desc // sum(thanos_shipper_uploads_total)
 	uploadFailures  *prometheus.Desc // sum(thanos_shipper_upload_failures_total)
 
+	// Metrics aggregated from the Thanos shipper of the out-of-order blocks.
+	outOfOrderDirSyncs        *prometheus.Desc // sum(out_of_order_thanos_shipper_dir_syncs_total)
+	outOfOrderDirSyncFailures *prometheus.Desc // sum(out_of_order_thanos_shipper_dir_sync_failures_total)
+	outOfOrderUploads         *prometheus.Desc // sum(out_of_order_thanos_shipper_uploads_total)
+	outOfOrderUploadFailures  *prometheus.Desc // sum(out_of_order_thanos_shipper_upload_failures_total)
+
 	// Metrics aggregated from TSDB.
 	tsdbCompactionsTotal         *prometheus.Desc
 	tsdbCompactionDuration       *prometheus.Desc
@@ -277,6 +334,22 @@ func newTSDBMetrics(r prometheus.Registerer) *tsdbMetrics {
 			"cortex_ingester_shipper_upload_failures_total",
 			"Total number of TSDB block upload failures",
 			nil, nil),
+		outOfOrderDirSyncs: prometheus.NewDesc(
+			"cortex_ingester_out_of_order_shipper_dir_syncs_total",
+			"Total number of out-of-order blocks dir syncs",
+			nil, nil),
+		outOfOrderDirSyncFailures: prometheus.NewDesc(
+			"cortex_ingester_out_of_order_shipper_dir_sync_failures_total",
+			"Total number of failed out-of-order blocks dir syncs",
+			nil, nil),
+		outOfOrderUploads: prometheus.NewDesc(
+			"cortex_ingester_out_of_order_shipper_uploads_total",
+			"Total number of uploaded out-of-order blocks",
+			nil, nil),
+		outOfOrderUploadFailures: prometheus.NewDesc(
+			"cortex_ingester_out_of_order_shipper_upload_failures_total",
+			"Total number of out-of-order block upload failures",
+			nil, nil),
 		tsdbCompactionsTotal: prometheus.NewDesc(
 			"cortex_ingester_tsdb_compactions_total",
 			"Total number of TSDB compactions that were executed.",
@@ -385,6 +458,10 @@ func (sm *tsdbMetrics) Describe(out chan<- *prometheus.Desc) {
 	out <- sm.dirSyncFailures
 	out <- sm.uploads
 	out <- sm.uploadFailures
+	out <- sm.outOfOrderDirSyncs
+	out <- sm.outOfOrderDirSyncFailures
+	out <- sm.outOfOrderUploads
+	out <- sm.outOfOrderUploadFailures
 
 	out <- sm.tsdbCompactionsTotal
 	out <- sm.tsdbCompactionDuration
@@ -422,6 +499,10 @@ func (sm *tsdbMetrics) Collect(out chan<- prometheus.Metric) {
 	data.SendSumOfCounters(out, sm.dirSyncFailures, "thanos_shipper_dir_sync_failures_total")
 	data.SendSumOfCounters(out, sm.uploads, "thanos_shipper_uploads_total")
 	data.SendSumOfCounters(out, sm.uploadFailures, "thanos_shipper_upload_failures_total")
+	data.SendSumOfCounters(out, sm.outOfOrderDirSyncs, outOfOrderShipperMetricsPrefix+"thanos_shipper_dir_syncs_total")
+	data.SendSumOfCounters(out, sm.outOfOrderDirSyncFailures, outOfOrderShipperMetricsPrefix+"thanos_shipper_dir_sync_failures_total")
+	data.SendSumOfCounters(out, sm.outOfOrderUploads, outOfOrderShipperMetricsPrefix+"thanos_shipper_uploads_total")
+	data.SendSumOfCounters(out, sm.outOfOrderUploadFailures, outOfOrderShipperMetricsPrefix+"thanos_shipper_upload_failures_total")
 
 	data.SendSumOfCounters(out, sm.tsdbCompactionsTotal, "prometheus_tsdb_compactions_total")
 	data.SendSumOfHistograms(out, sm.tsdbCompactionDuration, "prometheus_tsdb_compaction_duration_seconds")
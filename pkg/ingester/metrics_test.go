@@ -40,6 +40,26 @@ func TestTSDBMetrics(t *testing.T) {
 			# 4*(12345 + 85787 + 999)
 			cortex_ingester_shipper_upload_failures_total 396524
 
+			# HELP cortex_ingester_out_of_order_shipper_dir_syncs_total Total number of out-of-order blocks dir syncs
+			# TYPE cortex_ingester_out_of_order_shipper_dir_syncs_total counter
+			# 30*(12345 + 85787 + 999)
+			cortex_ingester_out_of_order_shipper_dir_syncs_total 2973930
+
+			# HELP cortex_ingester_out_of_order_shipper_dir_sync_failures_total Total number of failed out-of-order blocks dir syncs
+			# TYPE cortex_ingester_out_of_order_shipper_dir_sync_failures_total counter
+			# 31*(12345 + 85787 + 999)
+			cortex_ingester_out_of_order_shipper_dir_sync_failures_total 3073061
+
+			# HELP cortex_ingester_out_of_order_shipper_uploads_total Total number of uploaded out-of-order blocks
+			# TYPE cortex_ingester_out_of_order_shipper_uploads_total counter
+			# 32*(12345 + 85787 + 999)
+			cortex_ingester_out_of_order_shipper_uploads_total 3172192
+
+			# HELP cortex_ingester_out_of_order_shipper_upload_failures_total Total number of out-of-order block upload failures
+			# TYPE cortex_ingester_out_of_order_shipper_upload_failures_total counter
+			# 33*(12345 + 85787 + 999)
+			cortex_ingester_out_of_order_shipper_upload_failures_total 3271323
+
 			# HELP cortex_ingester_tsdb_compactions_total Total number of TSDB compactions that were executed.
 			# TYPE cortex_ingester_tsdb_compactions_total counter
 			cortex_ingester_tsdb_compactions_total 693917
@@ -202,6 +222,26 @@ func TestTSDBMetricsWithRemoval(t *testing.T) {
 			# 4*(12345 + 85787 + 999)
 			cortex_ingester_shipper_upload_failures_total 396524
 
+			# HELP cortex_ingester_out_of_order_shipper_dir_syncs_total Total number of out-of-order blocks dir syncs
+			# TYPE cortex_ingester_out_of_order_shipper_dir_syncs_total counter
+			# 30*(12345 + 85787 + 999)
+			cortex_ingester_out_of_order_shipper_dir_syncs_total 2973930
+
+			# HELP cortex_ingester_out_of_order_shipper_dir_sync_failures_total Total number of failed out-of-order blocks dir syncs
+			# TYPE cortex_ingester_out_of_order_shipper_dir_sync_failures_total counter
+			# 31*(12345 + 85787 + 999)
+			cortex_ingester_out_of_order_shipper_dir_sync_failures_total 3073061
+
+			# HELP cortex_ingester_out_of_order_shipper_uploads_total Total number of uploaded out-of-order blocks
+			# TYPE cortex_ingester_out_of_order_shipper_uploads_total counter
+			# 32*(12345 + 85787 + 999)
+			cortex_ingester_out_of_order_shipper_uploads_total 3172192
+
+			# HELP cortex_ingester_out_of_order_shipper_upload_failures_total Total number of out-of-order block upload failures
+			# TYPE cortex_ingester_out_of_order_shipper_upload_failures_total counter
+			# 33*(12345 + 85787 + 999)
+			cortex_ingester_out_of_order_shipper_upload_failures_total 3271323
+
 			# HELP cortex_ingester_tsdb_compactions_total Total number of TSDB compactions that were executed.
 			# TYPE cortex_ingester_tsdb_compactions_total counter
 			cortex_ingester_tsdb_compactions_total 693917
@@ -357,6 +397,31 @@ func populateTSDBMetrics(base float64) *prometheus.Registry {
 	})
 	uploadFailures.Add(4 * base)
 
+	// Thanos shipper of the out-of-order blocks.
+	oooDirSyncs := promauto.With(r).NewCounter(prometheus.CounterOpts{
+		Name: "out_of_order_thanos_shipper_dir_syncs_total",
+		Help: "Total number of dir syncs",
+	})
+	oooDirSyncs.Add(30 * base)
+
+	oooDirSyncFailures := promauto.With(r).NewCounter(prometheus.CounterOpts{
+		Name: "out_of_order_thanos_shipper_dir_sync_failures_total",
+		Help: "Total number of failed dir syncs",
+	})
+	oooDirSyncFailures.Add(31 * base)
+
+	oooUploads := promauto.With(r).NewCounter(prometheus.CounterOpts{
+		Name: "out_of_order_thanos_shipper_uploads_total",
+		Help: "Total number of uploaded blocks",
+	})
+	oooUploads.Add(32 * base)
+
+	oooUploadFailures := promauto.With(r).NewCounter(prometheus.CounterOpts{
+		Name: "out_of_order_thanos_shipper_upload_failures_total",
+		Help: "Total number of block upload failures",
+	})
+	oooUploadFailures.Add(33 * base)
+
 	// TSDB Head
 	seriesCreated := promauto.With(r).NewCounter(prometheus.CounterOpts{
 		Name: "prometheus_tsdb_head_series_created_total",
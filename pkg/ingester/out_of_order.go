@@ -0,0 +1,768 @@
+package ingester
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/oklog/ulid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+	"github.com/prometheus/prometheus/tsdb/record"
+	"github.com/prometheus/prometheus/tsdb/wal"
+	"github.com/thanos-io/thanos/pkg/shipper"
+
+	"github.com/cortexproject/cortex/pkg/querier/series"
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+const (
+	// Name of the directory, within the tenant's TSDB directory, where the out-of-order
+	// blocks are stored. It's not a valid block ULID, so it's ignored by the TSDB.
+	outOfOrderBlocksDir = "out_of_order"
+
+	// Name of the directory, within the out-of-order blocks directory, where the out-of-order
+	// head WAL is stored.
+	outOfOrderWALDir = "wal"
+
+	// Prefix of the metrics of the out-of-order blocks shipper, registered in the tenant's TSDB
+	// registry, to not clash with the metrics of the TSDB blocks shipper.
+	outOfOrderShipperMetricsPrefix = "out_of_order_"
+)
+
+// outOfOrderStorage holds the samples of a single tenant which have been ingested out of order,
+// within the tenant's out-of-order time window. Samples are kept in memory in the out-of-order
+// head until the time range they belong to has been compacted from the TSDB head, then they're
+// compacted into blocks stored in a dedicated directory, which are shipped to the storage like
+// the other blocks. Samples in the out-of-order head are logged to a dedicated WAL, which is
+// replayed on startup and checkpointed each time the head is compacted.
+type outOfOrderStorage struct {
+	dir    string
+	logger log.Logger
+	head   *outOfOrderHead
+	wal    *wal.WAL
+
+	// Thanos shipper used to ship the out-of-order blocks to the storage.
+	shipper Shipper
+
+	blocksMtx sync.RWMutex
+	blocks    []*tsdb.Block
+}
+
+// newOutOfOrderStorage creates the out-of-order storage in the input directory, opening the
+// out-of-order blocks already stored in it and replaying the WAL into the out-of-order head.
+func newOutOfOrderStorage(dir string, walSegmentSize int, walCompression bool, logger log.Logger) (*outOfOrderStorage, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, errors.Wrap(err, "create out-of-order blocks directory")
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "read out-of-order blocks directory")
+	}
+
+	s := &outOfOrderStorage{
+		dir:    dir,
+		logger: logger,
+		head:   newOutOfOrderHead(),
+	}
+
+	for _, entry := range entries {
+		if _, err := ulid.Parse(entry.Name()); err != nil || !entry.IsDir() {
+			continue
+		}
+
+		block, err := tsdb.OpenBlock(logger, filepath.Join(dir, entry.Name()), nil)
+		if err != nil {
+			_ = s.close()
+			return nil, errors.Wrapf(err, "open out-of-order block %s", entry.Name())
+		}
+		s.blocks = append(s.blocks, block)
+	}
+
+	walDir := filepath.Join(dir, outOfOrderWALDir)
+	if err := s.replayWAL(walDir); err != nil {
+		_ = s.close()
+		return nil, errors.Wrap(err, "replay out-of-order WAL")
+	}
+
+	// Opening the WAL creates a new segment, after the replayed ones.
+	if s.wal, err = wal.NewSize(logger, nil, walDir, walSegmentSize, walCompression); err != nil {
+		_ = s.close()
+		return nil, errors.Wrap(err, "open out-of-order WAL")
+	}
+
+	// The series references assigned while replaying don't match the ones in the replayed
+	// segments, so the head is checkpointed to the new segment and the old ones are removed.
+	if err := s.checkpointWAL(); err != nil {
+		_ = s.close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// replayWAL adds the samples logged in the WAL to the out-of-order head. Samples of series whose
+// record has been removed by a checkpoint have already been compacted into blocks, so they're skipped.
+func (s *outOfOrderStorage) replayWAL(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	sr, err := wal.NewSegmentsReader(dir)
+	if err != nil {
+		return err
+	}
+	defer sr.Close() //nolint:errcheck
+
+	var (
+		dec     record.Decoder
+		series  = map[uint64]labels.Labels{}
+		refs    []record.RefSeries
+		samples []record.RefSample
+	)
+
+	r := wal.NewReader(sr)
+	for r.Next() {
+		rec := r.Record()
+
+		switch dec.Type(rec) {
+		case record.Series:
+			if refs, err = dec.Series(rec, refs[:0]); err != nil {
+				return errors.Wrap(err, "decode series")
+			}
+			for _, ref := range refs {
+				series[ref.Ref] = ref.Labels
+			}
+
+		case record.Samples:
+			if samples, err = dec.Samples(rec, samples[:0]); err != nil {
+				return errors.Wrap(err, "decode samples")
+			}
+			for _, sample := range samples {
+				lbls, ok := series[sample.Ref]
+				if !ok {
+					continue
+				}
+
+				// The value for the same timestamp can't change once acknowledged, so the error is ignored.
+				_, _, _ = s.head.add(lbls, sample.T, sample.V)
+			}
+		}
+	}
+
+	return r.Err()
+}
+
+// logWAL logs the input out-of-order series and samples to the WAL. Series must be logged
+// the first time a sample is added to them.
+func (s *outOfOrderStorage) logWAL(series []record.RefSeries, samples []record.RefSample) error {
+	var (
+		enc  record.Encoder
+		recs [][]byte
+	)
+
+	if len(series) > 0 {
+		recs = append(recs, enc.Series(series, nil))
+	}
+	if len(samples) > 0 {
+		recs = append(recs, enc.Samples(samples, nil))
+	}
+	if len(recs) == 0 {
+		return nil
+	}
+
+	return errors.Wrap(s.wal.Log(recs...), "log out-of-order samples to WAL")
+}
+
+// checkpointWAL logs all the series and samples in the out-of-order head to a new WAL segment and
+// removes the previous segments, which may contain samples already compacted into blocks.
+func (s *outOfOrderStorage) checkpointWAL() error {
+	if err := s.wal.NextSegment(); err != nil {
+		return errors.Wrap(err, "create out-of-order WAL segment")
+	}
+
+	_, last, err := wal.Segments(s.wal.Dir())
+	if err != nil {
+		return errors.Wrap(err, "list out-of-order WAL segments")
+	}
+
+	series, samples := s.head.snapshot()
+	if err := s.logWAL(series, samples); err != nil {
+		return err
+	}
+
+	return errors.Wrap(s.wal.Truncate(last), "truncate out-of-order WAL")
+}
+
+// queriers returns the queriers for the out-of-order head and blocks overlapping the input time range.
+func (s *outOfOrderStorage) queriers(mint, maxt int64) ([]storage.Querier, error) {
+	var queriers []storage.Querier
+	if !s.head.empty() {
+		queriers = append(queriers, &outOfOrderHeadQuerier{head: s.head, mint: mint, maxt: maxt})
+	}
+
+	s.blocksMtx.RLock()
+	defer s.blocksMtx.RUnlock()
+
+	for _, b := range s.blocks {
+		if !b.OverlapsClosedInterval(mint, maxt) {
+			continue
+		}
+
+		q, err := tsdb.NewBlockQuerier(b, mint, maxt)
+		if err != nil {
+			for _, q := range queriers {
+				_ = q.Close()
+			}
+			return nil, errors.Wrapf(err, "open querier for out-of-order block %s", b.Meta().ULID.String())
+		}
+		queriers = append(queriers, q)
+	}
+
+	return queriers, nil
+}
+
+// compact compacts the out-of-order head samples older than maxt into blocks, one for
+// each block range, and checkpoints the WAL.
+func (s *outOfOrderStorage) compact(maxt, blockDuration int64) error {
+	flushed, err := s.head.flush(maxt, func(flushed []storage.Series) error {
+		// Group the series samples by block range.
+		ranges := map[int64][]storage.Series{}
+		for _, fs := range flushed {
+			it := fs.Iterator()
+
+			var (
+				samples    []model.SamplePair
+				blockStart int64
+			)
+			for it.Next() {
+				t, v := it.At()
+				if start := (t / blockDuration) * blockDuration; len(samples) > 0 && start != blockStart {
+					ranges[blockStart] = append(ranges[blockStart], series.NewConcreteSeries(fs.Labels(), samples))
+					samples = nil
+				}
+				if len(samples) == 0 {
+					blockStart = (t / blockDuration) * blockDuration
+				}
+				samples = append(samples, model.SamplePair{Timestamp: model.Time(t), Value: model.SampleValue(v)})
+			}
+			if len(samples) > 0 {
+				ranges[blockStart] = append(ranges[blockStart], series.NewConcreteSeries(fs.Labels(), samples))
+			}
+		}
+
+		for _, rangeSeries := range ranges {
+			block, err := s.writeBlock(rangeSeries, blockDuration)
+			if err != nil {
+				return err
+			}
+
+			s.blocksMtx.Lock()
+			s.blocks = append(s.blocks, block)
+			s.blocksMtx.Unlock()
+		}
+
+		return nil
+	})
+	if err != nil || !flushed {
+		return err
+	}
+
+	return s.checkpointWAL()
+}
+
+// writeBlock writes a block containing the input series, which are expected to have samples
+// within the same block range, and opens it.
+func (s *outOfOrderStorage) writeBlock(input []storage.Series, blockDuration int64) (*tsdb.Block, error) {
+	// The TSDB head used to write the block doesn't accept samples older than half block range
+	// compared to the first sample appended, so we append the series with the oldest sample first.
+	sort.Slice(input, func(i, j int) bool {
+		return firstSampleTimestamp(input[i]) < firstSampleTimestamp(input[j])
+	})
+
+	w, err := tsdb.NewBlockWriter(s.logger, s.dir, blockDuration)
+	if err != nil {
+		return nil, errors.Wrap(err, "create out-of-order block writer")
+	}
+	defer func() {
+		if err := w.Close(); err != nil {
+			level.Warn(s.logger).Log("msg", "failed to close out-of-order block writer", "err", err)
+		}
+	}()
+
+	ctx := context.Background()
+	app := w.Appender(ctx)
+	for _, in := range input {
+		it := in.Iterator()
+		for it.Next() {
+			t, v := it.At()
+			if _, err := app.Add(in.Labels(), t, v); err != nil {
+				_ = app.Rollback()
+				return nil, errors.Wrap(err, "append out-of-order sample")
+			}
+		}
+	}
+	if err := app.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit out-of-order samples")
+	}
+
+	id, err := w.Flush(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "write out-of-order block")
+	}
+
+	block, err := tsdb.OpenBlock(s.logger, filepath.Join(s.dir, id.String()), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open out-of-order block %s", id.String())
+	}
+
+	level.Info(s.logger).Log("msg", "compacted out-of-order samples into a new block", "block", id.String(), "mint", block.MinTime(), "maxt", block.MaxTime())
+	return block, nil
+}
+
+// shippedBlocks returns the IDs of the out-of-order blocks which have been shipped to the storage.
+func (s *outOfOrderStorage) shippedBlocks() (map[ulid.ULID]struct{}, error) {
+	shipperMeta, err := shipper.ReadMetaFile(s.dir)
+	if os.IsNotExist(err) {
+		// If the meta file doesn't exist it means the shipper hasn't run yet.
+		shipperMeta = &shipper.Meta{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	shipped := make(map[ulid.ULID]struct{}, len(shipperMeta.Uploaded))
+	for _, blockID := range shipperMeta.Uploaded {
+		shipped[blockID] = struct{}{}
+	}
+	return shipped, nil
+}
+
+// hasUnshippedBlocks returns whether at least an out-of-order block hasn't been shipped to the storage yet.
+func (s *outOfOrderStorage) hasUnshippedBlocks() (bool, error) {
+	shipped, err := s.shippedBlocks()
+	if err != nil {
+		return false, err
+	}
+
+	s.blocksMtx.RLock()
+	defer s.blocksMtx.RUnlock()
+
+	for _, b := range s.blocks {
+		if _, ok := shipped[b.Meta().ULID]; !ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteBlocks deletes the out-of-order blocks whose max time is older than the retention
+// and, if shipping is enabled, have been shipped to the storage.
+func (s *outOfOrderStorage) deleteBlocks(retention time.Duration, now time.Time) error {
+	var shipped map[ulid.ULID]struct{}
+	if s.shipper != nil {
+		var err error
+		if shipped, err = s.shippedBlocks(); err != nil {
+			return err
+		}
+	}
+
+	s.blocksMtx.Lock()
+	defer s.blocksMtx.Unlock()
+
+	retained := s.blocks[:0]
+	for _, b := range s.blocks {
+		_, isShipped := shipped[b.Meta().ULID]
+		if b.MaxTime() >= util.TimeToMillis(now.Add(-retention)) || (s.shipper != nil && !isShipped) {
+			retained = append(retained, b)
+			continue
+		}
+
+		if err := b.Close(); err != nil {
+			level.Warn(s.logger).Log("msg", "failed to close out-of-order block", "block", b.Meta().ULID.String(), "err", err)
+		}
+		if err := os.RemoveAll(b.Dir()); err != nil {
+			level.Warn(s.logger).Log("msg", "failed to delete out-of-order block", "block", b.Meta().ULID.String(), "err", err)
+			continue
+		}
+
+		level.Info(s.logger).Log("msg", "deleted out-of-order block", "block", b.Meta().ULID.String())
+	}
+	s.blocks = retained
+
+	return nil
+}
+
+func (s *outOfOrderStorage) close() error {
+	s.blocksMtx.Lock()
+	defer s.blocksMtx.Unlock()
+
+	var firstErr error
+	if s.wal != nil {
+		firstErr = s.wal.Close()
+		s.wal = nil
+	}
+	for _, b := range s.blocks {
+		if err := b.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	s.blocks = nil
+
+	return firstErr
+}
+
+// appendOutOfOrder adds an out-of-order sample to the out-of-order head, unless a sample with the same
+// timestamp is already in the TSDB head (eg. because the request has been retried): if the value is the
+// same the sample is deduplicated, otherwise it's rejected. The sample is looked up in the TSDB head series
+// with the input reference. Returns the WAL reference of the series, whether the series has been created in
+// the out-of-order head and whether the sample has been added to it.
+func (u *userTSDB) appendOutOfOrder(head *headSampleLookup, headRef uint64, lbls labels.Labels, t int64, v float64) (ref uint64, created, added bool, err error) {
+	headValue, inHead, err := head.sampleAt(headRef, t)
+	if err != nil {
+		return 0, false, false, errors.Wrap(err, "look up sample in the TSDB head")
+	}
+	if inHead {
+		if math.Float64bits(headValue) == math.Float64bits(v) {
+			return 0, false, false, nil
+		}
+		return 0, false, false, storage.ErrDuplicateSampleForTimestamp
+	}
+
+	ref, created, err = u.outOfOrder.head.add(lbls, t, v)
+	return ref, created, err == nil, err
+}
+
+// headSampleLookup looks up samples of the TSDB head series by reference. It's used within a single
+// push request: the head readers are opened on the first lookup, and the chunks of a series are
+// resolved once and reused for all the consecutive lookups in the same series.
+type headSampleLookup struct {
+	head   *tsdb.Head
+	index  tsdb.IndexReader
+	chunks tsdb.ChunkReader
+
+	// Chunks of the last series looked up.
+	ref          uint64
+	resolved     bool
+	seriesLabels labels.Labels
+	seriesChunks []chunks.Meta
+
+	it chunkenc.Iterator
+}
+
+func newHeadSampleLookup(head *tsdb.Head) *headSampleLookup {
+	return &headSampleLookup{head: head}
+}
+
+// sampleAt returns the value of the sample at timestamp t of the head series with the input reference, if any.
+func (l *headSampleLookup) sampleAt(ref uint64, t int64) (float64, bool, error) {
+	if l.index == nil {
+		index, err := l.head.Index()
+		if err != nil {
+			return 0, false, err
+		}
+		chunkReader, err := l.head.Chunks()
+		if err != nil {
+			_ = index.Close()
+			return 0, false, err
+		}
+		l.index, l.chunks = index, chunkReader
+	}
+
+	if !l.resolved || l.ref != ref {
+		l.ref, l.resolved = ref, false
+		if err := l.index.Series(ref, &l.seriesLabels, &l.seriesChunks); err != nil {
+			if errors.Cause(err) != storage.ErrNotFound {
+				return 0, false, err
+			}
+			// The series has been garbage collected from the head.
+			l.seriesChunks = l.seriesChunks[:0]
+		}
+		l.resolved = true
+	}
+
+	for _, meta := range l.seriesChunks {
+		if t < meta.MinTime || t > meta.MaxTime {
+			continue
+		}
+
+		chk, err := l.chunks.Chunk(meta.Ref)
+		if errors.Cause(err) == storage.ErrNotFound {
+			continue
+		} else if err != nil {
+			return 0, false, err
+		}
+
+		// The iterator of the head chunk doesn't support Seek(), so the samples are iterated.
+		l.it = chk.Iterator(l.it)
+		for l.it.Next() {
+			if ts, v := l.it.At(); ts == t {
+				return v, true, nil
+			} else if ts > t {
+				break
+			}
+		}
+		if err := l.it.Err(); err != nil {
+			return 0, false, err
+		}
+	}
+
+	return 0, false, nil
+}
+
+// close closes the head readers, if opened. Closing the head readers never fails.
+func (l *headSampleLookup) close() {
+	if l.index != nil {
+		_ = l.index.Close()
+		_ = l.chunks.Close()
+	}
+}
+
+func firstSampleTimestamp(s storage.Series) int64 {
+	it := s.Iterator()
+	if !it.Next() {
+		return math.MaxInt64
+	}
+	t, _ := it.At()
+	return t
+}
+
+// outOfOrderHead is an in-memory storage of the out-of-order samples of a single tenant.
+type outOfOrderHead struct {
+	mtx        sync.RWMutex
+	series     map[uint64][]*outOfOrderSeries
+	numSamples int
+	lastRef    uint64
+}
+
+type outOfOrderSeries struct {
+	// Reference of the series in the WAL.
+	ref  uint64
+	lbls labels.Labels
+
+	// Sorted by timestamp.
+	samples []model.SamplePair
+}
+
+func newOutOfOrderHead() *outOfOrderHead {
+	return &outOfOrderHead{
+		series: map[uint64][]*outOfOrderSeries{},
+	}
+}
+
+// add adds a sample to the head and returns the WAL reference of its series, and whether the series
+// has been created. The input labels are retained, so the caller shouldn't modify them.
+func (h *outOfOrderHead) add(lbls labels.Labels, t int64, v float64) (ref uint64, created bool, err error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	s, created := h.getOrCreateSeries(lbls)
+
+	idx := sort.Search(len(s.samples), func(i int) bool {
+		return int64(s.samples[i].Timestamp) >= t
+	})
+
+	if idx < len(s.samples) && int64(s.samples[idx].Timestamp) == t {
+		if math.Float64bits(float64(s.samples[idx].Value)) == math.Float64bits(v) {
+			return s.ref, created, nil
+		}
+		return s.ref, created, storage.ErrDuplicateSampleForTimestamp
+	}
+
+	s.samples = append(s.samples, model.SamplePair{})
+	copy(s.samples[idx+1:], s.samples[idx:])
+	s.samples[idx] = model.SamplePair{Timestamp: model.Time(t), Value: model.SampleValue(v)}
+	h.numSamples++
+
+	return s.ref, created, nil
+}
+
+func (h *outOfOrderHead) getOrCreateSeries(lbls labels.Labels) (*outOfOrderSeries, bool) {
+	hash := lbls.Hash()
+	for _, s := range h.series[hash] {
+		if labels.Equal(s.lbls, lbls) {
+			return s, false
+		}
+	}
+
+	h.lastRef++
+	s := &outOfOrderSeries{ref: h.lastRef, lbls: lbls}
+	h.series[hash] = append(h.series[hash], s)
+	return s, true
+}
+
+// snapshot returns all the series and samples in the head, in the WAL format.
+func (h *outOfOrderHead) snapshot() ([]record.RefSeries, []record.RefSample) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	series := make([]record.RefSeries, 0, len(h.series))
+	samples := make([]record.RefSample, 0, h.numSamples)
+
+	for _, list := range h.series {
+		for _, s := range list {
+			series = append(series, record.RefSeries{Ref: s.ref, Labels: s.lbls})
+			for _, sample := range s.samples {
+				samples = append(samples, record.RefSample{Ref: s.ref, T: int64(sample.Timestamp), V: float64(sample.Value)})
+			}
+		}
+	}
+
+	return series, samples
+}
+
+func (h *outOfOrderHead) empty() bool {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	return h.numSamples == 0
+}
+
+// samplesCount returns the number of samples in the head.
+func (h *outOfOrderHead) samplesCount() int {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	return h.numSamples
+}
+
+// forEachSeries calls fn for each series with at least a sample within mint and maxt (both inclusive),
+// passing the samples within the range. The head lock must be held.
+func (h *outOfOrderHead) forEachSeries(mint, maxt int64, fn func(lbls labels.Labels, samples []model.SamplePair)) {
+	for _, list := range h.series {
+		for _, s := range list {
+			start := sort.Search(len(s.samples), func(i int) bool {
+				return int64(s.samples[i].Timestamp) >= mint
+			})
+			end := sort.Search(len(s.samples), func(i int) bool {
+				return int64(s.samples[i].Timestamp) > maxt
+			})
+			if start < end {
+				fn(s.lbls, s.samples[start:end])
+			}
+		}
+	}
+}
+
+// flush calls fn with the series samples older than maxt and removes them from the head, if
+// fn succeeds. The head is locked while fn runs, so samples can't be added in the meanwhile.
+// Returns whether any sample has been flushed.
+func (h *outOfOrderHead) flush(maxt int64, fn func(flushed []storage.Series) error) (bool, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	var flushed []storage.Series
+	h.forEachSeries(math.MinInt64, maxt-1, func(lbls labels.Labels, samples []model.SamplePair) {
+		flushed = append(flushed, series.NewConcreteSeries(lbls, samples))
+	})
+
+	if len(flushed) == 0 {
+		return false, nil
+	}
+
+	if err := fn(flushed); err != nil {
+		return false, err
+	}
+
+	for hash, list := range h.series {
+		retained := list[:0]
+		for _, s := range list {
+			idx := sort.Search(len(s.samples), func(i int) bool {
+				return int64(s.samples[i].Timestamp) >= maxt
+			})
+			h.numSamples -= idx
+
+			if idx < len(s.samples) {
+				s.samples = append([]model.SamplePair(nil), s.samples[idx:]...)
+				retained = append(retained, s)
+			}
+		}
+
+		if len(retained) == 0 {
+			delete(h.series, hash)
+		} else {
+			h.series[hash] = retained
+		}
+	}
+
+	return true, nil
+}
+
+// outOfOrderHeadQuerier is a storage.Querier over the out-of-order head.
+type outOfOrderHeadQuerier struct {
+	head       *outOfOrderHead
+	mint, maxt int64
+}
+
+func (q *outOfOrderHeadQuerier) Select(_ bool, _ *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	q.head.mtx.RLock()
+	defer q.head.mtx.RUnlock()
+
+	var result []storage.Series
+	q.head.forEachSeries(q.mint, q.maxt, func(lbls labels.Labels, samples []model.SamplePair) {
+		for _, m := range matchers {
+			if !m.Matches(lbls.Get(m.Name)) {
+				return
+			}
+		}
+
+		// Samples are copied because the head could be modified once the lock is released.
+		result = append(result, series.NewConcreteSeries(lbls, append([]model.SamplePair(nil), samples...)))
+	})
+
+	// The series set sorts the series by labels.
+	return series.NewConcreteSeriesSet(result)
+}
+
+func (q *outOfOrderHeadQuerier) LabelValues(name string) ([]string, storage.Warnings, error) {
+	q.head.mtx.RLock()
+	defer q.head.mtx.RUnlock()
+
+	values := map[string]struct{}{}
+	q.head.forEachSeries(q.mint, q.maxt, func(lbls labels.Labels, _ []model.SamplePair) {
+		if v := lbls.Get(name); v != "" {
+			values[v] = struct{}{}
+		}
+	})
+
+	return sortedKeys(values), nil, nil
+}
+
+func (q *outOfOrderHeadQuerier) LabelNames() ([]string, storage.Warnings, error) {
+	q.head.mtx.RLock()
+	defer q.head.mtx.RUnlock()
+
+	names := map[string]struct{}{}
+	q.head.forEachSeries(q.mint, q.maxt, func(lbls labels.Labels, _ []model.SamplePair) {
+		for _, l := range lbls {
+			names[l.Name] = struct{}{}
+		}
+	})
+
+	return sortedKeys(names), nil, nil
+}
+
+func (q *outOfOrderHeadQuerier) Close() error {
+	return nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
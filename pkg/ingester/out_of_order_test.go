@@ -0,0 +1,306 @@
+package ingester
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/record"
+	"github.com/prometheus/prometheus/tsdb/wal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/ring"
+	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/services"
+	"github.com/cortexproject/cortex/pkg/util/test"
+)
+
+func TestOutOfOrderHead_Add(t *testing.T) {
+	h := newOutOfOrderHead()
+	series := labels.FromStrings(labels.MetricName, "test")
+
+	addOutOfOrderSample(t, h, series, 30, 3)
+	addOutOfOrderSample(t, h, series, 10, 1)
+	addOutOfOrderSample(t, h, series, 20, 2)
+
+	// The same sample can be added again, but not with a different value.
+	addOutOfOrderSample(t, h, series, 20, 2)
+	_, _, err := h.add(series, 20, 5)
+	require.Equal(t, storage.ErrDuplicateSampleForTimestamp, err)
+
+	assert.Equal(t, 3, h.samplesCount())
+	assert.Equal(t, map[string][]model.SamplePair{
+		series.String(): {{Timestamp: 10, Value: 1}, {Timestamp: 20, Value: 2}, {Timestamp: 30, Value: 3}},
+	}, queryOutOfOrderHead(t, h, 0, 100))
+}
+
+func TestOutOfOrderHeadQuerier(t *testing.T) {
+	h := newOutOfOrderHead()
+	series1 := labels.FromStrings(labels.MetricName, "test", "job", "a")
+	series2 := labels.FromStrings(labels.MetricName, "test", "job", "b")
+	series3 := labels.FromStrings(labels.MetricName, "other", "instance", "c")
+
+	addOutOfOrderSample(t, h, series1, 10, 1)
+	addOutOfOrderSample(t, h, series1, 20, 2)
+	addOutOfOrderSample(t, h, series2, 30, 3)
+	addOutOfOrderSample(t, h, series3, 40, 4)
+
+	q := &outOfOrderHeadQuerier{head: h, mint: 15, maxt: 30}
+
+	set := q.Select(true, nil, labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test"))
+	var actual []labels.Labels
+	for set.Next() {
+		actual = append(actual, set.At().Labels())
+	}
+	require.NoError(t, set.Err())
+	assert.Equal(t, []labels.Labels{series1, series2}, actual)
+
+	names, _, err := q.LabelNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{labels.MetricName, "job"}, names)
+
+	values, _, err := q.LabelValues("job")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, values)
+}
+
+func TestOutOfOrderStorage_Compact(t *testing.T) {
+	const blockDuration = int64(100)
+
+	dir, err := ioutil.TempDir("", "out-of-order")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dir)) })
+
+	s, err := newOutOfOrderStorage(filepath.Join(dir, outOfOrderBlocksDir), wal.DefaultSegmentSize, false, log.NewNopLogger())
+	require.NoError(t, err)
+
+	series1 := labels.FromStrings(labels.MetricName, "test", "job", "a")
+	series2 := labels.FromStrings(labels.MetricName, "test", "job", "b")
+	addOutOfOrderSample(t, s.head, series1, 10, 1)
+	addOutOfOrderSample(t, s.head, series2, 90, 2)
+	addOutOfOrderSample(t, s.head, series1, 150, 3)
+	addOutOfOrderSample(t, s.head, series2, 250, 4)
+
+	// Only the samples older than 200 should be compacted, in a block for each block range.
+	require.NoError(t, s.compact(200, blockDuration))
+	require.Len(t, s.blocks, 2)
+	assert.Equal(t, 1, s.head.samplesCount())
+
+	// Samples should be queryable from both the blocks and the head.
+	expected := map[string][]model.SamplePair{
+		series1.String(): {{Timestamp: 10, Value: 1}, {Timestamp: 150, Value: 3}},
+		series2.String(): {{Timestamp: 90, Value: 2}, {Timestamp: 250, Value: 4}},
+	}
+	assert.Equal(t, expected, queryOutOfOrderStorage(t, s, 0, 300))
+
+	// Blocks should be opened again on startup.
+	require.NoError(t, s.close())
+	s, err = newOutOfOrderStorage(filepath.Join(dir, outOfOrderBlocksDir), wal.DefaultSegmentSize, false, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Len(t, s.blocks, 2)
+
+	// Blocks beyond the retention should be deleted, if shipping is disabled.
+	require.NoError(t, s.deleteBlocks(time.Millisecond, time.Unix(0, 0).Add(150*time.Millisecond)))
+	require.Len(t, s.blocks, 1)
+	assert.Equal(t, int64(150), s.blocks[0].MinTime())
+	require.NoError(t, s.close())
+}
+
+func TestOutOfOrderStorage_WALReplay(t *testing.T) {
+	const blockDuration = int64(100)
+
+	dir, err := ioutil.TempDir("", "out-of-order")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dir)) })
+
+	openStorage := func() *outOfOrderStorage {
+		s, err := newOutOfOrderStorage(filepath.Join(dir, outOfOrderBlocksDir), wal.DefaultSegmentSize, false, log.NewNopLogger())
+		require.NoError(t, err)
+		return s
+	}
+
+	appendAndLog := func(s *outOfOrderStorage, lbls labels.Labels, ts int64, v float64) {
+		ref, created, err := s.head.add(lbls, ts, v)
+		require.NoError(t, err)
+
+		var series []record.RefSeries
+		if created {
+			series = append(series, record.RefSeries{Ref: ref, Labels: lbls})
+		}
+		require.NoError(t, s.logWAL(series, []record.RefSample{{Ref: ref, T: ts, V: v}}))
+	}
+
+	series1 := labels.FromStrings(labels.MetricName, "test", "job", "a")
+	series2 := labels.FromStrings(labels.MetricName, "test", "job", "b")
+
+	s := openStorage()
+	appendAndLog(s, series1, 10, 1)
+	appendAndLog(s, series2, 90, 2)
+	appendAndLog(s, series1, 150, 3)
+
+	// Samples in the head should be replayed from the WAL on startup.
+	require.NoError(t, s.close())
+	s = openStorage()
+	assert.Equal(t, map[string][]model.SamplePair{
+		series1.String(): {{Timestamp: 10, Value: 1}, {Timestamp: 150, Value: 3}},
+		series2.String(): {{Timestamp: 90, Value: 2}},
+	}, queryOutOfOrderHead(t, s.head, 0, 300))
+
+	// Samples added after the replay, with series references assigned while replaying, should be replayed too.
+	appendAndLog(s, series2, 250, 4)
+
+	// Samples compacted into blocks should not be replayed anymore.
+	require.NoError(t, s.compact(200, blockDuration))
+	require.NoError(t, s.close())
+	s = openStorage()
+	assert.Equal(t, map[string][]model.SamplePair{
+		series2.String(): {{Timestamp: 250, Value: 4}},
+	}, queryOutOfOrderHead(t, s.head, 0, 300))
+	require.NoError(t, s.close())
+}
+
+func addOutOfOrderSample(t *testing.T, h *outOfOrderHead, lbls labels.Labels, ts int64, v float64) {
+	_, _, err := h.add(lbls, ts, v)
+	require.NoError(t, err)
+}
+
+func queryOutOfOrderHead(t *testing.T, h *outOfOrderHead, mint, maxt int64) map[string][]model.SamplePair {
+	return querySamples(t, []storage.Querier{&outOfOrderHeadQuerier{head: h, mint: mint, maxt: maxt}})
+}
+
+func queryOutOfOrderStorage(t *testing.T, s *outOfOrderStorage, mint, maxt int64) map[string][]model.SamplePair {
+	queriers, err := s.queriers(mint, maxt)
+	require.NoError(t, err)
+	return querySamples(t, queriers)
+}
+
+func querySamples(t *testing.T, queriers []storage.Querier) map[string][]model.SamplePair {
+	q := storage.NewMergeQuerier(queriers, nil, storage.ChainedSeriesMerge)
+	defer q.Close()
+
+	result := map[string][]model.SamplePair{}
+	set := q.Select(true, nil, labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, ".+"))
+	for set.Next() {
+		it := set.At().Iterator()
+		for it.Next() {
+			ts, v := it.At()
+			key := set.At().Labels().String()
+			result[key] = append(result[key], model.SamplePair{Timestamp: model.Time(ts), Value: model.SampleValue(v)})
+		}
+		require.NoError(t, it.Err())
+	}
+	require.NoError(t, set.Err())
+
+	return result
+}
+
+func TestIngester_v2PushOutOfOrderSamples(t *testing.T) {
+	limits := defaultLimitsTestConfig()
+	limits.OutOfOrderTimeWindow = model.Duration(time.Hour)
+
+	dataDir, err := ioutil.TempDir("", "ingester")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dataDir)) })
+
+	i, err := prepareIngesterWithBlocksStorageAndLimits(t, defaultIngesterTestConfig(), limits, dataDir, nil)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's ACTIVE.
+	test.Poll(t, 1*time.Second, ring.ACTIVE, func() interface{} {
+		return i.lifecycler.GetState()
+	})
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+	series := labels.FromStrings(labels.MetricName, "test")
+	now := time.Now().Truncate(time.Minute)
+
+	push := func(ts time.Time, value float64) error {
+		req, _, _ := mockWriteRequest(series, value, ts.UnixNano()/int64(time.Millisecond))
+		_, err := i.v2Push(ctx, req)
+		return err
+	}
+
+	require.NoError(t, push(now, 1))
+	require.NoError(t, push(now.Add(-30*time.Minute), 2))
+	require.NoError(t, push(now.Add(-10*time.Minute), 3))
+
+	// Samples older than the window should be rejected.
+	require.Error(t, push(now.Add(-2*time.Hour), 4))
+
+	// A different value for an existing out-of-order sample should be rejected.
+	require.Error(t, push(now.Add(-10*time.Minute), 5))
+
+	// A sample already in the TSDB head should be deduplicated if it has the same value, and rejected otherwise.
+	require.NoError(t, push(now.Add(time.Minute), 6))
+	require.NoError(t, push(now, 1))
+	require.Error(t, push(now, 7))
+
+	// All the out-of-order samples of a series in the same request should be deduplicated against
+	// the TSDB head and the out-of-order head.
+	req := &client.WriteRequest{Timeseries: []client.PreallocTimeseries{{TimeSeries: &client.TimeSeries{
+		Labels: client.FromLabelsToLabelAdapters(series.Copy()),
+		Samples: []client.Sample{
+			{TimestampMs: util.TimeToMillis(now.Add(-20 * time.Minute)), Value: 8},
+			{TimestampMs: util.TimeToMillis(now), Value: 1},
+			{TimestampMs: util.TimeToMillis(now.Add(-30 * time.Minute)), Value: 2},
+		},
+	}}}}
+	_, err = i.v2Push(ctx, req)
+	require.NoError(t, err)
+
+	expected := []model.SamplePair{
+		{Timestamp: model.TimeFromUnixNano(now.Add(-30 * time.Minute).UnixNano()), Value: 2},
+		{Timestamp: model.TimeFromUnixNano(now.Add(-20 * time.Minute).UnixNano()), Value: 8},
+		{Timestamp: model.TimeFromUnixNano(now.Add(-10 * time.Minute).UnixNano()), Value: 3},
+		{Timestamp: model.TimeFromUnixNano(now.UnixNano()), Value: 1},
+		{Timestamp: model.TimeFromUnixNano(now.Add(time.Minute).UnixNano()), Value: 6},
+	}
+
+	db := i.getTSDB("test")
+	require.NotNil(t, db)
+	assert.Equal(t, 3, db.outOfOrder.head.samplesCount())
+	assert.Equal(t, map[string][]model.SamplePair{series.String(): expected}, queryUserTSDB(t, db))
+
+	// After a forced compaction, samples should be queryable from the blocks.
+	i.compactBlocks(ctx, true)
+	assert.True(t, db.outOfOrder.head.empty())
+	assert.NotEmpty(t, db.outOfOrder.blocks)
+	assert.Equal(t, map[string][]model.SamplePair{series.String(): expected}, queryUserTSDB(t, db))
+
+	// Out-of-order blocks should be shipped to the storage.
+	i.shipBlocks(ctx)
+	unshipped, err := db.outOfOrder.hasUnshippedBlocks()
+	require.NoError(t, err)
+	assert.False(t, unshipped)
+
+	// The out-of-order blocks shipper metrics should be tracked separately.
+	assert.NoError(t, testutil.CollectAndCompare(i.TSDBState.tsdbMetrics, strings.NewReader(fmt.Sprintf(`
+		# HELP cortex_ingester_out_of_order_shipper_uploads_total Total number of uploaded out-of-order blocks
+		# TYPE cortex_ingester_out_of_order_shipper_uploads_total counter
+		cortex_ingester_out_of_order_shipper_uploads_total %d
+		# HELP cortex_ingester_out_of_order_shipper_upload_failures_total Total number of out-of-order block upload failures
+		# TYPE cortex_ingester_out_of_order_shipper_upload_failures_total counter
+		cortex_ingester_out_of_order_shipper_upload_failures_total 0
+	`, len(db.outOfOrder.blocks))), "cortex_ingester_out_of_order_shipper_uploads_total", "cortex_ingester_out_of_order_shipper_upload_failures_total"))
+}
+
+func queryUserTSDB(t *testing.T, db *userTSDB) map[string][]model.SamplePair {
+	q, err := db.Querier(context.Background(), 0, time.Now().Add(time.Hour).UnixNano()/int64(time.Millisecond))
+	require.NoError(t, err)
+	return querySamples(t, []storage.Querier{q})
+}
@@ -86,6 +86,7 @@ func (us *userStates) gc() {
 			us.states.Delete(key)
 			state.activeSeries.clear()
 			state.activeSeriesGauge.Set(0)
+			us.metrics.deleteActiveSeriesCustomTrackers(state.userID, state.activeSeries.CurrentMatchers())
 		}
 		return true
 	})
@@ -110,12 +111,22 @@ func (us *userStates) updateActiveSeriesForUser(userID string, now time.Time, lb
 func (us *userStates) purgeAndUpdateActiveSeries(purgeTime time.Time) {
 	us.states.Range(func(key, value interface{}) bool {
 		state := value.(*userState)
+		us.updateActiveSeriesMatchers(state)
 		state.activeSeries.Purge(purgeTime)
-		state.activeSeriesGauge.Set(float64(state.activeSeries.Active()))
+		us.metrics.setActiveSeries(state.userID, state.activeSeries)
 		return true
 	})
 }
 
+func (us *userStates) updateActiveSeriesMatchers(state *userState) {
+	// The limiter is not set when running the flusher, which doesn't track active series.
+	if us.limiter == nil {
+		return
+	}
+
+	updateActiveSeriesMatchers(state.userID, state.activeSeries, us.limiter.limits, us.metrics)
+}
+
 func (us *userStates) get(userID string) (*userState, bool) {
 	state, ok := us.states.Load(userID)
 	if !ok {
@@ -151,6 +162,7 @@ func (us *userStates) getOrCreate(userID string) *userState {
 			activeSeriesGauge: us.metrics.activeSeriesPerUser.WithLabelValues(userID),
 		}
 		state.mapper = newFPMapper(state.fpToSeries)
+		us.updateActiveSeriesMatchers(state)
 		stored, ok := us.states.LoadOrStore(userID, state)
 		if !ok {
 			us.metrics.memUsers.Inc()
@@ -167,6 +179,7 @@ func (us *userStates) teardown() {
 		u.memSeriesRemovedTotal.Add(float64(u.fpToSeries.length()))
 		u.memSeries.Sub(float64(u.fpToSeries.length()))
 		u.activeSeriesGauge.Set(0)
+		us.metrics.deleteActiveSeriesCustomTrackers(u.userID, u.activeSeries.CurrentMatchers())
 		us.metrics.memUsers.Dec()
 	}
 }
@@ -299,6 +299,12 @@ func (shard ShardAnnotation) Label() labels.Label {
 	}
 }
 
+// Matches returns whether the series belongs to the shard, when series are sharded by the hash
+// of their labels (which doesn't include the shard label).
+func (shard ShardAnnotation) Matches(series labels.Labels) bool {
+	return series.Hash()%uint64(shard.Of) == uint64(shard.Shard)
+}
+
 // ShardFromMatchers extracts a ShardAnnotation and the index it was pulled from in the matcher list
 func ShardFromMatchers(matchers []*labels.Matcher) (shard *ShardAnnotation, idx int, err error) {
 	for i, matcher := range matchers {
@@ -312,3 +318,17 @@ func ShardFromMatchers(matchers []*labels.Matcher) (shard *ShardAnnotation, idx
 	}
 	return nil, 0, nil
 }
+
+// RemoveShardFromMatchers extracts a ShardAnnotation from the matcher list and returns it along with
+// a copy of the matchers without the shard matcher. The input matchers are returned if no shard is found.
+func RemoveShardFromMatchers(matchers []*labels.Matcher) (shard *ShardAnnotation, filtered []*labels.Matcher, err error) {
+	shard, idx, err := ShardFromMatchers(matchers)
+	if err != nil || shard == nil {
+		return nil, matchers, err
+	}
+
+	filtered = make([]*labels.Matcher, 0, len(matchers)-1)
+	filtered = append(filtered, matchers[:idx]...)
+	filtered = append(filtered, matchers[idx+1:]...)
+	return shard, filtered, nil
+}
@@ -267,3 +267,55 @@ func TestShardFromMatchers(t *testing.T) {
 	}
 
 }
+
+func TestRemoveShardFromMatchers(t *testing.T) {
+	nameMatcher := labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "foo")
+	shardMatcher := labels.MustNewMatcher(labels.MatchEqual, ShardLabel, ShardAnnotation{Shard: 1, Of: 4}.String())
+	jobMatcher := labels.MustNewMatcher(labels.MatchRegexp, "job", "a.*")
+
+	t.Run("shard matcher found", func(t *testing.T) {
+		input := []*labels.Matcher{nameMatcher, shardMatcher, jobMatcher}
+
+		shard, filtered, err := RemoveShardFromMatchers(input)
+		require.NoError(t, err)
+		require.Equal(t, &ShardAnnotation{Shard: 1, Of: 4}, shard)
+		require.Equal(t, []*labels.Matcher{nameMatcher, jobMatcher}, filtered)
+
+		// The input matchers must not be modified.
+		require.Equal(t, []*labels.Matcher{nameMatcher, shardMatcher, jobMatcher}, input)
+	})
+
+	t.Run("no shard matcher", func(t *testing.T) {
+		input := []*labels.Matcher{nameMatcher, jobMatcher}
+
+		shard, filtered, err := RemoveShardFromMatchers(input)
+		require.NoError(t, err)
+		require.Nil(t, shard)
+		require.Equal(t, input, filtered)
+	})
+
+	t.Run("invalid shard matcher", func(t *testing.T) {
+		_, _, err := RemoveShardFromMatchers([]*labels.Matcher{
+			labels.MustNewMatcher(labels.MatchEqual, ShardLabel, "invalid-fmt"),
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestShardAnnotation_Matches(t *testing.T) {
+	const shards = 4
+
+	for i := 0; i < 100; i++ {
+		series := labels.FromStrings(labels.MetricName, "foo", "idx", fmt.Sprint(i))
+
+		matches := 0
+		for s := 0; s < shards; s++ {
+			if (ShardAnnotation{Shard: s, Of: shards}).Matches(series) {
+				matches++
+			}
+		}
+
+		// Each series must belong to exactly one shard.
+		require.Equal(t, 1, matches, series.String())
+	}
+}
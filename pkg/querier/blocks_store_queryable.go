@@ -21,13 +21,16 @@ import (
 	"github.com/thanos-io/thanos/pkg/block"
 	"github.com/thanos-io/thanos/pkg/extprom"
 	"github.com/thanos-io/thanos/pkg/store/hintspb"
+	"github.com/thanos-io/thanos/pkg/store/labelpb"
 	"github.com/thanos-io/thanos/pkg/store/storepb"
 	"github.com/thanos-io/thanos/pkg/strutil"
 	"go.uber.org/atomic"
 	"golang.org/x/sync/errgroup"
 	grpc_metadata "google.golang.org/grpc/metadata"
 
+	"github.com/cortexproject/cortex/pkg/querier/astmapper"
 	"github.com/cortexproject/cortex/pkg/querier/series"
+	querier_stats "github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/ring"
 	"github.com/cortexproject/cortex/pkg/ring/kv"
 	"github.com/cortexproject/cortex/pkg/storage/bucket"
@@ -37,6 +40,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/storegateway/storegatewaypb"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
 	"github.com/cortexproject/cortex/pkg/util/math"
 	"github.com/cortexproject/cortex/pkg/util/services"
@@ -308,6 +312,17 @@ type blocksStoreQuerier struct {
 // Select implements storage.Querier interface.
 // The bool passed is ignored because the series is always sorted.
 func (q *blocksStoreQuerier) Select(_ bool, sp *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	// Series are not sharded by the store-gateways, so the shard matcher (if any) is removed
+	// from the request and series are filtered by shard once fetched. Each shard fetches
+	// all the series matching the other matchers.
+	shard, matchers, err := astmapper.RemoveShardFromMatchers(matchers)
+	if err != nil {
+		return storage.ErrSeriesSet(err)
+	}
+	if shard != nil {
+		return series.NewShardedSeriesSet(q.selectSorted(sp, matchers...), *shard)
+	}
+
 	return q.selectSorted(sp, matchers...)
 }
 
@@ -560,6 +575,8 @@ func (q *blocksStoreQuerier) fetchSeriesFromStores(
 		queriedBlocks = []ulid.ULID(nil)
 		numChunks     = atomic.NewInt32(0)
 		spanLog       = spanlogger.FromContext(ctx)
+		stats         = querier_stats.FromContext(ctx)
+		queryLimiter  = limiter.QueryLimiterFromContextWithFallback(ctx)
 	)
 
 	// Concurrently fetch series from all clients.
@@ -615,6 +632,14 @@ func (q *blocksStoreQuerier) fetchSeriesFromStores(
 							return fmt.Errorf(errMaxChunksPerQueryLimit, convertMatchersToString(matchers), maxChunksLimit)
 						}
 					}
+
+					// Ensure the max number of series and chunk bytes fetched by the query haven't been reached.
+					if limitErr := queryLimiter.AddSeries(labelpb.ZLabelsToPromLabels(s.Labels)); limitErr != nil {
+						return limitErr
+					}
+					if limitErr := queryLimiter.AddChunkBytes(countChunkBytes(s)); limitErr != nil {
+						return limitErr
+					}
 				}
 
 				if w := resp.GetWarning(); w != "" {
@@ -636,10 +661,12 @@ func (q *blocksStoreQuerier) fetchSeriesFromStores(
 				}
 			}
 
+			numSeriesBytes := countSeriesBytes(mySeries)
+
 			level.Debug(spanLog).Log("msg", "received series from store-gateway",
 				"instance", c.RemoteAddress(),
 				"num series", len(mySeries),
-				"bytes series", countSeriesBytes(mySeries),
+				"bytes series", numSeriesBytes,
 				"requested blocks", strings.Join(convertULIDsToString(blockIDs), " "),
 				"queried blocks", strings.Join(convertULIDsToString(myQueriedBlocks), " "))
 
@@ -650,6 +677,10 @@ func (q *blocksStoreQuerier) fetchSeriesFromStores(
 			queriedBlocks = append(queriedBlocks, myQueriedBlocks...)
 			mtx.Unlock()
 
+			stats.AddFetchedSeries(uint64(len(mySeries)))
+			stats.AddFetchedChunkBytes(numSeriesBytes)
+			stats.AddFetchedBlocks(uint64(len(myQueriedBlocks)))
+
 			return nil
 		})
 	}
@@ -923,10 +954,16 @@ func convertBlockHintsToULIDs(hints []hintspb.Block) ([]ulid.ULID, error) {
 
 func countSeriesBytes(series []*storepb.Series) (count uint64) {
 	for _, s := range series {
-		for _, c := range s.Chunks {
-			if c.Raw != nil {
-				count += uint64(len(c.Raw.Data))
-			}
+		count += uint64(countChunkBytes(s))
+	}
+
+	return count
+}
+
+func countChunkBytes(s *storepb.Series) (count int) {
+	for _, c := range s.Chunks {
+		if c.Raw != nil {
+			count += len(c.Raw.Data)
 		}
 	}
 
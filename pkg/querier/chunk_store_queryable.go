@@ -12,6 +12,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/querier/chunkstore"
 	seriesset "github.com/cortexproject/cortex/pkg/querier/series"
+	querier_stats "github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/tenant"
 )
 
@@ -56,6 +57,12 @@ func (q *chunkStoreQuerier) Select(_ bool, sp *storage.SelectHints, matchers ...
 		return storage.ErrSeriesSet(err)
 	}
 
+	if stats := querier_stats.FromContext(q.ctx); stats != nil {
+		numSeries, numBytes := countChunksSeriesAndBytes(chunks)
+		stats.AddFetchedSeries(numSeries)
+		stats.AddFetchedChunkBytes(numBytes)
+	}
+
 	return partitionChunks(chunks, q.mint, q.maxt, q.chunkIteratorFunc)
 }
 
@@ -81,6 +88,17 @@ func partitionChunks(chunks []chunk.Chunk, mint, maxt int64, iteratorFunc chunkI
 	return seriesset.NewConcreteSeriesSet(series)
 }
 
+// countChunksSeriesAndBytes returns the number of distinct series and the size in bytes of the chunks.
+func countChunksSeriesAndBytes(chunks []chunk.Chunk) (numSeries, numBytes uint64) {
+	fingerprints := map[model.Fingerprint]struct{}{}
+	for _, c := range chunks {
+		fingerprints[c.Fingerprint] = struct{}{}
+		numBytes += uint64(c.Data.Size())
+	}
+
+	return uint64(len(fingerprints)), numBytes
+}
+
 func (q *chunkStoreQuerier) LabelValues(name string) ([]string, storage.Warnings, error) {
 	return nil, nil, nil
 }
@@ -13,7 +13,9 @@ import (
 
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/prom1/storage/metric"
+	"github.com/cortexproject/cortex/pkg/querier/astmapper"
 	"github.com/cortexproject/cortex/pkg/querier/series"
+	querier_stats "github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/chunkcompat"
@@ -30,6 +32,7 @@ type Distributor interface {
 	LabelNames(context.Context, model.Time, model.Time) ([]string, error)
 	MetricsForLabelMatchers(ctx context.Context, from, through model.Time, matchers ...*labels.Matcher) ([]metric.Metric, error)
 	MetricsMetadata(ctx context.Context) ([]scrape.MetricMetadata, error)
+	QueryExemplars(ctx context.Context, from, to model.Time, matchersSet ...[]*labels.Matcher) (*client.ExemplarQueryResponse, error)
 }
 
 func newDistributorQueryable(distributor Distributor, streaming bool, iteratorFn chunkIteratorFunc, queryIngestersWithin time.Duration) QueryableWithFilter {
@@ -77,6 +80,21 @@ type distributorQuerier struct {
 // Select implements storage.Querier interface.
 // The bool passed is ignored because the series is always sorted.
 func (q *distributorQuerier) Select(_ bool, sp *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	// Series are not sharded by the ingesters, so the shard matcher (if any) is removed
+	// from the request and series are filtered by shard once fetched. Each shard fetches
+	// all the series matching the other matchers.
+	shard, matchers, err := astmapper.RemoveShardFromMatchers(matchers)
+	if err != nil {
+		return storage.ErrSeriesSet(err)
+	}
+	if shard != nil {
+		return series.NewShardedSeriesSet(q.selectSorted(sp, matchers...), *shard)
+	}
+
+	return q.selectSorted(sp, matchers...)
+}
+
+func (q *distributorQuerier) selectSorted(sp *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
 	log, ctx := spanlogger.New(q.ctx, "distributorQuerier.Select")
 	defer log.Span.Finish()
 
@@ -125,6 +143,16 @@ func (q *distributorQuerier) Select(_ bool, sp *storage.SelectHints, matchers ..
 		return storage.ErrSeriesSet(err)
 	}
 
+	if stats := querier_stats.FromContext(ctx); stats != nil {
+		numSamples := 0
+		for _, stream := range matrix {
+			numSamples += len(stream.Values)
+		}
+
+		stats.AddFetchedSeries(uint64(len(matrix)))
+		stats.AddIngesterSamples(uint64(numSamples))
+	}
+
 	// Using MatrixToSeriesSet (and in turn NewConcreteSeriesSet), sorts the series.
 	return series.MatrixToSeriesSet(matrix)
 }
@@ -140,9 +168,20 @@ func (q *distributorQuerier) streamingSelect(ctx context.Context, minT, maxT int
 		return storage.ErrSeriesSet(err)
 	}
 
+	stats := querier_stats.FromContext(ctx)
+	stats.AddFetchedSeries(uint64(len(results.Timeseries) + len(results.Chunkseries)))
+
 	sets := []storage.SeriesSet(nil)
 	if len(results.Timeseries) > 0 {
 		sets = append(sets, newTimeSeriesSeriesSet(results.Timeseries))
+
+		if stats != nil {
+			numSamples := 0
+			for _, ts := range results.Timeseries {
+				numSamples += len(ts.Samples)
+			}
+			stats.AddIngesterSamples(uint64(numSamples))
+		}
 	}
 
 	serieses := make([]storage.Series, 0, len(results.Chunkseries))
@@ -160,6 +199,15 @@ func (q *distributorQuerier) streamingSelect(ctx context.Context, minT, maxT int
 			return storage.ErrSeriesSet(err)
 		}
 
+		if stats != nil {
+			numSamples := 0
+			for _, c := range chunks {
+				numSamples += c.Data.Len()
+			}
+			stats.AddFetchedChunkBytes(uint64(client.ChunksSize(result.Chunks)))
+			stats.AddIngesterSamples(uint64(numSamples))
+		}
+
 		serieses = append(serieses, &chunkSeries{
 			labels:            ls,
 			chunks:            chunks,
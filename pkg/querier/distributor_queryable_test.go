@@ -19,6 +19,8 @@ import (
 	"github.com/cortexproject/cortex/pkg/chunk/encoding"
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/prom1/storage/metric"
+	"github.com/cortexproject/cortex/pkg/querier/astmapper"
+	querier_stats "github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/chunkcompat"
 )
@@ -64,6 +66,50 @@ func TestDistributorQuerier(t *testing.T) {
 	require.NoError(t, seriesSet.Err())
 }
 
+func TestDistributorQuerier_SelectShouldFilterSeriesByShard(t *testing.T) {
+	var matrix model.Matrix
+	for i := 0; i < 10; i++ {
+		matrix = append(matrix, &model.SampleStream{
+			Metric: model.Metric{model.MetricNameLabel: "foo", "idx": model.LabelValue(fmt.Sprint(i))},
+			Values: []model.SamplePair{{Timestamp: mint, Value: model.SampleValue(i)}},
+		})
+	}
+
+	d := &mockDistributor{}
+	d.On("Query", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(matrix, nil)
+
+	shard := astmapper.ShardAnnotation{Shard: 1, Of: 3}
+	nameMatcher := labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "foo")
+	shardMatcher := labels.MustNewMatcher(labels.MatchEqual, astmapper.ShardLabel, shard.String())
+
+	queryable := newDistributorQueryable(d, false, nil, 0)
+	querier, err := queryable.Querier(context.Background(), mint, maxt)
+	require.NoError(t, err)
+
+	seriesSet := querier.Select(true, &storage.SelectHints{Start: mint, End: maxt}, nameMatcher, shardMatcher)
+
+	var actual int
+	for seriesSet.Next() {
+		lbls := seriesSet.At().Labels()
+		assert.Equal(t, shard.String(), lbls.Get(astmapper.ShardLabel))
+		assert.True(t, shard.Matches(labels.NewBuilder(lbls).Del(astmapper.ShardLabel).Labels()))
+		actual++
+	}
+	require.NoError(t, seriesSet.Err())
+
+	var expected int
+	for _, stream := range matrix {
+		if shard.Matches(client.FromLabelAdaptersToLabels(client.FromMetricsToLabelAdapters(stream.Metric))) {
+			expected++
+		}
+	}
+	assert.Equal(t, expected, actual)
+
+	// The shard matcher must not be sent to the ingesters.
+	require.Len(t, d.Calls, 1)
+	assert.Equal(t, []*labels.Matcher{nameMatcher}, d.Calls[0].Arguments.Get(3))
+}
+
 func TestDistributorQuerier_SelectShouldHonorQueryIngestersWithin(t *testing.T) {
 	now := time.Now()
 
@@ -270,7 +316,8 @@ func TestIngesterStreamingMixedResults(t *testing.T) {
 		},
 		nil)
 
-	ctx := user.InjectOrgID(context.Background(), "0")
+	stats, ctx := querier_stats.ContextWithEmptyStats(context.Background())
+	ctx = user.InjectOrgID(ctx, "0")
 	queryable := newDistributorQueryable(d, true, mergeChunks, 0)
 	querier, err := queryable.Querier(ctx, mint, maxt)
 	require.NoError(t, err)
@@ -278,6 +325,10 @@ func TestIngesterStreamingMixedResults(t *testing.T) {
 	seriesSet := querier.Select(true, &storage.SelectHints{Start: mint, End: maxt}, labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, ".*"))
 	require.NoError(t, seriesSet.Err())
 
+	assert.Equal(t, uint64(4), stats.LoadFetchedSeries())
+	assert.Equal(t, uint64(len(s1)*3+len(s2)), stats.LoadIngesterSamples())
+	assert.Greater(t, stats.LoadFetchedChunkBytes(), uint64(0))
+
 	require.True(t, seriesSet.Next())
 	verifySeries(t, seriesSet.At(), labels.Labels{{Name: labels.MetricName, Value: "one"}}, s1)
 
@@ -355,3 +406,8 @@ func (m *mockDistributor) MetricsMetadata(ctx context.Context) ([]scrape.MetricM
 	args := m.Called(ctx)
 	return args.Get(0).([]scrape.MetricMetadata), args.Error(1)
 }
+
+func (m *mockDistributor) QueryExemplars(ctx context.Context, from, to model.Time, matchersSet ...[]*labels.Matcher) (*client.ExemplarQueryResponse, error) {
+	args := m.Called(ctx, from, to, matchersSet)
+	return args.Get(0).(*client.ExemplarQueryResponse), args.Error(1)
+}
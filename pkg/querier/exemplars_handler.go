@@ -0,0 +1,123 @@
+package querier
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+const errorTypeBadData = "bad_data"
+
+var errExemplarsEndBeforeStart = errors.New("end timestamp must not be before start time")
+
+type exemplar struct {
+	Labels    labels.Labels `json:"labels"`
+	Value     string        `json:"value"`
+	Timestamp float64       `json:"timestamp"`
+}
+
+type exemplarsResult struct {
+	SeriesLabels labels.Labels `json:"seriesLabels"`
+	Exemplars    []exemplar    `json:"exemplars"`
+}
+
+type exemplarsResponse struct {
+	Status    string            `json:"status"`
+	Data      []exemplarsResult `json:"data"`
+	ErrorType string            `json:"errorType,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// ExemplarsHandler returns the exemplars held by the ingesters for the series selected by the
+// input query, in the same format of the Prometheus /api/v1/query_exemplars endpoint.
+func ExemplarsHandler(d Distributor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, err := parseTimeParam(r, "start", math.MinInt64)
+		if err != nil {
+			writeExemplarsError(w, err)
+			return
+		}
+
+		end, err := parseTimeParam(r, "end", math.MaxInt64)
+		if err != nil {
+			writeExemplarsError(w, err)
+			return
+		}
+
+		if end < start {
+			writeExemplarsError(w, errExemplarsEndBeforeStart)
+			return
+		}
+
+		expr, err := parser.ParseExpr(r.FormValue("query"))
+		if err != nil {
+			writeExemplarsError(w, err)
+			return
+		}
+
+		resp, err := d.QueryExemplars(r.Context(), model.Time(start), model.Time(end), extractSelectors(expr)...)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			util.WriteJSONResponse(w, exemplarsResponse{Status: statusError, Error: err.Error()})
+			return
+		}
+
+		data := make([]exemplarsResult, 0, len(resp.Timeseries))
+		for _, ts := range resp.Timeseries {
+			result := exemplarsResult{
+				SeriesLabels: client.FromLabelAdaptersToLabels(ts.Labels),
+				Exemplars:    make([]exemplar, 0, len(ts.Exemplars)),
+			}
+
+			for _, e := range ts.Exemplars {
+				result.Exemplars = append(result.Exemplars, exemplar{
+					Labels:    client.FromLabelAdaptersToLabels(e.Labels),
+					Value:     model.SampleValue(e.Value).String(),
+					Timestamp: float64(e.TimestampMs) / 1000,
+				})
+			}
+
+			data = append(data, result)
+		}
+
+		util.WriteJSONResponse(w, exemplarsResponse{Status: statusSuccess, Data: data})
+	})
+}
+
+// extractSelectors returns the label matchers of each vector selector in the input expression.
+func extractSelectors(expr parser.Expr) [][]*labels.Matcher {
+	var selectors [][]*labels.Matcher
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		if vs, ok := node.(*parser.VectorSelector); ok {
+			selectors = append(selectors, vs.LabelMatchers)
+		}
+		return nil
+	})
+	return selectors
+}
+
+func parseTimeParam(r *http.Request, paramName string, defaultValue int64) (int64, error) {
+	val := r.FormValue(paramName)
+	if val == "" {
+		return defaultValue, nil
+	}
+	return util.ParseTime(val)
+}
+
+func writeExemplarsError(w http.ResponseWriter, err error) {
+	msg := err.Error()
+	if resp, ok := httpgrpc.HTTPResponseFromError(err); ok {
+		msg = string(resp.Body)
+	}
+
+	w.WriteHeader(http.StatusBadRequest)
+	util.WriteJSONResponse(w, exemplarsResponse{Status: statusError, ErrorType: errorTypeBadData, Error: msg})
+}
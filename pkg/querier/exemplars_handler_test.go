@@ -0,0 +1,110 @@
+package querier
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+)
+
+func TestExemplarsHandler_Success(t *testing.T) {
+	d := &mockDistributor{}
+	d.On("QueryExemplars", mock.Anything, model.Time(1000), model.Time(2000), [][]*labels.Matcher{
+		{labels.MustNewMatcher(labels.MatchEqual, model.MetricNameLabel, "test_metric")},
+	}).Return(&client.ExemplarQueryResponse{Timeseries: []client.TimeSeries{{
+		Labels: []client.LabelAdapter{{Name: model.MetricNameLabel, Value: "test_metric"}},
+		Exemplars: []client.Exemplar{
+			{Labels: []client.LabelAdapter{{Name: "traceID", Value: "abc"}}, Value: 1.5, TimestampMs: 1500},
+		},
+	}}}, nil)
+
+	handler := ExemplarsHandler(d)
+
+	request, err := http.NewRequest("GET", "/api/v1/query_exemplars?query=rate(test_metric[5m])&start=1&end=2", nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	responseBody, err := ioutil.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+
+	expectedJSON := `
+	{
+		"status": "success",
+		"data": [
+			{
+				"seriesLabels": {"__name__": "test_metric"},
+				"exemplars": [
+					{
+						"labels": {"traceID": "abc"},
+						"value": "1.5",
+						"timestamp": 1.5
+					}
+				]
+			}
+		]
+	}
+	`
+
+	require.JSONEq(t, expectedJSON, string(responseBody))
+}
+
+func TestExemplarsHandler_Error(t *testing.T) {
+	tests := map[string]struct {
+		url            string
+		distributorErr error
+		expectedStatus int
+		expectedJSON   string
+	}{
+		"invalid query": {
+			url:            "/api/v1/query_exemplars?query=up{",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"invalid start time": {
+			url:            "/api/v1/query_exemplars?query=up&start=foo",
+			expectedStatus: http.StatusBadRequest,
+			expectedJSON:   `{"status": "error", "errorType": "bad_data", "error": "cannot parse \"foo\" to a valid timestamp", "data": null}`,
+		},
+		"end before start": {
+			url:            "/api/v1/query_exemplars?query=up&start=2&end=1",
+			expectedStatus: http.StatusBadRequest,
+			expectedJSON:   `{"status": "error", "errorType": "bad_data", "error": "end timestamp must not be before start time", "data": null}`,
+		},
+		"distributor error": {
+			url:            "/api/v1/query_exemplars?query=up",
+			distributorErr: fmt.Errorf("no user id"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedJSON:   `{"status": "error", "error": "no user id", "data": null}`,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			d := &mockDistributor{}
+			d.On("QueryExemplars", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&client.ExemplarQueryResponse{}, testData.distributorErr)
+
+			request, err := http.NewRequest("GET", testData.url, nil)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			ExemplarsHandler(d).ServeHTTP(recorder, request)
+
+			require.Equal(t, testData.expectedStatus, recorder.Result().StatusCode)
+			if testData.expectedJSON != "" {
+				responseBody, err := ioutil.ReadAll(recorder.Result().Body)
+				require.NoError(t, err)
+				require.JSONEq(t, testData.expectedJSON, string(responseBody))
+			}
+		})
+	}
+}
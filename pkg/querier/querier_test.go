@@ -761,6 +761,10 @@ func (m *errDistributor) MetricsMetadata(ctx context.Context) ([]scrape.MetricMe
 	return nil, errDistributorError
 }
 
+func (m *errDistributor) QueryExemplars(ctx context.Context, from, to model.Time, matchersSet ...[]*labels.Matcher) (*client.ExemplarQueryResponse, error) {
+	return nil, errDistributorError
+}
+
 type emptyChunkStore struct {
 	sync.Mutex
 	called bool
@@ -805,6 +809,10 @@ func (d *emptyDistributor) MetricsMetadata(ctx context.Context) ([]scrape.Metric
 	return nil, nil
 }
 
+func (d *emptyDistributor) QueryExemplars(ctx context.Context, from, to model.Time, matchersSet ...[]*labels.Matcher) (*client.ExemplarQueryResponse, error) {
+	return &client.ExemplarQueryResponse{}, nil
+}
+
 func TestShortTermQueryToLTS(t *testing.T) {
 	testCases := []struct {
 		name                 string
@@ -31,6 +31,10 @@ type Limits interface {
 	// MaxCacheFreshness returns the period after which results are cacheable,
 	// to prevent caching of very recent results.
 	MaxCacheFreshness(string) time.Duration
+
+	// QueryShardingTotalShards returns the number of shards queries are split into
+	// when running the blocks storage.
+	QueryShardingTotalShards(string) int
 }
 
 type limitsMiddleware struct {
@@ -190,6 +190,7 @@ type mockLimits struct {
 	maxQueryLookback  time.Duration
 	maxQueryLength    time.Duration
 	maxCacheFreshness time.Duration
+	totalShards       int
 }
 
 func (m mockLimits) MaxQueryLookback(string) time.Duration {
@@ -208,6 +209,10 @@ func (m mockLimits) MaxCacheFreshness(string) time.Duration {
 	return m.maxCacheFreshness
 }
 
+func (m mockLimits) QueryShardingTotalShards(string) int {
+	return m.totalShards
+}
+
 type mockHandler struct {
 	mock.Mock
 }
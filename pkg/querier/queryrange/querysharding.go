@@ -16,7 +16,9 @@ import (
 	"github.com/cortexproject/cortex/pkg/chunk"
 	"github.com/cortexproject/cortex/pkg/querier/astmapper"
 	"github.com/cortexproject/cortex/pkg/querier/lazyquery"
+	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/validation"
 )
 
 var (
@@ -63,6 +65,15 @@ func (confs ShardingConfigs) GetConf(r Request) (chunk.PeriodConfig, error) {
 	return conf, nil
 }
 
+// Shards implements shardingResolver.
+func (confs ShardingConfigs) Shards(_ context.Context, r Request) (int, error) {
+	conf, err := confs.GetConf(r)
+	if err != nil {
+		return 0, err
+	}
+	return int(conf.RowShards), nil
+}
+
 func (confs ShardingConfigs) hasShards() bool {
 	for _, conf := range confs {
 		if conf.RowShards > 0 {
@@ -72,6 +83,26 @@ func (confs ShardingConfigs) hasShards() bool {
 	return false
 }
 
+// shardingResolver returns the number of shards a request should be split into. A request
+// is not sharded if an error is returned or the number of shards is lower than 2.
+type shardingResolver interface {
+	Shards(ctx context.Context, r Request) (int, error)
+}
+
+// limitsShardingResolver resolves the number of shards from the per-tenant limits.
+type limitsShardingResolver struct {
+	limits Limits
+}
+
+// Shards implements shardingResolver.
+func (l limitsShardingResolver) Shards(ctx context.Context, _ Request) (int, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return validation.SmallestPositiveIntPerTenant(tenantIDs, l.limits.QueryShardingTotalShards), nil
+}
+
 func mapQuery(mapper astmapper.ASTMapper, query string) (parser.Node, error) {
 	expr, err := parser.ParseExpr(query)
 	if err != nil {
@@ -81,6 +112,7 @@ func mapQuery(mapper astmapper.ASTMapper, query string) (parser.Node, error) {
 }
 
 // NewQueryShardMiddleware creates a middleware which downstreams queries after AST mapping and query encoding.
+// The number of shards is defined by the chunks storage schema config.
 func NewQueryShardMiddleware(
 	logger log.Logger,
 	engine *promql.Engine,
@@ -108,9 +140,9 @@ func NewQueryShardMiddleware(
 
 	shardingware := MiddlewareFunc(func(next Handler) Handler {
 		return &queryShard{
-			confs:  confs,
-			next:   next,
-			engine: engine,
+			sharding: confs,
+			next:     next,
+			engine:   engine,
 		}
 	})
 
@@ -130,10 +162,43 @@ func NewQueryShardMiddleware(
 
 }
 
+// NewBlocksQueryShardMiddleware creates a middleware which downstreams queries after AST mapping and query
+// encoding, for the blocks storage. The number of shards is defined by a per-tenant limit. Differently than
+// the chunks storage, queries are sharded regardless of their time range, because the queriers filter the series
+// by shard once fetched from both the ingesters and the store-gateways. Each shard fetches all the series
+// matching the query, so only the PromQL evaluation is parallelised.
+func NewBlocksQueryShardMiddleware(
+	logger log.Logger,
+	engine *promql.Engine,
+	limits Limits,
+	metrics *InstrumentMiddlewareMetrics,
+	registerer prometheus.Registerer,
+) Middleware {
+	sharding := limitsShardingResolver{limits: limits}
+
+	mapperware := MiddlewareFunc(func(next Handler) Handler {
+		return newASTMapperware(sharding, next, logger, registerer)
+	})
+
+	shardingware := MiddlewareFunc(func(next Handler) Handler {
+		return &queryShard{
+			sharding: sharding,
+			next:     next,
+			engine:   engine,
+		}
+	})
+
+	return MergeMiddlewares(
+		InstrumentMiddleware("shardingware", metrics),
+		mapperware,
+		shardingware,
+	)
+}
+
 type astMapperware struct {
-	confs  ShardingConfigs
-	logger log.Logger
-	next   Handler
+	sharding shardingResolver
+	logger   log.Logger
+	next     Handler
 
 	// Metrics.
 	registerer            prometheus.Registerer
@@ -141,9 +206,9 @@ type astMapperware struct {
 	shardedQueriesCounter prometheus.Counter
 }
 
-func newASTMapperware(confs ShardingConfigs, next Handler, logger log.Logger, registerer prometheus.Registerer) *astMapperware {
+func newASTMapperware(sharding shardingResolver, next Handler, logger log.Logger, registerer prometheus.Registerer) *astMapperware {
 	return &astMapperware{
-		confs:      confs,
+		sharding:   sharding,
 		logger:     log.With(logger, "middleware", "QueryShard.astMapperware"),
 		next:       next,
 		registerer: registerer,
@@ -161,14 +226,17 @@ func newASTMapperware(confs ShardingConfigs, next Handler, logger log.Logger, re
 }
 
 func (ast *astMapperware) Do(ctx context.Context, r Request) (Response, error) {
-	conf, err := ast.confs.GetConf(r)
+	shards, err := ast.sharding.Shards(ctx, r)
 	// cannot shard with this timerange
 	if err != nil {
 		level.Warn(ast.logger).Log("err", err.Error(), "msg", "skipped AST mapper for request")
 		return ast.next.Do(ctx, r)
 	}
+	if shards < 2 {
+		return ast.next.Do(ctx, r)
+	}
 
-	shardSummer, err := astmapper.NewShardSummer(int(conf.RowShards), astmapper.VectorSquasher, ast.shardedQueriesCounter)
+	shardSummer, err := astmapper.NewShardSummer(shards, astmapper.VectorSquasher, ast.shardedQueriesCounter)
 	if err != nil {
 		return nil, err
 	}
@@ -197,15 +265,15 @@ func (ast *astMapperware) Do(ctx context.Context, r Request) (Response, error) {
 }
 
 type queryShard struct {
-	confs  ShardingConfigs
-	next   Handler
-	engine *promql.Engine
+	sharding shardingResolver
+	next     Handler
+	engine   *promql.Engine
 }
 
 func (qs *queryShard) Do(ctx context.Context, r Request) (Response, error) {
 	// since there's no available sharding configuration for this time range,
 	// no astmapping has been performed, so skip this middleware.
-	if _, err := qs.confs.GetConf(r); err != nil {
+	if shards, err := qs.sharding.Shards(ctx, r); err != nil || shards < 2 {
 		return qs.next.Do(ctx, r)
 	}
 
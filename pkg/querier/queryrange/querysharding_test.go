@@ -5,19 +5,24 @@ import (
 	"fmt"
 	"math"
 	"runtime"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/pkg/errors"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/promql"
 	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
 
 	"github.com/cortexproject/cortex/pkg/chunk"
 	"github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/querier/astmapper"
 	"github.com/cortexproject/cortex/pkg/util"
 )
 
@@ -423,6 +428,149 @@ func TestQueryshardingCorrectness(t *testing.T) {
 	}
 }
 
+func TestBlocksQueryShardMiddleware(t *testing.T) {
+	req := &PrometheusRequest{
+		Path:  "/query_range",
+		Start: util.TimeToMillis(start),
+		End:   util.TimeToMillis(end),
+		Step:  int64(step) / int64(time.Second),
+	}
+
+	for _, tc := range []struct {
+		desc        string
+		query       string
+		totalShards int
+		shouldShard bool
+	}{
+		{
+			desc:        "sharding disabled",
+			query:       `sum by (foo,bar) (min_over_time(bar1{baz="blip"}[1m]))`,
+			totalShards: 0,
+			shouldShard: false,
+		},
+		{
+			desc:        "sharding disabled with a single shard",
+			query:       `sum by (foo,bar) (min_over_time(bar1{baz="blip"}[1m]))`,
+			totalShards: 1,
+			shouldShard: false,
+		},
+		{
+			desc:        "shardable query",
+			query:       `sum by (foo,bar) (min_over_time(bar1{baz="blip"}[1m]))`,
+			totalShards: 3,
+			shouldShard: true,
+		},
+		{
+			desc:        "shard one leg encode the other",
+			query:       "sum(rate(bar1[1m])) or rate(bar1[1m])",
+			totalShards: 2,
+			shouldShard: true,
+		},
+		{
+			desc:        "non shardable query",
+			query:       `histogram_quantile(0.5, rate(bar1{baz="blip"}[30s]))`,
+			totalShards: 2,
+			shouldShard: false,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			downstream := &downstreamHandler{
+				engine:    engine,
+				queryable: shardAwareQueryable,
+			}
+
+			var didShard bool
+			spy := MiddlewareFunc(func(next Handler) Handler {
+				return HandlerFunc(func(ctx context.Context, r Request) (Response, error) {
+					if r.GetQuery() != tc.query {
+						didShard = true
+					}
+					return next.Do(ctx, r)
+				})
+			})
+
+			shardingware := NewBlocksQueryShardMiddleware(
+				log.NewNopLogger(),
+				engine,
+				mockLimits{totalShards: tc.totalShards},
+				nil,
+				nil,
+			)
+
+			ctx := user.InjectOrgID(context.Background(), "user-1")
+			r := req.WithQuery(tc.query)
+
+			shardedRes, err := MergeMiddlewares(shardingware, spy).Wrap(downstream).Do(ctx, r)
+			require.Nil(t, err)
+			require.Equal(t, tc.shouldShard, didShard)
+
+			res, err := downstream.Do(ctx, r)
+			require.Nil(t, err)
+
+			approximatelyEquals(t, res.(*PrometheusResponse), shardedRes.(*PrometheusResponse))
+		})
+	}
+}
+
+func TestBlocksQueryShardMiddleware_ShouldSendShardedRequestsToQueryable(t *testing.T) {
+	var (
+		mtx    sync.Mutex
+		shards []string
+	)
+
+	// Record the shard of each request received by the queryable.
+	queryable := storage.QueryableFunc(func(ctx context.Context, mint, maxt int64) (storage.Querier, error) {
+		q, err := shardAwareQueryable.Querier(ctx, mint, maxt)
+		if err != nil {
+			return nil, err
+		}
+		return &shardRecordingQuerier{Querier: q, record: func(shard string) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			shards = append(shards, shard)
+		}}, nil
+	})
+
+	shardingware := NewBlocksQueryShardMiddleware(log.NewNopLogger(), engine, mockLimits{totalShards: 3}, nil, nil)
+	downstream := &downstreamHandler{engine: engine, queryable: queryable}
+
+	req := &PrometheusRequest{
+		Path:  "/query_range",
+		Start: util.TimeToMillis(start),
+		End:   util.TimeToMillis(end),
+		Step:  int64(step) / int64(time.Second),
+		Query: `sum by (foo) (rate(bar1{baz="blip"}[1m]))`,
+	}
+
+	_, err := shardingware.Wrap(downstream).Do(user.InjectOrgID(context.Background(), "user-1"), req)
+	require.NoError(t, err)
+
+	sort.Strings(shards)
+	require.Equal(t, []string{
+		astmapper.ShardAnnotation{Shard: 0, Of: 3}.String(),
+		astmapper.ShardAnnotation{Shard: 1, Of: 3}.String(),
+		astmapper.ShardAnnotation{Shard: 2, Of: 3}.String(),
+	}, shards)
+}
+
+// shardRecordingQuerier records the value of the shard matcher of each Select.
+type shardRecordingQuerier struct {
+	storage.Querier
+	record func(shard string)
+}
+
+func (q *shardRecordingQuerier) Select(sorted bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	var shard string
+	for _, m := range matchers {
+		if m.Name == astmapper.ShardLabel {
+			shard = m.Value
+		}
+	}
+	q.record(shard)
+
+	return q.Querier.Select(sorted, hints, matchers...)
+}
+
 func TestShardSplitting(t *testing.T) {
 
 	for _, tc := range []struct {
@@ -35,6 +35,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/chunk"
 	"github.com/cortexproject/cortex/pkg/chunk/cache"
 	"github.com/cortexproject/cortex/pkg/tenant"
+	"github.com/cortexproject/cortex/pkg/util/httpheaders"
 )
 
 const day = 24 * time.Hour
@@ -57,6 +58,10 @@ type Config struct {
 	CacheResults           bool `yaml:"cache_results"`
 	MaxRetries             int  `yaml:"max_retries"`
 	ShardedQueries         bool `yaml:"parallelise_shardable_queries"`
+
+	// Whether the queriers are running the blocks storage. Injected internally, because
+	// query sharding works differently for the chunks and blocks storage.
+	BlocksStorageEnabled bool `yaml:"-"`
 }
 
 // RegisterFlags adds the flags required to config this to the given FlagSet.
@@ -66,7 +71,7 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.DurationVar(&cfg.SplitQueriesByInterval, "querier.split-queries-by-interval", 0, "Split queries by an interval and execute in parallel, 0 disables it. You should use an a multiple of 24 hours (same as the storage bucketing scheme), to avoid queriers downloading and processing the same chunks. This also determines how cache keys are chosen when result caching is enabled")
 	f.BoolVar(&cfg.AlignQueriesWithStep, "querier.align-querier-with-step", false, "Mutate incoming queries to align their start and end with their step.")
 	f.BoolVar(&cfg.CacheResults, "querier.cache-results", false, "Cache query results.")
-	f.BoolVar(&cfg.ShardedQueries, "querier.parallelise-shardable-queries", false, "Perform query parallelisations based on storage sharding configuration and query ASTs. This feature is supported only by the chunks storage engine.")
+	f.BoolVar(&cfg.ShardedQueries, "querier.parallelise-shardable-queries", false, "Perform query parallelisations based on storage sharding configuration and query ASTs. When running the chunks storage, the number of shards is defined by the schema config, while when running the blocks storage it's defined by the -frontend.query-sharding-total-shards limit.")
 	cfg.ResultsCacheConfig.RegisterFlags(f)
 }
 
@@ -182,7 +187,20 @@ func NewTripperware(
 		queryRangeMiddleware = append(queryRangeMiddleware, InstrumentMiddleware("results_cache", metrics), queryCacheMiddleware)
 	}
 
-	if cfg.ShardedQueries {
+	if cfg.ShardedQueries && cfg.BlocksStorageEnabled {
+		shardingware := NewBlocksQueryShardMiddleware(
+			log,
+			promql.NewEngine(engineOpts),
+			limits,
+			metrics,
+			registerer,
+		)
+
+		queryRangeMiddleware = append(
+			queryRangeMiddleware,
+			shardingware, // instrumentation is included in the sharding middleware
+		)
+	} else if cfg.ShardedQueries {
 		if minShardingLookback == 0 {
 			return nil, nil, errInvalidMinShardingLookback
 		}
@@ -252,23 +270,31 @@ func NewRoundTripper(next http.RoundTripper, codec Codec, middlewares ...Middlew
 	return transport
 }
 
+type priorityCtxKey struct{}
+
 func (q roundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx := r.Context()
 
-	request, err := q.codec.DecodeRequest(r.Context(), r)
+	request, err := q.codec.DecodeRequest(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 
-	if span := opentracing.SpanFromContext(r.Context()); span != nil {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
 		request.LogToSpan(span)
 	}
 
-	response, err := q.handler.Do(r.Context(), request)
+	// Propagate the query priority to the split queries sent to the query-scheduler.
+	if priority := r.Header.Get(httpheaders.QueryPriority); priority != "" {
+		ctx = context.WithValue(ctx, priorityCtxKey{}, priority)
+	}
+
+	response, err := q.handler.Do(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	return q.codec.EncodeResponse(r.Context(), response)
+	return q.codec.EncodeResponse(ctx, response)
 }
 
 // Do implements Handler.
@@ -282,6 +308,10 @@ func (q roundTripper) Do(ctx context.Context, r Request) (Response, error) {
 		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
 	}
 
+	if priority, ok := ctx.Value(priorityCtxKey{}).(string); ok {
+		request.Header.Set(httpheaders.QueryPriority, priority)
+	}
+
 	response, err := q.next.RoundTrip(request)
 	if err != nil {
 		return nil, err
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 
 	"github.com/cortexproject/cortex/pkg/chunk"
 	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/httpheaders"
 )
 
 func TestRoundTrip(t *testing.T) {
@@ -96,6 +98,44 @@ func TestRoundTrip(t *testing.T) {
 	}
 }
 
+func TestRoundTrip_ShouldPropagatePriorityHeader(t *testing.T) {
+	var priorities []string
+	downstream := RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+		priorities = append(priorities, r.Header.Get(httpheaders.QueryPriority))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(responseBody)),
+		}, nil
+	})
+
+	rt := NewRoundTripper(downstream, noResponseEncodingCodec{PrometheusCodec})
+
+	for _, priority := range []string{"", "low"} {
+		req, err := http.NewRequest("GET", query, http.NoBody)
+		require.NoError(t, err)
+		if priority != "" {
+			req.Header.Set(httpheaders.QueryPriority, priority)
+		}
+
+		ctx := user.InjectOrgID(context.Background(), "1")
+		resp, err := rt.RoundTrip(req.WithContext(ctx))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	require.Equal(t, []string{"", "low"}, priorities)
+}
+
+// noResponseEncodingCodec is a Codec which doesn't encode the response returned by the round tripper.
+type noResponseEncodingCodec struct {
+	Codec
+}
+
+func (noResponseEncodingCodec) EncodeResponse(context.Context, Response) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
 type singleHostRoundTripper struct {
 	host string
 	next http.RoundTripper
@@ -26,6 +26,7 @@ import (
 
 	"github.com/cortexproject/cortex/pkg/chunk/purger"
 	"github.com/cortexproject/cortex/pkg/prom1/storage/metric"
+	"github.com/cortexproject/cortex/pkg/querier/astmapper"
 )
 
 // ConcreteSeriesSet implements storage.SeriesSet.
@@ -383,3 +384,36 @@ func (s seriesSetWithWarnings) Err() error {
 func (s seriesSetWithWarnings) Warnings() storage.Warnings {
 	return append(s.wrapped.Warnings(), s.warnings...)
 }
+
+// NewShardedSeriesSet returns a series set with only the series of the wrapped set belonging
+// to the input shard, with the shard label injected. Series are sorted again, because injecting
+// a label may change their order.
+func NewShardedSeriesSet(wrapped storage.SeriesSet, shard astmapper.ShardAnnotation) storage.SeriesSet {
+	shardLabel := shard.Label()
+
+	var result []storage.Series
+	for wrapped.Next() {
+		series := wrapped.At()
+		if !shard.Matches(series.Labels()) {
+			continue
+		}
+
+		lbls := labels.NewBuilder(series.Labels()).Set(shardLabel.Name, shardLabel.Value).Labels()
+		result = append(result, shardedSeries{Series: series, labels: lbls})
+	}
+
+	if err := wrapped.Err(); err != nil {
+		return storage.ErrSeriesSet(err)
+	}
+
+	return NewSeriesSetWithWarnings(NewConcreteSeriesSet(result), wrapped.Warnings())
+}
+
+type shardedSeries struct {
+	storage.Series
+	labels labels.Labels
+}
+
+func (s shardedSeries) Labels() labels.Labels {
+	return s.labels
+}
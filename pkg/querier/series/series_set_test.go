@@ -2,12 +2,15 @@ package series
 
 import (
 	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/querier/astmapper"
 )
 
 func TestConcreteSeriesSet(t *testing.T) {
@@ -150,3 +153,37 @@ func TestDeletedIterator_WithSeek(t *testing.T) {
 func inbound(t model.Time, interval model.Interval) bool {
 	return interval.Start <= t && t <= interval.End
 }
+
+func TestShardedSeriesSet(t *testing.T) {
+	const shards = 3
+
+	var input []storage.Series
+	for i := 0; i < 30; i++ {
+		input = append(input, NewConcreteSeries(
+			labels.FromStrings(labels.MetricName, "foo", "idx", strconv.Itoa(i)),
+			[]model.SamplePair{{Value: model.SampleValue(i), Timestamp: 1}},
+		))
+	}
+
+	total := 0
+	for s := 0; s < shards; s++ {
+		shard := astmapper.ShardAnnotation{Shard: s, Of: shards}
+		set := NewShardedSeriesSet(NewConcreteSeriesSet(input), shard)
+
+		var prev labels.Labels
+		for set.Next() {
+			lbls := set.At().Labels()
+			require.Equal(t, shard.String(), lbls.Get(astmapper.ShardLabel))
+			require.True(t, shard.Matches(labels.NewBuilder(lbls).Del(astmapper.ShardLabel).Labels()))
+			if prev != nil {
+				require.True(t, labels.Compare(prev, lbls) < 0)
+			}
+			prev = lbls
+			total++
+		}
+		require.NoError(t, set.Err())
+	}
+
+	// Each series must be returned by exactly one shard.
+	require.Equal(t, len(input), total)
+}
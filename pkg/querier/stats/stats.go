@@ -54,6 +54,78 @@ func (s *Stats) LoadWallTime() time.Duration {
 	return time.Duration(atomic.LoadInt64((*int64)(&s.WallTime)))
 }
 
+// AddFetchedSeries adds some series to the counter.
+func (s *Stats) AddFetchedSeries(series uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.FetchedSeriesCount, series)
+}
+
+// LoadFetchedSeries returns the number of fetched series.
+func (s *Stats) LoadFetchedSeries() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.FetchedSeriesCount)
+}
+
+// AddFetchedChunkBytes adds some bytes to the counter.
+func (s *Stats) AddFetchedChunkBytes(bytes uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.FetchedChunkBytes, bytes)
+}
+
+// LoadFetchedChunkBytes returns the number of bytes of the fetched chunks.
+func (s *Stats) LoadFetchedChunkBytes() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.FetchedChunkBytes)
+}
+
+// AddFetchedBlocks adds some blocks to the counter.
+func (s *Stats) AddFetchedBlocks(blocks uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.FetchedBlocksCount, blocks)
+}
+
+// LoadFetchedBlocks returns the number of blocks queried in the store-gateways.
+func (s *Stats) LoadFetchedBlocks() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.FetchedBlocksCount)
+}
+
+// AddIngesterSamples adds some samples to the counter.
+func (s *Stats) AddIngesterSamples(samples uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.IngesterSamplesCount, samples)
+}
+
+// LoadIngesterSamples returns the number of samples returned by the ingesters.
+func (s *Stats) LoadIngesterSamples() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.IngesterSamplesCount)
+}
+
 // Merge the provide Stats into this one.
 func (s *Stats) Merge(other *Stats) {
 	if s == nil || other == nil {
@@ -61,6 +133,10 @@ func (s *Stats) Merge(other *Stats) {
 	}
 
 	s.AddWallTime(other.LoadWallTime())
+	s.AddFetchedSeries(other.LoadFetchedSeries())
+	s.AddFetchedChunkBytes(other.LoadFetchedChunkBytes())
+	s.AddFetchedBlocks(other.LoadFetchedBlocks())
+	s.AddIngesterSamples(other.LoadIngesterSamples())
 }
 
 func ShouldTrackHTTPGRPCResponse(r *httpgrpc.HTTPResponse) bool {
@@ -32,6 +32,14 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type Stats struct {
 	// The sum of all wall time spent in the querier to execute the query.
 	WallTime time.Duration `protobuf:"bytes,1,opt,name=wall_time,json=wallTime,proto3,stdduration" json:"wall_time"`
+	// The number of series fetched for the query.
+	FetchedSeriesCount uint64 `protobuf:"varint,2,opt,name=fetched_series_count,json=fetchedSeriesCount,proto3" json:"fetched_series_count,omitempty"`
+	// The number of bytes of the chunks fetched for the query.
+	FetchedChunkBytes uint64 `protobuf:"varint,3,opt,name=fetched_chunk_bytes,json=fetchedChunkBytes,proto3" json:"fetched_chunk_bytes,omitempty"`
+	// The number of blocks queried in the store-gateways.
+	FetchedBlocksCount uint64 `protobuf:"varint,4,opt,name=fetched_blocks_count,json=fetchedBlocksCount,proto3" json:"fetched_blocks_count,omitempty"`
+	// The number of samples returned by the ingesters.
+	IngesterSamplesCount uint64 `protobuf:"varint,5,opt,name=ingester_samples_count,json=ingesterSamplesCount,proto3" json:"ingester_samples_count,omitempty"`
 }
 
 func (m *Stats) Reset()      { *m = Stats{} }
@@ -73,6 +81,34 @@ func (m *Stats) GetWallTime() time.Duration {
 	return 0
 }
 
+func (m *Stats) GetFetchedSeriesCount() uint64 {
+	if m != nil {
+		return m.FetchedSeriesCount
+	}
+	return 0
+}
+
+func (m *Stats) GetFetchedChunkBytes() uint64 {
+	if m != nil {
+		return m.FetchedChunkBytes
+	}
+	return 0
+}
+
+func (m *Stats) GetFetchedBlocksCount() uint64 {
+	if m != nil {
+		return m.FetchedBlocksCount
+	}
+	return 0
+}
+
+func (m *Stats) GetIngesterSamplesCount() uint64 {
+	if m != nil {
+		return m.IngesterSamplesCount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Stats)(nil), "stats.Stats")
 }
@@ -80,21 +116,27 @@ func init() {
 func init() { proto.RegisterFile("stats.proto", fileDescriptor_b4756a0aec8b9d44) }
 
 var fileDescriptor_b4756a0aec8b9d44 = []byte{
-	// 213 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2e, 0x2e, 0x49, 0x2c,
-	0x29, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x73, 0xa4, 0x74, 0xd3, 0x33, 0x4b,
-	0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xf5, 0xd3, 0xf3, 0xd3, 0xf3, 0xf5, 0xc1, 0xb2, 0x49,
-	0xa5, 0x69, 0x60, 0x1e, 0x98, 0x03, 0x66, 0x41, 0x74, 0x49, 0xc9, 0xa5, 0xe7, 0xe7, 0xa7, 0xe7,
-	0xa4, 0x22, 0x54, 0xa5, 0x94, 0x16, 0x25, 0x96, 0x64, 0xe6, 0xe7, 0x41, 0xe4, 0x95, 0x3c, 0xb9,
-	0x58, 0x83, 0x41, 0xe6, 0x0a, 0x39, 0x70, 0x71, 0x96, 0x27, 0xe6, 0xe4, 0xc4, 0x97, 0x64, 0xe6,
-	0xa6, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x1b, 0x49, 0xea, 0x41, 0x34, 0xeb, 0xc1, 0x34, 0xeb,
-	0xb9, 0x40, 0x35, 0x3b, 0x71, 0x9c, 0xb8, 0x27, 0xcf, 0x30, 0xe3, 0xbe, 0x3c, 0x63, 0x10, 0x07,
-	0x48, 0x57, 0x48, 0x66, 0x6e, 0xaa, 0x93, 0xf5, 0x85, 0x87, 0x72, 0x0c, 0x37, 0x1e, 0xca, 0x31,
-	0x7c, 0x78, 0x28, 0xc7, 0xd8, 0xf0, 0x48, 0x8e, 0x71, 0xc5, 0x23, 0x39, 0xc6, 0x13, 0x8f, 0xe4,
-	0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0xf1, 0xc5, 0x23, 0x39, 0x86, 0x0f, 0x8f,
-	0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0xb8, 0xf0, 0x58, 0x8e, 0xe1, 0xc6, 0x63, 0x39, 0x86, 0x28,
-	0x88, 0xb7, 0x92, 0xd8, 0xc0, 0x76, 0x18, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0x2d, 0xc4, 0x26,
-	0x5d, 0xf3, 0x00, 0x00, 0x00,
+	// 320 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xbf, 0x4e, 0x02, 0x31,
+	0x1c, 0xc7, 0x5b, 0x04, 0x83, 0xc7, 0xe4, 0x49, 0x0c, 0x32, 0xfc, 0x20, 0x4e, 0x2c, 0x16, 0xa3,
+	0x6e, 0x2e, 0xe6, 0xf0, 0x09, 0xc0, 0xc9, 0xe5, 0x72, 0x77, 0x94, 0xd2, 0x70, 0x77, 0x25, 0xd7,
+	0x5e, 0x8c, 0x9b, 0x8f, 0x60, 0xe2, 0xe2, 0x23, 0xf8, 0x28, 0x8c, 0x8c, 0x4c, 0x2a, 0x65, 0x71,
+	0xe4, 0x11, 0xcc, 0xb5, 0x9c, 0x7f, 0xb6, 0x7e, 0xf3, 0xe9, 0xa7, 0xdf, 0xe6, 0xf7, 0x73, 0x1a,
+	0x52, 0x05, 0x4a, 0x92, 0x79, 0x26, 0x94, 0x70, 0x6b, 0x26, 0xb4, 0xcf, 0x18, 0x57, 0xd3, 0x3c,
+	0x24, 0x91, 0x48, 0xfa, 0x4c, 0x30, 0xd1, 0x37, 0x34, 0xcc, 0x27, 0x26, 0x99, 0x60, 0x4e, 0xd6,
+	0x6a, 0x03, 0x13, 0x82, 0xc5, 0xf4, 0xf7, 0xd6, 0x38, 0xcf, 0x02, 0xc5, 0x45, 0x6a, 0xf9, 0xe9,
+	0x4b, 0xc5, 0xa9, 0x8d, 0x8a, 0x87, 0xdd, 0x1b, 0xe7, 0xe0, 0x21, 0x88, 0x63, 0x5f, 0xf1, 0x84,
+	0xb6, 0x70, 0x17, 0xf7, 0x1a, 0x17, 0x27, 0xc4, 0xda, 0xa4, 0xb4, 0xc9, 0xed, 0xce, 0xf6, 0xea,
+	0x8b, 0xf7, 0x0e, 0x7a, 0xfd, 0xe8, 0xe0, 0x61, 0xbd, 0xb0, 0xee, 0x78, 0x42, 0xdd, 0x73, 0xa7,
+	0x39, 0xa1, 0x2a, 0x9a, 0xd2, 0xb1, 0x2f, 0x69, 0xc6, 0xa9, 0xf4, 0x23, 0x91, 0xa7, 0xaa, 0x55,
+	0xe9, 0xe2, 0x5e, 0x75, 0xe8, 0xee, 0xd8, 0xc8, 0xa0, 0x41, 0x41, 0x5c, 0xe2, 0x1c, 0x95, 0x46,
+	0x34, 0xcd, 0xd3, 0x99, 0x1f, 0x3e, 0x2a, 0x2a, 0x5b, 0x7b, 0x46, 0x38, 0xdc, 0xa1, 0x41, 0x41,
+	0xbc, 0x02, 0xfc, 0x6d, 0x08, 0x63, 0x11, 0xcd, 0xca, 0x86, 0xea, 0xbf, 0x06, 0xcf, 0x20, 0xdb,
+	0x70, 0xe5, 0x1c, 0xf3, 0x94, 0x51, 0xa9, 0x68, 0xe6, 0xcb, 0x20, 0x99, 0xc7, 0x3f, 0xbf, 0xaa,
+	0x19, 0xa7, 0x59, 0xd2, 0x91, 0x85, 0xc6, 0xf2, 0xae, 0x97, 0x6b, 0x40, 0xab, 0x35, 0xa0, 0xed,
+	0x1a, 0xf0, 0x93, 0x06, 0xfc, 0xa6, 0x01, 0x2f, 0x34, 0xe0, 0xa5, 0x06, 0xfc, 0xa9, 0x01, 0x7f,
+	0x69, 0x40, 0x5b, 0x0d, 0xf8, 0x79, 0x03, 0x68, 0xb9, 0x01, 0xb4, 0xda, 0x00, 0xba, 0xb7, 0x1b,
+	0x0a, 0xf7, 0xcd, 0xb4, 0x2e, 0xbf, 0x07, 0x00, 0xb7, 0x73, 0xdf, 0xed, 0xbe, 0x01, 0x00, 0x00,
 }
 
 func (this *Stats) Equal(that interface{}) bool {
@@ -119,15 +161,31 @@ func (this *Stats) Equal(that interface{}) bool {
 	if this.WallTime != that1.WallTime {
 		return false
 	}
+	if this.FetchedSeriesCount != that1.FetchedSeriesCount {
+		return false
+	}
+	if this.FetchedChunkBytes != that1.FetchedChunkBytes {
+		return false
+	}
+	if this.FetchedBlocksCount != that1.FetchedBlocksCount {
+		return false
+	}
+	if this.IngesterSamplesCount != that1.IngesterSamplesCount {
+		return false
+	}
 	return true
 }
 func (this *Stats) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
+	s := make([]string, 0, 9)
 	s = append(s, "&stats.Stats{")
 	s = append(s, "WallTime: "+fmt.Sprintf("%#v", this.WallTime)+",\n")
+	s = append(s, "FetchedSeriesCount: "+fmt.Sprintf("%#v", this.FetchedSeriesCount)+",\n")
+	s = append(s, "FetchedChunkBytes: "+fmt.Sprintf("%#v", this.FetchedChunkBytes)+",\n")
+	s = append(s, "FetchedBlocksCount: "+fmt.Sprintf("%#v", this.FetchedBlocksCount)+",\n")
+	s = append(s, "IngesterSamplesCount: "+fmt.Sprintf("%#v", this.IngesterSamplesCount)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -159,6 +217,26 @@ func (m *Stats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.IngesterSamplesCount != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.IngesterSamplesCount))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.FetchedBlocksCount != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.FetchedBlocksCount))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.FetchedChunkBytes != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.FetchedChunkBytes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.FetchedSeriesCount != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.FetchedSeriesCount))
+		i--
+		dAtA[i] = 0x10
+	}
 	n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.WallTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.WallTime):])
 	if err1 != nil {
 		return 0, err1
@@ -189,6 +267,18 @@ func (m *Stats) Size() (n int) {
 	_ = l
 	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.WallTime)
 	n += 1 + l + sovStats(uint64(l))
+	if m.FetchedSeriesCount != 0 {
+		n += 1 + sovStats(uint64(m.FetchedSeriesCount))
+	}
+	if m.FetchedChunkBytes != 0 {
+		n += 1 + sovStats(uint64(m.FetchedChunkBytes))
+	}
+	if m.FetchedBlocksCount != 0 {
+		n += 1 + sovStats(uint64(m.FetchedBlocksCount))
+	}
+	if m.IngesterSamplesCount != 0 {
+		n += 1 + sovStats(uint64(m.IngesterSamplesCount))
+	}
 	return n
 }
 
@@ -204,6 +294,10 @@ func (this *Stats) String() string {
 	}
 	s := strings.Join([]string{`&Stats{`,
 		`WallTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.WallTime), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
+		`FetchedSeriesCount:` + fmt.Sprintf("%v", this.FetchedSeriesCount) + `,`,
+		`FetchedChunkBytes:` + fmt.Sprintf("%v", this.FetchedChunkBytes) + `,`,
+		`FetchedBlocksCount:` + fmt.Sprintf("%v", this.FetchedBlocksCount) + `,`,
+		`IngesterSamplesCount:` + fmt.Sprintf("%v", this.IngesterSamplesCount) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -278,6 +372,82 @@ func (m *Stats) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedSeriesCount", wireType)
+			}
+			m.FetchedSeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedSeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedChunkBytes", wireType)
+			}
+			m.FetchedChunkBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedChunkBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedBlocksCount", wireType)
+			}
+			m.FetchedBlocksCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedBlocksCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IngesterSamplesCount", wireType)
+			}
+			m.IngesterSamplesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IngesterSamplesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStats(dAtA[iNdEx:])
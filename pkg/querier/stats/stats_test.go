@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats_Merge(t *testing.T) {
+	t.Run("merge two stats objects", func(t *testing.T) {
+		stats1 := &Stats{}
+		stats1.AddWallTime(time.Millisecond)
+		stats1.AddFetchedSeries(50)
+		stats1.AddFetchedChunkBytes(42)
+		stats1.AddFetchedBlocks(3)
+		stats1.AddIngesterSamples(100)
+
+		stats2 := &Stats{}
+		stats2.AddWallTime(time.Second)
+		stats2.AddFetchedSeries(60)
+		stats2.AddFetchedChunkBytes(100)
+		stats2.AddFetchedBlocks(2)
+		stats2.AddIngesterSamples(10)
+
+		stats1.Merge(stats2)
+
+		assert.Equal(t, 1001*time.Millisecond, stats1.LoadWallTime())
+		assert.Equal(t, uint64(110), stats1.LoadFetchedSeries())
+		assert.Equal(t, uint64(142), stats1.LoadFetchedChunkBytes())
+		assert.Equal(t, uint64(5), stats1.LoadFetchedBlocks())
+		assert.Equal(t, uint64(110), stats1.LoadIngesterSamples())
+	})
+
+	t.Run("merge two nil stats objects", func(t *testing.T) {
+		var stats1 *Stats
+		var stats2 *Stats
+
+		stats1.Merge(stats2)
+		stats1.AddFetchedSeries(1)
+
+		assert.Equal(t, time.Duration(0), stats1.LoadWallTime())
+		assert.Equal(t, uint64(0), stats1.LoadFetchedSeries())
+		assert.Equal(t, uint64(0), stats1.LoadFetchedChunkBytes())
+		assert.Equal(t, uint64(0), stats1.LoadFetchedBlocks())
+		assert.Equal(t, uint64(0), stats1.LoadIngesterSamples())
+	})
+}
@@ -1,7 +1,9 @@
 package ruler
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -17,6 +19,7 @@ import (
 	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/pkg/rulefmt"
+	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/weaveworks/common/user"
 	"gopkg.in/yaml.v3"
 
@@ -121,15 +124,18 @@ func respondError(logger log.Logger, w http.ResponseWriter, msg string) {
 
 // API is used to handle HTTP requests for the ruler service
 type API struct {
-	ruler *Ruler
-	store rules.RuleStore
+	ruler     *Ruler
+	store     rules.RuleStore
+	queryFunc TenantQueryFunc
 }
 
-// NewAPI returns a new API struct with the provided ruler and rule store
-func NewAPI(r *Ruler, s rules.RuleStore) *API {
+// NewAPI returns a new API struct with the provided ruler and rule store. The queryFunc is
+// used to evaluate the rules on validation and can be nil if the evaluation is not supported.
+func NewAPI(r *Ruler, s rules.RuleStore, queryFunc TenantQueryFunc) *API {
 	return &API{
-		ruler: r,
-		store: s,
+		ruler:     r,
+		store:     s,
+		queryFunc: queryFunc,
 	}
 }
 
@@ -285,6 +291,8 @@ var (
 	ErrNoRuleGroups = errors.New("no rule groups found")
 	// ErrBadRuleGroup is returned when the provided rule group can not be unmarshalled
 	ErrBadRuleGroup = errors.New("unable to decoded rule group")
+	// ErrNamespaceReplaceNotSupported is returned when the rule store doesn't support the atomic replacement of a namespace
+	ErrNamespaceReplaceNotSupported = errors.New("the configured rule storage doesn't support the atomic replacement of a namespace")
 )
 
 func marshalAndSend(output interface{}, w http.ResponseWriter, logger log.Logger) {
@@ -389,6 +397,17 @@ func (a *API) ListRules(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// The namespace version is returned to be used for the optimistic concurrency control of the namespace
+	// replacement. It's read before the rule groups, so that a concurrent change results in a conflict.
+	if replacer, ok := a.store.(rules.NamespaceReplacer); ok && namespace != "" {
+		version, err := replacer.NamespaceVersion(req.Context(), userID, namespace)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("ETag", formatNamespaceVersion(version))
+	}
+
 	level.Debug(logger).Log("msg", "retrieving rule groups with namespace", "userID", userID, "namespace", namespace)
 	rgs, err := a.store.ListRuleGroupsForUserAndNamespace(req.Context(), userID, namespace)
 	if err != nil {
@@ -505,6 +524,480 @@ func (a *API) CreateRuleGroup(w http.ResponseWriter, req *http.Request) {
 	respondAccepted(w, logger)
 }
 
+// NamespaceDiff has the names of the rule groups added, changed and removed by the replacement of a namespace.
+type NamespaceDiff struct {
+	Added   []string `json:"added"`
+	Changed []string `json:"changed"`
+	Removed []string `json:"removed"`
+}
+
+// ruleGroupsLimitError is returned when the replacement of a namespace exceeds the max number of
+// rule groups of the tenant, to tell it apart from the errors of the rule store.
+type ruleGroupsLimitError struct {
+	err error
+}
+
+func (e *ruleGroupsLimitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ruleGroupsLimitError) Unwrap() error {
+	return e.err
+}
+
+// ReplaceNamespace atomically replaces all the rule groups of a namespace with the ones in the
+// rules file of the request body, and returns the difference with the replaced rule groups.
+func (a *API) ReplaceNamespace(w http.ResponseWriter, req *http.Request) {
+	logger := util_log.WithContext(req.Context(), util_log.Logger)
+	userID, namespace, _, err := parseRequest(req, true, false)
+	if err != nil {
+		respondError(logger, w, err.Error())
+		return
+	}
+
+	replacer, ok := a.store.(rules.NamespaceReplacer)
+	if !ok {
+		http.Error(w, ErrNamespaceReplaceNotSupported.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	expectedVersion, err := parseExpectedNamespaceVersion(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	payload, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		level.Error(logger).Log("msg", "unable to read rules file payload", "err", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rf := rulefmt.RuleGroups{}
+	if err := yaml.Unmarshal(payload, &rf); err != nil {
+		level.Error(logger).Log("msg", "unable to unmarshal rules file payload", "err", err.Error())
+		http.Error(w, ErrBadRuleGroup.Error(), http.StatusBadRequest)
+		return
+	}
+
+	names := map[string]struct{}{}
+	groups := make(rules.RuleGroupList, 0, len(rf.Groups))
+	for _, rg := range rf.Groups {
+		if _, ok := names[rg.Name]; ok {
+			http.Error(w, fmt.Sprintf("invalid rules config: repeated rule group name '%s'", rg.Name), http.StatusBadRequest)
+			return
+		}
+		names[rg.Name] = struct{}{}
+
+		if errs := a.ruler.manager.ValidateRuleGroup(rg); len(errs) > 0 {
+			e := []string{}
+			for _, err := range errs {
+				level.Error(logger).Log("msg", "unable to validate rule group payload", "err", err.Error())
+				e = append(e, err.Error())
+			}
+
+			http.Error(w, strings.Join(e, ", "), http.StatusBadRequest)
+			return
+		}
+
+		if err := a.ruler.AssertMaxRulesPerRuleGroup(userID, len(rg.Rules)); err != nil {
+			level.Error(logger).Log("msg", "limit validation failure", "err", err.Error(), "user", userID)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		groups = append(groups, store.ToProto(userID, namespace, rg))
+	}
+
+	// The limit is checked within the store transaction, as if the rule groups were created
+	// one by one after the ones currently stored in the namespace have been removed.
+	validate := func(otherGroups int) error {
+		if len(groups) == 0 {
+			return nil
+		}
+		if err := a.ruler.AssertMaxRuleGroups(userID, otherGroups+len(groups)-1); err != nil {
+			return &ruleGroupsLimitError{err: err}
+		}
+		return nil
+	}
+
+	level.Debug(logger).Log("msg", "attempting to replace namespace", "userID", userID, "namespace", namespace, "groups", len(groups), "expectedVersion", expectedVersion)
+	previous, version, err := replacer.ReplaceNamespaceWithVersion(req.Context(), userID, namespace, groups, expectedVersion, validate)
+	var limitErr *ruleGroupsLimitError
+	switch {
+	case errors.As(err, &limitErr):
+		level.Error(logger).Log("msg", "limit validation failure", "err", err.Error(), "user", userID)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, rules.ErrVersionConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		level.Error(logger).Log("msg", "unable to replace namespace", "err", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", formatNamespaceVersion(version))
+	b, err := json.Marshal(&response{
+		Status: "success",
+		Data:   diffRuleGroups(previous, groups),
+	})
+	if err != nil {
+		level.Error(logger).Log("msg", "error marshaling json response", "err", err)
+		respondError(logger, w, "unable to marshal the requested data")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if n, err := w.Write(b); err != nil {
+		level.Error(logger).Log("msg", "error writing response", "bytesWritten", n, "err", err)
+	}
+}
+
+// parseExpectedNamespaceVersion returns the namespace version in the If-Match header of the request,
+// or rules.AnyVersion if the header is not set or matches any version.
+func parseExpectedNamespaceVersion(req *http.Request) (int64, error) {
+	value := strings.TrimSpace(req.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return rules.AnyVersion, nil
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid If-Match header %q: must be a namespace version returned in the ETag header", value)
+	}
+	return version, nil
+}
+
+// formatNamespaceVersion returns the namespace version formatted as an ETag header value.
+func formatNamespaceVersion(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// diffRuleGroups returns the names of the rule groups added, changed and removed by the
+// replacement of the previous rule groups with the current ones.
+func diffRuleGroups(previous, current rules.RuleGroupList) *NamespaceDiff {
+	diff := &NamespaceDiff{Added: []string{}, Changed: []string{}, Removed: []string{}}
+
+	byName := make(map[string]*rules.RuleGroupDesc, len(previous))
+	for _, rg := range previous {
+		byName[rg.Name] = rg
+	}
+
+	for _, rg := range current {
+		prev, ok := byName[rg.Name]
+		if !ok {
+			diff.Added = append(diff.Added, rg.Name)
+			continue
+		}
+		delete(byName, rg.Name)
+
+		if !prev.Equal(rg) {
+			diff.Changed = append(diff.Changed, rg.Name)
+		}
+	}
+
+	for name := range byName {
+		diff.Removed = append(diff.Removed, name)
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Removed)
+	return diff
+}
+
+// RuleGroupValidation is the result of the validation of a rule group.
+type RuleGroupValidation struct {
+	Name   string           `json:"name"`
+	Valid  bool             `json:"valid"`
+	Errors []string         `json:"errors"`
+	Rules  []RuleValidation `json:"rules"`
+}
+
+// RuleValidation is the result of the validation of a single rule of a group. The
+// series count and evaluation time are set only if the rule has been evaluated, while
+// the skip reason is set only if the evaluation of the rule has been skipped.
+type RuleValidation struct {
+	Name           string   `json:"name"`
+	Query          string   `json:"query"`
+	Errors         []string `json:"errors"`
+	Evaluated      bool     `json:"evaluated"`
+	Skipped        bool     `json:"skipped"`
+	SkipReason     string   `json:"skipReason"`
+	SeriesCount    int      `json:"seriesCount"`
+	EvaluationTime float64  `json:"evaluationTime"`
+}
+
+// ValidateRuleGroup validates the rule group in the request body without storing it, type
+// checking the expression of each rule. If the evaluate parameter is true, each valid rule
+// is also evaluated once against the current data.
+func (a *API) ValidateRuleGroup(w http.ResponseWriter, req *http.Request) {
+	logger := util_log.WithContext(req.Context(), util_log.Logger)
+	userID, _, _, err := parseRequest(req, true, false)
+	if err != nil {
+		respondError(logger, w, err.Error())
+		return
+	}
+
+	evaluate := false
+	if value := req.URL.Query().Get("evaluate"); value != "" {
+		if evaluate, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, "invalid evaluate parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if evaluate && a.queryFunc == nil {
+		http.Error(w, "rules evaluation is not supported", http.StatusBadRequest)
+		return
+	}
+
+	payload, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		level.Error(logger).Log("msg", "unable to read rule group payload", "err", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rg := rulefmt.RuleGroup{}
+	if err := yaml.Unmarshal(payload, &rg); err != nil {
+		level.Error(logger).Log("msg", "unable to unmarshal rule group payload", "err", err.Error())
+		http.Error(w, ErrBadRuleGroup.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := a.validateRuleGroup(userID, rg)
+	if evaluate && result.Valid {
+		a.evaluateRuleGroup(req.Context(), userID, result)
+	}
+
+	b, err := json.Marshal(&response{
+		Status: "success",
+		Data:   result,
+	})
+	if err != nil {
+		level.Error(logger).Log("msg", "error marshaling json response", "err", err)
+		respondError(logger, w, "unable to marshal the requested data")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if n, err := w.Write(b); err != nil {
+		level.Error(logger).Log("msg", "error writing response", "bytesWritten", n, "err", err)
+	}
+}
+
+// validateRuleGroup runs the same validation of CreateRuleGroup and additionally
+// checks that each rule expression returns a vector or a scalar.
+func (a *API) validateRuleGroup(userID string, rg rulefmt.RuleGroup) *RuleGroupValidation {
+	result := &RuleGroupValidation{
+		Name:   rg.Name,
+		Errors: []string{},
+		Rules:  make([]RuleValidation, 0, len(rg.Rules)),
+	}
+
+	for _, r := range rg.Rules {
+		name := r.Record.Value
+		if r.Alert.Value != "" {
+			name = r.Alert.Value
+		}
+		result.Rules = append(result.Rules, RuleValidation{Name: name, Query: r.Expr.Value, Errors: []string{}})
+	}
+
+	for _, err := range a.ruler.manager.ValidateRuleGroup(rg) {
+		var ruleErr *rulefmt.Error
+		if errors.As(err, &ruleErr) && ruleErr.Rule >= 0 && ruleErr.Rule < len(result.Rules) {
+			result.Rules[ruleErr.Rule].Errors = append(result.Rules[ruleErr.Rule].Errors, ruleErr.Error())
+			continue
+		}
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	if err := a.ruler.AssertMaxRulesPerRuleGroup(userID, len(rg.Rules)); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	for i := range result.Rules {
+		if len(result.Rules[i].Errors) > 0 {
+			continue
+		}
+
+		expr, err := parser.ParseExpr(result.Rules[i].Query)
+		if err != nil {
+			result.Rules[i].Errors = append(result.Rules[i].Errors, err.Error())
+			continue
+		}
+		if t := expr.Type(); t != parser.ValueTypeVector && t != parser.ValueTypeScalar {
+			result.Rules[i].Errors = append(result.Rules[i].Errors, fmt.Sprintf("rule expression must return a vector or a scalar, not a %s", t))
+		}
+	}
+
+	result.Valid = isRuleGroupValid(result)
+	return result
+}
+
+// evaluateRuleGroup evaluates each rule of the group once at the current time. The rules above
+// the max number of evaluated rules, and the ones not evaluated within the timeout, are skipped.
+func (a *API) evaluateRuleGroup(ctx context.Context, userID string, result *RuleGroupValidation) {
+	queryFunc := a.queryFunc(userID)
+	ctx = user.InjectOrgID(ctx, userID)
+	if timeout := a.ruler.cfg.ValidationEvaluationTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	maxRules := a.ruler.cfg.ValidationMaxEvaluatedRules
+	now := time.Now()
+
+	for i := range result.Rules {
+		if maxRules > 0 && i >= maxRules {
+			result.Rules[i].Skipped = true
+			result.Rules[i].SkipReason = fmt.Sprintf("max number of evaluated rules (%d) reached", maxRules)
+			continue
+		}
+		if ctx.Err() != nil {
+			result.Rules[i].Skipped = true
+			result.Rules[i].SkipReason = a.evaluationSkipReason(ctx)
+			continue
+		}
+
+		start := time.Now()
+		vector, err := queryFunc(ctx, result.Rules[i].Query, now)
+
+		// A rule interrupted by the timeout is reported as skipped, because the failure doesn't
+		// depend on the rule itself, so that it doesn't invalidate the group.
+		if err != nil && ctx.Err() != nil {
+			result.Rules[i].Skipped = true
+			result.Rules[i].SkipReason = a.evaluationSkipReason(ctx)
+			continue
+		}
+
+		result.Rules[i].Evaluated = true
+		result.Rules[i].EvaluationTime = time.Since(start).Seconds()
+
+		if err != nil {
+			result.Rules[i].Errors = append(result.Rules[i].Errors, err.Error())
+			continue
+		}
+		result.Rules[i].SeriesCount = len(vector)
+	}
+
+	result.Valid = isRuleGroupValid(result)
+}
+
+// evaluationSkipReason returns the reason why the evaluation of a rule has been skipped because the input
+// context is done.
+func (a *API) evaluationSkipReason(ctx context.Context) string {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Sprintf("evaluation timeout (%s) reached", a.ruler.cfg.ValidationEvaluationTimeout)
+	}
+	return ctx.Err().Error()
+}
+
+func isRuleGroupValid(result *RuleGroupValidation) bool {
+	if len(result.Errors) > 0 {
+		return false
+	}
+	for _, r := range result.Rules {
+		if len(r.Errors) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RuleGroupEvaluationHistory has the most recent evaluations of the rules of a group.
+type RuleGroupEvaluationHistory struct {
+	Name  string                   `json:"name"`
+	File  string                   `json:"file"`
+	Rules []*RuleEvaluationHistory `json:"rules"`
+}
+
+// RuleEvaluationHistory has the most recent evaluations of a rule, newest first.
+type RuleEvaluationHistory struct {
+	Name        string            `json:"name"`
+	Query       string            `json:"query"`
+	Type        v1.RuleType       `json:"type"`
+	Health      string            `json:"health"`
+	LastError   string            `json:"lastError"`
+	Evaluations []*RuleEvaluation `json:"evaluations"`
+}
+
+// RuleEvaluation is a past evaluation of a rule.
+type RuleEvaluation struct {
+	Timestamp      time.Time `json:"timestamp"`
+	EvaluationTime float64   `json:"evaluationTime"`
+	Samples        int64     `json:"samples"`
+	Error          string    `json:"error"`
+}
+
+// GetRuleGroupEvaluationHistory returns the most recent evaluations of each rule of a group,
+// as tracked by the ruler owning it.
+func (a *API) GetRuleGroupEvaluationHistory(w http.ResponseWriter, req *http.Request) {
+	logger := util_log.WithContext(req.Context(), util_log.Logger)
+	_, namespace, groupName, err := parseRequest(req, true, true)
+	if err != nil {
+		respondError(logger, w, err.Error())
+		return
+	}
+
+	resp, err := a.ruler.GetRuleGroupEvaluationHistory(req.Context(), namespace, groupName)
+	if err != nil {
+		respondError(logger, w, err.Error())
+		return
+	}
+	if resp.Group == nil || len(resp.Rules) != len(resp.Group.ActiveRules) {
+		http.Error(w, store.ErrGroupNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	result := &RuleGroupEvaluationHistory{
+		Name:  resp.Group.Group.Name,
+		File:  resp.Group.Group.Namespace,
+		Rules: make([]*RuleEvaluationHistory, 0, len(resp.Group.ActiveRules)),
+	}
+
+	for i, rl := range resp.Group.ActiveRules {
+		history := &RuleEvaluationHistory{
+			Name:        rl.Rule.GetRecord(),
+			Query:       rl.Rule.GetExpr(),
+			Type:        v1.RuleTypeRecording,
+			Health:      rl.GetHealth(),
+			LastError:   rl.GetLastError(),
+			Evaluations: make([]*RuleEvaluation, 0, len(resp.Rules[i].Evaluations)),
+		}
+		if rl.Rule.GetAlert() != "" {
+			history.Name = rl.Rule.GetAlert()
+			history.Type = v1.RuleTypeAlerting
+		}
+
+		for _, e := range resp.Rules[i].Evaluations {
+			history.Evaluations = append(history.Evaluations, &RuleEvaluation{
+				Timestamp:      e.Timestamp,
+				EvaluationTime: e.Duration.Seconds(),
+				Samples:        e.Samples,
+				Error:          e.Error,
+			})
+		}
+		result.Rules = append(result.Rules, history)
+	}
+
+	b, err := json.Marshal(&response{
+		Status: "success",
+		Data:   result,
+	})
+	if err != nil {
+		level.Error(logger).Log("msg", "error marshaling json response", "err", err)
+		respondError(logger, w, "unable to marshal the requested data")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if n, err := w.Write(b); err != nil {
+		level.Error(logger).Log("msg", "error writing response", "bytesWritten", n, "err", err)
+	}
+}
+
 func (a *API) DeleteNamespace(w http.ResponseWriter, req *http.Request) {
 	logger := util_log.WithContext(req.Context(), util_log.Logger)
 
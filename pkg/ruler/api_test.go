@@ -10,8 +10,12 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	promRules "github.com/prometheus/prometheus/rules"
 	"github.com/stretchr/testify/require"
 	"github.com/weaveworks/common/user"
 
@@ -27,7 +31,7 @@ func TestRuler_rules(t *testing.T) {
 	defer rcleanup()
 	defer services.StopAndAwaitTerminated(context.Background(), r) //nolint:errcheck
 
-	a := NewAPI(r, r.store)
+	a := NewAPI(r, r.store, nil)
 
 	req := requestFor(t, "GET", "https://localhost:8080/api/prom/api/v1/rules", nil, "user1")
 	w := httptest.NewRecorder()
@@ -84,7 +88,7 @@ func TestRuler_rules_special_characters(t *testing.T) {
 	defer rcleanup()
 	defer services.StopAndAwaitTerminated(context.Background(), r) //nolint:errcheck
 
-	a := NewAPI(r, r.store)
+	a := NewAPI(r, r.store, nil)
 
 	req := requestFor(t, http.MethodGet, "https://localhost:8080/api/prom/api/v1/rules", nil, "user1")
 	w := httptest.NewRecorder()
@@ -141,7 +145,7 @@ func TestRuler_alerts(t *testing.T) {
 	defer rcleanup()
 	defer r.StopAsync()
 
-	a := NewAPI(r, r.store)
+	a := NewAPI(r, r.store, nil)
 
 	req := requestFor(t, http.MethodGet, "https://localhost:8080/api/prom/api/v1/alerts", nil, "user1")
 	w := httptest.NewRecorder()
@@ -177,7 +181,7 @@ func TestRuler_Create(t *testing.T) {
 	defer rcleanup()
 	defer services.StopAndAwaitTerminated(context.Background(), r) //nolint:errcheck
 
-	a := NewAPI(r, r.store)
+	a := NewAPI(r, r.store, nil)
 
 	tc := []struct {
 		name   string
@@ -268,7 +272,7 @@ func TestRuler_DeleteNamespace(t *testing.T) {
 	defer rcleanup()
 	defer services.StopAndAwaitTerminated(context.Background(), r) //nolint:errcheck
 
-	a := NewAPI(r, r.store)
+	a := NewAPI(r, r.store, nil)
 
 	router := mux.NewRouter()
 	router.Path("/api/v1/rules/{namespace}").Methods(http.MethodDelete).HandlerFunc(a.DeleteNamespace)
@@ -299,6 +303,190 @@ func TestRuler_DeleteNamespace(t *testing.T) {
 	require.Equal(t, "{\"status\":\"error\",\"data\":null,\"errorType\":\"server_error\",\"error\":\"unable to delete rg\"}", w.Body.String())
 }
 
+func TestRuler_ReplaceNamespace(t *testing.T) {
+	newGroup := func(namespace, name, expr string) *rules.RuleGroupDesc {
+		return &rules.RuleGroupDesc{
+			Name:      name,
+			Namespace: namespace,
+			User:      "user1",
+			Interval:  interval,
+			Rules:     []*rules.RuleDesc{{Record: "UP_RULE", Expr: expr}},
+		}
+	}
+
+	cfg, cleanup := defaultRulerConfig(newMockRuleStore(map[string]rules.RuleGroupList{
+		"user1": {
+			newGroup("namespace1", "group1", "up"),
+			newGroup("namespace1", "group2", "up"),
+			newGroup("namespace1", "group3", "up"),
+			newGroup("namespace2", "group1", "up"),
+		},
+	}))
+	defer cleanup()
+
+	r, rcleanup := newTestRuler(t, cfg)
+	defer rcleanup()
+	defer services.StopAndAwaitTerminated(context.Background(), r) //nolint:errcheck
+
+	r.limits = &ruleLimits{maxRuleGroups: 4}
+
+	a := NewAPI(r, r.store, nil)
+
+	router := mux.NewRouter()
+	router.Path("/api/v1/rules/{namespace}").Methods(http.MethodPut).HandlerFunc(a.ReplaceNamespace)
+
+	replaceWithVersion := func(input, ifMatch string) *httptest.ResponseRecorder {
+		req := requestFor(t, http.MethodPut, "https://localhost:8080/api/v1/rules/namespace1", strings.NewReader(input), "user1")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	replace := func(input string) *httptest.ResponseRecorder {
+		return replaceWithVersion(input, "")
+	}
+	storedGroups := func() map[string]string {
+		rgs, err := r.store.ListRuleGroupsForUserAndNamespace(context.Background(), "user1", "")
+		require.NoError(t, err)
+
+		result := map[string]string{}
+		for _, rg := range rgs {
+			result[rg.Namespace+"/"+rg.Name] = rg.Rules[0].Expr
+		}
+		return result
+	}
+
+	// Invalid rules files should be rejected without changing the namespace.
+	w := replace(`
+groups:
+- name: group1
+  rules:
+  - record: UP_RULE
+    expr: up
+- name: group1
+  rules:
+  - record: UP_RULE
+    expr: up
+`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "invalid rules config: repeated rule group name 'group1'\n", w.Body.String())
+
+	w = replace(`
+groups:
+- name: group1
+  rules:
+  - record: UP_RULE
+    expr: up
+- name: group4
+`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "invalid rules config: rule group 'group4' has no rules\n", w.Body.String())
+
+	// The rule groups of the other namespaces count toward the limit.
+	w = replace(`
+groups:
+- name: group1
+  rules:
+  - record: UP_RULE
+    expr: up
+- name: group2
+  rules:
+  - record: UP_RULE
+    expr: up
+- name: group3
+  rules:
+  - record: UP_RULE
+    expr: up
+- name: group4
+  rules:
+  - record: UP_RULE
+    expr: up
+`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "per-user rule groups limit (limit: 4 actual: 4) exceeded\n", w.Body.String())
+	require.Equal(t, map[string]string{
+		"namespace1/group1": "up",
+		"namespace1/group2": "up",
+		"namespace1/group3": "up",
+		"namespace2/group1": "up",
+	}, storedGroups())
+
+	// A valid rules file should replace the namespace and return the diff.
+	w = replace(`
+groups:
+- name: group1
+  interval: 1m
+  rules:
+  - record: UP_RULE
+    expr: up
+- name: group2
+  interval: 1m
+  rules:
+  - record: UP_RULE
+    expr: up == 1
+- name: group4
+  rules:
+  - record: UP_RULE
+    expr: up
+`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	expectedResponse, _ := json.Marshal(response{
+		Status: "success",
+		Data: &NamespaceDiff{
+			Added:   []string{"group4"},
+			Changed: []string{"group2"},
+			Removed: []string{"group3"},
+		},
+	})
+	require.Equal(t, string(expectedResponse), w.Body.String())
+	require.Equal(t, map[string]string{
+		"namespace1/group1": "up",
+		"namespace1/group2": "up == 1",
+		"namespace1/group4": "up",
+		"namespace2/group1": "up",
+	}, storedGroups())
+	require.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+	// A replacement with a stale version should be rejected without changing the namespace.
+	w = replaceWithVersion(`
+groups:
+- name: group1
+  rules:
+  - record: UP_RULE
+    expr: up == 2
+`, `"0"`)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, "up", storedGroups()["namespace1/group1"])
+
+	w = replaceWithVersion("groups: []", "invalid")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// A replacement with the current version should succeed.
+	w = replaceWithVersion(`
+groups:
+- name: group1
+  rules:
+  - record: UP_RULE
+    expr: up == 2
+`, `"1"`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Equal(t, `"2"`, w.Header().Get("ETag"))
+	require.Equal(t, map[string]string{
+		"namespace1/group1": "up == 2",
+		"namespace2/group1": "up",
+	}, storedGroups())
+
+	// Rule stores not supporting the atomic replacement should reject the request.
+	a = NewAPI(r, struct{ rules.RuleStore }{r.store}, nil)
+	router = mux.NewRouter()
+	router.Path("/api/v1/rules/{namespace}").Methods(http.MethodPut).HandlerFunc(a.ReplaceNamespace)
+
+	w = replace("groups: []")
+	require.Equal(t, http.StatusNotImplemented, w.Code)
+}
+
 func TestRuler_Limits(t *testing.T) {
 	cfg, cleanup := defaultRulerConfig(newMockRuleStore(make(map[string]rules.RuleGroupList)))
 	defer cleanup()
@@ -309,7 +497,7 @@ func TestRuler_Limits(t *testing.T) {
 
 	r.limits = &ruleLimits{maxRuleGroups: 1, maxRulesPerRuleGroup: 1}
 
-	a := NewAPI(r, r.store)
+	a := NewAPI(r, r.store, nil)
 
 	tc := []struct {
 		name   string
@@ -366,6 +554,309 @@ rules:
 	}
 }
 
+func TestRuler_GetRuleGroupEvaluationHistory(t *testing.T) {
+	cfg, cleanup := defaultRulerConfig(newMockRuleStore(mockRules))
+	defer cleanup()
+
+	r, rcleanup := newTestRuler(t, cfg)
+	defer rcleanup()
+	defer services.StopAndAwaitTerminated(context.Background(), r) //nolint:errcheck
+
+	// Simulate a failed evaluation of the recording rule of user1, running its query
+	// through the query function of the rules manager.
+	mngr := getManager(r.manager.(*DefaultMultiTenantManager), "user1").(*evaluationHistoryManager)
+	groups := mngr.RuleGroups()
+	require.Len(t, groups, 1)
+
+	recordingRule := groups[0].Rules()[0].(*promRules.RecordingRule)
+	recordingRule.SetLastError(errors.New("evaluation failed"))
+
+	queryFunc := mngr.recorder.wrap(func(_ context.Context, _ string, _ time.Time) (promql.Vector, error) {
+		return nil, errors.New("evaluation failed")
+	})
+	ctx := promql.NewOriginContext(context.Background(), map[string]interface{}{
+		"ruleGroup": map[string]string{"file": groups[0].File(), "name": groups[0].Name()},
+	})
+	_, err := queryFunc(ctx, "up", time.Now())
+	require.Error(t, err)
+
+	evaluations := mngr.EvaluationHistory(groups[0].File(), groups[0].Name(), 0, "UP_RULE")
+	require.Len(t, evaluations, 1)
+
+	a := NewAPI(r, r.store, nil)
+
+	router := mux.NewRouter()
+	router.Path("/api/v1/rules/{namespace}/{groupName}/history").Methods(http.MethodGet).HandlerFunc(a.GetRuleGroupEvaluationHistory)
+
+	req := requestFor(t, http.MethodGet, "https://localhost:8080/api/v1/rules/namespace1/group1/history", nil, "user1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	expectedResponse, _ := json.Marshal(response{
+		Status: "success",
+		Data: &RuleGroupEvaluationHistory{
+			Name: "group1",
+			File: "namespace1",
+			Rules: []*RuleEvaluationHistory{
+				{
+					Name:      "UP_RULE",
+					Query:     "up",
+					Type:      "recording",
+					Health:    "unknown",
+					LastError: "evaluation failed",
+					Evaluations: []*RuleEvaluation{
+						{Timestamp: evaluations[0].Timestamp, EvaluationTime: evaluations[0].Duration.Seconds(), Error: "evaluation failed"},
+					},
+				},
+				{
+					Name:        "UP_ALERT",
+					Query:       "up < 1",
+					Type:        "alerting",
+					Health:      "unknown",
+					Evaluations: []*RuleEvaluation{},
+				},
+			},
+		},
+	})
+	require.Equal(t, string(expectedResponse), w.Body.String())
+
+	// A group not evaluated by the rulers should not be found.
+	req = requestFor(t, http.MethodGet, "https://localhost:8080/api/v1/rules/namespace1/group2/history", nil, "user1")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRuler_ValidateRuleGroup(t *testing.T) {
+	cfg, cleanup := defaultRulerConfig(newMockRuleStore(make(map[string]rules.RuleGroupList)))
+	defer cleanup()
+
+	r, rcleanup := newTestRuler(t, cfg)
+	defer rcleanup()
+	defer services.StopAndAwaitTerminated(context.Background(), r) //nolint:errcheck
+
+	queryFunc := func(userID string) promRules.QueryFunc {
+		return func(ctx context.Context, qs string, _ time.Time) (promql.Vector, error) {
+			orgID, err := user.ExtractOrgID(ctx)
+			require.NoError(t, err)
+			require.Equal(t, userID, orgID)
+
+			if qs == "fail" {
+				return nil, errors.New("query failed")
+			}
+			return promql.Vector{{Metric: labels.FromStrings("job", "a")}, {Metric: labels.FromStrings("job", "b")}}, nil
+		}
+	}
+
+	tc := []struct {
+		name      string
+		input     string
+		evaluate  bool
+		queryFunc TenantQueryFunc
+		status    int
+		expected  RuleGroupValidation
+	}{
+		{
+			name:   "with a bad payload",
+			input:  "name: [",
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "with no rules",
+			input: `
+name: rg_name
+`,
+			status: http.StatusOK,
+			expected: RuleGroupValidation{
+				Name:   "rg_name",
+				Errors: []string{"invalid rules config: rule group 'rg_name' has no rules"},
+				Rules:  []RuleValidation{},
+			},
+		},
+		{
+			name: "with invalid expressions",
+			input: `
+name: test
+rules:
+- record: up_rule
+  expr: up{
+- record: range_rule
+  expr: up[5m]
+- alert: up_alert
+  expr: up < 1
+`,
+			status: http.StatusOK,
+			expected: RuleGroupValidation{
+				Name:   "test",
+				Errors: []string{},
+				Rules: []RuleValidation{
+					{Name: "up_rule", Query: "up{", Errors: []string{`9:9: group "test", rule 0, "up_rule": could not parse expression: 1:4: parse error: unexpected end of input inside braces`}},
+					{Name: "range_rule", Query: "up[5m]", Errors: []string{"rule expression must return a vector or a scalar, not a matrix"}},
+					{Name: "up_alert", Query: "up < 1", Errors: []string{}},
+				},
+			},
+		},
+		{
+			name: "with valid rules without evaluation",
+			input: `
+name: test
+rules:
+- record: up_rule
+  expr: up
+`,
+			status: http.StatusOK,
+			expected: RuleGroupValidation{
+				Name:   "test",
+				Valid:  true,
+				Errors: []string{},
+				Rules:  []RuleValidation{{Name: "up_rule", Query: "up", Errors: []string{}}},
+			},
+		},
+		{
+			name: "with evaluation",
+			input: `
+name: test
+rules:
+- record: up_rule
+  expr: up
+- alert: fail_alert
+  expr: fail
+`,
+			evaluate:  true,
+			queryFunc: queryFunc,
+			status:    http.StatusOK,
+			expected: RuleGroupValidation{
+				Name:   "test",
+				Errors: []string{},
+				Rules: []RuleValidation{
+					{Name: "up_rule", Query: "up", Errors: []string{}, Evaluated: true, SeriesCount: 2},
+					{Name: "fail_alert", Query: "fail", Errors: []string{"query failed"}, Evaluated: true},
+				},
+			},
+		},
+		{
+			name: "with evaluation not supported",
+			input: `
+name: test
+rules:
+- record: up_rule
+  expr: up
+`,
+			evaluate: true,
+			status:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAPI(r, r.store, tt.queryFunc)
+
+			router := mux.NewRouter()
+			router.Path("/api/v1/rules/{namespace}/validate").Methods("POST").HandlerFunc(a.ValidateRuleGroup)
+
+			url := "https://localhost:8080/api/v1/rules/namespace/validate"
+			if tt.evaluate {
+				url += "?evaluate=true"
+			}
+			req := requestFor(t, http.MethodPost, url, strings.NewReader(tt.input), "user1")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			require.Equal(t, tt.status, w.Code)
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			actual := struct {
+				Status string              `json:"status"`
+				Data   RuleGroupValidation `json:"data"`
+			}{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+			require.Equal(t, "success", actual.Status)
+
+			// The evaluation time is not deterministic.
+			for i := range actual.Data.Rules {
+				require.GreaterOrEqual(t, actual.Data.Rules[i].EvaluationTime, float64(0))
+				actual.Data.Rules[i].EvaluationTime = 0
+			}
+			require.Equal(t, tt.expected, actual.Data)
+
+			// Nothing should have been stored.
+			rgs, err := r.store.ListAllRuleGroups(context.Background())
+			require.NoError(t, err)
+			require.Empty(t, rgs)
+		})
+	}
+}
+
+func TestRuler_ValidateRuleGroupWithEvaluationLimits(t *testing.T) {
+	cfg, cleanup := defaultRulerConfig(newMockRuleStore(make(map[string]rules.RuleGroupList)))
+	defer cleanup()
+
+	cfg.ValidationEvaluationTimeout = 100 * time.Millisecond
+	cfg.ValidationMaxEvaluatedRules = 3
+
+	r, rcleanup := newTestRuler(t, cfg)
+	defer rcleanup()
+	defer services.StopAndAwaitTerminated(context.Background(), r) //nolint:errcheck
+
+	evaluated := []string{}
+	queryFunc := func(_ string) promRules.QueryFunc {
+		return func(ctx context.Context, qs string, _ time.Time) (promql.Vector, error) {
+			evaluated = append(evaluated, qs)
+
+			// The slow query runs until the evaluation timeout is reached.
+			if qs == "slow" {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return promql.Vector{{Metric: labels.FromStrings("job", "a")}}, nil
+		}
+	}
+
+	a := NewAPI(r, r.store, queryFunc)
+	router := mux.NewRouter()
+	router.Path("/api/v1/rules/{namespace}/validate").Methods("POST").HandlerFunc(a.ValidateRuleGroup)
+
+	req := requestFor(t, http.MethodPost, "https://localhost:8080/api/v1/rules/namespace/validate?evaluate=true", strings.NewReader(`
+name: test
+rules:
+- record: up_rule
+  expr: up
+- record: slow_rule
+  expr: slow
+- record: after_timeout_rule
+  expr: up
+- record: above_max_rule
+  expr: up
+`), "user1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	actual := struct {
+		Status string              `json:"status"`
+		Data   RuleGroupValidation `json:"data"`
+	}{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+	actual.Data.Rules[0].EvaluationTime = 0
+
+	// The skipped rules don't invalidate the group.
+	require.Equal(t, RuleGroupValidation{
+		Name:   "test",
+		Valid:  true,
+		Errors: []string{},
+		Rules: []RuleValidation{
+			{Name: "up_rule", Query: "up", Errors: []string{}, Evaluated: true, SeriesCount: 1},
+			{Name: "slow_rule", Query: "slow", Errors: []string{}, Skipped: true, SkipReason: "evaluation timeout (100ms) reached"},
+			{Name: "after_timeout_rule", Query: "up", Errors: []string{}, Skipped: true, SkipReason: "evaluation timeout (100ms) reached"},
+			{Name: "above_max_rule", Query: "up", Errors: []string{}, Skipped: true, SkipReason: "max number of evaluated rules (3) reached"},
+		},
+	}, actual.Data)
+	require.Equal(t, []string{"up", "slow"}, evaluated)
+}
+
 func requestFor(t *testing.T, method string, url string, body io.Reader, userID string) *http.Request {
 	t.Helper()
 
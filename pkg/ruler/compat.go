@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-kit/kit/log"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/prometheus/notifier"
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/pkg/value"
@@ -15,6 +16,7 @@ import (
 	"github.com/weaveworks/common/user"
 
 	"github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/util/validation"
 )
 
 // Pusher is an ingester server that accepts pushes.
@@ -92,6 +94,8 @@ type RulesLimits interface {
 	RulerTenantShardSize(userID string) int
 	RulerMaxRuleGroupsPerTenant(userID string) int
 	RulerMaxRulesPerRuleGroup(userID string) int
+	RulerAlertmanagerConfig(userID string) validation.RulerAlertmanagerConfig
+	RulerMaxIndependentRuleEvaluationConcurrency(userID string) int
 }
 
 // engineQueryFunc returns a new query function using the rules.EngineQueryFunc function
@@ -106,6 +110,34 @@ func engineQueryFunc(engine *promql.Engine, q storage.Queryable, overrides Rules
 	}
 }
 
+// remoteQueryFunc returns a new query function running the queries in the query-frontend
+// and passing an altered timestamp.
+func remoteQueryFunc(q *RemoteQuerier, overrides RulesLimits, userID string) rules.QueryFunc {
+	return func(ctx context.Context, qs string, t time.Time) (promql.Vector, error) {
+		// Delay the evaluation of all rules by a set interval to give a buffer
+		// to metric that haven't been forwarded to cortex yet.
+		evaluationDelay := overrides.EvaluationDelay(userID)
+		return q.Query(ctx, qs, t.Add(-evaluationDelay))
+	}
+}
+
+// metricsQueryFunc returns a new query function tracking the number of queries, failed
+// queries and the time spent running them.
+func metricsQueryFunc(qf rules.QueryFunc, queries, failedQueries, queryTime prometheus.Counter) rules.QueryFunc {
+	return func(ctx context.Context, qs string, t time.Time) (promql.Vector, error) {
+		queries.Inc()
+
+		start := time.Now()
+		result, err := qf(ctx, qs, t)
+		queryTime.Add(time.Since(start).Seconds())
+
+		if err != nil {
+			failedQueries.Inc()
+		}
+		return result, err
+	}
+}
+
 // This interface mimicks rules.Manager API. Interface is used to simplify tests.
 type RulesManager interface {
 	// Starts rules manager. Blocks until Stop is called.
@@ -124,12 +156,59 @@ type RulesManager interface {
 // ManagerFactory is a function that creates new RulesManager for given user and notifier.Manager.
 type ManagerFactory func(ctx context.Context, userID string, notifier *notifier.Manager, logger log.Logger, reg prometheus.Registerer) RulesManager
 
-func DefaultTenantManagerFactory(cfg Config, p Pusher, q storage.Queryable, engine *promql.Engine, overrides RulesLimits) ManagerFactory {
+// TenantQueryFunc returns the function used to run the queries of the rules of a tenant.
+type TenantQueryFunc func(userID string) rules.QueryFunc
+
+// DefaultTenantQueryFunc returns a TenantQueryFunc running the queries with the input engine, or
+// sending them to the query-frontend if remoteQuerier is not nil.
+func DefaultTenantQueryFunc(q storage.Queryable, engine *promql.Engine, remoteQuerier *RemoteQuerier, overrides RulesLimits) TenantQueryFunc {
+	return func(userID string) rules.QueryFunc {
+		if remoteQuerier != nil {
+			return remoteQueryFunc(remoteQuerier, overrides, userID)
+		}
+		return engineQueryFunc(engine, q, overrides, userID)
+	}
+}
+
+// DefaultTenantManagerFactory returns a ManagerFactory evaluating the rules with the input engine, or
+// sending the queries to the query-frontend if remoteQuerier is not nil. The queryable is used to
+// restore the "for" state of the alerts in both cases.
+func DefaultTenantManagerFactory(cfg Config, p Pusher, q storage.Queryable, engine *promql.Engine, remoteQuerier *RemoteQuerier, overrides RulesLimits) ManagerFactory {
+	tenantQueryFunc := DefaultTenantQueryFunc(q, engine, remoteQuerier, overrides)
+
 	return func(ctx context.Context, userID string, notifier *notifier.Manager, logger log.Logger, reg prometheus.Registerer) RulesManager {
-		return rules.NewManager(&rules.ManagerOptions{
+		queryFunc := metricsQueryFunc(tenantQueryFunc(userID),
+			promauto.With(reg).NewCounter(prometheus.CounterOpts{
+				Name: "ruler_queries_total",
+				Help: "Number of queries executed by the ruler to evaluate the rules.",
+			}),
+			promauto.With(reg).NewCounter(prometheus.CounterOpts{
+				Name: "ruler_queries_failed_total",
+				Help: "Number of queries executed by the ruler to evaluate the rules which failed.",
+			}),
+			promauto.With(reg).NewCounter(prometheus.CounterOpts{
+				Name: "ruler_query_seconds_total",
+				Help: "Total amount of wall clock time spent running the queries to evaluate the rules.",
+			}),
+		)
+
+		evaluator := newConcurrentRuleEvaluator(userID, overrides, queryFunc, promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "ruler_independent_rule_evaluations_concurrent_total",
+			Help: "Number of rules evaluated concurrently with the other rules of their group.",
+		}))
+		queryFunc = evaluator.QueryFunc()
+
+		// The recorder wraps the evaluator, so that it sees the queries in the order the rules are evaluated.
+		var recorder *evaluationHistoryRecorder
+		if cfg.EvaluationHistorySize > 0 {
+			recorder = newEvaluationHistoryRecorder(cfg.EvaluationHistorySize)
+			queryFunc = recorder.wrap(queryFunc)
+		}
+
+		manager := rules.NewManager(&rules.ManagerOptions{
 			Appendable:      &PusherAppendable{pusher: p, userID: userID, rulesLimits: overrides},
 			Queryable:       q,
-			QueryFunc:       engineQueryFunc(engine, q, overrides, userID),
+			QueryFunc:       queryFunc,
 			Context:         user.InjectOrgID(ctx, userID),
 			ExternalURL:     cfg.ExternalURL.URL,
 			NotifyFunc:      SendAlerts(notifier, cfg.ExternalURL.URL.String()),
@@ -139,5 +218,11 @@ func DefaultTenantManagerFactory(cfg Config, p Pusher, q storage.Queryable, engi
 			ForGracePeriod:  cfg.ForGracePeriod,
 			ResendDelay:     cfg.ResendDelay,
 		})
+
+		var rulesManager RulesManager = &concurrentEvaluationManager{RulesManager: manager, evaluator: evaluator}
+		if recorder != nil {
+			rulesManager = &evaluationHistoryManager{RulesManager: rulesManager, recorder: recorder}
+		}
+		return rulesManager
 	}
 }
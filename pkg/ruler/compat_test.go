@@ -2,11 +2,16 @@ package ruler
 
 import (
 	"context"
+	"errors"
 	"math"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/prometheus/pkg/value"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/cortexproject/cortex/pkg/ingester/client"
@@ -76,3 +81,26 @@ func TestPusherAppendable(t *testing.T) {
 		})
 	}
 }
+
+func TestMetricsQueryFunc(t *testing.T) {
+	queries := prometheus.NewCounter(prometheus.CounterOpts{})
+	failedQueries := prometheus.NewCounter(prometheus.CounterOpts{})
+	queryTime := prometheus.NewCounter(prometheus.CounterOpts{})
+
+	queryErr := errors.New("query failed")
+	qf := metricsQueryFunc(func(_ context.Context, qs string, _ time.Time) (promql.Vector, error) {
+		if qs == "fail" {
+			return nil, queryErr
+		}
+		return promql.Vector{}, nil
+	}, queries, failedQueries, queryTime)
+
+	_, err := qf(context.Background(), "up", time.Now())
+	require.NoError(t, err)
+	_, err = qf(context.Background(), "fail", time.Now())
+	require.Equal(t, queryErr, err)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(queries))
+	assert.Equal(t, float64(1), testutil.ToFloat64(failedQueries))
+	assert.Greater(t, testutil.ToFloat64(queryTime), float64(0))
+}
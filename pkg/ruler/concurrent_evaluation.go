@@ -0,0 +1,290 @@
+package ruler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/rules"
+)
+
+// ruleGroupKey identifies a rule group within the rules of a tenant.
+type ruleGroupKey struct {
+	file string
+	name string
+}
+
+// concurrentGroupRules has the query of each rule of a group and whether the rule
+// can be evaluated concurrently with the preceding rules of the group.
+type concurrentGroupRules struct {
+	queries     []string
+	independent []bool
+}
+
+// groupIteration is the state of a single evaluation of a rule group.
+type groupIteration struct {
+	ts time.Time
+	// Index of the next rule expected to be evaluated by the group.
+	next    int
+	queries []*prefetchedQuery
+}
+
+// prefetchedQuery is the query of a rule started before the rule evaluation.
+type prefetchedQuery struct {
+	done   chan struct{}
+	result promql.Vector
+	err    error
+}
+
+// concurrentRuleEvaluator evaluates the rules of a tenant which don't depend on the output of the
+// preceding rules of their group concurrently with the other rules of the group.
+//
+// The Prometheus rule group evaluates its rules sequentially, running the query of each rule via the
+// QueryFunc. When the QueryFunc is called for a rule of a group, the evaluator starts the queries of the
+// following independent rules of the group, up to the per-tenant concurrency limit, and returns their
+// results once the group evaluates them. The rules depending on the preceding rules of the group are
+// still evaluated sequentially, so that they see the samples written by the rules they depend on.
+type concurrentRuleEvaluator struct {
+	userID            string
+	limits            RulesLimits
+	queryFunc         rules.QueryFunc
+	concurrentQueries prometheus.Counter
+
+	mtx        sync.Mutex
+	running    int
+	groups     map[ruleGroupKey]*concurrentGroupRules
+	iterations map[ruleGroupKey]*groupIteration
+}
+
+func newConcurrentRuleEvaluator(userID string, limits RulesLimits, queryFunc rules.QueryFunc, concurrentQueries prometheus.Counter) *concurrentRuleEvaluator {
+	return &concurrentRuleEvaluator{
+		userID:            userID,
+		limits:            limits,
+		queryFunc:         queryFunc,
+		concurrentQueries: concurrentQueries,
+		groups:            map[ruleGroupKey]*concurrentGroupRules{},
+		iterations:        map[ruleGroupKey]*groupIteration{},
+	}
+}
+
+// setRuleGroups updates the rule groups of the tenant, analysing the dependencies between
+// the rules of each group.
+func (e *concurrentRuleEvaluator) setRuleGroups(groups []*rules.Group) {
+	updated := make(map[ruleGroupKey]*concurrentGroupRules, len(groups))
+	for _, g := range groups {
+		updated[ruleGroupKey{file: g.File(), name: g.Name()}] = newConcurrentGroupRules(g.Rules())
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	// Drop the in-progress iterations of the changed groups, because the rules indexes
+	// could be different. Queries already started are left completing in background.
+	for key := range e.iterations {
+		if prev, ok := e.groups[key]; !ok || !prev.equals(updated[key]) {
+			delete(e.iterations, key)
+		}
+	}
+	e.groups = updated
+}
+
+// QueryFunc returns the query function to be used by the rules manager of the tenant.
+func (e *concurrentRuleEvaluator) QueryFunc() rules.QueryFunc {
+	return e.query
+}
+
+func (e *concurrentRuleEvaluator) query(ctx context.Context, qs string, t time.Time) (promql.Vector, error) {
+	key, ok := ruleGroupFromContext(ctx)
+	if !ok {
+		return e.queryFunc(ctx, qs, t)
+	}
+
+	e.mtx.Lock()
+	q := e.nextQuery(ctx, key, qs, t)
+	e.mtx.Unlock()
+
+	if q == nil {
+		return e.queryFunc(ctx, qs, t)
+	}
+
+	select {
+	case <-q.done:
+		return q.result, q.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// nextQuery moves the iteration of the group forward to the rule running the input query, starts
+// the queries of the following independent rules and returns the query of the rule if it has
+// already been started. Must be called with the lock held.
+func (e *concurrentRuleEvaluator) nextQuery(ctx context.Context, key ruleGroupKey, qs string, t time.Time) *prefetchedQuery {
+	group, ok := e.groups[key]
+	if !ok {
+		return nil
+	}
+
+	limit := e.limits.RulerMaxIndependentRuleEvaluationConcurrency(e.userID)
+
+	it, ok := e.iterations[key]
+	if !ok || !it.ts.Equal(t) {
+		if limit <= 0 {
+			delete(e.iterations, key)
+			return nil
+		}
+
+		it = &groupIteration{ts: t, queries: make([]*prefetchedQuery, len(group.queries))}
+		e.iterations[key] = it
+	}
+
+	// The query could be run by something else than a rule, like the template expansion of an alert.
+	idx := -1
+	for i := it.next; i < len(group.queries); i++ {
+		if group.queries[i] == qs {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+
+	it.next = idx + 1
+	if it.next == len(group.queries) {
+		delete(e.iterations, key)
+	}
+
+	for i := it.next; i < len(group.queries) && e.running < limit; i++ {
+		if group.independent[i] && it.queries[i] == nil {
+			it.queries[i] = e.startQuery(ctx, group.queries[i], t)
+		}
+	}
+
+	return it.queries[idx]
+}
+
+// startQuery runs the query in background. Must be called with the lock held.
+func (e *concurrentRuleEvaluator) startQuery(ctx context.Context, qs string, t time.Time) *prefetchedQuery {
+	e.running++
+	e.concurrentQueries.Inc()
+
+	q := &prefetchedQuery{done: make(chan struct{})}
+	go func() {
+		q.result, q.err = e.queryFunc(ctx, qs, t)
+		close(q.done)
+
+		e.mtx.Lock()
+		e.running--
+		e.mtx.Unlock()
+	}()
+
+	return q
+}
+
+// ruleGroupFromContext returns the rule group set by the Prometheus rule group in the query origin.
+func ruleGroupFromContext(ctx context.Context) (ruleGroupKey, bool) {
+	origin, ok := ctx.Value(promql.QueryOrigin{}).(map[string]interface{})
+	if !ok {
+		return ruleGroupKey{}, false
+	}
+
+	group, ok := origin["ruleGroup"].(map[string]string)
+	if !ok {
+		return ruleGroupKey{}, false
+	}
+
+	return ruleGroupKey{file: group["file"], name: group["name"]}, true
+}
+
+func newConcurrentGroupRules(rs []rules.Rule) *concurrentGroupRules {
+	g := &concurrentGroupRules{
+		queries:     make([]string, 0, len(rs)),
+		independent: make([]bool, 0, len(rs)),
+	}
+
+	// Metric names written by the preceding rules of the group.
+	written := map[string]struct{}{}
+
+	for _, r := range rs {
+		q, ok := r.(interface{ Query() parser.Expr })
+		if !ok {
+			g.queries = append(g.queries, "")
+			g.independent = append(g.independent, false)
+			continue
+		}
+
+		g.queries = append(g.queries, q.Query().String())
+		g.independent = append(g.independent, !readsMetricNames(q.Query(), written))
+
+		if _, ok := r.(*rules.AlertingRule); ok {
+			written["ALERTS"] = struct{}{}
+			written["ALERTS_FOR_STATE"] = struct{}{}
+		} else {
+			written[r.Name()] = struct{}{}
+		}
+	}
+
+	return g
+}
+
+func (g *concurrentGroupRules) equals(other *concurrentGroupRules) bool {
+	if other == nil || len(g.queries) != len(other.queries) {
+		return false
+	}
+	for i := range g.queries {
+		if g.queries[i] != other.queries[i] || g.independent[i] != other.independent[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// readsMetricNames returns whether the expression could read any of the input metric names. Selectors
+// without a metric name equality matcher are considered to read any metric name.
+func readsMetricNames(expr parser.Expr, names map[string]struct{}) bool {
+	if len(names) == 0 {
+		return false
+	}
+
+	reads := false
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		vs, ok := node.(*parser.VectorSelector)
+		if !ok || reads {
+			return nil
+		}
+
+		name := ""
+		for _, m := range vs.LabelMatchers {
+			if m.Name == labels.MetricName && m.Type == labels.MatchEqual {
+				name = m.Value
+				break
+			}
+		}
+
+		if _, ok := names[name]; ok || name == "" {
+			reads = true
+		}
+		return nil
+	})
+
+	return reads
+}
+
+// concurrentEvaluationManager wraps a RulesManager to keep the rule groups of the
+// concurrentRuleEvaluator in sync with the ones of the wrapped manager.
+type concurrentEvaluationManager struct {
+	RulesManager
+
+	evaluator *concurrentRuleEvaluator
+}
+
+// Update updates the wrapped manager and the rule groups of the evaluator.
+func (m *concurrentEvaluationManager) Update(interval time.Duration, files []string, externalLabels labels.Labels) error {
+	err := m.RulesManager.Update(interval, files, externalLabels)
+	m.evaluator.setRuleGroups(m.RulesManager.RuleGroups())
+	return err
+}
@@ -0,0 +1,199 @@
+package ruler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	promRules "github.com/prometheus/prometheus/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/util/test"
+)
+
+func TestNewConcurrentGroupRules(t *testing.T) {
+	recording := func(name, qs string) promRules.Rule {
+		return promRules.NewRecordingRule(name, mustParseExpr(t, qs), nil)
+	}
+	alerting := func(name, qs string) promRules.Rule {
+		return promRules.NewAlertingRule(name, mustParseExpr(t, qs), 0, nil, nil, nil, true, nil)
+	}
+
+	tests := map[string]struct {
+		rules    []promRules.Rule
+		expected []bool
+	}{
+		"no rules": {
+			expected: []bool{},
+		},
+		"rules not reading the output of the other rules": {
+			rules:    []promRules.Rule{recording("a", "up"), recording("b", "sum(rate(requests_total[5m]))"), alerting("c", "down > 0")},
+			expected: []bool{true, true, true},
+		},
+		"rules reading the output of the preceding rules": {
+			rules:    []promRules.Rule{recording("a", "up"), recording("b", "sum(a)"), recording("c", "up"), recording("d", "rate(c[5m]) / b")},
+			expected: []bool{true, false, true, false},
+		},
+		"rules reading the output of the following rules": {
+			rules:    []promRules.Rule{recording("a", "b"), recording("b", "up")},
+			expected: []bool{true, true},
+		},
+		"rules reading the alerts of the preceding alerting rules": {
+			rules:    []promRules.Rule{alerting("a", "up == 0"), recording("b", "count(ALERTS)"), alerting("c", "up == 0")},
+			expected: []bool{true, false, true},
+		},
+		"rules with selectors without a metric name": {
+			rules:    []promRules.Rule{recording("a", `{job="test"}`), recording("b", `{job="test"}`), recording("c", `{__name__=~"up|down"}`)},
+			expected: []bool{true, false, false},
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testData.expected, newConcurrentGroupRules(testData.rules).independent)
+		})
+	}
+}
+
+func TestConcurrentRuleEvaluator(t *testing.T) {
+	const (
+		upQuery   = "up"
+		downQuery = "down"
+		sumQuery  = "sum(a)"
+		rateQuery = "rate(requests_total[5m])"
+	)
+
+	for _, limit := range []int{0, 1, 3} {
+		limit := limit
+
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			// Queries are blocked until released, to check which ones are run concurrently.
+			var (
+				mtx      sync.Mutex
+				started  []string
+				released = map[string]chan struct{}{}
+			)
+			for _, qs := range []string{upQuery, downQuery, sumQuery, rateQuery} {
+				released[qs] = make(chan struct{})
+			}
+
+			queryFunc := func(_ context.Context, qs string, _ time.Time) (promql.Vector, error) {
+				mtx.Lock()
+				started = append(started, qs)
+				mtx.Unlock()
+
+				<-released[qs]
+				return promql.Vector{{Metric: labels.FromStrings("query", qs)}}, nil
+			}
+			getStarted := func() interface{} {
+				mtx.Lock()
+				defer mtx.Unlock()
+
+				result := append([]string{}, started...)
+				sort.Strings(result)
+				return result
+			}
+
+			concurrentQueries := prometheus.NewCounter(prometheus.CounterOpts{})
+			e := newConcurrentRuleEvaluator("user-1", ruleLimits{maxIndependentRuleConcurrency: limit}, queryFunc, concurrentQueries)
+			e.setRuleGroups([]*promRules.Group{promRules.NewGroup(promRules.GroupOptions{
+				Name: "group",
+				File: "file",
+				Rules: []promRules.Rule{
+					promRules.NewRecordingRule("a", mustParseExpr(t, upQuery), nil),
+					promRules.NewRecordingRule("b", mustParseExpr(t, downQuery), nil),
+					promRules.NewRecordingRule("c", mustParseExpr(t, sumQuery), nil),
+					promRules.NewRecordingRule("d", mustParseExpr(t, rateQuery), nil),
+				},
+				Opts: &promRules.ManagerOptions{},
+			})})
+
+			ctx := promql.NewOriginContext(context.Background(), map[string]interface{}{
+				"ruleGroup": map[string]string{"file": "file", "name": "group"},
+			})
+			ts := time.Unix(10, 0)
+
+			// Evaluate the rules sequentially, like the rule group does.
+			results := make(chan promql.Vector)
+			evaluate := func(qs string) {
+				go func() {
+					result, err := e.QueryFunc()(ctx, qs, ts)
+					require.NoError(t, err)
+					results <- result
+				}()
+			}
+
+			// The independent rules following the first one should be started concurrently, up to the limit.
+			evaluate(upQuery)
+			expected := map[int][]string{
+				0: {upQuery},
+				1: {downQuery, upQuery},
+				3: {downQuery, rateQuery, upQuery},
+			}[limit]
+			test.Poll(t, time.Second, expected, getStarted)
+
+			close(released[upQuery])
+			assert.Equal(t, promql.Vector{{Metric: labels.FromStrings("query", upQuery)}}, <-results)
+
+			close(released[downQuery])
+			evaluate(downQuery)
+			assert.Equal(t, promql.Vector{{Metric: labels.FromStrings("query", downQuery)}}, <-results)
+
+			// The rule depending on the preceding ones should be run only once the group evaluates it.
+			evaluate(sumQuery)
+			test.Poll(t, time.Second, true, func() interface{} {
+				for _, qs := range getStarted().([]string) {
+					if qs == sumQuery {
+						return true
+					}
+				}
+				return false
+			})
+			close(released[sumQuery])
+			assert.Equal(t, promql.Vector{{Metric: labels.FromStrings("query", sumQuery)}}, <-results)
+
+			close(released[rateQuery])
+			evaluate(rateQuery)
+			assert.Equal(t, promql.Vector{{Metric: labels.FromStrings("query", rateQuery)}}, <-results)
+
+			// Each query should have been run once, and the iteration should be completed.
+			assert.Equal(t, []string{downQuery, rateQuery, sumQuery, upQuery}, getStarted())
+			test.Poll(t, time.Second, 0, func() interface{} {
+				e.mtx.Lock()
+				defer e.mtx.Unlock()
+				return e.running
+			})
+			assert.Empty(t, e.iterations)
+		})
+	}
+}
+
+func TestConcurrentRuleEvaluator_QueriesNotFromRuleGroups(t *testing.T) {
+	calls := 0
+	queryFunc := func(_ context.Context, _ string, _ time.Time) (promql.Vector, error) {
+		calls++
+		return nil, nil
+	}
+
+	e := newConcurrentRuleEvaluator("user-1", ruleLimits{maxIndependentRuleConcurrency: 10}, queryFunc, prometheus.NewCounter(prometheus.CounterOpts{}))
+
+	// Queries without a rule group in the context, or of an unknown group, should be run as is.
+	_, err := e.QueryFunc()(context.Background(), "up", time.Now())
+	require.NoError(t, err)
+
+	ctx := promql.NewOriginContext(context.Background(), map[string]interface{}{
+		"ruleGroup": map[string]string{"file": "file", "name": "unknown"},
+	})
+	_, err = e.QueryFunc()(ctx, "up", time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, e.iterations)
+}
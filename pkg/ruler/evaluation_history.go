@@ -0,0 +1,197 @@
+package ruler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/rules"
+)
+
+// evaluationHistoryProvider is implemented by the RulesManager keeping track of the
+// past evaluations of the rules.
+type evaluationHistoryProvider interface {
+	// EvaluationHistory returns the most recent evaluations of a rule, newest first.
+	EvaluationHistory(file, group string, ruleIndex int, ruleName string) []*RuleEvaluationDesc
+}
+
+// ruleEvaluationHistory is a bounded ring buffer of the most recent evaluations of a rule.
+type ruleEvaluationHistory struct {
+	name    string
+	entries []*RuleEvaluationDesc
+	next    int
+}
+
+func newRuleEvaluationHistory(name string, size int) *ruleEvaluationHistory {
+	return &ruleEvaluationHistory{
+		name:    name,
+		entries: make([]*RuleEvaluationDesc, 0, size),
+	}
+}
+
+func (h *ruleEvaluationHistory) add(entry *RuleEvaluationDesc) {
+	if len(h.entries) < cap(h.entries) {
+		h.entries = append(h.entries, entry)
+	} else {
+		h.entries[h.next] = entry
+	}
+	h.next = (h.next + 1) % cap(h.entries)
+}
+
+// list returns the entries of the history, newest first.
+func (h *ruleEvaluationHistory) list() []*RuleEvaluationDesc {
+	result := make([]*RuleEvaluationDesc, 0, len(h.entries))
+	for i := 1; i <= len(h.entries); i++ {
+		result = append(result, h.entries[(h.next-i+len(h.entries))%len(h.entries)])
+	}
+	return result
+}
+
+// groupEvaluationHistory has the history of the rules of a group and the state of the
+// in-progress evaluation of the group.
+type groupEvaluationHistory struct {
+	queries []string
+	rules   []*ruleEvaluationHistory
+
+	// Evaluation timestamp of the in-progress iteration and index of the next rule
+	// expected to be evaluated by the group.
+	ts   time.Time
+	next int
+}
+
+// evaluationHistoryRecorder records the evaluations of the rules of a tenant from the query
+// function of the rules manager.
+//
+// The Prometheus rule group evaluates its rules sequentially, running the query of each rule via
+// the QueryFunc, and doesn't offer any hook on the rule evaluation. So each query run within the
+// evaluation of a group is attributed to the next rule of the group running the same query, like
+// the concurrentRuleEvaluator does.
+type evaluationHistoryRecorder struct {
+	size int
+
+	mtx    sync.Mutex
+	groups map[ruleGroupKey]*groupEvaluationHistory
+}
+
+func newEvaluationHistoryRecorder(size int) *evaluationHistoryRecorder {
+	return &evaluationHistoryRecorder{
+		size:   size,
+		groups: map[ruleGroupKey]*groupEvaluationHistory{},
+	}
+}
+
+// wrap returns a new query function recording the evaluations of the rules run by qf.
+func (r *evaluationHistoryRecorder) wrap(qf rules.QueryFunc) rules.QueryFunc {
+	return func(ctx context.Context, qs string, t time.Time) (promql.Vector, error) {
+		start := time.Now()
+		result, err := qf(ctx, qs, t)
+
+		if key, ok := ruleGroupFromContext(ctx); ok {
+			entry := &RuleEvaluationDesc{
+				Timestamp: start,
+				Duration:  time.Since(start),
+				Samples:   int64(len(result)),
+			}
+			if err != nil {
+				entry.Error = err.Error()
+			}
+			r.record(key, qs, t, entry)
+		}
+
+		return result, err
+	}
+}
+
+// record adds the entry to the history of the rule of the group running the input query.
+func (r *evaluationHistoryRecorder) record(key ruleGroupKey, qs string, t time.Time, entry *RuleEvaluationDesc) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	g, ok := r.groups[key]
+	if !ok {
+		return
+	}
+
+	if !g.ts.Equal(t) {
+		g.ts = t
+		g.next = 0
+	}
+
+	// The query could be run by something else than a rule, like the template expansion of an alert.
+	for i := g.next; i < len(g.queries); i++ {
+		if g.queries[i] == qs {
+			g.next = i + 1
+			g.rules[i].add(entry)
+			return
+		}
+	}
+}
+
+// setRuleGroups updates the rule groups of the tenant. The history of a rule is kept only if the
+// rule at the same position of the same group has the same name, and dropped otherwise.
+func (r *evaluationHistoryRecorder) setRuleGroups(groups []*rules.Group) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	updated := make(map[ruleGroupKey]*groupEvaluationHistory, len(groups))
+	for _, g := range groups {
+		key := ruleGroupKey{file: g.File(), name: g.Name()}
+		prev := r.groups[key]
+
+		h := &groupEvaluationHistory{
+			queries: make([]string, 0, len(g.Rules())),
+			rules:   make([]*ruleEvaluationHistory, 0, len(g.Rules())),
+		}
+		for i, rl := range g.Rules() {
+			qs := ""
+			if q, ok := rl.(interface{ Query() parser.Expr }); ok {
+				qs = q.Query().String()
+			}
+			h.queries = append(h.queries, qs)
+
+			if prev != nil && i < len(prev.rules) && prev.rules[i].name == rl.Name() {
+				h.rules = append(h.rules, prev.rules[i])
+			} else {
+				h.rules = append(h.rules, newRuleEvaluationHistory(rl.Name(), r.size))
+			}
+		}
+		updated[key] = h
+	}
+
+	r.groups = updated
+}
+
+// EvaluationHistory implements evaluationHistoryProvider.
+func (r *evaluationHistoryRecorder) EvaluationHistory(file, group string, ruleIndex int, ruleName string) []*RuleEvaluationDesc {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	g, ok := r.groups[ruleGroupKey{file: file, name: group}]
+	if !ok || ruleIndex < 0 || ruleIndex >= len(g.rules) || g.rules[ruleIndex].name != ruleName {
+		return nil
+	}
+	return g.rules[ruleIndex].list()
+}
+
+// evaluationHistoryManager wraps a RulesManager to keep the rule groups of the
+// evaluationHistoryRecorder in sync with the ones of the wrapped manager.
+type evaluationHistoryManager struct {
+	RulesManager
+
+	recorder *evaluationHistoryRecorder
+}
+
+// Update updates the wrapped manager and the rule groups of the recorder.
+func (m *evaluationHistoryManager) Update(interval time.Duration, files []string, externalLabels labels.Labels) error {
+	err := m.RulesManager.Update(interval, files, externalLabels)
+	m.recorder.setRuleGroups(m.RulesManager.RuleGroups())
+	return err
+}
+
+// EvaluationHistory implements evaluationHistoryProvider.
+func (m *evaluationHistoryManager) EvaluationHistory(file, group string, ruleIndex int, ruleName string) []*RuleEvaluationDesc {
+	return m.recorder.EvaluationHistory(file, group, ruleIndex, ruleName)
+}
@@ -0,0 +1,161 @@
+package ruler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	promRules "github.com/prometheus/prometheus/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+)
+
+func TestRuleEvaluationHistory(t *testing.T) {
+	h := newRuleEvaluationHistory("test", 3)
+	assert.Empty(t, h.list())
+
+	for i := int64(1); i <= 5; i++ {
+		h.add(&RuleEvaluationDesc{Samples: i})
+
+		// The history should contain up to 3 entries, newest first.
+		var expected []int64
+		for j := i; j > 0 && j > i-3; j-- {
+			expected = append(expected, j)
+		}
+
+		var actual []int64
+		for _, e := range h.list() {
+			actual = append(actual, e.Samples)
+		}
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestEvaluationHistoryRecorder(t *testing.T) {
+	recorder := newEvaluationHistoryRecorder(2)
+	queryFunc := recorder.wrap(func(_ context.Context, qs string, _ time.Time) (promql.Vector, error) {
+		if qs == "fail" {
+			return nil, errors.New("query failed")
+		}
+		return promql.Vector{{Metric: labels.FromStrings("job", "a")}, {Metric: labels.FromStrings("job", "b")}}, nil
+	})
+
+	newGroup := func(rules ...promRules.Rule) *promRules.Group {
+		return promRules.NewGroup(promRules.GroupOptions{
+			Name:  "group",
+			File:  "file",
+			Rules: rules,
+			Opts: &promRules.ManagerOptions{
+				QueryFunc:  queryFunc,
+				Logger:     log.NewNopLogger(),
+				Appendable: &PusherAppendable{pusher: &fakePusher{response: &client.WriteResponse{}}, userID: "user-1", rulesLimits: ruleLimits{}},
+			},
+		})
+	}
+
+	// Two rules of the group share the same query.
+	group := newGroup(
+		promRules.NewRecordingRule("up_rule", mustParseExpr(t, "up"), nil),
+		promRules.NewRecordingRule("fail_rule", mustParseExpr(t, "fail"), nil),
+		promRules.NewRecordingRule("other_up_rule", mustParseExpr(t, "up"), nil),
+	)
+	recorder.setRuleGroups([]*promRules.Group{group})
+
+	// The rule group sets its file and name in the context of the queries.
+	ctx := promql.NewOriginContext(context.Background(), map[string]interface{}{
+		"ruleGroup": map[string]string{"file": "file", "name": "group"},
+	})
+
+	// Rules never evaluated should have no history.
+	assert.Empty(t, recorder.EvaluationHistory("file", "group", 0, "up_rule"))
+
+	group.Eval(ctx, time.Unix(10, 0))
+
+	upHistory := recorder.EvaluationHistory("file", "group", 0, "up_rule")
+	require.Len(t, upHistory, 1)
+	assert.Equal(t, int64(2), upHistory[0].Samples)
+	assert.Empty(t, upHistory[0].Error)
+
+	failHistory := recorder.EvaluationHistory("file", "group", 1, "fail_rule")
+	require.Len(t, failHistory, 1)
+	assert.Equal(t, int64(0), failHistory[0].Samples)
+	assert.Equal(t, "query failed", failHistory[0].Error)
+
+	assert.Len(t, recorder.EvaluationHistory("file", "group", 2, "other_up_rule"), 1)
+
+	// Queries not run by the rule group, like the ones without a rule group in the context,
+	// should not be recorded.
+	_, err := queryFunc(context.Background(), "up", time.Unix(10, 0))
+	require.NoError(t, err)
+	assert.Len(t, recorder.EvaluationHistory("file", "group", 0, "up_rule"), 1)
+
+	// Each evaluation should be recorded, even if close to each other, and only the most recent ones kept.
+	group.Eval(ctx, time.Unix(20, 0))
+	group.Eval(ctx, time.Unix(30, 0))
+
+	upHistory = recorder.EvaluationHistory("file", "group", 0, "up_rule")
+	require.Len(t, upHistory, 2)
+	assert.False(t, upHistory[0].Timestamp.Before(upHistory[1].Timestamp))
+	assert.Len(t, recorder.EvaluationHistory("file", "group", 1, "fail_rule"), 2)
+	assert.Len(t, recorder.EvaluationHistory("file", "group", 2, "other_up_rule"), 2)
+
+	// A rule replaced by a different one at the same position should have a new history,
+	// while the history of the rules not changed should be kept.
+	recorder.setRuleGroups([]*promRules.Group{newGroup(
+		promRules.NewRecordingRule("replaced_rule", mustParseExpr(t, "up"), nil),
+		promRules.NewRecordingRule("fail_rule", mustParseExpr(t, "fail"), nil),
+	)})
+
+	assert.Empty(t, recorder.EvaluationHistory("file", "group", 0, "up_rule"))
+	assert.Empty(t, recorder.EvaluationHistory("file", "group", 0, "replaced_rule"))
+	assert.Len(t, recorder.EvaluationHistory("file", "group", 1, "fail_rule"), 2)
+	assert.Empty(t, recorder.EvaluationHistory("file", "group", 2, "other_up_rule"))
+
+	// The history of removed groups should be dropped.
+	recorder.setRuleGroups(nil)
+	assert.Empty(t, recorder.groups)
+}
+
+func TestEvaluationHistoryManager_ShouldSyncRuleGroupsOnUpdate(t *testing.T) {
+	recorder := newEvaluationHistoryRecorder(5)
+	mngr := &mockRulesManager{done: make(chan struct{}), groups: []*promRules.Group{
+		promRules.NewGroup(promRules.GroupOptions{
+			Name:  "group",
+			File:  "file",
+			Rules: []promRules.Rule{promRules.NewRecordingRule("up_rule", mustParseExpr(t, "up"), nil)},
+			Opts:  &promRules.ManagerOptions{},
+		}),
+	}}
+	m := &evaluationHistoryManager{RulesManager: mngr, recorder: recorder}
+
+	// Queries of rule groups unknown to the recorder should not be recorded.
+	ctx := promql.NewOriginContext(context.Background(), map[string]interface{}{
+		"ruleGroup": map[string]string{"file": "file", "name": "group"},
+	})
+	queryFunc := recorder.wrap(func(_ context.Context, _ string, _ time.Time) (promql.Vector, error) {
+		return nil, nil
+	})
+
+	_, err := queryFunc(ctx, "up", time.Unix(10, 0))
+	require.NoError(t, err)
+	assert.Empty(t, m.EvaluationHistory("file", "group", 0, "up_rule"))
+
+	require.NoError(t, m.Update(time.Minute, nil, nil))
+
+	_, err = queryFunc(ctx, "up", time.Unix(20, 0))
+	require.NoError(t, err)
+	assert.Len(t, m.EvaluationHistory("file", "group", 0, "up_rule"), 1)
+}
+
+func mustParseExpr(t *testing.T, qs string) parser.Expr {
+	expr, err := parser.ParseExpr(qs)
+	require.NoError(t, err)
+	return expr
+}
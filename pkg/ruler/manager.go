@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"sync"
 
 	"github.com/go-kit/kit/log"
@@ -26,6 +27,7 @@ type DefaultMultiTenantManager struct {
 	cfg            Config
 	notifierCfg    *config.Config
 	managerFactory ManagerFactory
+	limits         RulesLimits
 
 	mapper *mapper
 
@@ -47,7 +49,7 @@ type DefaultMultiTenantManager struct {
 	logger                        log.Logger
 }
 
-func NewDefaultMultiTenantManager(cfg Config, managerFactory ManagerFactory, reg prometheus.Registerer, logger log.Logger) (*DefaultMultiTenantManager, error) {
+func NewDefaultMultiTenantManager(cfg Config, managerFactory ManagerFactory, limits RulesLimits, reg prometheus.Registerer, logger log.Logger) (*DefaultMultiTenantManager, error) {
 	ncfg, err := buildNotifierConfig(&cfg)
 	if err != nil {
 		return nil, err
@@ -62,6 +64,7 @@ func NewDefaultMultiTenantManager(cfg Config, managerFactory ManagerFactory, reg
 		cfg:                cfg,
 		notifierCfg:        ncfg,
 		managerFactory:     managerFactory,
+		limits:             limits,
 		notifiers:          map[string]*rulerNotifier{},
 		mapper:             newMapper(cfg.RulePath, logger),
 		userManagers:       map[string]RulesManager{},
@@ -115,6 +118,9 @@ func (r *DefaultMultiTenantManager) SyncRuleGroups(ctx context.Context, ruleGrou
 	}
 
 	r.managersTotal.Set(float64(len(r.userManagers)))
+
+	// Apply the changes to the tenants' Alertmanager config, if any.
+	r.updateNotifiers()
 }
 
 // syncRulesToManager maps the rule files to disk, detects any changes and will create/update the
@@ -207,16 +213,64 @@ func (r *DefaultMultiTenantManager) getOrCreateNotifier(userID string) (*notifie
 	}, log.With(r.logger, "user", userID))
 
 	n.run()
+	r.notifiers[userID] = n
 
-	// This should never fail, unless there's a programming mistake.
-	if err := n.applyConfig(r.notifierCfg); err != nil {
-		return nil, err
+	// An invalid tenant's Alertmanager config shouldn't prevent the rules evaluation. The
+	// notifier falls back to the global config and the tenant's config is applied again on
+	// the next rules sync.
+	if err := r.applyNotifierConfig(userID, n); err != nil {
+		level.Error(r.logger).Log("msg", "unable to apply notifier config, falling back to the global one", "user", userID, "err", err)
+
+		if err := n.applyConfig(r.notifierCfg); err != nil {
+			level.Error(r.logger).Log("msg", "unable to apply global notifier config", "user", userID, "err", err)
+		}
 	}
 
-	r.notifiers[userID] = n
 	return n.notifier, nil
 }
 
+// applyNotifierConfig applies the current config of the tenant to its notifier.
+func (r *DefaultMultiTenantManager) applyNotifierConfig(userID string, n *rulerNotifier) error {
+	ncfg, err := r.getNotifierConfig(userID)
+	if err != nil {
+		return err
+	}
+
+	if n.cfg != nil && reflect.DeepEqual(ncfg, n.cfg) {
+		return nil
+	}
+
+	return n.applyConfig(ncfg)
+}
+
+// getNotifierConfig returns the notifier config of the tenant, which is the global one
+// unless the tenant has its own Alertmanager(s) configured.
+func (r *DefaultMultiTenantManager) getNotifierConfig(userID string) (*config.Config, error) {
+	tenantCfg := r.limits.RulerAlertmanagerConfig(userID)
+	if tenantCfg.URL == "" {
+		return r.notifierCfg, nil
+	}
+
+	ncfg, err := buildTenantNotifierConfig(&r.cfg, tenantCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid tenant Alertmanager config")
+	}
+	return ncfg, nil
+}
+
+// updateNotifiers applies the current notifier config to each tenant's notifier whose
+// config has changed since it was last applied.
+func (r *DefaultMultiTenantManager) updateNotifiers() {
+	r.notifiersMtx.Lock()
+	defer r.notifiersMtx.Unlock()
+
+	for userID, n := range r.notifiers {
+		if err := r.applyNotifierConfig(userID, n); err != nil {
+			level.Error(r.logger).Log("msg", "unable to apply notifier config, keeping the previous one", "user", userID, "err", err)
+		}
+	}
+}
+
 func (r *DefaultMultiTenantManager) GetRules(userID string) []*promRules.Group {
 	var groups []*promRules.Group
 	r.userManagerMtx.Lock()
@@ -227,6 +281,17 @@ func (r *DefaultMultiTenantManager) GetRules(userID string) []*promRules.Group {
 	return groups
 }
 
+func (r *DefaultMultiTenantManager) GetRuleEvaluationHistory(userID, file, group string, ruleIndex int, ruleName string) []*RuleEvaluationDesc {
+	r.userManagerMtx.Lock()
+	mngr, exists := r.userManagers[userID]
+	r.userManagerMtx.Unlock()
+
+	if provider, ok := mngr.(evaluationHistoryProvider); exists && ok {
+		return provider.EvaluationHistory(file, group, ruleIndex, ruleName)
+	}
+	return nil
+}
+
 func (r *DefaultMultiTenantManager) Stop() {
 	r.notifiersMtx.Lock()
 	for _, n := range r.notifiers {
@@ -22,6 +22,12 @@ type ManagerMetrics struct {
 	GroupLastDuration    *prometheus.Desc
 	GroupRules           *prometheus.Desc
 	GroupLastEvalSamples *prometheus.Desc
+	Queries              *prometheus.Desc
+	FailedQueries        *prometheus.Desc
+	QuerySeconds         *prometheus.Desc
+
+	GroupIterationsMissed      *prometheus.Desc
+	IndependentRuleEvaluations *prometheus.Desc
 }
 
 // NewManagerMetrics returns a ManagerMetrics struct
@@ -95,6 +101,36 @@ func NewManagerMetrics() *ManagerMetrics {
 			[]string{"user", "rule_group"},
 			nil,
 		),
+		Queries: prometheus.NewDesc(
+			"cortex_ruler_queries_total",
+			"Number of queries executed by the ruler to evaluate the rules.",
+			[]string{"user"},
+			nil,
+		),
+		FailedQueries: prometheus.NewDesc(
+			"cortex_ruler_queries_failed_total",
+			"Number of queries executed by the ruler to evaluate the rules which failed.",
+			[]string{"user"},
+			nil,
+		),
+		QuerySeconds: prometheus.NewDesc(
+			"cortex_ruler_query_seconds_total",
+			"Total amount of wall clock time spent running the queries to evaluate the rules.",
+			[]string{"user"},
+			nil,
+		),
+		GroupIterationsMissed: prometheus.NewDesc(
+			"cortex_ruler_group_missed_iterations_total",
+			"The total number of rule group evaluations missed due to slow rule group evaluation.",
+			[]string{"user", "rule_group"},
+			nil,
+		),
+		IndependentRuleEvaluations: prometheus.NewDesc(
+			"cortex_ruler_independent_rule_evaluations_concurrent_total",
+			"Number of rules evaluated concurrently with the other rules of their group.",
+			[]string{"user"},
+			nil,
+		),
 	}
 }
 
@@ -121,6 +157,11 @@ func (m *ManagerMetrics) Describe(out chan<- *prometheus.Desc) {
 	out <- m.GroupLastDuration
 	out <- m.GroupRules
 	out <- m.GroupLastEvalSamples
+	out <- m.Queries
+	out <- m.FailedQueries
+	out <- m.QuerySeconds
+	out <- m.GroupIterationsMissed
+	out <- m.IndependentRuleEvaluations
 }
 
 // Collect implements the Collector interface
@@ -144,4 +185,11 @@ func (m *ManagerMetrics) Collect(out chan<- prometheus.Metric) {
 	data.SendSumOfGaugesPerUserWithLabels(out, m.GroupLastDuration, "prometheus_rule_group_last_duration_seconds", "rule_group")
 	data.SendSumOfGaugesPerUserWithLabels(out, m.GroupRules, "prometheus_rule_group_rules", "rule_group")
 	data.SendSumOfGaugesPerUserWithLabels(out, m.GroupLastEvalSamples, "prometheus_rule_group_last_evaluation_samples", "rule_group")
+
+	data.SendSumOfCountersPerUser(out, m.Queries, "ruler_queries_total")
+	data.SendSumOfCountersPerUser(out, m.FailedQueries, "ruler_queries_failed_total")
+	data.SendSumOfCountersPerUser(out, m.QuerySeconds, "ruler_query_seconds_total")
+
+	data.SendSumOfCountersPerUserWithLabels(out, m.GroupIterationsMissed, "prometheus_rule_group_iterations_missed_total", "rule_group")
+	data.SendSumOfCountersPerUser(out, m.IndependentRuleEvaluations, "ruler_independent_rule_evaluations_concurrent_total")
 }
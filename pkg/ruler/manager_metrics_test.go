@@ -124,6 +124,31 @@ cortex_prometheus_rule_group_rules{rule_group="group_one",user="user3"} 100000
 cortex_prometheus_rule_group_rules{rule_group="group_two",user="user1"} 1000
 cortex_prometheus_rule_group_rules{rule_group="group_two",user="user2"} 10000
 cortex_prometheus_rule_group_rules{rule_group="group_two",user="user3"} 100000
+# HELP cortex_ruler_group_missed_iterations_total The total number of rule group evaluations missed due to slow rule group evaluation.
+# TYPE cortex_ruler_group_missed_iterations_total counter
+cortex_ruler_group_missed_iterations_total{rule_group="group_one",user="user1"} 1
+cortex_ruler_group_missed_iterations_total{rule_group="group_one",user="user2"} 10
+cortex_ruler_group_missed_iterations_total{rule_group="group_one",user="user3"} 100
+# HELP cortex_ruler_independent_rule_evaluations_concurrent_total Number of rules evaluated concurrently with the other rules of their group.
+# TYPE cortex_ruler_independent_rule_evaluations_concurrent_total counter
+cortex_ruler_independent_rule_evaluations_concurrent_total{user="user1"} 2
+cortex_ruler_independent_rule_evaluations_concurrent_total{user="user2"} 20
+cortex_ruler_independent_rule_evaluations_concurrent_total{user="user3"} 200
+# HELP cortex_ruler_queries_failed_total Number of queries executed by the ruler to evaluate the rules which failed.
+# TYPE cortex_ruler_queries_failed_total counter
+cortex_ruler_queries_failed_total{user="user1"} 1
+cortex_ruler_queries_failed_total{user="user2"} 10
+cortex_ruler_queries_failed_total{user="user3"} 100
+# HELP cortex_ruler_queries_total Number of queries executed by the ruler to evaluate the rules.
+# TYPE cortex_ruler_queries_total counter
+cortex_ruler_queries_total{user="user1"} 10
+cortex_ruler_queries_total{user="user2"} 100
+cortex_ruler_queries_total{user="user3"} 1000
+# HELP cortex_ruler_query_seconds_total Total amount of wall clock time spent running the queries to evaluate the rules.
+# TYPE cortex_ruler_query_seconds_total counter
+cortex_ruler_query_seconds_total{user="user1"} 1
+cortex_ruler_query_seconds_total{user="user2"} 10
+cortex_ruler_query_seconds_total{user="user3"} 100
 `))
 	require.NoError(t, err)
 }
@@ -135,8 +160,8 @@ func populateManager(base float64) *prometheus.Registry {
 
 	metrics.evalDuration.Observe(base)
 	metrics.iterationDuration.Observe(base)
-	metrics.iterationsMissed.Add(base)
-	metrics.iterationsScheduled.Add(base)
+	metrics.iterationsMissed.WithLabelValues("group_one").Add(base)
+	metrics.iterationsScheduled.WithLabelValues("group_one").Add(base)
 
 	metrics.evalTotal.WithLabelValues("group_one").Add(base)
 	metrics.evalTotal.WithLabelValues("group_two").Add(base)
@@ -155,6 +180,11 @@ func populateManager(base float64) *prometheus.Registry {
 	metrics.groupLastEvalSamples.WithLabelValues("group_one").Add(base * 1000)
 	metrics.groupLastEvalSamples.WithLabelValues("group_two").Add(base * 1000)
 
+	promauto.With(r).NewCounter(prometheus.CounterOpts{Name: "ruler_queries_total"}).Add(base * 10)
+	promauto.With(r).NewCounter(prometheus.CounterOpts{Name: "ruler_queries_failed_total"}).Add(base)
+	promauto.With(r).NewCounter(prometheus.CounterOpts{Name: "ruler_query_seconds_total"}).Add(base)
+	promauto.With(r).NewCounter(prometheus.CounterOpts{Name: "ruler_independent_rule_evaluations_concurrent_total"}).Add(base * 2)
+
 	return r
 }
 
@@ -162,8 +192,8 @@ func populateManager(base float64) *prometheus.Registry {
 type groupMetrics struct {
 	evalDuration         prometheus.Summary
 	iterationDuration    prometheus.Summary
-	iterationsMissed     prometheus.Counter
-	iterationsScheduled  prometheus.Counter
+	iterationsMissed     *prometheus.CounterVec
+	iterationsScheduled  *prometheus.CounterVec
 	evalTotal            *prometheus.CounterVec
 	evalFailures         *prometheus.CounterVec
 	groupInterval        *prometheus.GaugeVec
@@ -186,14 +216,20 @@ func newGroupMetrics(r prometheus.Registerer) *groupMetrics {
 			Help:       "The duration of rule group evaluations.",
 			Objectives: map[float64]float64{0.01: 0.001, 0.05: 0.005, 0.5: 0.05, 0.90: 0.01, 0.99: 0.001},
 		}),
-		iterationsMissed: promauto.With(r).NewCounter(prometheus.CounterOpts{
-			Name: "prometheus_rule_group_iterations_missed_total",
-			Help: "The total number of rule group evaluations missed due to slow rule group evaluation.",
-		}),
-		iterationsScheduled: promauto.With(r).NewCounter(prometheus.CounterOpts{
-			Name: "prometheus_rule_group_iterations_total",
-			Help: "The total number of scheduled rule group evaluations, whether executed or missed.",
-		}),
+		iterationsMissed: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "prometheus_rule_group_iterations_missed_total",
+				Help: "The total number of rule group evaluations missed due to slow rule group evaluation.",
+			},
+			[]string{"rule_group"},
+		),
+		iterationsScheduled: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "prometheus_rule_group_iterations_total",
+				Help: "The total number of scheduled rule group evaluations, whether executed or missed.",
+			},
+			[]string{"rule_group"},
+		),
 		evalTotal: promauto.With(r).NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "prometheus_rule_evaluations_total",
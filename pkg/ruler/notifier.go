@@ -16,6 +16,8 @@ import (
 	"github.com/prometheus/prometheus/discovery"
 	"github.com/prometheus/prometheus/discovery/dns"
 	"github.com/prometheus/prometheus/notifier"
+
+	"github.com/cortexproject/cortex/pkg/util/validation"
 )
 
 // rulerNotifier bundles a notifier.Manager together with an associated
@@ -27,6 +29,9 @@ type rulerNotifier struct {
 	sdManager *discovery.Manager
 	wg        sync.WaitGroup
 	logger    gklog.Logger
+
+	// The last applied config.
+	cfg *config.Config
 }
 
 func newRulerNotifier(o *notifier.Options, l gklog.Logger) *rulerNotifier {
@@ -63,7 +68,12 @@ func (rn *rulerNotifier) applyConfig(cfg *config.Config) error {
 	for k, v := range cfg.AlertingConfig.AlertmanagerConfigs.ToMap() {
 		sdCfgs[k] = v.ServiceDiscoveryConfigs
 	}
-	return rn.sdManager.ApplyConfig(sdCfgs)
+	if err := rn.sdManager.ApplyConfig(sdCfgs); err != nil {
+		return err
+	}
+
+	rn.cfg = cfg
+	return nil
 }
 
 func (rn *rulerNotifier) stop() {
@@ -122,6 +132,42 @@ func buildNotifierConfig(rulerConfig *Config) (*config.Config, error) {
 	return promConfig, nil
 }
 
+// Builds a Prometheus config.Config to send notifications to the Alertmanager(s) of a tenant.
+// The options not overridden by the tenant are taken from the ruler.Config.
+func buildTenantNotifierConfig(rulerConfig *Config, tenantConfig validation.RulerAlertmanagerConfig) (*config.Config, error) {
+	cfg := *rulerConfig
+	cfg.AlertmanagerURL = tenantConfig.URL
+
+	switch tenantConfig.APIVersion {
+	case string(config.AlertmanagerAPIVersionV1):
+		cfg.AlertmanangerEnableV2API = false
+	case string(config.AlertmanagerAPIVersionV2):
+		cfg.AlertmanangerEnableV2API = true
+	}
+
+	promConfig, err := buildNotifierConfig(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, amConfig := range promConfig.AlertingConfig.AlertmanagerConfigs {
+		amConfig.HTTPClientConfig.BearerToken = config_util.Secret(tenantConfig.BearerToken.Value)
+		amConfig.HTTPClientConfig.TLSConfig = config_util.TLSConfig{
+			CAFile:             tenantConfig.TLSCAPath,
+			CertFile:           tenantConfig.TLSCertPath,
+			KeyFile:            tenantConfig.TLSKeyPath,
+			ServerName:         tenantConfig.TLSServerName,
+			InsecureSkipVerify: tenantConfig.TLSInsecureSkipVerify,
+		}
+
+		if err := amConfig.HTTPClientConfig.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return promConfig, nil
+}
+
 func amConfigFromURL(rulerConfig *Config, url *url.URL, apiVersion config.AlertmanagerAPIVersion) *config.AlertmanagerConfig {
 	var sdConfig discovery.Configs
 	if rulerConfig.AlertmanagerDiscovery {
@@ -0,0 +1,228 @@
+package ruler
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+	"google.golang.org/grpc"
+
+	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/grpcclient"
+)
+
+const (
+	remoteQueryMinBackoff = 100 * time.Millisecond
+	remoteQueryMaxBackoff = 2 * time.Second
+)
+
+// QueryFrontendConfig configures the query-frontend used to evaluate the rules remotely.
+type QueryFrontendConfig struct {
+	// The address of the query-frontend. Rules are evaluated by the ruler itself if empty.
+	Address string `yaml:"address"`
+
+	GRPCClientConfig grpcclient.ConfigWithTLS `yaml:"grpc_client_config"`
+
+	Timeout    time.Duration `yaml:"timeout"`
+	MaxRetries int           `yaml:"max_retries"`
+}
+
+// RegisterFlags adds the flags required to config this to the given FlagSet.
+func (cfg *QueryFrontendConfig) RegisterFlags(f *flag.FlagSet) {
+	cfg.GRPCClientConfig.RegisterFlagsWithPrefix("ruler.query-frontend.grpc-client-config", f)
+
+	f.StringVar(&cfg.Address, "ruler.query-frontend.address", "", "GRPC listen address of the query-frontend(s). Must be a DNS address (prefixed with dns:///) to enable client side load balancing. If set, the rules are evaluated sending instant queries to the query-frontend, instead of evaluating them in the ruler.")
+	f.DurationVar(&cfg.Timeout, "ruler.query-frontend.timeout", 2*time.Minute, "Timeout of each query sent to the query-frontend to evaluate a rule, including retries.")
+	f.IntVar(&cfg.MaxRetries, "ruler.query-frontend.max-retries", 3, "Maximum number of retries of a query sent to the query-frontend, when it fails with a server error. 0 to disable retries.")
+}
+
+// Validate the config and returns an error if the validation doesn't pass.
+func (cfg *QueryFrontendConfig) Validate(log log.Logger) error {
+	if cfg.Address == "" {
+		return nil
+	}
+
+	if cfg.MaxRetries < 0 {
+		return errors.New("the query-frontend max retries must be greater than or equal to 0")
+	}
+
+	return cfg.GRPCClientConfig.Validate(log)
+}
+
+// DialQueryFrontend creates and initializes a new httpgrpc.HTTPClient connected to the query-frontend.
+// The returned connection must be closed once the client is not used anymore.
+func DialQueryFrontend(cfg QueryFrontendConfig, reg prometheus.Registerer) (httpgrpc.HTTPClient, *grpc.ClientConn, error) {
+	requestDuration := promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "cortex",
+		Name:      "ruler_query_frontend_request_duration_seconds",
+		Help:      "Time spent doing requests to the query-frontend to evaluate the rules.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+	}, []string{"operation", "status_code"})
+
+	opts, err := cfg.GRPCClientConfig.DialOption(grpcclient.Instrument(requestDuration))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts = append(opts, grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`))
+
+	conn, err := grpc.Dial(cfg.Address, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return httpgrpc.NewHTTPClient(conn), conn, nil
+}
+
+// RemoteQuerier evaluates instant queries sending them to the query-frontend, so that the rules
+// evaluation gets the same queueing, caching and splitting of the other queries.
+type RemoteQuerier struct {
+	client         httpgrpc.HTTPClient
+	timeout        time.Duration
+	maxRetries     int
+	promHTTPPrefix string
+	logger         log.Logger
+}
+
+// NewRemoteQuerier makes a new RemoteQuerier sending the queries to the query-frontend via the input
+// client. The promHTTPPrefix is the prefix under which the Prometheus API is exposed by the query-frontend.
+func NewRemoteQuerier(client httpgrpc.HTTPClient, cfg QueryFrontendConfig, promHTTPPrefix string, logger log.Logger) *RemoteQuerier {
+	return &RemoteQuerier{
+		client:         client,
+		timeout:        cfg.Timeout,
+		maxRetries:     cfg.MaxRetries,
+		promHTTPPrefix: promHTTPPrefix,
+		logger:         logger,
+	}
+}
+
+// Query runs the input instant query for the tenant in the context at time t. Queries failing
+// with a server error are retried, while queries failing with a client error are not.
+func (q *RemoteQuerier) Query(ctx context.Context, qs string, t time.Time) (promql.Vector, error) {
+	orgID, err := user.ExtractOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body := url.Values{
+		"query": []string{qs},
+		"time":  []string{strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)},
+	}.Encode()
+
+	req := &httpgrpc.HTTPRequest{
+		Method: http.MethodPost,
+		Url:    q.promHTTPPrefix + "/api/v1/query",
+		Body:   []byte(body),
+		Headers: []*httpgrpc.Header{
+			{Key: "Content-Type", Values: []string{"application/x-www-form-urlencoded"}},
+			{Key: "Content-Length", Values: []string{strconv.Itoa(len(body))}},
+			{Key: user.OrgIDHeaderName, Values: []string{orgID}},
+		},
+	}
+
+	if q.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.timeout)
+		defer cancel()
+	}
+
+	backoff := util.NewBackoff(ctx, util.BackoffConfig{
+		MinBackoff: remoteQueryMinBackoff,
+		MaxBackoff: remoteQueryMaxBackoff,
+		MaxRetries: q.maxRetries + 1,
+	})
+
+	for {
+		resp, err := q.client.Handle(ctx, req)
+		if err == nil && resp.Code/100 == 2 {
+			return decodeQueryResponse(resp.Body)
+		}
+
+		// The query-frontend returns the server errors as gRPC errors, while the client errors as responses.
+		if err == nil {
+			err = httpgrpc.ErrorFromHTTPResponse(resp)
+		}
+		if errResp, ok := httpgrpc.HTTPResponseFromError(err); ok && errResp.Code/100 == 4 {
+			return nil, fmt.Errorf("query-frontend returned status code %d: %s", errResp.Code, errResp.Body)
+		}
+
+		backoff.Wait()
+		if !backoff.Ongoing() {
+			return nil, errors.Wrap(err, "failed to run the query in the query-frontend")
+		}
+		level.Warn(q.logger).Log("msg", "failed to run the query in the query-frontend, retrying", "user", orgID, "query", qs, "err", err)
+	}
+}
+
+type queryResponse struct {
+	Status    string    `json:"status"`
+	Data      queryData `json:"data"`
+	ErrorType string    `json:"errorType"`
+	Error     string    `json:"error"`
+}
+
+type queryData struct {
+	ResultType model.ValueType `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// decodeQueryResponse decodes a Prometheus instant query API response. Scalar results are converted
+// to a vector with a single sample without labels, like the local rules evaluation does.
+func decodeQueryResponse(body []byte) (promql.Vector, error) {
+	var resp queryResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the query-frontend response")
+	}
+
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("query-frontend returned %s: %s", resp.ErrorType, resp.Error)
+	}
+
+	switch resp.Data.ResultType {
+	case model.ValVector:
+		var vector model.Vector
+		if err := json.Unmarshal(resp.Data.Result, &vector); err != nil {
+			return nil, errors.Wrap(err, "failed to decode the query-frontend vector result")
+		}
+
+		result := make(promql.Vector, 0, len(vector))
+		for _, s := range vector {
+			lbls := make(map[string]string, len(s.Metric))
+			for name, value := range s.Metric {
+				lbls[string(name)] = string(value)
+			}
+
+			result = append(result, promql.Sample{
+				Point:  promql.Point{T: int64(s.Timestamp), V: float64(s.Value)},
+				Metric: labels.FromMap(lbls),
+			})
+		}
+		return result, nil
+
+	case model.ValScalar:
+		var scalar model.Scalar
+		if err := json.Unmarshal(resp.Data.Result, &scalar); err != nil {
+			return nil, errors.Wrap(err, "failed to decode the query-frontend scalar result")
+		}
+
+		return promql.Vector{{Point: promql.Point{T: int64(scalar.Timestamp), V: float64(scalar.Value)}}}, nil
+
+	default:
+		return nil, fmt.Errorf("rule result is not a vector or scalar: %s", resp.Data.ResultType)
+	}
+}
@@ -0,0 +1,158 @@
+package ruler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+	"google.golang.org/grpc"
+
+	"github.com/cortexproject/cortex/pkg/util/flagext"
+)
+
+type mockHTTPGRPCClient struct {
+	requests  []*httpgrpc.HTTPRequest
+	responses []*httpgrpc.HTTPResponse
+	errs      []error
+}
+
+func (c *mockHTTPGRPCClient) Handle(_ context.Context, req *httpgrpc.HTTPRequest, _ ...grpc.CallOption) (*httpgrpc.HTTPResponse, error) {
+	i := len(c.requests)
+	c.requests = append(c.requests, req)
+
+	if i < len(c.errs) && c.errs[i] != nil {
+		return nil, c.errs[i]
+	}
+	return c.responses[i], nil
+}
+
+func TestRemoteQuerier_Query(t *testing.T) {
+	const (
+		vectorResponse = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"__name__":"up","job":"test"},"value":[1.5,"1"]}]}}`
+		scalarResponse = `{"status":"success","data":{"resultType":"scalar","result":[1.5,"2"]}}`
+	)
+
+	ok := func(body string) *httpgrpc.HTTPResponse {
+		return &httpgrpc.HTTPResponse{Code: http.StatusOK, Body: []byte(body)}
+	}
+
+	tests := map[string]struct {
+		responses        []*httpgrpc.HTTPResponse
+		errs             []error
+		expected         promql.Vector
+		expectedErr      bool
+		expectedRequests int
+	}{
+		"vector result": {
+			responses: []*httpgrpc.HTTPResponse{ok(vectorResponse)},
+			expected: promql.Vector{{
+				Point:  promql.Point{T: 1500, V: 1},
+				Metric: labels.FromStrings(labels.MetricName, "up", "job", "test"),
+			}},
+			expectedRequests: 1,
+		},
+		"scalar result": {
+			responses:        []*httpgrpc.HTTPResponse{ok(scalarResponse)},
+			expected:         promql.Vector{{Point: promql.Point{T: 1500, V: 2}}},
+			expectedRequests: 1,
+		},
+		"matrix result": {
+			responses:        []*httpgrpc.HTTPResponse{ok(`{"status":"success","data":{"resultType":"matrix","result":[]}}`)},
+			expectedErr:      true,
+			expectedRequests: 1,
+		},
+		"client error is not retried": {
+			responses:        []*httpgrpc.HTTPResponse{{Code: http.StatusBadRequest, Body: []byte("bad query")}},
+			expectedErr:      true,
+			expectedRequests: 1,
+		},
+		"server error is retried": {
+			responses:        []*httpgrpc.HTTPResponse{nil, nil, ok(vectorResponse)},
+			errs:             []error{httpgrpc.Errorf(http.StatusInternalServerError, "error"), errors.New("connection error")},
+			expected:         promql.Vector{{Point: promql.Point{T: 1500, V: 1}, Metric: labels.FromStrings(labels.MetricName, "up", "job", "test")}},
+			expectedRequests: 3,
+		},
+		"server error exceeding max retries": {
+			responses:        []*httpgrpc.HTTPResponse{{Code: http.StatusServiceUnavailable}, {Code: http.StatusServiceUnavailable}, {Code: http.StatusServiceUnavailable}},
+			expectedErr:      true,
+			expectedRequests: 3,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			client := &mockHTTPGRPCClient{responses: testData.responses, errs: testData.errs}
+			q := NewRemoteQuerier(client, QueryFrontendConfig{Timeout: time.Minute, MaxRetries: 2}, "/prometheus", log.NewNopLogger())
+
+			ctx := user.InjectOrgID(context.Background(), "user-1")
+			actual, err := q.Query(ctx, "up", time.Unix(1, 500*int64(time.Millisecond)))
+			if testData.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testData.expected, actual)
+			}
+
+			require.Len(t, client.requests, testData.expectedRequests)
+
+			req := client.requests[0]
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, "/prometheus/api/v1/query", req.Url)
+
+			headers := map[string][]string{}
+			for _, h := range req.Headers {
+				headers[h.Key] = h.Values
+			}
+			assert.Equal(t, []string{"user-1"}, headers[user.OrgIDHeaderName])
+
+			form, err := url.ParseQuery(string(req.Body))
+			require.NoError(t, err)
+			assert.Equal(t, "up", form.Get("query"))
+			assert.Equal(t, "1.5", form.Get("time"))
+		})
+	}
+}
+
+func TestDialQueryFrontend(t *testing.T) {
+	cfg := QueryFrontendConfig{}
+	flagext.DefaultValues(&cfg)
+	cfg.Address = "localhost:9095"
+
+	// The request duration metric is registered on the input registerer, so dialing with
+	// different registerers doesn't panic for duplicated registration.
+	for i := 0; i < 2; i++ {
+		reg := prometheus.NewPedanticRegistry()
+
+		client, conn, err := DialQueryFrontend(cfg, reg)
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+		require.NoError(t, conn.Close())
+
+		assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+			# HELP cortex_ruler_query_frontend_request_duration_seconds Time spent doing requests to the query-frontend to evaluate the rules.
+			# TYPE cortex_ruler_query_frontend_request_duration_seconds histogram
+		`), "cortex_ruler_query_frontend_request_duration_seconds"))
+	}
+}
+
+func TestRemoteQuerier_QueryWithoutOrgID(t *testing.T) {
+	client := &mockHTTPGRPCClient{}
+	q := NewRemoteQuerier(client, QueryFrontendConfig{Timeout: time.Minute}, "/prometheus", log.NewNopLogger())
+
+	_, err := q.Query(context.Background(), "up", time.Now())
+	require.Error(t, err)
+	assert.Empty(t, client.requests)
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"net/http"
 	"net/url"
 	"path/filepath"
@@ -42,8 +43,10 @@ var (
 	supportedShardingStrategies = []string{util.ShardingStrategyDefault, util.ShardingStrategyShuffle}
 
 	// Validation errors.
-	errInvalidShardingStrategy = errors.New("invalid sharding strategy")
-	errInvalidTenantShardSize  = errors.New("invalid tenant shard size, the value must be greater than 0")
+	errInvalidShardingStrategy      = errors.New("invalid sharding strategy")
+	errInvalidTenantShardSize       = errors.New("invalid tenant shard size, the value must be greater than 0")
+	errInvalidEvaluationHistorySize = errors.New("invalid evaluation history size, the value must be greater than or equal to 0")
+	errInvalidValidationMaxRules    = errors.New("invalid validation max evaluated rules, the value must be greater than or equal to 0")
 )
 
 const (
@@ -103,6 +106,16 @@ type Config struct {
 
 	EnableAPI bool `yaml:"enable_api"`
 
+	// Query-frontend used to evaluate the rules remotely.
+	QueryFrontend QueryFrontendConfig `yaml:"query_frontend"`
+
+	// Number of recent evaluations kept for each rule.
+	EvaluationHistorySize int `yaml:"evaluation_history_size"`
+
+	// Limits of the rules evaluation run by the rule group validation API.
+	ValidationEvaluationTimeout time.Duration `yaml:"validation_evaluation_timeout"`
+	ValidationMaxEvaluatedRules int           `yaml:"validation_max_evaluated_rules"`
+
 	RingCheckPeriod time.Duration `yaml:"-"`
 }
 
@@ -122,6 +135,15 @@ func (cfg *Config) Validate(limits validation.Limits, log log.Logger) error {
 	if err := cfg.ClientTLSConfig.Validate(log); err != nil {
 		return errors.Wrap(err, "invalid ruler gRPC client config")
 	}
+	if err := cfg.QueryFrontend.Validate(log); err != nil {
+		return errors.Wrap(err, "invalid ruler query-frontend config")
+	}
+	if cfg.EvaluationHistorySize < 0 {
+		return errInvalidEvaluationHistorySize
+	}
+	if cfg.ValidationMaxEvaluatedRules < 0 {
+		return errInvalidValidationMaxRules
+	}
 	return nil
 }
 
@@ -130,6 +152,7 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	cfg.ClientTLSConfig.RegisterFlagsWithPrefix("ruler.client", f)
 	cfg.StoreConfig.RegisterFlags(f)
 	cfg.Ring.RegisterFlags(f)
+	cfg.QueryFrontend.RegisterFlags(f)
 
 	// Deprecated Flags that will be maintained to avoid user disruption
 	flagext.DeprecatedFlag(f, "ruler.client-timeout", "This flag has been renamed to ruler.configs.client-timeout")
@@ -157,6 +180,9 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.DurationVar(&cfg.OutageTolerance, "ruler.for-outage-tolerance", time.Hour, `Max time to tolerate outage for restoring "for" state of alert.`)
 	f.DurationVar(&cfg.ForGracePeriod, "ruler.for-grace-period", 10*time.Minute, `Minimum duration between alert and restored "for" state. This is maintained only for alerts with configured "for" time greater than grace period.`)
 	f.DurationVar(&cfg.ResendDelay, "ruler.resend-delay", time.Minute, `Minimum amount of time to wait before resending an alert to Alertmanager.`)
+	f.IntVar(&cfg.EvaluationHistorySize, "ruler.evaluation-history-size", 10, "Number of recent evaluations kept in memory for each rule, exposed by the rule evaluation history API. 0 to disable.")
+	f.DurationVar(&cfg.ValidationEvaluationTimeout, "ruler.validation-evaluation-timeout", time.Minute, "Timeout of the evaluation of a rule group by the rule group validation API. The rules not evaluated within the timeout are reported as skipped. 0 to disable.")
+	f.IntVar(&cfg.ValidationMaxEvaluatedRules, "ruler.validation-max-evaluated-rules", 20, "Max number of rules of a group evaluated by the rule group validation API. The rules above the max are reported as skipped. 0 to disable.")
 
 	cfg.RingCheckPeriod = 5 * time.Second
 }
@@ -172,6 +198,8 @@ type MultiTenantManager interface {
 	Stop()
 	// ValidateRuleGroup validates a rulegroup
 	ValidateRuleGroup(rulefmt.RuleGroup) []error
+	// GetRuleEvaluationHistory returns the most recent evaluations of a rule of a tenant, newest first.
+	GetRuleEvaluationHistory(userID, file, group string, ruleIndex int, ruleName string) []*RuleEvaluationDesc
 }
 
 // Ruler evaluates rules.
@@ -211,6 +239,9 @@ type Ruler struct {
 	manager     MultiTenantManager
 	limits      RulesLimits
 
+	// Connection to the query-frontend used to evaluate the rules remotely, closed once the ruler stops.
+	queryFrontendConn io.Closer
+
 	ringCheckErrors prometheus.Counter
 	rulerSync       *prometheus.CounterVec
 
@@ -218,15 +249,17 @@ type Ruler struct {
 	logger   log.Logger
 }
 
-// NewRuler creates a new ruler from a distributor and chunk store.
-func NewRuler(cfg Config, manager MultiTenantManager, reg prometheus.Registerer, logger log.Logger, ruleStore rules.RuleStore, limits RulesLimits) (*Ruler, error) {
+// NewRuler creates a new ruler from a distributor and chunk store. The queryFrontendConn is the
+// connection used to evaluate the rules in the query-frontend, if any, and is closed once the ruler stops.
+func NewRuler(cfg Config, manager MultiTenantManager, reg prometheus.Registerer, logger log.Logger, ruleStore rules.RuleStore, limits RulesLimits, queryFrontendConn io.Closer) (*Ruler, error) {
 	ruler := &Ruler{
-		cfg:      cfg,
-		store:    ruleStore,
-		manager:  manager,
-		registry: reg,
-		logger:   logger,
-		limits:   limits,
+		cfg:               cfg,
+		store:             ruleStore,
+		manager:           manager,
+		registry:          reg,
+		logger:            logger,
+		limits:            limits,
+		queryFrontendConn: queryFrontendConn,
 
 		ringCheckErrors: promauto.With(reg).NewCounter(prometheus.CounterOpts{
 			Name: "cortex_ruler_ring_check_errors_total",
@@ -308,6 +341,13 @@ func (r *Ruler) starting(ctx context.Context) error {
 func (r *Ruler) stopping(_ error) error {
 	r.manager.Stop()
 
+	// The rules are not evaluated anymore once the manager is stopped, so the connection to the query-frontend can be closed.
+	if r.queryFrontendConn != nil {
+		if err := r.queryFrontendConn.Close(); err != nil {
+			level.Warn(r.logger).Log("msg", "failed to close gRPC connection to the query-frontend", "err", err)
+		}
+	}
+
 	if r.subservices != nil {
 		// subservices manages ring and lifecycler, if sharding was enabled.
 		_ = services.StopManagerAndAwaitStopped(context.Background(), r.subservices)
@@ -600,87 +640,183 @@ func (r *Ruler) getLocalRules(userID string) ([]*GroupStateDesc, error) {
 	groups := r.manager.GetRules(userID)
 
 	groupDescs := make([]*GroupStateDesc, 0, len(groups))
-	prefix := filepath.Join(r.cfg.RulePath, userID) + "/"
-
 	for _, group := range groups {
-		interval := group.Interval()
-
-		// The mapped filename is url path escaped encoded to make handling `/` characters easier
-		decodedNamespace, err := url.PathUnescape(strings.TrimPrefix(group.File(), prefix))
+		groupDesc, err := r.newGroupStateDesc(userID, group)
 		if err != nil {
-			return nil, errors.Wrap(err, "unable to decode rule filename")
+			return nil, err
 		}
+		groupDescs = append(groupDescs, groupDesc)
+	}
+	return groupDescs, nil
+}
+
+// newGroupStateDesc returns the state of a rule group evaluated by this ruler.
+func (r *Ruler) newGroupStateDesc(userID string, group *promRules.Group) (*GroupStateDesc, error) {
+	interval := group.Interval()
+	prefix := filepath.Join(r.cfg.RulePath, userID) + "/"
+
+	// The mapped filename is url path escaped encoded to make handling `/` characters easier
+	decodedNamespace, err := url.PathUnescape(strings.TrimPrefix(group.File(), prefix))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode rule filename")
+	}
 
-		groupDesc := &GroupStateDesc{
-			Group: &rules.RuleGroupDesc{
-				Name:      group.Name(),
-				Namespace: string(decodedNamespace),
-				Interval:  interval,
-				User:      userID,
-			},
+	groupDesc := &GroupStateDesc{
+		Group: &rules.RuleGroupDesc{
+			Name:      group.Name(),
+			Namespace: string(decodedNamespace),
+			Interval:  interval,
+			User:      userID,
+		},
 
-			EvaluationTimestamp: group.GetLastEvaluation(),
-			EvaluationDuration:  group.GetEvaluationTime(),
+		EvaluationTimestamp: group.GetLastEvaluation(),
+		EvaluationDuration:  group.GetEvaluationTime(),
+	}
+	for _, rl := range group.Rules() {
+		lastError := ""
+		if rl.LastError() != nil {
+			lastError = rl.LastError().Error()
 		}
-		for _, r := range group.Rules() {
-			lastError := ""
-			if r.LastError() != nil {
-				lastError = r.LastError().Error()
-			}
 
-			var ruleDesc *RuleStateDesc
-			switch rule := r.(type) {
-			case *promRules.AlertingRule:
-				rule.ActiveAlerts()
-				alerts := []*AlertStateDesc{}
-				for _, a := range rule.ActiveAlerts() {
-					alerts = append(alerts, &AlertStateDesc{
-						State:       a.State.String(),
-						Labels:      client.FromLabelsToLabelAdapters(a.Labels),
-						Annotations: client.FromLabelsToLabelAdapters(a.Annotations),
-						Value:       a.Value,
-						ActiveAt:    a.ActiveAt,
-						FiredAt:     a.FiredAt,
-						ResolvedAt:  a.ResolvedAt,
-						LastSentAt:  a.LastSentAt,
-						ValidUntil:  a.ValidUntil,
-					})
-				}
-				ruleDesc = &RuleStateDesc{
-					Rule: &rules.RuleDesc{
-						Expr:        rule.Query().String(),
-						Alert:       rule.Name(),
-						For:         rule.HoldDuration(),
-						Labels:      client.FromLabelsToLabelAdapters(rule.Labels()),
-						Annotations: client.FromLabelsToLabelAdapters(rule.Annotations()),
-					},
-					State:               rule.State().String(),
-					Health:              string(rule.Health()),
-					LastError:           lastError,
-					Alerts:              alerts,
-					EvaluationTimestamp: rule.GetEvaluationTimestamp(),
-					EvaluationDuration:  rule.GetEvaluationDuration(),
-				}
-			case *promRules.RecordingRule:
-				ruleDesc = &RuleStateDesc{
-					Rule: &rules.RuleDesc{
-						Record: rule.Name(),
-						Expr:   rule.Query().String(),
-						Labels: client.FromLabelsToLabelAdapters(rule.Labels()),
-					},
-					Health:              string(rule.Health()),
-					LastError:           lastError,
-					EvaluationTimestamp: rule.GetEvaluationTimestamp(),
-					EvaluationDuration:  rule.GetEvaluationDuration(),
-				}
-			default:
-				return nil, errors.Errorf("failed to assert type of rule '%v'", rule.Name())
+		var ruleDesc *RuleStateDesc
+		switch rule := rl.(type) {
+		case *promRules.AlertingRule:
+			rule.ActiveAlerts()
+			alerts := []*AlertStateDesc{}
+			for _, a := range rule.ActiveAlerts() {
+				alerts = append(alerts, &AlertStateDesc{
+					State:       a.State.String(),
+					Labels:      client.FromLabelsToLabelAdapters(a.Labels),
+					Annotations: client.FromLabelsToLabelAdapters(a.Annotations),
+					Value:       a.Value,
+					ActiveAt:    a.ActiveAt,
+					FiredAt:     a.FiredAt,
+					ResolvedAt:  a.ResolvedAt,
+					LastSentAt:  a.LastSentAt,
+					ValidUntil:  a.ValidUntil,
+				})
 			}
-			groupDesc.ActiveRules = append(groupDesc.ActiveRules, ruleDesc)
+			ruleDesc = &RuleStateDesc{
+				Rule: &rules.RuleDesc{
+					Expr:        rule.Query().String(),
+					Alert:       rule.Name(),
+					For:         rule.HoldDuration(),
+					Labels:      client.FromLabelsToLabelAdapters(rule.Labels()),
+					Annotations: client.FromLabelsToLabelAdapters(rule.Annotations()),
+				},
+				State:               rule.State().String(),
+				Health:              string(rule.Health()),
+				LastError:           lastError,
+				Alerts:              alerts,
+				EvaluationTimestamp: rule.GetEvaluationTimestamp(),
+				EvaluationDuration:  rule.GetEvaluationDuration(),
+			}
+		case *promRules.RecordingRule:
+			ruleDesc = &RuleStateDesc{
+				Rule: &rules.RuleDesc{
+					Record: rule.Name(),
+					Expr:   rule.Query().String(),
+					Labels: client.FromLabelsToLabelAdapters(rule.Labels()),
+				},
+				Health:              string(rule.Health()),
+				LastError:           lastError,
+				EvaluationTimestamp: rule.GetEvaluationTimestamp(),
+				EvaluationDuration:  rule.GetEvaluationDuration(),
+			}
+		default:
+			return nil, errors.Errorf("failed to assert type of rule '%v'", rule.Name())
 		}
-		groupDescs = append(groupDescs, groupDesc)
+		groupDesc.ActiveRules = append(groupDesc.ActiveRules, ruleDesc)
 	}
-	return groupDescs, nil
+	return groupDesc, nil
+}
+
+// getLocalRuleGroupEvaluationHistory returns the state and the evaluation history of a rule group
+// evaluated by this ruler. The group of the response is not set if the ruler doesn't evaluate it.
+func (r *Ruler) getLocalRuleGroupEvaluationHistory(userID, namespace, groupName string) (*RuleGroupEvaluationHistoryResponse, error) {
+	for _, group := range r.manager.GetRules(userID) {
+		if group.Name() != groupName {
+			continue
+		}
+
+		groupDesc, err := r.newGroupStateDesc(userID, group)
+		if err != nil {
+			return nil, err
+		}
+		if groupDesc.Group.Namespace != namespace {
+			continue
+		}
+
+		resp := &RuleGroupEvaluationHistoryResponse{
+			Group: groupDesc,
+			Rules: make([]*RuleEvaluationHistoryDesc, 0, len(group.Rules())),
+		}
+		for i, rl := range group.Rules() {
+			resp.Rules = append(resp.Rules, &RuleEvaluationHistoryDesc{
+				Evaluations: r.manager.GetRuleEvaluationHistory(userID, group.File(), group.Name(), i, rl.Name()),
+			})
+		}
+		return resp, nil
+	}
+
+	return &RuleGroupEvaluationHistoryResponse{}, nil
+}
+
+// GetRuleGroupEvaluationHistory returns the state and the evaluation history of a rule group,
+// asking the ruler owning the rule group if sharding is enabled.
+func (r *Ruler) GetRuleGroupEvaluationHistory(ctx context.Context, namespace, groupName string) (*RuleGroupEvaluationHistoryResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("no user id found in context")
+	}
+
+	if !r.cfg.EnableSharding {
+		return r.getLocalRuleGroupEvaluationHistory(userID, namespace, groupName)
+	}
+
+	// The rule group is owned by a single ruler, selected in the same way of the rules sync.
+	userRing := ring.ReadRing(r.ring)
+	if r.cfg.ShardingStrategy == util.ShardingStrategyShuffle {
+		if shardSize := r.limits.RulerTenantShardSize(userID); shardSize > 0 {
+			userRing = r.ring.ShuffleShard(userID, shardSize)
+		}
+	}
+
+	rlrs, err := userRing.Get(tokenForGroup(&rules.RuleGroupDesc{User: userID, Namespace: namespace, Name: groupName}), RingOp, nil, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading ring to find the rule group owner")
+	}
+
+	addr := rlrs.Ingesters[0].Addr
+	if addr == r.lifecycler.GetInstanceAddr() {
+		return r.getLocalRuleGroupEvaluationHistory(userID, namespace, groupName)
+	}
+
+	ctx, err = user.InjectIntoGRPCRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to inject user ID into grpc request, %v", err)
+	}
+
+	dialOpts, err := r.cfg.ClientTLSConfig.DialOption(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.DialContext(ctx, addr, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := NewRulerClient(conn).RuleGroupEvaluationHistory(ctx, &RuleGroupEvaluationHistoryRequest{Namespace: namespace, GroupName: groupName})
+
+	// Close the gRPC connection regardless the RPC was successful or not.
+	if closeErr := conn.Close(); closeErr != nil {
+		level.Warn(r.logger).Log("msg", "failed to close gRPC connection to ruler", "remote", addr, "err", closeErr)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve the rule group evaluation history from the ruler %s, %v", addr, err)
+	}
+	return resp, nil
 }
 
 func (r *Ruler) getShardedRules(ctx context.Context) ([]*GroupStateDesc, error) {
@@ -737,6 +873,17 @@ func (r *Ruler) Rules(ctx context.Context, in *RulesRequest) (*RulesResponse, er
 	return &RulesResponse{Groups: groupDescs}, nil
 }
 
+// RuleGroupEvaluationHistory implements the rules service, returning the evaluation history of a rule
+// group evaluated by this ruler.
+func (r *Ruler) RuleGroupEvaluationHistory(ctx context.Context, in *RuleGroupEvaluationHistoryRequest) (*RuleGroupEvaluationHistoryResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("no user id found in context")
+	}
+
+	return r.getLocalRuleGroupEvaluationHistory(userID, in.Namespace, in.GroupName)
+}
+
 // AssertMaxRuleGroups limit has not been reached compared to the current
 // number of total rule groups in input and returns an error if so.
 func (r *Ruler) AssertMaxRuleGroups(userID string, rg int) error {
@@ -276,6 +276,222 @@ func (m *RuleStateDesc) GetEvaluationDuration() time.Duration {
 	return 0
 }
 
+// RuleEvaluationDesc is a past evaluation of a rule.
+type RuleEvaluationDesc struct {
+	Timestamp time.Time     `protobuf:"bytes,1,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Duration  time.Duration `protobuf:"bytes,2,opt,name=duration,proto3,stdduration" json:"duration"`
+	Samples   int64         `protobuf:"varint,3,opt,name=samples,proto3" json:"samples,omitempty"`
+	Error     string        `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *RuleEvaluationDesc) Reset()      { *m = RuleEvaluationDesc{} }
+func (*RuleEvaluationDesc) ProtoMessage() {}
+func (*RuleEvaluationDesc) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ecbec0a4cfddea6, []int{4}
+}
+func (m *RuleEvaluationDesc) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RuleEvaluationDesc) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RuleEvaluationDesc.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RuleEvaluationDesc) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleEvaluationDesc.Merge(m, src)
+}
+func (m *RuleEvaluationDesc) XXX_Size() int {
+	return m.Size()
+}
+func (m *RuleEvaluationDesc) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleEvaluationDesc.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleEvaluationDesc proto.InternalMessageInfo
+
+func (m *RuleEvaluationDesc) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *RuleEvaluationDesc) GetDuration() time.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *RuleEvaluationDesc) GetSamples() int64 {
+	if m != nil {
+		return m.Samples
+	}
+	return 0
+}
+
+func (m *RuleEvaluationDesc) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// RuleGroupEvaluationHistoryRequest requests the evaluation history of a rule group of the tenant.
+type RuleGroupEvaluationHistoryRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	GroupName string `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+}
+
+func (m *RuleGroupEvaluationHistoryRequest) Reset()      { *m = RuleGroupEvaluationHistoryRequest{} }
+func (*RuleGroupEvaluationHistoryRequest) ProtoMessage() {}
+func (*RuleGroupEvaluationHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ecbec0a4cfddea6, []int{5}
+}
+func (m *RuleGroupEvaluationHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RuleGroupEvaluationHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RuleGroupEvaluationHistoryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RuleGroupEvaluationHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleGroupEvaluationHistoryRequest.Merge(m, src)
+}
+func (m *RuleGroupEvaluationHistoryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RuleGroupEvaluationHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleGroupEvaluationHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleGroupEvaluationHistoryRequest proto.InternalMessageInfo
+
+func (m *RuleGroupEvaluationHistoryRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *RuleGroupEvaluationHistoryRequest) GetGroupName() string {
+	if m != nil {
+		return m.GroupName
+	}
+	return ""
+}
+
+// RuleGroupEvaluationHistoryResponse has the state and the evaluation history of a rule group.
+type RuleGroupEvaluationHistoryResponse struct {
+	Group *GroupStateDesc              `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Rules []*RuleEvaluationHistoryDesc `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (m *RuleGroupEvaluationHistoryResponse) Reset()      { *m = RuleGroupEvaluationHistoryResponse{} }
+func (*RuleGroupEvaluationHistoryResponse) ProtoMessage() {}
+func (*RuleGroupEvaluationHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ecbec0a4cfddea6, []int{6}
+}
+func (m *RuleGroupEvaluationHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RuleGroupEvaluationHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RuleGroupEvaluationHistoryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RuleGroupEvaluationHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleGroupEvaluationHistoryResponse.Merge(m, src)
+}
+func (m *RuleGroupEvaluationHistoryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RuleGroupEvaluationHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleGroupEvaluationHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleGroupEvaluationHistoryResponse proto.InternalMessageInfo
+
+func (m *RuleGroupEvaluationHistoryResponse) GetGroup() *GroupStateDesc {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+func (m *RuleGroupEvaluationHistoryResponse) GetRules() []*RuleEvaluationHistoryDesc {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+// RuleEvaluationHistoryDesc is the evaluation history of a rule, newest first.
+type RuleEvaluationHistoryDesc struct {
+	Evaluations []*RuleEvaluationDesc `protobuf:"bytes,1,rep,name=evaluations,proto3" json:"evaluations,omitempty"`
+}
+
+func (m *RuleEvaluationHistoryDesc) Reset()      { *m = RuleEvaluationHistoryDesc{} }
+func (*RuleEvaluationHistoryDesc) ProtoMessage() {}
+func (*RuleEvaluationHistoryDesc) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ecbec0a4cfddea6, []int{7}
+}
+func (m *RuleEvaluationHistoryDesc) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RuleEvaluationHistoryDesc) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RuleEvaluationHistoryDesc.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RuleEvaluationHistoryDesc) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleEvaluationHistoryDesc.Merge(m, src)
+}
+func (m *RuleEvaluationHistoryDesc) XXX_Size() int {
+	return m.Size()
+}
+func (m *RuleEvaluationHistoryDesc) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleEvaluationHistoryDesc.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleEvaluationHistoryDesc proto.InternalMessageInfo
+
+func (m *RuleEvaluationHistoryDesc) GetEvaluations() []*RuleEvaluationDesc {
+	if m != nil {
+		return m.Evaluations
+	}
+	return nil
+}
+
 type AlertStateDesc struct {
 	State       string                                                             `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
 	Labels      []github_com_cortexproject_cortex_pkg_ingester_client.LabelAdapter `protobuf:"bytes,2,rep,name=labels,proto3,customtype=github.com/cortexproject/cortex/pkg/ingester/client.LabelAdapter" json:"labels"`
@@ -291,7 +507,7 @@ type AlertStateDesc struct {
 func (m *AlertStateDesc) Reset()      { *m = AlertStateDesc{} }
 func (*AlertStateDesc) ProtoMessage() {}
 func (*AlertStateDesc) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9ecbec0a4cfddea6, []int{4}
+	return fileDescriptor_9ecbec0a4cfddea6, []int{8}
 }
 func (m *AlertStateDesc) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -374,56 +590,72 @@ func init() {
 	proto.RegisterType((*RulesResponse)(nil), "ruler.RulesResponse")
 	proto.RegisterType((*GroupStateDesc)(nil), "ruler.GroupStateDesc")
 	proto.RegisterType((*RuleStateDesc)(nil), "ruler.RuleStateDesc")
+	proto.RegisterType((*RuleEvaluationDesc)(nil), "ruler.RuleEvaluationDesc")
+	proto.RegisterType((*RuleGroupEvaluationHistoryRequest)(nil), "ruler.RuleGroupEvaluationHistoryRequest")
+	proto.RegisterType((*RuleGroupEvaluationHistoryResponse)(nil), "ruler.RuleGroupEvaluationHistoryResponse")
+	proto.RegisterType((*RuleEvaluationHistoryDesc)(nil), "ruler.RuleEvaluationHistoryDesc")
 	proto.RegisterType((*AlertStateDesc)(nil), "ruler.AlertStateDesc")
 }
 
 func init() { proto.RegisterFile("ruler.proto", fileDescriptor_9ecbec0a4cfddea6) }
 
 var fileDescriptor_9ecbec0a4cfddea6 = []byte{
-	// 686 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x3d, 0x6f, 0xd3, 0x4e,
-	0x1c, 0xf6, 0xa5, 0x75, 0x9a, 0x5c, 0xfa, 0xef, 0x5f, 0x5c, 0x03, 0x32, 0x11, 0xba, 0x54, 0x61,
-	0xa9, 0x90, 0xea, 0x48, 0xa5, 0x12, 0x03, 0xe2, 0x25, 0x55, 0x0b, 0x0c, 0x0c, 0xc8, 0x05, 0xd6,
-	0xea, 0x92, 0x5c, 0x5d, 0x83, 0xeb, 0x33, 0x77, 0xe7, 0x88, 0x05, 0x89, 0x99, 0xa9, 0x23, 0x33,
-	0x13, 0x1f, 0xa5, 0x63, 0xc7, 0x0a, 0xa1, 0x42, 0xdd, 0x85, 0xb1, 0x1f, 0x01, 0xdd, 0x8b, 0x89,
-	0x03, 0x45, 0x22, 0xa0, 0x2e, 0x96, 0x7f, 0x2f, 0xcf, 0xf3, 0xdc, 0x3d, 0xbf, 0xbb, 0x83, 0x0d,
-	0x9e, 0xc5, 0x94, 0xfb, 0x29, 0x67, 0x92, 0x21, 0x57, 0x07, 0xad, 0x95, 0x30, 0x92, 0xbb, 0x59,
-	0xdf, 0x1f, 0xb0, 0xbd, 0x6e, 0xc8, 0x42, 0xd6, 0xd5, 0xd5, 0x7e, 0xb6, 0xa3, 0x23, 0x1d, 0xe8,
-	0x3f, 0x83, 0x6a, 0xe1, 0x90, 0xb1, 0x30, 0xa6, 0xe3, 0xae, 0x61, 0xc6, 0x89, 0x8c, 0x58, 0x62,
-	0xeb, 0xed, 0x9f, 0xeb, 0x32, 0xda, 0xa3, 0x42, 0x92, 0xbd, 0xd4, 0x36, 0xdc, 0x2f, 0xe9, 0x0d,
-	0x18, 0x97, 0xf4, 0x75, 0xca, 0xd9, 0x0b, 0x3a, 0x90, 0x36, 0xea, 0xa6, 0x2f, 0xc3, 0x6e, 0x94,
-	0x84, 0x54, 0x48, 0xca, 0xbb, 0x83, 0x38, 0xa2, 0x49, 0x51, 0xb2, 0x0c, 0xb7, 0xff, 0x84, 0x41,
-	0x6f, 0x4e, 0x7f, 0x85, 0xf9, 0x1a, 0x70, 0x67, 0x01, 0xce, 0x07, 0x2a, 0x0c, 0xe8, 0xab, 0x8c,
-	0x0a, 0xd9, 0xb9, 0x0b, 0xff, 0xb3, 0xb1, 0x48, 0x59, 0x22, 0x28, 0x5a, 0x81, 0xd5, 0x90, 0xb3,
-	0x2c, 0x15, 0x1e, 0x58, 0x9a, 0x59, 0x6e, 0xac, 0x5e, 0xf6, 0x8d, 0x69, 0x0f, 0x55, 0x72, 0x4b,
-	0x12, 0x49, 0x37, 0xa8, 0x18, 0x04, 0xb6, 0xa9, 0xf3, 0xa1, 0x02, 0x17, 0x26, 0x4b, 0xe8, 0x06,
-	0x74, 0x75, 0xd1, 0x03, 0x4b, 0x60, 0xb9, 0xb1, 0xda, 0xf4, 0x8d, 0xbe, 0x92, 0xd1, 0x9d, 0x1a,
-	0x6f, 0x5a, 0xd0, 0x2d, 0x38, 0x4f, 0x06, 0x32, 0x1a, 0xd1, 0x6d, 0xdd, 0xe4, 0x55, 0xb4, 0x66,
-	0xd3, 0x6a, 0x2a, 0xc8, 0x58, 0xb2, 0x61, 0x3a, 0xf5, 0x72, 0xd1, 0x73, 0xb8, 0x48, 0x47, 0x24,
-	0xce, 0xb4, 0xf7, 0x4f, 0x0b, 0x8f, 0xbd, 0x19, 0x2d, 0xd9, 0xf2, 0xcd, 0x14, 0xfc, 0x62, 0x0a,
-	0xfe, 0x8f, 0x8e, 0xf5, 0xda, 0xc1, 0x71, 0xdb, 0xd9, 0xff, 0xd2, 0x06, 0xc1, 0x79, 0x04, 0x68,
-	0x0b, 0xa2, 0x71, 0x7a, 0xc3, 0xce, 0xd6, 0x9b, 0xd5, 0xb4, 0x57, 0x7f, 0xa1, 0x2d, 0x1a, 0x0c,
-	0xeb, 0x7b, 0xc5, 0x7a, 0x0e, 0xbc, 0xf3, 0xb9, 0x62, 0x5c, 0x1e, 0x7b, 0x74, 0x1d, 0xce, 0xaa,
-	0x2d, 0x5a, 0x8b, 0xfe, 0x2f, 0x59, 0xa4, 0xb7, 0xaa, 0x8b, 0xa8, 0x09, 0x5d, 0xa1, 0x10, 0x5e,
-	0x65, 0x09, 0x2c, 0xd7, 0x03, 0x13, 0xa0, 0x2b, 0xb0, 0xba, 0x4b, 0x49, 0x2c, 0x77, 0xf5, 0x66,
-	0xeb, 0x81, 0x8d, 0xd0, 0x35, 0x58, 0x8f, 0x89, 0x90, 0x9b, 0x9c, 0x33, 0xae, 0x17, 0x5c, 0x0f,
-	0xc6, 0x09, 0x35, 0x56, 0x12, 0x53, 0x2e, 0x85, 0xe7, 0x4e, 0x8c, 0xb5, 0xa7, 0x92, 0xa5, 0xb1,
-	0x9a, 0xa6, 0xdf, 0xd9, 0x5b, 0xbd, 0x18, 0x7b, 0xe7, 0xfe, 0xcd, 0xde, 0x77, 0x2e, 0x5c, 0x98,
-	0xdc, 0xc7, 0xd8, 0x3a, 0x50, 0xb6, 0x4e, 0xc0, 0x6a, 0x4c, 0xfa, 0x34, 0x2e, 0xce, 0xd9, 0x25,
-	0xdf, 0x5e, 0xac, 0xc7, 0x2a, 0xfb, 0x84, 0x44, 0x7c, 0xfd, 0x91, 0x52, 0xfa, 0x74, 0xdc, 0xfe,
-	0x9b, 0x6b, 0x6a, 0x68, 0x7a, 0x43, 0x92, 0x4a, 0xca, 0x03, 0x2b, 0x85, 0xde, 0xc0, 0x06, 0x49,
-	0x12, 0x26, 0xf5, 0x5a, 0x85, 0x37, 0x73, 0xf1, 0xca, 0x65, 0x3d, 0xe5, 0x84, 0x72, 0x8c, 0xea,
-	0x23, 0x01, 0x02, 0x13, 0xa0, 0x1e, 0xac, 0xdb, 0x7b, 0x47, 0xa4, 0xe7, 0x4e, 0x31, 0xd5, 0x9a,
-	0x81, 0xf5, 0x24, 0xba, 0x07, 0x6b, 0x3b, 0x11, 0xa7, 0x43, 0xc5, 0x30, 0xcd, 0xb9, 0x98, 0xd3,
-	0xa8, 0x9e, 0x44, 0x9b, 0xb0, 0xc1, 0xa9, 0x60, 0xf1, 0xc8, 0x70, 0xcc, 0x4d, 0xc1, 0x01, 0x0b,
-	0x60, 0x4f, 0xa2, 0x07, 0x70, 0x5e, 0x1d, 0xf3, 0x6d, 0x41, 0x13, 0xa9, 0x78, 0x6a, 0xd3, 0xf0,
-	0x28, 0xe4, 0x16, 0x4d, 0xa4, 0x59, 0xce, 0x88, 0xc4, 0xd1, 0x70, 0x3b, 0x4b, 0x64, 0x14, 0x7b,
-	0xf5, 0x69, 0x68, 0x34, 0xf0, 0x99, 0xc2, 0xad, 0xde, 0x81, 0xae, 0xba, 0xc6, 0x1c, 0xad, 0x99,
-	0x1f, 0x81, 0x16, 0x4b, 0xaf, 0x59, 0xf1, 0xee, 0xb6, 0x9a, 0x93, 0x49, 0xf3, 0xf8, 0x76, 0x9c,
-	0xf5, 0xb5, 0xc3, 0x13, 0xec, 0x1c, 0x9d, 0x60, 0xe7, 0xec, 0x04, 0x83, 0xb7, 0x39, 0x06, 0x1f,
-	0x73, 0x0c, 0x0e, 0x72, 0x0c, 0x0e, 0x73, 0x0c, 0xbe, 0xe6, 0x18, 0x7c, 0xcb, 0xb1, 0x73, 0x96,
-	0x63, 0xb0, 0x7f, 0x8a, 0x9d, 0xc3, 0x53, 0xec, 0x1c, 0x9d, 0x62, 0xa7, 0x5f, 0xd5, 0xcb, 0xbb,
-	0xf9, 0x3d, 0x00, 0x00, 0xff, 0xff, 0x22, 0x68, 0xe6, 0xe5, 0xe1, 0x06, 0x00, 0x00,
+	// 868 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcf, 0x6f, 0xdc, 0x44,
+	0x14, 0xf6, 0xec, 0xc6, 0x9b, 0xf5, 0xdb, 0x34, 0xc0, 0x34, 0x20, 0x67, 0x05, 0xce, 0x62, 0x2e,
+	0x01, 0x54, 0xaf, 0x14, 0x2a, 0x38, 0x54, 0x02, 0x36, 0x6a, 0xa0, 0x42, 0x08, 0x21, 0x07, 0x10,
+	0xb7, 0x30, 0xd9, 0x4c, 0x1d, 0x83, 0xd7, 0x63, 0x66, 0xc6, 0x11, 0x1c, 0x90, 0xb8, 0xc2, 0xa9,
+	0x47, 0xce, 0x9c, 0x38, 0xf3, 0x57, 0x94, 0x5b, 0x8e, 0x15, 0x87, 0x42, 0x9c, 0x0b, 0xc7, 0xfe,
+	0x09, 0x68, 0x7e, 0x78, 0xed, 0xa5, 0x49, 0x95, 0xa5, 0xea, 0x65, 0xe5, 0xf7, 0xe6, 0x7d, 0xdf,
+	0x9b, 0x79, 0xef, 0x7d, 0x6f, 0x61, 0xc0, 0xcb, 0x8c, 0xf2, 0xa8, 0xe0, 0x4c, 0x32, 0xec, 0x6a,
+	0x63, 0x78, 0x23, 0x49, 0xe5, 0x71, 0x79, 0x18, 0x4d, 0xd9, 0x6c, 0x9c, 0xb0, 0x84, 0x8d, 0xf5,
+	0xe9, 0x61, 0x79, 0x57, 0x5b, 0xda, 0xd0, 0x5f, 0x06, 0x35, 0x0c, 0x12, 0xc6, 0x92, 0x8c, 0x36,
+	0x51, 0x47, 0x25, 0x27, 0x32, 0x65, 0xb9, 0x3d, 0xdf, 0xfa, 0xef, 0xb9, 0x4c, 0x67, 0x54, 0x48,
+	0x32, 0x2b, 0x6c, 0xc0, 0xfb, 0xad, 0x7c, 0x53, 0xc6, 0x25, 0xfd, 0xae, 0xe0, 0xec, 0x6b, 0x3a,
+	0x95, 0xd6, 0x1a, 0x17, 0xdf, 0x24, 0xe3, 0x34, 0x4f, 0xa8, 0x90, 0x94, 0x8f, 0xa7, 0x59, 0x4a,
+	0xf3, 0xfa, 0xc8, 0x32, 0xdc, 0xba, 0x0a, 0x83, 0x7e, 0x9c, 0xfe, 0x15, 0xe6, 0xd7, 0x80, 0xc3,
+	0x75, 0x58, 0x8b, 0x95, 0x19, 0xd3, 0x6f, 0x4b, 0x2a, 0x64, 0xf8, 0x2e, 0x5c, 0xb3, 0xb6, 0x28,
+	0x58, 0x2e, 0x28, 0xbe, 0x01, 0xbd, 0x84, 0xb3, 0xb2, 0x10, 0x3e, 0x1a, 0x75, 0xb7, 0x07, 0x3b,
+	0x2f, 0x46, 0xa6, 0x68, 0x1f, 0x2a, 0xe7, 0xbe, 0x24, 0x92, 0xde, 0xa6, 0x62, 0x1a, 0xdb, 0xa0,
+	0xf0, 0xd7, 0x0e, 0xac, 0x2f, 0x1e, 0xe1, 0x37, 0xc0, 0xd5, 0x87, 0x3e, 0x1a, 0xa1, 0xed, 0xc1,
+	0xce, 0x46, 0x64, 0xf2, 0xab, 0x34, 0x3a, 0x52, 0xe3, 0x4d, 0x08, 0x7e, 0x07, 0xd6, 0xc8, 0x54,
+	0xa6, 0x27, 0xf4, 0x40, 0x07, 0xf9, 0x9d, 0x51, 0x77, 0x0e, 0xe1, 0x1a, 0xd2, 0xa4, 0x1c, 0x98,
+	0x48, 0x7d, 0x5d, 0xfc, 0x05, 0x5c, 0xa7, 0x27, 0x24, 0x2b, 0x75, 0xed, 0x3f, 0xab, 0x6b, 0xec,
+	0x77, 0x75, 0xca, 0x61, 0x64, 0xba, 0x10, 0xd5, 0x5d, 0x88, 0xe6, 0x11, 0xbb, 0xfd, 0xfb, 0x0f,
+	0xb7, 0x9c, 0x7b, 0x7f, 0x6d, 0xa1, 0xf8, 0x22, 0x02, 0xbc, 0x0f, 0xb8, 0x71, 0xdf, 0xb6, 0xbd,
+	0xf5, 0x57, 0x34, 0xed, 0xe6, 0x63, 0xb4, 0x75, 0x80, 0x61, 0xfd, 0x45, 0xb1, 0x5e, 0x00, 0x0f,
+	0xab, 0x0e, 0x5c, 0x5b, 0x78, 0x0b, 0x7e, 0x0d, 0x56, 0xd4, 0x13, 0x6d, 0x89, 0x9e, 0x6b, 0x95,
+	0x48, 0x3f, 0x55, 0x1f, 0xe2, 0x0d, 0x70, 0x85, 0x42, 0xf8, 0x9d, 0x11, 0xda, 0xf6, 0x62, 0x63,
+	0xe0, 0x97, 0xa0, 0x77, 0x4c, 0x49, 0x26, 0x8f, 0xf5, 0x63, 0xbd, 0xd8, 0x5a, 0xf8, 0x65, 0xf0,
+	0x32, 0x22, 0xe4, 0x1e, 0xe7, 0x8c, 0xeb, 0x0b, 0x7b, 0x71, 0xe3, 0x50, 0x6d, 0x25, 0x19, 0xe5,
+	0x52, 0xf8, 0xee, 0x42, 0x5b, 0x27, 0xca, 0xd9, 0x6a, 0xab, 0x09, 0xba, 0xac, 0xbc, 0xbd, 0x67,
+	0x53, 0xde, 0xd5, 0xa7, 0x2a, 0xef, 0x47, 0x2b, 0xfd, 0xfe, 0xf3, 0x5e, 0xf8, 0x07, 0x02, 0xac,
+	0x0a, 0xb8, 0xd7, 0x04, 0xa8, 0x4a, 0xef, 0x82, 0x37, 0x97, 0xa0, 0x8f, 0x96, 0xb8, 0x7f, 0x03,
+	0xc3, 0xef, 0x41, 0xbf, 0x96, 0xb9, 0xdf, 0xb9, 0xfa, 0x5d, 0xe7, 0x20, 0xec, 0xc3, 0xaa, 0x20,
+	0xb3, 0x42, 0x4d, 0xb8, 0x6a, 0x5a, 0x37, 0xae, 0x4d, 0xd5, 0x63, 0xda, 0xea, 0x98, 0x31, 0xc2,
+	0xaf, 0xe0, 0xd5, 0xb9, 0x5c, 0x9a, 0xf7, 0xdc, 0x49, 0x85, 0x64, 0xfc, 0x7b, 0x2b, 0x5d, 0xd5,
+	0xf0, 0x9c, 0xcc, 0xa8, 0x28, 0xc8, 0xd4, 0x0c, 0x92, 0x17, 0x37, 0x0e, 0xfc, 0x0a, 0x80, 0x96,
+	0xd8, 0x81, 0x72, 0xd9, 0x09, 0xf2, 0xb4, 0xe7, 0x13, 0x32, 0xa3, 0xe1, 0x4f, 0x08, 0xc2, 0x27,
+	0xa5, 0xb0, 0xdb, 0xe0, 0xcd, 0x45, 0x2d, 0x5f, 0xb2, 0x0c, 0xac, 0x98, 0xdf, 0x06, 0xb7, 0xad,
+	0xe2, 0x51, 0x4b, 0xc5, 0x8f, 0x65, 0x30, 0x38, 0x1d, 0x1e, 0x7e, 0x09, 0x9b, 0x97, 0xc6, 0xe0,
+	0x5b, 0x30, 0x68, 0x5a, 0x5e, 0x2f, 0xa5, 0xcd, 0x0b, 0xa9, 0xcd, 0x96, 0x68, 0x45, 0x87, 0x3f,
+	0xbb, 0xb0, 0xbe, 0x38, 0xe1, 0x8d, 0xa8, 0x50, 0x5b, 0x54, 0x02, 0x7a, 0x19, 0x39, 0xa4, 0x59,
+	0x7d, 0xf7, 0x17, 0x22, 0xbb, 0x72, 0x3f, 0x56, 0xde, 0x4f, 0x49, 0xca, 0x77, 0xef, 0xa8, 0xbe,
+	0xfe, 0xf9, 0x70, 0xeb, 0xff, 0x2c, 0x70, 0x43, 0x33, 0x39, 0x22, 0x85, 0xa4, 0x3c, 0xb6, 0xa9,
+	0xf0, 0x0f, 0x30, 0x20, 0x79, 0xce, 0xa4, 0x7d, 0x5a, 0xf7, 0xd9, 0x67, 0x6e, 0xe7, 0x53, 0x95,
+	0x50, 0xa5, 0xa2, 0x7a, 0xf4, 0x50, 0x6c, 0x0c, 0x3c, 0x01, 0xcf, 0x6e, 0x64, 0x22, 0x7d, 0x77,
+	0x09, 0xbd, 0xf4, 0x0d, 0x6c, 0x22, 0x95, 0x5c, 0xee, 0xa6, 0x9c, 0x1e, 0x29, 0x86, 0x65, 0x36,
+	0xc6, 0xaa, 0x46, 0x4d, 0x24, 0xde, 0x83, 0x01, 0xa7, 0x82, 0x65, 0x27, 0x86, 0x63, 0x75, 0x09,
+	0x0e, 0xa8, 0x81, 0x13, 0x89, 0x3f, 0x80, 0x35, 0xb5, 0x00, 0x0f, 0x04, 0xcd, 0xa5, 0xe2, 0xe9,
+	0x2f, 0xc3, 0xa3, 0x90, 0xfb, 0x34, 0x97, 0xe6, 0x3a, 0x27, 0x24, 0x4b, 0x8f, 0x0e, 0xca, 0x5c,
+	0xa6, 0x99, 0xef, 0x2d, 0x43, 0xa3, 0x81, 0x9f, 0x2b, 0xdc, 0xce, 0xef, 0x08, 0x5c, 0x35, 0xb0,
+	0x1c, 0xdf, 0x34, 0x1f, 0x02, 0x5f, 0x6f, 0xcd, 0x71, 0xfd, 0x97, 0x3c, 0xdc, 0x58, 0x74, 0x1a,
+	0x25, 0x86, 0x0e, 0x16, 0x30, 0xbc, 0x5c, 0xb1, 0x78, 0xbb, 0x85, 0x7a, 0xe2, 0xde, 0x18, 0xbe,
+	0x7e, 0x85, 0xc8, 0x3a, 0xe9, 0xee, 0xcd, 0xd3, 0xb3, 0xc0, 0x79, 0x70, 0x16, 0x38, 0x8f, 0xce,
+	0x02, 0xf4, 0x63, 0x15, 0xa0, 0xdf, 0xaa, 0x00, 0xdd, 0xaf, 0x02, 0x74, 0x5a, 0x05, 0xe8, 0xef,
+	0x2a, 0x40, 0xff, 0x54, 0x81, 0xf3, 0xa8, 0x0a, 0xd0, 0xbd, 0xf3, 0xc0, 0x39, 0x3d, 0x0f, 0x9c,
+	0x07, 0xe7, 0x81, 0x73, 0xd8, 0xd3, 0x45, 0x79, 0xeb, 0xdf, 0x01, 0x00, 0x5e, 0xc9, 0x67, 0xf5,
+	0x71, 0x09, 0x00, 0x00,
 }
 
 func (this *RulesRequest) Equal(that interface{}) bool {
@@ -561,6 +793,127 @@ func (this *RuleStateDesc) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *RuleEvaluationDesc) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RuleEvaluationDesc)
+	if !ok {
+		that2, ok := that.(RuleEvaluationDesc)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Timestamp.Equal(that1.Timestamp) {
+		return false
+	}
+	if this.Duration != that1.Duration {
+		return false
+	}
+	if this.Samples != that1.Samples {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	return true
+}
+func (this *RuleGroupEvaluationHistoryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RuleGroupEvaluationHistoryRequest)
+	if !ok {
+		that2, ok := that.(RuleGroupEvaluationHistoryRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Namespace != that1.Namespace {
+		return false
+	}
+	if this.GroupName != that1.GroupName {
+		return false
+	}
+	return true
+}
+func (this *RuleGroupEvaluationHistoryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RuleGroupEvaluationHistoryResponse)
+	if !ok {
+		that2, ok := that.(RuleGroupEvaluationHistoryResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Group.Equal(that1.Group) {
+		return false
+	}
+	if len(this.Rules) != len(that1.Rules) {
+		return false
+	}
+	for i := range this.Rules {
+		if !this.Rules[i].Equal(that1.Rules[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *RuleEvaluationHistoryDesc) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RuleEvaluationHistoryDesc)
+	if !ok {
+		that2, ok := that.(RuleEvaluationHistoryDesc)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Evaluations) != len(that1.Evaluations) {
+		return false
+	}
+	for i := range this.Evaluations {
+		if !this.Evaluations[i].Equal(that1.Evaluations[i]) {
+			return false
+		}
+	}
+	return true
+}
 func (this *AlertStateDesc) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -677,38 +1030,89 @@ func (this *RuleStateDesc) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func (this *AlertStateDesc) GoString() string {
+func (this *RuleEvaluationDesc) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 13)
-	s = append(s, "&ruler.AlertStateDesc{")
-	s = append(s, "State: "+fmt.Sprintf("%#v", this.State)+",\n")
-	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
-	s = append(s, "Annotations: "+fmt.Sprintf("%#v", this.Annotations)+",\n")
-	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
-	s = append(s, "ActiveAt: "+fmt.Sprintf("%#v", this.ActiveAt)+",\n")
-	s = append(s, "FiredAt: "+fmt.Sprintf("%#v", this.FiredAt)+",\n")
-	s = append(s, "ResolvedAt: "+fmt.Sprintf("%#v", this.ResolvedAt)+",\n")
-	s = append(s, "LastSentAt: "+fmt.Sprintf("%#v", this.LastSentAt)+",\n")
-	s = append(s, "ValidUntil: "+fmt.Sprintf("%#v", this.ValidUntil)+",\n")
+	s := make([]string, 0, 8)
+	s = append(s, "&ruler.RuleEvaluationDesc{")
+	s = append(s, "Timestamp: "+fmt.Sprintf("%#v", this.Timestamp)+",\n")
+	s = append(s, "Duration: "+fmt.Sprintf("%#v", this.Duration)+",\n")
+	s = append(s, "Samples: "+fmt.Sprintf("%#v", this.Samples)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
-func valueToGoStringRuler(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
+func (this *RuleGroupEvaluationHistoryRequest) GoString() string {
+	if this == nil {
 		return "nil"
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+	s := make([]string, 0, 6)
+	s = append(s, "&ruler.RuleGroupEvaluationHistoryRequest{")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "GroupName: "+fmt.Sprintf("%#v", this.GroupName)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
+func (this *RuleGroupEvaluationHistoryResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&ruler.RuleGroupEvaluationHistoryResponse{")
+	if this.Group != nil {
+		s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	}
+	if this.Rules != nil {
+		s = append(s, "Rules: "+fmt.Sprintf("%#v", this.Rules)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RuleEvaluationHistoryDesc) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&ruler.RuleEvaluationHistoryDesc{")
+	if this.Evaluations != nil {
+		s = append(s, "Evaluations: "+fmt.Sprintf("%#v", this.Evaluations)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AlertStateDesc) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 13)
+	s = append(s, "&ruler.AlertStateDesc{")
+	s = append(s, "State: "+fmt.Sprintf("%#v", this.State)+",\n")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	s = append(s, "Annotations: "+fmt.Sprintf("%#v", this.Annotations)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "ActiveAt: "+fmt.Sprintf("%#v", this.ActiveAt)+",\n")
+	s = append(s, "FiredAt: "+fmt.Sprintf("%#v", this.FiredAt)+",\n")
+	s = append(s, "ResolvedAt: "+fmt.Sprintf("%#v", this.ResolvedAt)+",\n")
+	s = append(s, "LastSentAt: "+fmt.Sprintf("%#v", this.LastSentAt)+",\n")
+	s = append(s, "ValidUntil: "+fmt.Sprintf("%#v", this.ValidUntil)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringRuler(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
 const _ = grpc.SupportPackageIsVersion4
 
@@ -717,6 +1121,7 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type RulerClient interface {
 	Rules(ctx context.Context, in *RulesRequest, opts ...grpc.CallOption) (*RulesResponse, error)
+	RuleGroupEvaluationHistory(ctx context.Context, in *RuleGroupEvaluationHistoryRequest, opts ...grpc.CallOption) (*RuleGroupEvaluationHistoryResponse, error)
 }
 
 type rulerClient struct {
@@ -736,9 +1141,19 @@ func (c *rulerClient) Rules(ctx context.Context, in *RulesRequest, opts ...grpc.
 	return out, nil
 }
 
+func (c *rulerClient) RuleGroupEvaluationHistory(ctx context.Context, in *RuleGroupEvaluationHistoryRequest, opts ...grpc.CallOption) (*RuleGroupEvaluationHistoryResponse, error) {
+	out := new(RuleGroupEvaluationHistoryResponse)
+	err := c.cc.Invoke(ctx, "/ruler.Ruler/RuleGroupEvaluationHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RulerServer is the server API for Ruler service.
 type RulerServer interface {
 	Rules(context.Context, *RulesRequest) (*RulesResponse, error)
+	RuleGroupEvaluationHistory(context.Context, *RuleGroupEvaluationHistoryRequest) (*RuleGroupEvaluationHistoryResponse, error)
 }
 
 // UnimplementedRulerServer can be embedded to have forward compatible implementations.
@@ -748,6 +1163,9 @@ type UnimplementedRulerServer struct {
 func (*UnimplementedRulerServer) Rules(ctx context.Context, req *RulesRequest) (*RulesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Rules not implemented")
 }
+func (*UnimplementedRulerServer) RuleGroupEvaluationHistory(ctx context.Context, req *RuleGroupEvaluationHistoryRequest) (*RuleGroupEvaluationHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RuleGroupEvaluationHistory not implemented")
+}
 
 func RegisterRulerServer(s *grpc.Server, srv RulerServer) {
 	s.RegisterService(&_Ruler_serviceDesc, srv)
@@ -771,6 +1189,24 @@ func _Ruler_Rules_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Ruler_RuleGroupEvaluationHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleGroupEvaluationHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulerServer).RuleGroupEvaluationHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ruler.Ruler/RuleGroupEvaluationHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulerServer).RuleGroupEvaluationHistory(ctx, req.(*RuleGroupEvaluationHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Ruler_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "ruler.Ruler",
 	HandlerType: (*RulerServer)(nil),
@@ -779,6 +1215,10 @@ var _Ruler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Rules",
 			Handler:    _Ruler_Rules_Handler,
 		},
+		{
+			MethodName: "RuleGroupEvaluationHistory",
+			Handler:    _Ruler_RuleGroupEvaluationHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ruler.proto",
@@ -995,7 +1435,7 @@ func (m *RuleStateDesc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *AlertStateDesc) Marshal() (dAtA []byte, err error) {
+func (m *RuleEvaluationDesc) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1005,55 +1445,229 @@ func (m *AlertStateDesc) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AlertStateDesc) MarshalTo(dAtA []byte) (int, error) {
+func (m *RuleEvaluationDesc) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AlertStateDesc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RuleEvaluationDesc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ValidUntil, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ValidUntil):])
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintRuler(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Samples != 0 {
+		i = encodeVarintRuler(dAtA, i, uint64(m.Samples))
+		i--
+		dAtA[i] = 0x18
+	}
+	n7, err7 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Duration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Duration):])
 	if err7 != nil {
 		return 0, err7
 	}
 	i -= n7
 	i = encodeVarintRuler(dAtA, i, uint64(n7))
 	i--
-	dAtA[i] = 0x4a
-	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastSentAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastSentAt):])
+	dAtA[i] = 0x12
+	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
 	if err8 != nil {
 		return 0, err8
 	}
 	i -= n8
 	i = encodeVarintRuler(dAtA, i, uint64(n8))
 	i--
-	dAtA[i] = 0x42
-	n9, err9 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ResolvedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ResolvedAt):])
-	if err9 != nil {
-		return 0, err9
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *RuleGroupEvaluationHistoryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	i -= n9
-	i = encodeVarintRuler(dAtA, i, uint64(n9))
-	i--
-	dAtA[i] = 0x3a
-	n10, err10 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.FiredAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.FiredAt):])
+	return dAtA[:n], nil
+}
+
+func (m *RuleGroupEvaluationHistoryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RuleGroupEvaluationHistoryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.GroupName) > 0 {
+		i -= len(m.GroupName)
+		copy(dAtA[i:], m.GroupName)
+		i = encodeVarintRuler(dAtA, i, uint64(len(m.GroupName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintRuler(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RuleGroupEvaluationHistoryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RuleGroupEvaluationHistoryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RuleGroupEvaluationHistoryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Rules) > 0 {
+		for iNdEx := len(m.Rules) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Rules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRuler(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Group != nil {
+		{
+			size, err := m.Group.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRuler(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RuleEvaluationHistoryDesc) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RuleEvaluationHistoryDesc) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RuleEvaluationHistoryDesc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Evaluations) > 0 {
+		for iNdEx := len(m.Evaluations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Evaluations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRuler(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AlertStateDesc) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AlertStateDesc) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AlertStateDesc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n10, err10 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ValidUntil, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ValidUntil):])
 	if err10 != nil {
 		return 0, err10
 	}
 	i -= n10
 	i = encodeVarintRuler(dAtA, i, uint64(n10))
 	i--
-	dAtA[i] = 0x32
-	n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ActiveAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ActiveAt):])
+	dAtA[i] = 0x4a
+	n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastSentAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastSentAt):])
 	if err11 != nil {
 		return 0, err11
 	}
 	i -= n11
 	i = encodeVarintRuler(dAtA, i, uint64(n11))
 	i--
+	dAtA[i] = 0x42
+	n12, err12 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ResolvedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ResolvedAt):])
+	if err12 != nil {
+		return 0, err12
+	}
+	i -= n12
+	i = encodeVarintRuler(dAtA, i, uint64(n12))
+	i--
+	dAtA[i] = 0x3a
+	n13, err13 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.FiredAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.FiredAt):])
+	if err13 != nil {
+		return 0, err13
+	}
+	i -= n13
+	i = encodeVarintRuler(dAtA, i, uint64(n13))
+	i--
+	dAtA[i] = 0x32
+	n14, err14 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ActiveAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ActiveAt):])
+	if err14 != nil {
+		return 0, err14
+	}
+	i -= n14
+	i = encodeVarintRuler(dAtA, i, uint64(n14))
+	i--
 	dAtA[i] = 0x2a
 	if m.Value != 0 {
 		i -= 8
@@ -1192,45 +1806,116 @@ func (m *RuleStateDesc) Size() (n int) {
 	return n
 }
 
-func (m *AlertStateDesc) Size() (n int) {
+func (m *RuleEvaluationDesc) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.State)
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovRuler(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Duration)
+	n += 1 + l + sovRuler(uint64(l))
+	if m.Samples != 0 {
+		n += 1 + sovRuler(uint64(m.Samples))
+	}
+	l = len(m.Error)
 	if l > 0 {
 		n += 1 + l + sovRuler(uint64(l))
 	}
-	if len(m.Labels) > 0 {
-		for _, e := range m.Labels {
-			l = e.Size()
-			n += 1 + l + sovRuler(uint64(l))
-		}
+	return n
+}
+
+func (m *RuleGroupEvaluationHistoryRequest) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	if len(m.Annotations) > 0 {
-		for _, e := range m.Annotations {
-			l = e.Size()
-			n += 1 + l + sovRuler(uint64(l))
-		}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovRuler(uint64(l))
 	}
-	if m.Value != 0 {
-		n += 9
+	l = len(m.GroupName)
+	if l > 0 {
+		n += 1 + l + sovRuler(uint64(l))
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ActiveAt)
-	n += 1 + l + sovRuler(uint64(l))
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.FiredAt)
-	n += 1 + l + sovRuler(uint64(l))
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ResolvedAt)
-	n += 1 + l + sovRuler(uint64(l))
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.LastSentAt)
-	n += 1 + l + sovRuler(uint64(l))
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ValidUntil)
-	n += 1 + l + sovRuler(uint64(l))
 	return n
 }
 
-func sovRuler(x uint64) (n int) {
+func (m *RuleGroupEvaluationHistoryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Group != nil {
+		l = m.Group.Size()
+		n += 1 + l + sovRuler(uint64(l))
+	}
+	if len(m.Rules) > 0 {
+		for _, e := range m.Rules {
+			l = e.Size()
+			n += 1 + l + sovRuler(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RuleEvaluationHistoryDesc) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Evaluations) > 0 {
+		for _, e := range m.Evaluations {
+			l = e.Size()
+			n += 1 + l + sovRuler(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AlertStateDesc) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovRuler(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovRuler(uint64(l))
+		}
+	}
+	if len(m.Annotations) > 0 {
+		for _, e := range m.Annotations {
+			l = e.Size()
+			n += 1 + l + sovRuler(uint64(l))
+		}
+	}
+	if m.Value != 0 {
+		n += 9
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ActiveAt)
+	n += 1 + l + sovRuler(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.FiredAt)
+	n += 1 + l + sovRuler(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ResolvedAt)
+	n += 1 + l + sovRuler(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.LastSentAt)
+	n += 1 + l + sovRuler(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ValidUntil)
+	n += 1 + l + sovRuler(uint64(l))
+	return n
+}
+
+func sovRuler(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
 func sozRuler(x uint64) (n int) {
@@ -1299,6 +1984,61 @@ func (this *RuleStateDesc) String() string {
 	}, "")
 	return s
 }
+func (this *RuleEvaluationDesc) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RuleEvaluationDesc{`,
+		`Timestamp:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Timestamp), "Timestamp", "timestamp.Timestamp", 1), `&`, ``, 1) + `,`,
+		`Duration:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Duration), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
+		`Samples:` + fmt.Sprintf("%v", this.Samples) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RuleGroupEvaluationHistoryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RuleGroupEvaluationHistoryRequest{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`GroupName:` + fmt.Sprintf("%v", this.GroupName) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RuleGroupEvaluationHistoryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRules := "[]*RuleEvaluationHistoryDesc{"
+	for _, f := range this.Rules {
+		repeatedStringForRules += strings.Replace(f.String(), "RuleEvaluationHistoryDesc", "RuleEvaluationHistoryDesc", 1) + ","
+	}
+	repeatedStringForRules += "}"
+	s := strings.Join([]string{`&RuleGroupEvaluationHistoryResponse{`,
+		`Group:` + strings.Replace(this.Group.String(), "GroupStateDesc", "GroupStateDesc", 1) + `,`,
+		`Rules:` + repeatedStringForRules + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RuleEvaluationHistoryDesc) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForEvaluations := "[]*RuleEvaluationDesc{"
+	for _, f := range this.Evaluations {
+		repeatedStringForEvaluations += strings.Replace(f.String(), "RuleEvaluationDesc", "RuleEvaluationDesc", 1) + ","
+	}
+	repeatedStringForEvaluations += "}"
+	s := strings.Join([]string{`&RuleEvaluationHistoryDesc{`,
+		`Evaluations:` + repeatedStringForEvaluations + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *AlertStateDesc) String() string {
 	if this == nil {
 		return "nil"
@@ -1388,28 +2128,503 @@ func (m *RulesResponse) Unmarshal(dAtA []byte) error {
 			if shift >= 64 {
 				return ErrIntOverflowRuler
 			}
-			if iNdEx >= l {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RulesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RulesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Groups = append(m.Groups, &GroupStateDesc{})
+			if err := m.Groups[len(m.Groups)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRuler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GroupStateDesc) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRuler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GroupStateDesc: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GroupStateDesc: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Group == nil {
+				m.Group = &rules.RuleGroupDesc{}
+			}
+			if err := m.Group.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveRules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActiveRules = append(m.ActiveRules, &RuleStateDesc{})
+			if err := m.ActiveRules[len(m.ActiveRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvaluationTimestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.EvaluationTimestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvaluationDuration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.EvaluationDuration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRuler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RuleStateDesc) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRuler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RuleStateDesc: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RuleStateDesc: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Rule == nil {
+				m.Rule = &rules.RuleDesc{}
+			}
+			if err := m.Rule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Health", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Health = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Alerts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Alerts = append(m.Alerts, &AlertStateDesc{})
+			if err := m.Alerts[len(m.Alerts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvaluationTimestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRuler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRuler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.EvaluationTimestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RulesResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RulesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EvaluationDuration", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1436,8 +2651,7 @@ func (m *RulesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Groups = append(m.Groups, &GroupStateDesc{})
-			if err := m.Groups[len(m.Groups)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.EvaluationDuration, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1465,7 +2679,7 @@ func (m *RulesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GroupStateDesc) Unmarshal(dAtA []byte) error {
+func (m *RuleEvaluationDesc) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1488,15 +2702,15 @@ func (m *GroupStateDesc) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GroupStateDesc: wiretype end group for non-group")
+			return fmt.Errorf("proto: RuleEvaluationDesc: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GroupStateDesc: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RuleEvaluationDesc: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1523,16 +2737,13 @@ func (m *GroupStateDesc) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Group == nil {
-				m.Group = &rules.RuleGroupDesc{}
-			}
-			if err := m.Group.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActiveRules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1559,16 +2770,15 @@ func (m *GroupStateDesc) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ActiveRules = append(m.ActiveRules, &RuleStateDesc{})
-			if err := m.ActiveRules[len(m.ActiveRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Duration, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EvaluationTimestamp", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
 			}
-			var msglen int
+			m.Samples = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRuler
@@ -1578,30 +2788,16 @@ func (m *GroupStateDesc) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Samples |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRuler
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRuler
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.EvaluationTimestamp, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EvaluationDuration", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRuler
@@ -1611,24 +2807,23 @@ func (m *GroupStateDesc) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRuler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRuler
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.EvaluationDuration, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1654,7 +2849,7 @@ func (m *GroupStateDesc) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RuleStateDesc) Unmarshal(dAtA []byte) error {
+func (m *RuleGroupEvaluationHistoryRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1677,51 +2872,15 @@ func (m *RuleStateDesc) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RuleStateDesc: wiretype end group for non-group")
+			return fmt.Errorf("proto: RuleGroupEvaluationHistoryRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RuleStateDesc: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RuleGroupEvaluationHistoryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Rule", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRuler
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRuler
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRuler
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Rule == nil {
-				m.Rule = &rules.RuleDesc{}
-			}
-			if err := m.Rule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1749,11 +2908,11 @@ func (m *RuleStateDesc) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.State = string(dAtA[iNdEx:postIndex])
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Health", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1781,43 +2940,64 @@ func (m *RuleStateDesc) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Health = string(dAtA[iNdEx:postIndex])
+			m.GroupName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastError", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRuler
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRuler(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRuler
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRuler
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LastError = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RuleGroupEvaluationHistoryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRuler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RuleGroupEvaluationHistoryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RuleGroupEvaluationHistoryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Alerts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1844,14 +3024,16 @@ func (m *RuleStateDesc) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Alerts = append(m.Alerts, &AlertStateDesc{})
-			if err := m.Alerts[len(m.Alerts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Group == nil {
+				m.Group = &GroupStateDesc{}
+			}
+			if err := m.Group.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EvaluationTimestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1878,13 +3060,67 @@ func (m *RuleStateDesc) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.EvaluationTimestamp, dAtA[iNdEx:postIndex]); err != nil {
+			m.Rules = append(m.Rules, &RuleEvaluationHistoryDesc{})
+			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRuler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRuler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RuleEvaluationHistoryDesc) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRuler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RuleEvaluationHistoryDesc: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RuleEvaluationHistoryDesc: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EvaluationDuration", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Evaluations", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1911,7 +3147,8 @@ func (m *RuleStateDesc) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.EvaluationDuration, dAtA[iNdEx:postIndex]); err != nil {
+			m.Evaluations = append(m.Evaluations, &RuleEvaluationDesc{})
+			if err := m.Evaluations[len(m.Evaluations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io/ioutil"
 	"math/rand"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -25,7 +26,9 @@ import (
 	"github.com/prometheus/prometheus/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/middleware"
 	"github.com/weaveworks/common/user"
+	"google.golang.org/grpc"
 
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/ring"
@@ -36,6 +39,8 @@ import (
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/flagext"
 	"github.com/cortexproject/cortex/pkg/util/services"
+	"github.com/cortexproject/cortex/pkg/util/test"
+	"github.com/cortexproject/cortex/pkg/util/validation"
 )
 
 func defaultRulerConfig(store rules.RuleStore) (Config, func()) {
@@ -63,10 +68,12 @@ func defaultRulerConfig(store rules.RuleStore) (Config, func()) {
 }
 
 type ruleLimits struct {
-	evalDelay            time.Duration
-	tenantShard          int
-	maxRulesPerRuleGroup int
-	maxRuleGroups        int
+	evalDelay                     time.Duration
+	tenantShard                   int
+	maxRulesPerRuleGroup          int
+	maxRuleGroups                 int
+	alertmanagerConfig            validation.RulerAlertmanagerConfig
+	maxIndependentRuleConcurrency int
 }
 
 func (r ruleLimits) EvaluationDelay(_ string) time.Duration {
@@ -85,6 +92,14 @@ func (r ruleLimits) RulerMaxRulesPerRuleGroup(_ string) int {
 	return r.maxRulesPerRuleGroup
 }
 
+func (r ruleLimits) RulerAlertmanagerConfig(_ string) validation.RulerAlertmanagerConfig {
+	return r.alertmanagerConfig
+}
+
+func (r ruleLimits) RulerMaxIndependentRuleEvaluationConcurrency(_ string) int {
+	return r.maxIndependentRuleConcurrency
+}
+
 func testSetup(t *testing.T, cfg Config) (*promql.Engine, storage.QueryableFunc, Pusher, log.Logger, RulesLimits, func()) {
 	dir, err := ioutil.TempDir("", filepath.Base(t.Name()))
 	assert.NoError(t, err)
@@ -116,7 +131,7 @@ func testSetup(t *testing.T, cfg Config) (*promql.Engine, storage.QueryableFunc,
 
 func newManager(t *testing.T, cfg Config) (*DefaultMultiTenantManager, func()) {
 	engine, noopQueryable, pusher, logger, overrides, cleanup := testSetup(t, cfg)
-	manager, err := NewDefaultMultiTenantManager(cfg, DefaultTenantManagerFactory(cfg, pusher, noopQueryable, engine, overrides), prometheus.NewRegistry(), logger)
+	manager, err := NewDefaultMultiTenantManager(cfg, DefaultTenantManagerFactory(cfg, pusher, noopQueryable, engine, nil, overrides), overrides, prometheus.NewRegistry(), logger)
 	require.NoError(t, err)
 
 	return manager, cleanup
@@ -128,8 +143,8 @@ func newRuler(t *testing.T, cfg Config) (*Ruler, func()) {
 	require.NoError(t, err)
 
 	reg := prometheus.NewRegistry()
-	managerFactory := DefaultTenantManagerFactory(cfg, pusher, noopQueryable, engine, overrides)
-	manager, err := NewDefaultMultiTenantManager(cfg, managerFactory, reg, util.Logger)
+	managerFactory := DefaultTenantManagerFactory(cfg, pusher, noopQueryable, engine, nil, overrides)
+	manager, err := NewDefaultMultiTenantManager(cfg, managerFactory, overrides, reg, util.Logger)
 	require.NoError(t, err)
 
 	ruler, err := NewRuler(
@@ -139,6 +154,7 @@ func newRuler(t *testing.T, cfg Config) (*Ruler, func()) {
 		logger,
 		storage,
 		overrides,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -202,6 +218,32 @@ func TestNotifierSendsUserIDHeader(t *testing.T) {
 	`), "cortex_prometheus_notifications_dropped_total"))
 }
 
+type mockCloser struct {
+	closed int
+}
+
+func (c *mockCloser) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestRuler_ShouldCloseQueryFrontendConnWhenStopping(t *testing.T) {
+	cfg, cleanup := defaultRulerConfig(newMockRuleStore(nil))
+	defer cleanup()
+
+	r, rcleanup := newRuler(t, cfg)
+	defer rcleanup()
+
+	conn := &mockCloser{}
+	r.queryFrontendConn = conn
+
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), r))
+	assert.Equal(t, 0, conn.closed)
+
+	require.NoError(t, services.StopAndAwaitTerminated(context.Background(), r))
+	assert.Equal(t, 1, conn.closed)
+}
+
 func TestRuler_Rules(t *testing.T) {
 	cfg, cleanup := defaultRulerConfig(newMockRuleStore(mockRules))
 	defer cleanup()
@@ -239,6 +281,77 @@ func compareRuleGroupDescToStateDesc(t *testing.T, expected *rules.RuleGroupDesc
 	}
 }
 
+func TestRuler_GetRuleGroupEvaluationHistoryFromOwner(t *testing.T) {
+	// The owner of the rule group evaluates all the rules and is reached via gRPC.
+	ownerCfg, ownerCleanup := defaultRulerConfig(newMockRuleStore(mockRules))
+	defer ownerCleanup()
+
+	owner, rcleanup := newTestRuler(t, ownerCfg)
+	defer rcleanup()
+	defer services.StopAndAwaitTerminated(context.Background(), owner) //nolint:errcheck
+
+	serv := grpc.NewServer(grpc.UnaryInterceptor(middleware.ServerUserHeaderInterceptor))
+	defer serv.GracefulStop()
+	RegisterRulerServer(serv, owner)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() {
+		_ = serv.Serve(listener)
+	}()
+
+	// Simulate an evaluation of the recording rule of the group on the owner.
+	mngr := getManager(owner.manager.(*DefaultMultiTenantManager), "user1").(*evaluationHistoryManager)
+	groups := mngr.RuleGroups()
+	require.Len(t, groups, 1)
+
+	ctx := promql.NewOriginContext(context.Background(), map[string]interface{}{
+		"ruleGroup": map[string]string{"file": groups[0].File(), "name": groups[0].Name()},
+	})
+	_, err = mngr.recorder.wrap(func(_ context.Context, _ string, _ time.Time) (promql.Vector, error) {
+		return promql.Vector{{}}, nil
+	})(ctx, "up", time.Now())
+	require.NoError(t, err)
+
+	// The ruler receiving the request doesn't evaluate any rule and asks the owner found in the ring.
+	kvStore := consul.NewInMemoryClient(ring.GetCodec())
+	cfg, cleanup := defaultRulerConfig(newMockRuleStore(nil))
+	defer cleanup()
+	cfg.EnableSharding = true
+	cfg.Ring.KVStore = kv.Config{Mock: kvStore}
+	cfg.Ring.HeartbeatTimeout = time.Minute
+
+	r, rcleanup := newRuler(t, cfg)
+	defer rcleanup()
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), r.ring))
+	defer r.ring.StopAsync()
+
+	token := tokenForGroup(&rules.RuleGroupDesc{User: "user1", Namespace: "namespace1", Name: "group1"})
+	require.NoError(t, kvStore.CAS(context.Background(), ring.RulerRingKey, func(in interface{}) (out interface{}, retry bool, err error) {
+		d := ring.NewDesc()
+		d.AddIngester("owner", listener.Addr().String(), "", []uint32{token + 1}, ring.ACTIVE, time.Now())
+		d.AddIngester("other", "localhost:1", "", []uint32{token + 2}, ring.ACTIVE, time.Now())
+		return d, true, nil
+	}))
+	test.Poll(t, time.Second, true, func() interface{} {
+		return r.ring.HasInstance("owner")
+	})
+
+	resp, err := r.GetRuleGroupEvaluationHistory(user.InjectOrgID(context.Background(), "user1"), "namespace1", "group1")
+	require.NoError(t, err)
+	require.NotNil(t, resp.Group)
+	assert.Equal(t, "group1", resp.Group.Group.Name)
+	require.Len(t, resp.Rules, len(resp.Group.ActiveRules))
+	require.Len(t, resp.Rules[0].Evaluations, 1)
+	assert.Equal(t, int64(1), resp.Rules[0].Evaluations[0].Samples)
+	assert.Empty(t, resp.Rules[1].Evaluations)
+
+	// A rule group not evaluated by the owner should not be returned.
+	resp, err = r.GetRuleGroupEvaluationHistory(user.InjectOrgID(context.Background(), "user1"), "namespace1", "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, resp.Group)
+}
+
 func TestSharding(t *testing.T) {
 	const (
 		user1 = "user1"
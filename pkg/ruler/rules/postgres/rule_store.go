@@ -0,0 +1,672 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres" // Import the postgres migrations driver
+	_ "github.com/golang-migrate/migrate/v4/source/file"       // Import the file migrations source
+	_ "github.com/lib/pq"                                      // Import the postgres sql driver
+	"github.com/pkg/errors"
+
+	"github.com/cortexproject/cortex/pkg/ruler/rules"
+)
+
+const (
+	// AnyVersion can be used as expected version to update a rule group or namespace
+	// regardless of its current version.
+	AnyVersion = rules.AnyVersion
+
+	// Table tracking the migrations applied to the rule store schema. It's not the
+	// migrate default one, so that the rules can be stored in the configs database.
+	migrationsTable = "ruler_schema_migrations"
+
+	// Timeout waiting for the database connection to be established.
+	dbTimeout = 5 * time.Minute
+
+	// Key space of the advisory locks taken on the tenants, so that they don't collide with
+	// the advisory locks taken by someone else on the same database.
+	tenantLockKeySpace = 0x52554c45 // "RULE"
+)
+
+var statementBuilder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+// Config configures the Postgres rule store.
+type Config struct {
+	URI           string `yaml:"uri"`
+	MigrationsDir string `yaml:"migrations_dir"`
+	PasswordFile  string `yaml:"password_file"`
+}
+
+// RegisterFlagsWithPrefix registers flags with prefix.
+func (cfg *Config) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
+	f.StringVar(&cfg.URI, prefix+"postgres.uri", "", "URI of the Postgres database where the rules are stored, in the form postgres://user@host/database?sslmode=disable.")
+	f.StringVar(&cfg.MigrationsDir, prefix+"postgres.migrations-dir", "", "Path where the database migration files of the rule store can be found. If set, the migrations are run on startup.")
+	f.StringVar(&cfg.PasswordFile, prefix+"postgres.password-file", "", "File containing the password of the database user (username goes in the URI).")
+}
+
+// RuleStore is a rules.RuleStore storing the rule groups in a Postgres database. Each rule group
+// and namespace has a version, incremented on every change, which can be used to update them
+// only if they have not been modified since they have been read.
+type RuleStore struct {
+	db     *sql.DB
+	logger log.Logger
+}
+
+// NewRuleStore connects to the database, runs the migrations if configured and returns a new RuleStore.
+func NewRuleStore(cfg Config, logger log.Logger) (*RuleStore, error) {
+	uri, err := buildURI(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("postgres", uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot open postgres db")
+	}
+
+	if err := dbWait(db, logger); err != nil {
+		return nil, errors.Wrap(err, "cannot establish db connection")
+	}
+
+	if cfg.MigrationsDir != "" {
+		if err := runMigrations(uri, cfg.MigrationsDir, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	return &RuleStore{db: db, logger: logger}, nil
+}
+
+func buildURI(cfg Config) (string, error) {
+	u, err := url.Parse(cfg.URI)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "postgres" {
+		return "", fmt.Errorf("unsupported database type %q, the URI must start with postgres://", u.Scheme)
+	}
+
+	if cfg.PasswordFile != "" {
+		if u.User == nil {
+			return "", errors.New("the postgres password file requires the username in the URI")
+		}
+		password, err := ioutil.ReadFile(cfg.PasswordFile)
+		if err != nil {
+			return "", errors.Wrap(err, "could not read the postgres password file")
+		}
+		u.User = url.UserPassword(u.User.Username(), strings.TrimSpace(string(password)))
+	}
+
+	return u.String(), nil
+}
+
+// dbWait waits for database connection to be established.
+func dbWait(db *sql.DB, logger log.Logger) error {
+	deadline := time.Now().Add(dbTimeout)
+	var err error
+	for tries := 0; time.Now().Before(deadline); tries++ {
+		err = db.Ping()
+		if err == nil {
+			return nil
+		}
+		level.Warn(logger).Log("msg", "db connection not established, retrying...", "err", err)
+		time.Sleep(time.Second << uint(tries))
+	}
+	return errors.Wrapf(err, "db connection not established after %s", dbTimeout)
+}
+
+func runMigrations(uri, migrationsDir string, logger log.Logger) error {
+	// Add file scheme if no scheme is present
+	if !strings.HasPrefix(migrationsDir, "file:") {
+		migrationsDir = "file:" + migrationsDir
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	query := u.Query()
+	query.Set("x-migrations-table", migrationsTable)
+	u.RawQuery = query.Encode()
+
+	m, err := migrate.New(migrationsDir, u.String())
+	if err != nil {
+		return errors.Wrap(err, "database migrations initialization failed")
+	}
+
+	level.Info(logger).Log("msg", "running rule store database migrations...")
+
+	if err := m.Up(); err != nil {
+		if err != migrate.ErrNoChange {
+			return errors.Wrap(err, "database migrations failed")
+		}
+		level.Debug(logger).Log("msg", "no change in schema, error (ignored)", "err", err)
+	}
+	return nil
+}
+
+// ListAllUsers implements rules.RuleStore.
+func (s *RuleStore) ListAllUsers(ctx context.Context) ([]string, error) {
+	query, args, err := statementBuilder.Select("DISTINCT user_id").
+		From("rule_groups").
+		OrderBy("user_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		users = append(users, userID)
+	}
+
+	return users, rows.Err()
+}
+
+// ListAllRuleGroups implements rules.RuleStore. The returned groups are not loaded.
+func (s *RuleStore) ListAllRuleGroups(ctx context.Context) (map[string]rules.RuleGroupList, error) {
+	return s.listRuleGroups(ctx, squirrel.And{})
+}
+
+// ListRuleGroupsForUserAndNamespace implements rules.RuleStore. The returned groups are not loaded.
+func (s *RuleStore) ListRuleGroupsForUserAndNamespace(ctx context.Context, userID string, namespace string) (rules.RuleGroupList, error) {
+	filter := squirrel.Eq{"user_id": userID}
+	if namespace != "" {
+		filter["namespace"] = namespace
+	}
+
+	groups, err := s.listRuleGroups(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return groups[userID], nil
+}
+
+func (s *RuleStore) listRuleGroups(ctx context.Context, filter squirrel.Sqlizer) (map[string]rules.RuleGroupList, error) {
+	query, args, err := statementBuilder.Select("user_id", "namespace", "name").
+		From("rule_groups").
+		Where(filter).
+		OrderBy("user_id", "namespace", "name").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[string]rules.RuleGroupList{}
+	for rows.Next() {
+		group := &rules.RuleGroupDesc{}
+		if err := rows.Scan(&group.User, &group.Namespace, &group.Name); err != nil {
+			return nil, err
+		}
+		result[group.User] = append(result[group.User], group)
+	}
+
+	return result, rows.Err()
+}
+
+// LoadRuleGroups implements rules.RuleStore, loading the rule groups of each user with a single query.
+func (s *RuleStore) LoadRuleGroups(ctx context.Context, groupsToLoad map[string]rules.RuleGroupList) error {
+	for userID, groups := range groupsToLoad {
+		query, args, err := statementBuilder.Select("namespace", "name", "rule_group").
+			From("rule_groups").
+			Where(squirrel.Eq{"user_id": userID}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+
+		loaded := map[string]*rules.RuleGroupDesc{}
+		err = func() error {
+			rows, err := s.db.QueryContext(ctx, query, args...)
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+
+			for rows.Next() {
+				var namespace, name string
+				var data []byte
+				if err := rows.Scan(&namespace, &name, &data); err != nil {
+					return err
+				}
+
+				group, err := decodeRuleGroup(data)
+				if err != nil {
+					return errors.Wrapf(err, "failed to decode rule group, user: %s, namespace: %s, group: %s", userID, namespace, name)
+				}
+				loaded[groupKey(namespace, name)] = group
+			}
+			return rows.Err()
+		}()
+		if err != nil {
+			return err
+		}
+
+		for _, g := range groups {
+			group, ok := loaded[groupKey(g.Namespace, g.Name)]
+			if !ok {
+				return errors.Wrapf(rules.ErrGroupNotFound, "failed to load rule group, user: %s, namespace: %s, group: %s", userID, g.Namespace, g.Name)
+			}
+			*g = *group
+		}
+	}
+
+	return nil
+}
+
+// GetRuleGroup implements rules.RuleStore.
+func (s *RuleStore) GetRuleGroup(ctx context.Context, userID, namespace, group string) (*rules.RuleGroupDesc, error) {
+	rg, _, err := s.GetRuleGroupWithVersion(ctx, userID, namespace, group)
+	return rg, err
+}
+
+// GetRuleGroupWithVersion returns a rule group and its current version.
+func (s *RuleStore) GetRuleGroupWithVersion(ctx context.Context, userID, namespace, group string) (*rules.RuleGroupDesc, int64, error) {
+	query, args, err := statementBuilder.Select("rule_group", "version").
+		From("rule_groups").
+		Where(squirrel.Eq{"user_id": userID, "namespace": namespace, "name": group}).
+		ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var data []byte
+	var version int64
+	if err := s.db.QueryRowContext(ctx, query, args...).Scan(&data, &version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, rules.ErrGroupNotFound
+		}
+		return nil, 0, err
+	}
+
+	rg, err := decodeRuleGroup(data)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to decode rule group, user: %s, namespace: %s, group: %s", userID, namespace, group)
+	}
+	return rg, version, nil
+}
+
+// SetRuleGroup implements rules.RuleStore.
+func (s *RuleStore) SetRuleGroup(ctx context.Context, userID, namespace string, group *rules.RuleGroupDesc) error {
+	_, err := s.SetRuleGroupWithVersion(ctx, userID, namespace, group, AnyVersion)
+	return err
+}
+
+// SetRuleGroupWithVersion creates or updates a rule group if its current version matches the expected
+// one, and returns its new version. The expected version of a rule group not existing yet is 0. If the
+// version doesn't match, rules.ErrVersionConflict is returned.
+func (s *RuleStore) SetRuleGroupWithVersion(ctx context.Context, userID, namespace string, group *rules.RuleGroupDesc, expectedVersion int64) (int64, error) {
+	data, err := proto.Marshal(group)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	err = s.transaction(ctx, func(tx *sql.Tx) error {
+		if err := lockTenant(ctx, tx, userID); err != nil {
+			return err
+		}
+		if _, err := bumpNamespaceVersion(ctx, tx, userID, namespace, AnyVersion); err != nil {
+			return err
+		}
+
+		current, err := ruleGroupVersion(ctx, tx, userID, namespace, group.Name)
+		if err != nil {
+			return err
+		}
+		if expectedVersion != AnyVersion && expectedVersion != current {
+			return rules.ErrVersionConflict
+		}
+
+		version, err = upsertRuleGroup(ctx, tx, userID, namespace, group.Name, data)
+		return err
+	})
+
+	return version, err
+}
+
+// DeleteRuleGroup implements rules.RuleStore.
+func (s *RuleStore) DeleteRuleGroup(ctx context.Context, userID, namespace string, group string) error {
+	return s.transaction(ctx, func(tx *sql.Tx) error {
+		if err := lockTenant(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		query, args, err := statementBuilder.Delete("rule_groups").
+			Where(squirrel.Eq{"user_id": userID, "namespace": namespace, "name": group}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if deleted, err := res.RowsAffected(); err != nil {
+			return err
+		} else if deleted == 0 {
+			return rules.ErrGroupNotFound
+		}
+
+		_, err = bumpNamespaceVersion(ctx, tx, userID, namespace, AnyVersion)
+		return err
+	})
+}
+
+// DeleteNamespace implements rules.RuleStore.
+func (s *RuleStore) DeleteNamespace(ctx context.Context, userID, namespace string) error {
+	_, _, err := s.replaceNamespace(ctx, userID, namespace, nil, AnyVersion, nil)
+	return err
+}
+
+// NamespaceVersion returns the current version of a namespace, or 0 if the namespace has never been created.
+func (s *RuleStore) NamespaceVersion(ctx context.Context, userID, namespace string) (int64, error) {
+	query, args, err := statementBuilder.Select("version").
+		From("rule_namespaces").
+		Where(squirrel.Eq{"user_id": userID, "namespace": namespace}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	if err := s.db.QueryRowContext(ctx, query, args...).Scan(&version); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return version, nil
+}
+
+// ReplaceNamespace atomically replaces all the rule groups of a namespace with the input ones, regardless
+// of the namespace version, and returns the rule groups it replaced.
+func (s *RuleStore) ReplaceNamespace(ctx context.Context, userID, namespace string, groups rules.RuleGroupList) (rules.RuleGroupList, error) {
+	previous, _, err := s.ReplaceNamespaceWithVersion(ctx, userID, namespace, groups, AnyVersion, nil)
+	return previous, err
+}
+
+// ReplaceNamespaceWithVersion implements rules.NamespaceReplacer. The expected version of a namespace
+// never created is 0.
+func (s *RuleStore) ReplaceNamespaceWithVersion(ctx context.Context, userID, namespace string, groups rules.RuleGroupList, expectedVersion int64, validate func(otherGroups int) error) (rules.RuleGroupList, int64, error) {
+	previous, version, err := s.replaceNamespace(ctx, userID, namespace, groups, expectedVersion, validate)
+	if err == rules.ErrGroupNamespaceNotFound {
+		// Nothing has been changed, so the namespace version is still the current one.
+		version, err = s.NamespaceVersion(ctx, userID, namespace)
+		return nil, version, err
+	}
+	return previous, version, err
+}
+
+// replaceNamespace replaces the rule groups of a namespace and returns the replaced ones along with
+// the new version of the namespace. Replacing a namespace without rule groups with no rule groups
+// returns rules.ErrGroupNamespaceNotFound, without changing the namespace version.
+func (s *RuleStore) replaceNamespace(ctx context.Context, userID, namespace string, groups rules.RuleGroupList, expectedVersion int64, validate func(otherGroups int) error) (rules.RuleGroupList, int64, error) {
+	encoded := make(map[string][]byte, len(groups))
+	for _, g := range groups {
+		if _, ok := encoded[g.Name]; ok {
+			return nil, 0, fmt.Errorf("duplicate rule group %q in namespace %q", g.Name, namespace)
+		}
+
+		data, err := proto.Marshal(g)
+		if err != nil {
+			return nil, 0, err
+		}
+		encoded[g.Name] = data
+	}
+
+	var (
+		previous rules.RuleGroupList
+		version  int64
+	)
+	err := s.transaction(ctx, func(tx *sql.Tx) error {
+		// The tenant is locked, so that the rule groups of the other namespaces can't change
+		// before the end of the transaction while validating the limits.
+		if err := lockTenant(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		var err error
+		if version, err = bumpNamespaceVersion(ctx, tx, userID, namespace, expectedVersion); err != nil {
+			return err
+		}
+
+		if validate != nil {
+			otherGroups, err := countOtherRuleGroups(ctx, tx, userID, namespace)
+			if err != nil {
+				return err
+			}
+			if err := validate(otherGroups); err != nil {
+				return err
+			}
+		}
+
+		query, args, err := statementBuilder.Delete("rule_groups").
+			Where(squirrel.Eq{"user_id": userID, "namespace": namespace}).
+			Suffix("RETURNING rule_group").
+			ToSql()
+		if err != nil {
+			return err
+		}
+
+		if previous, err = queryRuleGroups(ctx, tx, query, args); err != nil {
+			return err
+		}
+		if len(previous) == 0 && len(groups) == 0 {
+			return rules.ErrGroupNamespaceNotFound
+		}
+
+		for name, data := range encoded {
+			if _, err := upsertRuleGroup(ctx, tx, userID, namespace, name, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return previous, version, nil
+}
+
+// transaction runs f in a transaction, which is rolled back if f returns an error.
+func (s *RuleStore) transaction(ctx context.Context, f func(*sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := f(tx); err != nil {
+		// Rollback error is ignored as we already have one in progress
+		if err2 := tx.Rollback(); err2 != nil {
+			level.Warn(s.logger).Log("msg", "transaction rollback error (ignored)", "err", err2)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// Close closes the connections to the database.
+func (s *RuleStore) Close() error {
+	return s.db.Close()
+}
+
+// lockTenant takes an advisory lock on the tenant, released at the end of the transaction, so that
+// the changes to the rule groups of a tenant are serialized, even when the namespace doesn't exist yet.
+func lockTenant(ctx context.Context, tx *sql.Tx, userID string) error {
+	query, args, err := statementBuilder.Select().
+		Column(squirrel.Expr("pg_advisory_xact_lock(?, hashtext(?))", tenantLockKeySpace, userID)).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// bumpNamespaceVersion increments the version of the namespace, creating it if it doesn't exist, and
+// returns the new version. The namespace row stays locked until the end of the transaction.
+func bumpNamespaceVersion(ctx context.Context, tx *sql.Tx, userID, namespace string, expectedVersion int64) (int64, error) {
+	// The namespace is created first, so that there's always a row to lock. A namespace created
+	// with version 0 is either bumped below or removed when the transaction is rolled back.
+	query, args, err := statementBuilder.Insert("rule_namespaces").
+		Columns("user_id", "namespace", "version").
+		Values(userID, namespace, 0).
+		Suffix("ON CONFLICT (user_id, namespace) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return 0, err
+	}
+
+	query, args, err = statementBuilder.Select("version").
+		From("rule_namespaces").
+		Where(squirrel.Eq{"user_id": userID, "namespace": namespace}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var current int64
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&current); err != nil {
+		return 0, err
+	}
+	if expectedVersion != AnyVersion && expectedVersion != current {
+		return 0, rules.ErrVersionConflict
+	}
+
+	query, args, err = statementBuilder.Update("rule_namespaces").
+		Set("version", squirrel.Expr("version + 1")).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"user_id": userID, "namespace": namespace}).
+		Suffix("RETURNING version").
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&version)
+	return version, err
+}
+
+// countOtherRuleGroups returns the number of rule groups of the tenant in the namespaces other than the input one.
+func countOtherRuleGroups(ctx context.Context, tx *sql.Tx, userID, namespace string) (int, error) {
+	query, args, err := statementBuilder.Select("COUNT(*)").
+		From("rule_groups").
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.NotEq{"namespace": namespace}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// ruleGroupVersion returns the current version of a rule group, or 0 if it doesn't exist.
+func ruleGroupVersion(ctx context.Context, tx *sql.Tx, userID, namespace, group string) (int64, error) {
+	query, args, err := statementBuilder.Select("version").
+		From("rule_groups").
+		Where(squirrel.Eq{"user_id": userID, "namespace": namespace, "name": group}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&version); err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return version, nil
+}
+
+// upsertRuleGroup creates or updates a rule group, and returns its new version.
+func upsertRuleGroup(ctx context.Context, tx *sql.Tx, userID, namespace, group string, data []byte) (int64, error) {
+	query, args, err := statementBuilder.Insert("rule_groups").
+		Columns("user_id", "namespace", "name", "rule_group", "version").
+		Values(userID, namespace, group, data, 1).
+		Suffix("ON CONFLICT (user_id, namespace, name) DO UPDATE SET rule_group = EXCLUDED.rule_group, version = rule_groups.version + 1, updated_at = now() RETURNING version").
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&version)
+	return version, err
+}
+
+// queryRuleGroups runs the query returning the encoded rule groups and decodes them.
+func queryRuleGroups(ctx context.Context, tx *sql.Tx, query string, args []interface{}) (rules.RuleGroupList, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result rules.RuleGroupList
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		rg, err := decodeRuleGroup(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode rule group")
+		}
+		result = append(result, rg)
+	}
+
+	return result, rows.Err()
+}
+
+func decodeRuleGroup(data []byte) (*rules.RuleGroupDesc, error) {
+	rg := &rules.RuleGroupDesc{}
+	if err := proto.Unmarshal(data, rg); err != nil {
+		return nil, err
+	}
+	return rg, nil
+}
+
+func groupKey(namespace, name string) string {
+	return namespace + "/" + name
+}
@@ -0,0 +1,265 @@
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/ruler/rules"
+)
+
+func setupRuleStore(t *testing.T) *RuleStore {
+	dbAddr := os.Getenv("DB_ADDR")
+	if dbAddr == "" {
+		dbAddr = "127.0.0.1"
+	}
+
+	migrationsDir := os.Getenv("MIGRATIONS_DIR")
+	if migrationsDir == "" {
+		migrationsDir = "/migrations"
+	}
+
+	store, err := NewRuleStore(Config{
+		URI:           fmt.Sprintf("postgres://postgres@%s/configs_test?sslmode=disable", dbAddr),
+		MigrationsDir: migrationsDir + "/ruler",
+	}, log.NewNopLogger())
+	require.NoError(t, err)
+
+	_, err = store.db.Exec("TRUNCATE rule_groups, rule_namespaces")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	return store
+}
+
+func ruleGroup(userID, namespace, name string) *rules.RuleGroupDesc {
+	return &rules.RuleGroupDesc{
+		User:      userID,
+		Namespace: namespace,
+		Name:      name,
+		Rules:     []*rules.RuleDesc{{Record: name + ":record", Expr: "up"}},
+	}
+}
+
+func TestRuleStore_SetGetAndList(t *testing.T) {
+	ctx := context.Background()
+	store := setupRuleStore(t)
+
+	require.NoError(t, store.SetRuleGroup(ctx, "user-1", "ns-1", ruleGroup("user-1", "ns-1", "group-1")))
+	require.NoError(t, store.SetRuleGroup(ctx, "user-1", "ns-2", ruleGroup("user-1", "ns-2", "group-2")))
+	require.NoError(t, store.SetRuleGroup(ctx, "user-2", "ns-1", ruleGroup("user-2", "ns-1", "group-1")))
+
+	users, err := store.ListAllUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-1", "user-2"}, users)
+
+	list, err := store.ListRuleGroupsForUserAndNamespace(ctx, "user-1", "ns-2")
+	require.NoError(t, err)
+	assert.Equal(t, rules.RuleGroupList{{User: "user-1", Namespace: "ns-2", Name: "group-2"}}, list)
+
+	all, err := store.ListAllRuleGroups(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all["user-1"], 2)
+	assert.Len(t, all["user-2"], 1)
+
+	require.NoError(t, store.LoadRuleGroups(ctx, all))
+	assert.Equal(t, ruleGroup("user-1", "ns-1", "group-1"), all["user-1"][0])
+
+	rg, err := store.GetRuleGroup(ctx, "user-2", "ns-1", "group-1")
+	require.NoError(t, err)
+	assert.Equal(t, ruleGroup("user-2", "ns-1", "group-1"), rg)
+
+	_, err = store.GetRuleGroup(ctx, "user-2", "ns-1", "missing")
+	assert.Equal(t, rules.ErrGroupNotFound, err)
+
+	require.NoError(t, store.DeleteRuleGroup(ctx, "user-2", "ns-1", "group-1"))
+	assert.Equal(t, rules.ErrGroupNotFound, store.DeleteRuleGroup(ctx, "user-2", "ns-1", "group-1"))
+
+	users, err = store.ListAllUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-1"}, users)
+}
+
+func TestRuleStore_OptimisticConcurrency(t *testing.T) {
+	ctx := context.Background()
+	store := setupRuleStore(t)
+
+	// A rule group not existing yet can be created only with the expected version 0.
+	_, err := store.SetRuleGroupWithVersion(ctx, "user-1", "ns", ruleGroup("user-1", "ns", "group"), 1)
+	assert.Equal(t, rules.ErrVersionConflict, err)
+
+	version, err := store.SetRuleGroupWithVersion(ctx, "user-1", "ns", ruleGroup("user-1", "ns", "group"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+
+	version, err = store.SetRuleGroupWithVersion(ctx, "user-1", "ns", ruleGroup("user-1", "ns", "group"), version)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+
+	// Updating with a stale version fails and leaves the group unchanged.
+	_, err = store.SetRuleGroupWithVersion(ctx, "user-1", "ns", ruleGroup("user-1", "ns", "other"), 1)
+	require.NoError(t, err)
+	_, err = store.SetRuleGroupWithVersion(ctx, "user-1", "ns", ruleGroup("user-1", "ns", "group"), 1)
+	assert.Equal(t, rules.ErrVersionConflict, err)
+
+	_, version, err = store.GetRuleGroupWithVersion(ctx, "user-1", "ns", "group")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+}
+
+func TestRuleStore_ReplaceNamespace(t *testing.T) {
+	ctx := context.Background()
+	store := setupRuleStore(t)
+
+	version, err := store.NamespaceVersion(ctx, "user-1", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+
+	_, version, err = store.ReplaceNamespaceWithVersion(ctx, "user-1", "ns", rules.RuleGroupList{
+		ruleGroup("user-1", "ns", "group-1"),
+		ruleGroup("user-1", "ns", "group-2"),
+	}, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+
+	// Replacing the namespace with a stale version fails and leaves the namespace unchanged.
+	_, _, err = store.ReplaceNamespaceWithVersion(ctx, "user-1", "ns", rules.RuleGroupList{ruleGroup("user-1", "ns", "group-3")}, 0, nil)
+	assert.Equal(t, rules.ErrVersionConflict, err)
+
+	// A validation failure leaves the namespace unchanged too.
+	require.NoError(t, store.SetRuleGroup(ctx, "user-1", "other", ruleGroup("user-1", "other", "group-1")))
+	errLimit := errors.New("limit exceeded")
+	_, _, err = store.ReplaceNamespaceWithVersion(ctx, "user-1", "ns", rules.RuleGroupList{ruleGroup("user-1", "ns", "group-3")}, version, func(otherGroups int) error {
+		assert.Equal(t, 1, otherGroups)
+		return errLimit
+	})
+	assert.Equal(t, errLimit, err)
+	require.NoError(t, store.DeleteNamespace(ctx, "user-1", "other"))
+
+	_, version, err = store.ReplaceNamespaceWithVersion(ctx, "user-1", "ns", rules.RuleGroupList{ruleGroup("user-1", "ns", "group-3")}, version, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+
+	list, err := store.ListRuleGroupsForUserAndNamespace(ctx, "user-1", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, rules.RuleGroupList{{User: "user-1", Namespace: "ns", Name: "group-3"}}, list)
+
+	// Any change to the rule groups of the namespace changes its version.
+	require.NoError(t, store.SetRuleGroup(ctx, "user-1", "ns", ruleGroup("user-1", "ns", "group-4")))
+	version, err = store.NamespaceVersion(ctx, "user-1", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), version)
+
+	require.NoError(t, store.DeleteNamespace(ctx, "user-1", "ns"))
+	assert.Equal(t, rules.ErrGroupNamespaceNotFound, store.DeleteNamespace(ctx, "user-1", "ns"))
+
+	list, err = store.ListRuleGroupsForUserAndNamespace(ctx, "user-1", "ns")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	// The replaced rule groups are returned.
+	previous, err := store.ReplaceNamespace(ctx, "user-1", "ns", rules.RuleGroupList{ruleGroup("user-1", "ns", "group-1")})
+	require.NoError(t, err)
+	assert.Empty(t, previous)
+
+	previous, err = store.ReplaceNamespace(ctx, "user-1", "ns", nil)
+	require.NoError(t, err)
+	assert.Equal(t, rules.RuleGroupList{ruleGroup("user-1", "ns", "group-1")}, previous)
+
+	previous, err = store.ReplaceNamespace(ctx, "user-1", "ns", nil)
+	require.NoError(t, err)
+	assert.Empty(t, previous)
+}
+
+func TestRuleStore_ConcurrentChanges(t *testing.T) {
+	const concurrency = 10
+	ctx := context.Background()
+	store := setupRuleStore(t)
+
+	// runConcurrently runs f concurrently and returns the number of calls succeeded. The calls
+	// not succeeded are expected to fail with expectedErr.
+	runConcurrently := func(expectedErr error, f func(i int) error) int {
+		var (
+			wg        sync.WaitGroup
+			mtx       sync.Mutex
+			succeeded int
+		)
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				err := f(i)
+				if err != nil {
+					assert.Equal(t, expectedErr, err)
+					return
+				}
+
+				mtx.Lock()
+				succeeded++
+				mtx.Unlock()
+			}(i)
+		}
+		wg.Wait()
+		return succeeded
+	}
+
+	// Only one of the concurrent creations of a namespace not existing yet should succeed.
+	succeeded := runConcurrently(rules.ErrVersionConflict, func(i int) error {
+		_, _, err := store.ReplaceNamespaceWithVersion(ctx, "user-1", "ns", rules.RuleGroupList{ruleGroup("user-1", "ns", fmt.Sprintf("group-%d", i))}, 0, nil)
+		return err
+	})
+	assert.Equal(t, 1, succeeded)
+
+	version, err := store.NamespaceVersion(ctx, "user-1", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+
+	list, err := store.ListRuleGroupsForUserAndNamespace(ctx, "user-1", "ns")
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	// Only one of the concurrent updates of a namespace with the same expected version should succeed.
+	succeeded = runConcurrently(rules.ErrVersionConflict, func(i int) error {
+		_, _, err := store.ReplaceNamespaceWithVersion(ctx, "user-1", "ns", rules.RuleGroupList{ruleGroup("user-1", "ns", fmt.Sprintf("group-%d", i))}, version, nil)
+		return err
+	})
+	assert.Equal(t, 1, succeeded)
+
+	// Only one of the concurrent creations of a rule group not existing yet should succeed.
+	succeeded = runConcurrently(rules.ErrVersionConflict, func(i int) error {
+		_, err := store.SetRuleGroupWithVersion(ctx, "user-1", "other", ruleGroup("user-1", "other", "group"), 0)
+		return err
+	})
+	assert.Equal(t, 1, succeeded)
+
+	// The validation of the concurrent changes to different namespaces of a tenant should see the
+	// rule groups of each other, so that the tenant limits can't be exceeded.
+	errLimit := errors.New("limit exceeded")
+	succeeded = runConcurrently(errLimit, func(i int) error {
+		namespace := fmt.Sprintf("limited-%d", i)
+		_, _, err := store.ReplaceNamespaceWithVersion(ctx, "user-2", namespace, rules.RuleGroupList{ruleGroup("user-2", namespace, "group")}, 0, func(otherGroups int) error {
+			if otherGroups > 0 {
+				return errLimit
+			}
+			return nil
+		})
+		return err
+	})
+	assert.Equal(t, 1, succeeded)
+
+	all, err := store.ListAllRuleGroups(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all["user-2"], 1)
+}
@@ -17,6 +17,8 @@ var (
 	ErrGroupNamespaceNotFound = errors.New("group namespace does not exist")
 	// ErrUserNotFound is returned if the user does not currently exist
 	ErrUserNotFound = errors.New("no rule groups found for user")
+	// ErrVersionConflict is returned if a rule group or namespace has been modified since the expected version
+	ErrVersionConflict = errors.New("rule group or namespace version conflict")
 )
 
 // RuleStore is used to store and retrieve rules.
@@ -40,6 +42,23 @@ type RuleStore interface {
 	DeleteNamespace(ctx context.Context, userID, namespace string) error
 }
 
+// AnyVersion can be used as expected version to update a rule group or namespace regardless of its current version.
+const AnyVersion int64 = -1
+
+// NamespaceReplacer is implemented by the RuleStore supporting the atomic replacement of all the rule groups of a namespace.
+type NamespaceReplacer interface {
+	// ReplaceNamespaceWithVersion atomically replaces all the rule groups of a namespace with the input ones,
+	// if the current version of the namespace matches the expected one, and returns the rule groups it replaced
+	// along with the new version of the namespace. Replacing a namespace with no rule groups deletes it. If the
+	// version doesn't match, ErrVersionConflict is returned. If validate is not nil, it's called within the same
+	// transaction with the number of rule groups of the tenant in the other namespaces, and the namespace is left
+	// unchanged if it returns an error.
+	ReplaceNamespaceWithVersion(ctx context.Context, userID, namespace string, groups RuleGroupList, expectedVersion int64, validate func(otherGroups int) error) (RuleGroupList, int64, error)
+
+	// NamespaceVersion returns the current version of a namespace, or 0 if the namespace has never been created.
+	NamespaceVersion(ctx context.Context, userID, namespace string) (int64, error)
+}
+
 // RuleGroupList contains a set of rule groups
 type RuleGroupList []*RuleGroupDesc
 
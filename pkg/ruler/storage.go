@@ -17,6 +17,8 @@ import (
 	"github.com/cortexproject/cortex/pkg/ruler/rules"
 	"github.com/cortexproject/cortex/pkg/ruler/rules/local"
 	"github.com/cortexproject/cortex/pkg/ruler/rules/objectclient"
+	"github.com/cortexproject/cortex/pkg/ruler/rules/postgres"
+	"github.com/cortexproject/cortex/pkg/util"
 )
 
 // RuleStoreConfig configures a rule store.
@@ -31,6 +33,9 @@ type RuleStoreConfig struct {
 	Swift openstack.SwiftConfig   `yaml:"swift"`
 	Local local.Config            `yaml:"local"`
 
+	// Database Configs
+	Postgres postgres.Config `yaml:"postgres"`
+
 	mock rules.RuleStore `yaml:"-"`
 }
 
@@ -42,8 +47,9 @@ func (cfg *RuleStoreConfig) RegisterFlags(f *flag.FlagSet) {
 	cfg.S3.RegisterFlagsWithPrefix("ruler.storage.", f)
 	cfg.Swift.RegisterFlagsWithPrefix("ruler.storage.", f)
 	cfg.Local.RegisterFlagsWithPrefix("ruler.storage.", f)
+	cfg.Postgres.RegisterFlagsWithPrefix("ruler.storage.", f)
 
-	f.StringVar(&cfg.Type, "ruler.storage.type", "configdb", "Method to use for backend rule storage (configdb, azure, gcs, s3, swift, local)")
+	f.StringVar(&cfg.Type, "ruler.storage.type", "configdb", "Method to use for backend rule storage (configdb, azure, gcs, s3, swift, local, postgres)")
 }
 
 // Validate config and returns error on failure
@@ -94,8 +100,10 @@ func NewRuleStorage(cfg RuleStoreConfig, loader promRules.GroupLoader) (rules.Ru
 		return newObjRuleStore(openstack.NewSwiftObjectClient(cfg.Swift))
 	case "local":
 		return local.NewLocalRulesClient(cfg.Local, loader)
+	case "postgres":
+		return postgres.NewRuleStore(cfg.Postgres, util.Logger)
 	default:
-		return nil, fmt.Errorf("Unrecognized rule storage mode %v, choose one of: configdb, gcs, s3, swift, azure, local", cfg.Type)
+		return nil, fmt.Errorf("Unrecognized rule storage mode %v, choose one of: configdb, gcs, s3, swift, azure, local, postgres", cfg.Type)
 	}
 }
 
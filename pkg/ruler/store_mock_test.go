@@ -10,8 +10,9 @@ import (
 )
 
 type mockRuleStore struct {
-	rules map[string]rules.RuleGroupList
-	mtx   sync.Mutex
+	rules    map[string]rules.RuleGroupList
+	versions map[string]int64
+	mtx      sync.Mutex
 }
 
 var (
@@ -111,7 +112,8 @@ var (
 
 func newMockRuleStore(rules map[string]rules.RuleGroupList) *mockRuleStore {
 	return &mockRuleStore{
-		rules: rules,
+		rules:    rules,
+		versions: map[string]int64{},
 	}
 }
 
@@ -270,3 +272,44 @@ func (m *mockRuleStore) DeleteNamespace(ctx context.Context, userID, namespace s
 
 	return nil
 }
+
+func (m *mockRuleStore) ReplaceNamespaceWithVersion(ctx context.Context, userID, namespace string, groups rules.RuleGroupList, expectedVersion int64, validate func(otherGroups int) error) (rules.RuleGroupList, int64, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if namespace == "" {
+		return nil, 0, rules.ErrGroupNamespaceNotFound
+	}
+
+	key := userID + "/" + namespace
+	if expectedVersion != rules.AnyVersion && expectedVersion != m.versions[key] {
+		return nil, 0, rules.ErrVersionConflict
+	}
+
+	var previous rules.RuleGroupList
+	updated := rules.RuleGroupList{}
+	for _, rg := range m.rules[userID] {
+		if rg.Namespace == namespace {
+			previous = append(previous, rg)
+			continue
+		}
+		updated = append(updated, rg)
+	}
+
+	if validate != nil {
+		if err := validate(len(updated)); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	m.rules[userID] = append(updated, groups...)
+	m.versions[key]++
+	return previous, m.versions[key], nil
+}
+
+func (m *mockRuleStore) NamespaceVersion(ctx context.Context, userID, namespace string) (int64, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.versions[userID+"/"+namespace], nil
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/scheduler/queue"
+	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/httpheaders"
+	"github.com/cortexproject/cortex/pkg/util/validation"
+)
+
+// getRequestPriority returns the priority of the request in the queue of the tenant. The priority is
+// taken from the httpheaders.QueryPriority header, if set to a valid priority. Otherwise queries with
+// a time range longer than the low priority query range of the tenant get the low priority, and the
+// other requests get the default priority of the tenant.
+func (s *Scheduler) getRequestPriority(req *httpgrpc.HTTPRequest, tenantIDs []string) queue.Priority {
+	r, err := http.NewRequest(req.Method, req.Url, ioutil.NopCloser(bytes.NewReader(req.Body)))
+	if err != nil {
+		return s.getDefaultPriority(tenantIDs)
+	}
+	for _, h := range req.Headers {
+		for _, v := range h.Values {
+			r.Header.Add(h.Key, v)
+		}
+	}
+
+	if value := r.Header.Get(httpheaders.QueryPriority); value != "" {
+		if priority, err := queue.ParsePriority(value); err == nil {
+			return priority
+		}
+	}
+
+	lowPriorityQueryRange := validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, s.limits.QuerySchedulerLowPriorityQueryRange)
+	if lowPriorityQueryRange > 0 {
+		if queryRange, ok := getQueryRange(r); ok && queryRange > lowPriorityQueryRange {
+			return queue.PriorityLow
+		}
+	}
+
+	return s.getDefaultPriority(tenantIDs)
+}
+
+// getDefaultPriority returns the lowest default priority of the tenants. Invalid default priorities
+// are considered as queue.PriorityNormal.
+func (s *Scheduler) getDefaultPriority(tenantIDs []string) queue.Priority {
+	result := queue.PriorityHigh
+	for _, tenantID := range tenantIDs {
+		priority, err := queue.ParsePriority(s.limits.QuerySchedulerDefaultPriority(tenantID))
+		if err != nil {
+			priority = queue.PriorityNormal
+		}
+		if priority > result {
+			result = priority
+		}
+	}
+	return result
+}
+
+// getQueryRange returns the time range (end - start) of the query, read from the URL
+// parameters or the form in the body. Returns false if the request has no valid time range.
+func getQueryRange(r *http.Request) (time.Duration, bool) {
+	if err := r.ParseForm(); err != nil {
+		return 0, false
+	}
+
+	start, err := util.ParseTime(r.Form.Get("start"))
+	if err != nil {
+		return 0, false
+	}
+	end, err := util.ParseTime(r.Form.Get("end"))
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(end-start) * time.Millisecond, true
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/scheduler/queue"
+	"github.com/cortexproject/cortex/pkg/util/httpheaders"
+)
+
+func TestScheduler_GetRequestPriority(t *testing.T) {
+	tests := map[string]struct {
+		limits    limits
+		request   *httpgrpc.HTTPRequest
+		tenantIDs []string
+		expected  queue.Priority
+	}{
+		"default priority of the tenant": {
+			limits:    limits{defaultPriority: "high"},
+			request:   &httpgrpc.HTTPRequest{Method: "GET", Url: "/api/v1/query_range?query=up&start=0&end=86400&step=60"},
+			tenantIDs: []string{"user-1"},
+			expected:  queue.PriorityHigh,
+		},
+		"invalid default priority of the tenant": {
+			limits:    limits{defaultPriority: "urgent"},
+			request:   &httpgrpc.HTTPRequest{Method: "GET", Url: "/api/v1/query?query=up"},
+			tenantIDs: []string{"user-1"},
+			expected:  queue.PriorityNormal,
+		},
+		"priority set by the request header": {
+			limits: limits{defaultPriority: "normal", lowPriorityQueryRange: time.Hour},
+			request: &httpgrpc.HTTPRequest{
+				Method:  "GET",
+				Url:     "/api/v1/query_range?query=up&start=0&end=86400&step=60",
+				Headers: []*httpgrpc.Header{{Key: httpheaders.QueryPriority, Values: []string{"high"}}},
+			},
+			tenantIDs: []string{"user-1"},
+			expected:  queue.PriorityHigh,
+		},
+		"invalid priority set by the request header": {
+			limits: limits{defaultPriority: "normal"},
+			request: &httpgrpc.HTTPRequest{
+				Method:  "GET",
+				Url:     "/api/v1/query?query=up",
+				Headers: []*httpgrpc.Header{{Key: httpheaders.QueryPriority, Values: []string{"urgent"}}},
+			},
+			tenantIDs: []string{"user-1"},
+			expected:  queue.PriorityNormal,
+		},
+		"query range longer than the low priority query range": {
+			limits:    limits{defaultPriority: "normal", lowPriorityQueryRange: time.Hour},
+			request:   &httpgrpc.HTTPRequest{Method: "GET", Url: "/api/v1/query_range?query=up&start=0&end=86400&step=60"},
+			tenantIDs: []string{"user-1"},
+			expected:  queue.PriorityLow,
+		},
+		"query range shorter than the low priority query range": {
+			limits:    limits{defaultPriority: "normal", lowPriorityQueryRange: time.Hour},
+			request:   &httpgrpc.HTTPRequest{Method: "GET", Url: "/api/v1/query_range?query=up&start=0&end=1800&step=60"},
+			tenantIDs: []string{"user-1"},
+			expected:  queue.PriorityNormal,
+		},
+		"query range in the POST form": {
+			limits: limits{defaultPriority: "normal", lowPriorityQueryRange: time.Hour},
+			request: &httpgrpc.HTTPRequest{
+				Method:  "POST",
+				Url:     "/api/v1/query_range",
+				Headers: []*httpgrpc.Header{{Key: "Content-Type", Values: []string{"application/x-www-form-urlencoded"}}},
+				Body:    []byte("query=up&start=2021-01-01T00:00:00Z&end=2021-01-02T00:00:00Z&step=60"),
+			},
+			tenantIDs: []string{"user-1"},
+			expected:  queue.PriorityLow,
+		},
+		"lowest default priority of multiple tenants": {
+			limits:    limits{defaultPriority: "low"},
+			request:   &httpgrpc.HTTPRequest{Method: "GET", Url: "/api/v1/query?query=up"},
+			tenantIDs: []string{"user-1", "user-2"},
+			expected:  queue.PriorityLow,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			s := &Scheduler{limits: testData.limits}
+			assert.Equal(t, testData.expected, s.getRequestPriority(testData.request, testData.tenantIDs))
+		})
+	}
+}
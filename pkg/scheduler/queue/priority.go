@@ -0,0 +1,107 @@
+package queue
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Priority of a request in the queue of its user. Requests with higher priority are dequeued first,
+// according to the configured PriorityPolicy.
+type Priority int
+
+const (
+	PriorityHigh Priority = iota
+	PriorityNormal
+	PriorityLow
+
+	numPriorities = 3
+)
+
+var priorityNames = [numPriorities]string{"high", "normal", "low"}
+
+func (p Priority) String() string {
+	if p < 0 || p >= numPriorities {
+		return fmt.Sprintf("unknown(%d)", int(p))
+	}
+	return priorityNames[p]
+}
+
+// ParsePriority parses the name of a priority, like "high", "normal" or "low".
+func ParsePriority(name string) (Priority, error) {
+	for p, n := range priorityNames {
+		if strings.EqualFold(name, n) {
+			return Priority(p), nil
+		}
+	}
+	return PriorityNormal, fmt.Errorf("unknown priority %q, supported values are: %s", name, strings.Join(priorityNames[:], ", "))
+}
+
+// Supported policies to pick the priority of the next request dequeued for a user.
+const (
+	// PriorityPolicyStrict always dequeues the requests with the highest priority first,
+	// so lower priority requests are only dequeued when there are no higher priority ones.
+	PriorityPolicyStrict = "strict"
+
+	// PriorityPolicyWeighted dequeues the requests of each priority in a weighted round-robin fashion,
+	// so lower priority requests are never starved by a constant flow of higher priority ones.
+	PriorityPolicyWeighted = "weighted"
+)
+
+var (
+	supportedPriorityPolicies = []string{PriorityPolicyWeighted, PriorityPolicyStrict}
+
+	errUnsupportedPriorityPolicy = errors.New("unsupported priority policy")
+	errInvalidPriorityWeight     = errors.New("the priority weights must be greater than 0")
+)
+
+// PriorityConfig configures how the requests of a user are dequeued, based on their priority.
+type PriorityConfig struct {
+	Policy       string `yaml:"policy"`
+	HighWeight   int    `yaml:"high_weight"`
+	NormalWeight int    `yaml:"normal_weight"`
+	LowWeight    int    `yaml:"low_weight"`
+}
+
+// RegisterFlagsWithPrefix registers the flags of the priority config, with the given prefix.
+func (cfg *PriorityConfig) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
+	f.StringVar(&cfg.Policy, prefix+".policy", PriorityPolicyWeighted, fmt.Sprintf("Policy used to pick the priority of the next request dequeued for a tenant. Supported values are: %s. The %s policy dequeues the requests of each priority in a round-robin fashion, proportionally to the priority weights, so low priority requests are never starved. The %s policy always dequeues the higher priority requests first.", strings.Join(supportedPriorityPolicies, ", "), PriorityPolicyWeighted, PriorityPolicyStrict))
+	f.IntVar(&cfg.HighWeight, prefix+".high-weight", 4, "Number of high priority requests dequeued for a tenant in each round, when using the weighted priority policy.")
+	f.IntVar(&cfg.NormalWeight, prefix+".normal-weight", 2, "Number of normal priority requests dequeued for a tenant in each round, when using the weighted priority policy.")
+	f.IntVar(&cfg.LowWeight, prefix+".low-weight", 1, "Number of low priority requests dequeued for a tenant in each round, when using the weighted priority policy.")
+}
+
+// Validate the priority config and returns an error if the validation doesn't pass.
+func (cfg *PriorityConfig) Validate() error {
+	switch cfg.Policy {
+	case PriorityPolicyStrict:
+		return nil
+	case PriorityPolicyWeighted:
+		if cfg.HighWeight <= 0 || cfg.NormalWeight <= 0 || cfg.LowWeight <= 0 {
+			return errInvalidPriorityWeight
+		}
+		return nil
+	default:
+		return errUnsupportedPriorityPolicy
+	}
+}
+
+func (cfg *PriorityConfig) weight(p Priority) int {
+	var w int
+	switch p {
+	case PriorityHigh:
+		w = cfg.HighWeight
+	case PriorityNormal:
+		w = cfg.NormalWeight
+	case PriorityLow:
+		w = cfg.LowWeight
+	}
+
+	// Guarantee progress even if the config has not been validated.
+	if w <= 0 {
+		return 1
+	}
+	return w
+}
@@ -0,0 +1,53 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriority(t *testing.T) {
+	for _, p := range []Priority{PriorityHigh, PriorityNormal, PriorityLow} {
+		parsed, err := ParsePriority(p.String())
+		require.NoError(t, err)
+		assert.Equal(t, p, parsed)
+	}
+
+	parsed, err := ParsePriority("LOW")
+	require.NoError(t, err)
+	assert.Equal(t, PriorityLow, parsed)
+
+	_, err = ParsePriority("urgent")
+	assert.Error(t, err)
+}
+
+func TestPriorityConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		cfg      PriorityConfig
+		expected error
+	}{
+		"strict policy": {
+			cfg:      PriorityConfig{Policy: PriorityPolicyStrict},
+			expected: nil,
+		},
+		"weighted policy": {
+			cfg:      PriorityConfig{Policy: PriorityPolicyWeighted, HighWeight: 4, NormalWeight: 2, LowWeight: 1},
+			expected: nil,
+		},
+		"weighted policy with a zero weight": {
+			cfg:      PriorityConfig{Policy: PriorityPolicyWeighted, HighWeight: 4, NormalWeight: 2, LowWeight: 0},
+			expected: errInvalidPriorityWeight,
+		},
+		"unsupported policy": {
+			cfg:      PriorityConfig{Policy: "random"},
+			expected: errUnsupportedPriorityPolicy,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testData.expected, testData.cfg.Validate())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package queue
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -18,10 +19,18 @@ var (
 // of RequestQueue.GetNextRequestForQuerier method.
 type UserIndex struct {
 	last int
+
+	// Number of requests consecutively dequeued for the last user, used to give each user
+	// a number of turns proportional to its weight.
+	turns int
 }
 
 // Modify index to start iteration on the same user, for which last queue was returned.
+// The request dequeued for the user in the last call doesn't count as one of its turns.
 func (ui UserIndex) ReuseLastUser() UserIndex {
+	if ui.turns > 1 {
+		return UserIndex{last: ui.last, turns: ui.turns - 1}
+	}
 	if ui.last >= 0 {
 		return UserIndex{last: ui.last - 1}
 	}
@@ -47,14 +56,21 @@ type RequestQueue struct {
 	queues  *queues
 	stopped bool
 
-	queueLength *prometheus.GaugeVec // Per user.
+	priorityCfg PriorityConfig
+
+	queueLength         *prometheus.GaugeVec // Per user.
+	priorityQueueLength *prometheus.GaugeVec // Per priority. Optional.
 }
 
-func NewRequestQueue(maxOutstandingPerTenant int, queueLength *prometheus.GaugeVec) *RequestQueue {
+// NewRequestQueue creates a new RequestQueue. The priorityQueueLength metric is optional, and tracks the
+// number of queued requests per priority if not nil.
+func NewRequestQueue(maxOutstandingPerTenant int, priorityCfg PriorityConfig, queueLength, priorityQueueLength *prometheus.GaugeVec) *RequestQueue {
 	q := &RequestQueue{
 		queues:                  newUserQueues(maxOutstandingPerTenant),
 		connectedQuerierWorkers: atomic.NewInt32(0),
+		priorityCfg:             priorityCfg,
 		queueLength:             queueLength,
+		priorityQueueLength:     priorityQueueLength,
 	}
 
 	q.cond = sync.NewCond(&q.mtx)
@@ -62,12 +78,13 @@ func NewRequestQueue(maxOutstandingPerTenant int, queueLength *prometheus.GaugeV
 	return q
 }
 
-// Puts the request into the queue. MaxQueries is user-specific value that specifies how many queriers can
-// this user use (zero or negative = all queriers). It is passed to each EnqueueRequest, because it can change
-// between calls.
+// Puts the request into the queue, with the given priority. MaxQueries is user-specific value that specifies
+// how many queriers can this user use (zero or negative = all queriers). Weight is user-specific value that
+// specifies how many turns this user gets, compared to the other users, when queriers ask for the next
+// request (zero or negative = 1). Both are passed to each EnqueueRequest, because they can change between calls.
 //
 // If request is successfully enqueued, successFn is called with the lock held, before any querier can receive the request.
-func (q *RequestQueue) EnqueueRequest(userID string, req Request, maxQueriers int, successFn func()) error {
+func (q *RequestQueue) EnqueueRequest(userID string, req Request, priority Priority, maxQueriers, weight int, successFn func()) error {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
 
@@ -75,24 +92,36 @@ func (q *RequestQueue) EnqueueRequest(userID string, req Request, maxQueriers in
 		return ErrStopped
 	}
 
-	queue := q.queues.getOrAddQueue(userID, maxQueriers)
+	if priority < 0 || priority >= numPriorities {
+		return fmt.Errorf("invalid priority %d", priority)
+	}
+
+	queue := q.queues.getOrAddQueue(userID, maxQueriers, weight)
 	if queue == nil {
 		// This can only happen if userID is "".
 		return errors.New("no queue found")
 	}
 
-	select {
-	case queue <- req:
-		q.queueLength.WithLabelValues(userID).Inc()
-		q.cond.Broadcast()
-		// Call this function while holding a lock. This guarantees that no querier can fetch the request before function returns.
-		if successFn != nil {
-			successFn()
+	// The max outstanding requests limit applies to the user queue, across all priorities.
+	if queue.len() >= q.queues.maxUserQueueSize {
+		// Don't keep an empty queue, it would be returned to the queriers looking for requests.
+		if queue.len() == 0 {
+			q.queues.deleteQueue(userID)
 		}
-		return nil
-	default:
 		return ErrTooManyRequests
 	}
+
+	queue.getOrAddChannel(priority, q.queues.maxUserQueueSize) <- req
+	q.queueLength.WithLabelValues(userID).Inc()
+	if q.priorityQueueLength != nil {
+		q.priorityQueueLength.WithLabelValues(priority.String()).Inc()
+	}
+	q.cond.Broadcast()
+	// Call this function while holding a lock. This guarantees that no querier can fetch the request before function returns.
+	if successFn != nil {
+		successFn()
+	}
+	return nil
 }
 
 // GetNextRequestForQuerier find next user queue and takes the next request off of it. Will block if there are no requests.
@@ -120,20 +149,31 @@ FindQueue:
 	}
 
 	for {
-		queue, userID, idx := q.queues.getNextQueueForQuerier(last.last, querierID)
-		last.last = idx
+		queue, userID, idx := q.queues.getNextQueueForQuerier(last.last, last.turns, querierID)
 		if queue == nil {
+			last = UserIndex{last: idx}
 			break
 		}
 
+		if idx == last.last && last.turns > 0 && last.turns < queue.weight {
+			last.turns++
+		} else {
+			last.turns = 1
+		}
+		last.last = idx
+
 		// Pick next request from the queue.
 		for {
-			request := <-queue
-			if len(queue) == 0 {
+			priority := queue.nextPriority(q.priorityCfg)
+			request := <-queue.chs[priority]
+			if queue.len() == 0 {
 				q.queues.deleteQueue(userID)
 			}
 
 			q.queueLength.WithLabelValues(userID).Dec()
+			if q.priorityQueueLength != nil {
+				q.priorityQueueLength.WithLabelValues(priority.String()).Dec()
+			}
 
 			// Tell close() we've processed a request.
 			q.cond.Broadcast()
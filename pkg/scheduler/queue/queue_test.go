@@ -7,6 +7,9 @@ import (
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func BenchmarkGetNextRequest(b *testing.B) {
@@ -17,7 +20,7 @@ func BenchmarkGetNextRequest(b *testing.B) {
 	queues := make([]*RequestQueue, 0, b.N)
 
 	for n := 0; n < b.N; n++ {
-		queue := NewRequestQueue(maxOutstandingPerTenant, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}))
+		queue := NewRequestQueue(maxOutstandingPerTenant, PriorityConfig{Policy: PriorityPolicyStrict}, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
 		queues = append(queues, queue)
 
 		for ix := 0; ix < queriers; ix++ {
@@ -28,7 +31,7 @@ func BenchmarkGetNextRequest(b *testing.B) {
 			for j := 0; j < numTenants; j++ {
 				userID := strconv.Itoa(j)
 
-				err := queue.EnqueueRequest(userID, "request", 0, nil)
+				err := queue.EnqueueRequest(userID, "request", PriorityNormal, 0, 1, nil)
 				if err != nil {
 					b.Fatal(err)
 				}
@@ -71,7 +74,7 @@ func BenchmarkQueueRequest(b *testing.B) {
 	requests := make([]string, 0, numTenants)
 
 	for n := 0; n < b.N; n++ {
-		q := NewRequestQueue(maxOutstandingPerTenant, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}))
+		q := NewRequestQueue(maxOutstandingPerTenant, PriorityConfig{Policy: PriorityPolicyStrict}, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
 
 		for ix := 0; ix < queriers; ix++ {
 			q.RegisterQuerierConnection(fmt.Sprintf("querier-%d", ix))
@@ -89,7 +92,7 @@ func BenchmarkQueueRequest(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		for i := 0; i < maxOutstandingPerTenant; i++ {
 			for j := 0; j < numTenants; j++ {
-				err := queues[n].EnqueueRequest(users[j], requests[j], 0, nil)
+				err := queues[n].EnqueueRequest(users[j], requests[j], PriorityNormal, 0, 1, nil)
 				if err != nil {
 					b.Fatal(err)
 				}
@@ -97,3 +100,127 @@ func BenchmarkQueueRequest(b *testing.B) {
 		}
 	}
 }
+
+func TestRequestQueue_Priorities(t *testing.T) {
+	enqueue := func(t *testing.T, q *RequestQueue, reqs map[Priority][]string) {
+		for _, p := range []Priority{PriorityLow, PriorityNormal, PriorityHigh} {
+			for _, r := range reqs[p] {
+				require.NoError(t, q.EnqueueRequest("user-1", r, p, 0, 1, nil))
+			}
+		}
+	}
+
+	tests := map[string]struct {
+		cfg      PriorityConfig
+		requests map[Priority][]string
+		expected []string
+	}{
+		"strict policy dequeues the higher priorities first": {
+			cfg: PriorityConfig{Policy: PriorityPolicyStrict},
+			requests: map[Priority][]string{
+				PriorityHigh:   {"h1", "h2"},
+				PriorityNormal: {"n1", "n2"},
+				PriorityLow:    {"l1", "l2"},
+			},
+			expected: []string{"h1", "h2", "n1", "n2", "l1", "l2"},
+		},
+		"weighted policy dequeues the priorities proportionally to their weight": {
+			cfg: PriorityConfig{Policy: PriorityPolicyWeighted, HighWeight: 2, NormalWeight: 1, LowWeight: 1},
+			requests: map[Priority][]string{
+				PriorityHigh:   {"h1", "h2", "h3", "h4", "h5"},
+				PriorityNormal: {"n1", "n2"},
+				PriorityLow:    {"l1", "l2"},
+			},
+			expected: []string{"h1", "h2", "n1", "l1", "h3", "h4", "n2", "l2", "h5"},
+		},
+		"weighted policy doesn't wait for the empty priorities": {
+			cfg: PriorityConfig{Policy: PriorityPolicyWeighted, HighWeight: 1, NormalWeight: 1, LowWeight: 3},
+			requests: map[Priority][]string{
+				PriorityHigh: {"h1", "h2", "h3"},
+				PriorityLow:  {"l1"},
+			},
+			expected: []string{"h1", "l1", "h2", "h3"},
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			queueLength := prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"})
+			priorityQueueLength := prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"priority"})
+			q := NewRequestQueue(100, testData.cfg, queueLength, priorityQueueLength)
+			q.RegisterQuerierConnection("querier-1")
+
+			enqueue(t, q, testData.requests)
+			for _, p := range []Priority{PriorityHigh, PriorityNormal, PriorityLow} {
+				assert.Equal(t, float64(len(testData.requests[p])), testutil.ToFloat64(priorityQueueLength.WithLabelValues(p.String())))
+			}
+
+			var actual []string
+			last := FirstUser()
+			for range testData.expected {
+				req, idx, err := q.GetNextRequestForQuerier(context.Background(), last, "querier-1")
+				require.NoError(t, err)
+				actual = append(actual, req.(string))
+				last = idx
+			}
+
+			assert.Equal(t, testData.expected, actual)
+			assert.Equal(t, float64(0), testutil.ToFloat64(queueLength.WithLabelValues("user-1")))
+			for _, p := range []Priority{PriorityHigh, PriorityNormal, PriorityLow} {
+				assert.Equal(t, float64(0), testutil.ToFloat64(priorityQueueLength.WithLabelValues(p.String())))
+			}
+		})
+	}
+}
+
+func TestRequestQueue_MaxOutstandingRequestsAcrossPriorities(t *testing.T) {
+	q := NewRequestQueue(2, PriorityConfig{Policy: PriorityPolicyStrict}, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
+
+	require.NoError(t, q.EnqueueRequest("user-1", "r1", PriorityHigh, 0, 1, nil))
+	require.NoError(t, q.EnqueueRequest("user-1", "r2", PriorityLow, 0, 1, nil))
+	assert.Equal(t, ErrTooManyRequests, q.EnqueueRequest("user-1", "r3", PriorityNormal, 0, 1, nil))
+
+	// The limit is per user.
+	require.NoError(t, q.EnqueueRequest("user-2", "r4", PriorityNormal, 0, 1, nil))
+}
+
+func TestRequestQueue_WeightedFairQueuing(t *testing.T) {
+	q := NewRequestQueue(100, PriorityConfig{Policy: PriorityPolicyStrict}, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
+	q.RegisterQuerierConnection("querier-1")
+
+	weights := map[string]int{"user-1": 3, "user-2": 1}
+	for i := 0; i < 10; i++ {
+		for _, userID := range []string{"user-1", "user-2"} {
+			require.NoError(t, q.EnqueueRequest(userID, userID, PriorityNormal, 0, weights[userID], nil))
+		}
+	}
+
+	var actual []string
+	last := FirstUser()
+	for i := 0; i < 8; i++ {
+		req, idx, err := q.GetNextRequestForQuerier(context.Background(), last, "querier-1")
+		require.NoError(t, err)
+		actual = append(actual, req.(string))
+		last = idx
+	}
+	assert.Equal(t, []string{"user-1", "user-1", "user-1", "user-2", "user-1", "user-1", "user-1", "user-2"}, actual)
+
+	// Reusing the last user doesn't consume one of its turns.
+	req, idx, err := q.GetNextRequestForQuerier(context.Background(), last, "querier-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", req)
+
+	req, idx, err = q.GetNextRequestForQuerier(context.Background(), idx.ReuseLastUser(), "querier-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", req)
+
+	actual = nil
+	last = idx
+	for i := 0; i < 3; i++ {
+		req, idx, err := q.GetNextRequestForQuerier(context.Background(), last, "querier-1")
+		require.NoError(t, err)
+		actual = append(actual, req.(string))
+		last = idx
+	}
+	assert.Equal(t, []string{"user-1", "user-1", "user-2"}, actual)
+}
@@ -26,13 +26,22 @@ type queues struct {
 }
 
 type userQueue struct {
-	ch chan Request
+	// Pending requests of the user, by priority. Only the channel of the normal priority is
+	// allocated with the queue, the others are allocated when the first request is enqueued.
+	chs [numPriorities]chan Request
+
+	// Number of requests dequeued per priority in the current round, when using the weighted priority policy.
+	dequeued [numPriorities]int
 
 	// If not nil, only these queriers can handle user requests. If nil, all queriers can.
 	// We set this to nil if number of available queriers <= maxQueriers.
 	queriers    map[string]struct{}
 	maxQueriers int
 
+	// Number of consecutive requests dequeued for this user, when a querier reaches it
+	// while iterating over users. Always >= 1.
+	weight int
+
 	// Seed for shuffle sharding of queriers. This seed is based on userID only and is therefore consistent
 	// between different frontends.
 	seed int64
@@ -74,7 +83,8 @@ func (q *queues) deleteQueue(userID string) {
 // MaxQueriers is used to compute which queriers should handle requests for this user.
 // If maxQueriers is <= 0, all queriers can handle this user's requests.
 // If maxQueriers has changed since the last call, queriers for this are recomputed.
-func (q *queues) getOrAddQueue(userID string, maxQueriers int) chan Request {
+// Weight is the number of turns of this user when iterating over users. If weight is <= 0, 1 is used.
+func (q *queues) getOrAddQueue(userID string, maxQueriers, weight int) *userQueue {
 	// Empty user is not allowed, as that would break our users list ("" is used for free spot).
 	if userID == "" {
 		return nil
@@ -84,14 +94,18 @@ func (q *queues) getOrAddQueue(userID string, maxQueriers int) chan Request {
 		maxQueriers = 0
 	}
 
+	if weight < 1 {
+		weight = 1
+	}
+
 	uq := q.userQueues[userID]
 
 	if uq == nil {
 		uq = &userQueue{
-			ch:    make(chan Request, q.maxUserQueueSize),
 			seed:  util.ShuffleShardSeed(userID, ""),
 			index: -1,
 		}
+		uq.chs[PriorityNormal] = make(chan Request, q.maxUserQueueSize)
 		q.userQueues[userID] = uq
 
 		// Add user to the list of users... find first free spot, and put it there.
@@ -115,13 +129,26 @@ func (q *queues) getOrAddQueue(userID string, maxQueriers int) chan Request {
 		uq.queriers = shuffleQueriersForUser(uq.seed, maxQueriers, q.sortedQueriers, nil)
 	}
 
-	return uq.ch
+	uq.weight = weight
+
+	return uq
 }
 
 // Finds next queue for the querier. To support fair scheduling between users, client is expected
 // to pass last user index returned by this function as argument. Is there was no previous
-// last user index, use -1.
-func (q *queues) getNextQueueForQuerier(lastUserIndex int, querier string) (chan Request, string, int) {
+// last user index, use -1. To support weighted scheduling, client is also expected to pass the
+// number of turns the last user had in a row (0 if unknown): the last user is returned again
+// until its turns reach its weight, and only then the iteration moves to the next user.
+func (q *queues) getNextQueueForQuerier(lastUserIndex, lastUserTurns int, querier string) (*userQueue, string, int) {
+	if lastUserTurns > 0 && lastUserIndex >= 0 && lastUserIndex < len(q.users) {
+		if u := q.users[lastUserIndex]; u != "" {
+			uq := q.userQueues[u]
+			if lastUserTurns < uq.weight && uq.isHandledBy(querier) {
+				return uq, u, lastUserIndex
+			}
+		}
+	}
+
 	uid := lastUserIndex
 
 	for iters := 0; iters < len(q.users); iters++ {
@@ -140,18 +167,68 @@ func (q *queues) getNextQueueForQuerier(lastUserIndex int, querier string) (chan
 
 		q := q.userQueues[u]
 
-		if q.queriers != nil {
-			if _, ok := q.queriers[querier]; !ok {
-				// This querier is not handling the user.
-				continue
-			}
+		if !q.isHandledBy(querier) {
+			continue
 		}
 
-		return q.ch, u, uid
+		return q, u, uid
 	}
 	return nil, "", uid
 }
 
+// Returns whether the querier can handle the user requests.
+func (uq *userQueue) isHandledBy(querier string) bool {
+	if uq.queriers == nil {
+		return true
+	}
+	_, ok := uq.queriers[querier]
+	return ok
+}
+
+// Returns the channel of the pending requests of the user with the given priority, allocating it
+// with the given size if needed.
+func (uq *userQueue) getOrAddChannel(p Priority, size int) chan Request {
+	if uq.chs[p] == nil {
+		uq.chs[p] = make(chan Request, size)
+	}
+	return uq.chs[p]
+}
+
+// Returns the number of pending requests of the user, across all priorities.
+func (uq *userQueue) len() int {
+	n := 0
+	for _, ch := range uq.chs {
+		n += len(ch)
+	}
+	return n
+}
+
+// Returns the priority of the next request to dequeue for the user, which must have pending requests.
+func (uq *userQueue) nextPriority(cfg PriorityConfig) Priority {
+	if cfg.Policy == PriorityPolicyStrict {
+		for p, ch := range uq.chs {
+			if len(ch) > 0 {
+				return Priority(p)
+			}
+		}
+		return PriorityNormal
+	}
+
+	// Weighted round-robin: in each round, up to "weight" requests are dequeued for each priority,
+	// starting from the highest one. When all the priorities with pending requests have used their
+	// weight, a new round starts. This way the lower priorities get a share of the dequeued requests.
+	for round := 0; round < 2; round++ {
+		for p, ch := range uq.chs {
+			if len(ch) > 0 && uq.dequeued[p] < cfg.weight(Priority(p)) {
+				uq.dequeued[p]++
+				return Priority(p)
+			}
+		}
+		uq.dequeued = [numPriorities]int{}
+	}
+	return PriorityNormal
+}
+
 func (q *queues) addQuerierConnection(querier string) {
 	conns := q.querierConnections[querier]
 
@@ -17,7 +17,7 @@ func TestQueues(t *testing.T) {
 	assert.NotNil(t, uq)
 	assert.NoError(t, isConsistent(uq))
 
-	q, u, lastUserIndex := uq.getNextQueueForQuerier(-1, "querier-1")
+	q, u, lastUserIndex := uq.getNextQueueForQuerier(-1, 0, "querier-1")
 	assert.Nil(t, q)
 	assert.Equal(t, "", u)
 
@@ -27,7 +27,7 @@ func TestQueues(t *testing.T) {
 
 	// [one two]
 	qTwo := getOrAdd(t, uq, "two", 0)
-	assert.NotEqual(t, qOne, qTwo)
+	assert.NotSame(t, qOne, qTwo)
 
 	lastUserIndex = confirmOrderForQuerier(t, uq, "querier-1", lastUserIndex, qTwo, qOne, qTwo, qOne)
 	confirmOrderForQuerier(t, uq, "querier-2", -1, qOne, qTwo, qOne)
@@ -63,10 +63,75 @@ func TestQueues(t *testing.T) {
 	uq.deleteQueue("four")
 	assert.NoError(t, isConsistent(uq))
 
-	q, _, _ = uq.getNextQueueForQuerier(lastUserIndex, "querier-1")
+	q, _, _ = uq.getNextQueueForQuerier(lastUserIndex, 0, "querier-1")
 	assert.Nil(t, q)
 }
 
+func TestQueues_ShouldAllocateNonDefaultPriorityChannelsOnFirstRequest(t *testing.T) {
+	uq := newUserQueues(10)
+
+	q := uq.getOrAddQueue("user-1", 0, 1)
+	require.NotNil(t, q)
+	assert.Equal(t, 10, cap(q.chs[PriorityNormal]))
+	assert.Nil(t, q.chs[PriorityHigh])
+	assert.Nil(t, q.chs[PriorityLow])
+	assert.Equal(t, 0, q.len())
+
+	q.getOrAddChannel(PriorityLow, 10) <- "request"
+	assert.Nil(t, q.chs[PriorityHigh])
+	assert.Equal(t, 10, cap(q.chs[PriorityLow]))
+	assert.Equal(t, 1, q.len())
+	assert.Equal(t, PriorityLow, q.nextPriority(PriorityConfig{Policy: PriorityPolicyStrict}))
+
+	// The allocated channel should be reused.
+	assert.Equal(t, q.chs[PriorityLow], q.getOrAddChannel(PriorityLow, 10))
+}
+
+func TestQueuesWithWeights(t *testing.T) {
+	uq := newUserQueues(0)
+
+	qOne := uq.getOrAddQueue("one", 0, 3)
+	qTwo := uq.getOrAddQueue("two", 0, 0)
+	qThree := uq.getOrAddQueue("three", 0, 2)
+	assert.NoError(t, isConsistent(uq))
+	assert.Equal(t, 1, qTwo.weight)
+
+	// Each user is returned up to its weight times in a row.
+	lastUserIndex, turns := -1, 0
+	var actual []*userQueue
+	for i := 0; i < 12; i++ {
+		q, _, idx := uq.getNextQueueForQuerier(lastUserIndex, turns, "querier-1")
+		if idx == lastUserIndex && turns > 0 && turns < q.weight {
+			turns++
+		} else {
+			turns = 1
+		}
+		lastUserIndex = idx
+		actual = append(actual, q)
+	}
+	expected := []*userQueue{qOne, qOne, qOne, qTwo, qThree, qThree, qOne, qOne, qOne, qTwo, qThree, qThree}
+	for i := range expected {
+		assert.Same(t, expected[i], actual[i], "position %d", i)
+	}
+
+	// The weight is updated on each call.
+	uq.getOrAddQueue("one", 0, 1)
+	assert.Equal(t, 1, qOne.weight)
+	q, _, _ := uq.getNextQueueForQuerier(qOne.index, 1, "querier-1")
+	assert.Same(t, qTwo, q)
+
+	// Users not handled by the querier are never returned, even if the querier had turns left for them.
+	uq.addQuerierConnection("querier-1")
+	uq.addQuerierConnection("querier-2")
+	uq.getOrAddQueue("one", 1, 3)
+	querier := "querier-1"
+	if qOne.isHandledBy(querier) {
+		querier = "querier-2"
+	}
+	q, _, _ = uq.getNextQueueForQuerier(qOne.index, 1, querier)
+	assert.NotSame(t, qOne, q)
+}
+
 func TestQueuesWithQueriers(t *testing.T) {
 	uq := newUserQueues(0)
 	assert.NotNil(t, uq)
@@ -82,7 +147,7 @@ func TestQueuesWithQueriers(t *testing.T) {
 		uq.addQuerierConnection(qid)
 
 		// No querier has any queues yet.
-		q, u, _ := uq.getNextQueueForQuerier(-1, qid)
+		q, u, _ := uq.getNextQueueForQuerier(-1, 0, qid)
 		assert.Nil(t, q)
 		assert.Equal(t, "", u)
 	}
@@ -108,7 +173,7 @@ func TestQueuesWithQueriers(t *testing.T) {
 
 		lastUserIndex := -1
 		for {
-			_, _, newIx := uq.getNextQueueForQuerier(lastUserIndex, qid)
+			_, _, newIx := uq.getNextQueueForQuerier(lastUserIndex, 0, qid)
 			if newIx < lastUserIndex {
 				break
 			}
@@ -149,10 +214,10 @@ func TestQueuesConsistency(t *testing.T) {
 	for i := 0; i < 1000; i++ {
 		switch r.Int() % 6 {
 		case 0:
-			assert.NotNil(t, uq.getOrAddQueue(generateTenant(r), 3))
+			assert.NotNil(t, uq.getOrAddQueue(generateTenant(r), 3, 1))
 		case 1:
 			qid := generateQuerier(r)
-			_, _, luid := uq.getNextQueueForQuerier(lastUserIndexes[qid], qid)
+			_, _, luid := uq.getNextQueueForQuerier(lastUserIndexes[qid], 0, qid)
 			lastUserIndexes[qid] = luid
 		case 2:
 			uq.deleteQueue(generateTenant(r))
@@ -180,19 +245,19 @@ func generateQuerier(r *rand.Rand) string {
 	return fmt.Sprint("querier-", r.Int()%5)
 }
 
-func getOrAdd(t *testing.T, uq *queues, tenant string, maxQueriers int) chan Request {
-	q := uq.getOrAddQueue(tenant, maxQueriers)
+func getOrAdd(t *testing.T, uq *queues, tenant string, maxQueriers int) *userQueue {
+	q := uq.getOrAddQueue(tenant, maxQueriers, 1)
 	assert.NotNil(t, q)
 	assert.NoError(t, isConsistent(uq))
-	assert.Equal(t, q, uq.getOrAddQueue(tenant, maxQueriers))
+	assert.Same(t, q, uq.getOrAddQueue(tenant, maxQueriers, 1))
 	return q
 }
 
-func confirmOrderForQuerier(t *testing.T, uq *queues, querier string, lastUserIndex int, qs ...chan Request) int {
-	var n chan Request
+func confirmOrderForQuerier(t *testing.T, uq *queues, querier string, lastUserIndex int, qs ...*userQueue) int {
+	var n *userQueue
 	for _, q := range qs {
-		n, _, lastUserIndex = uq.getNextQueueForQuerier(lastUserIndex, querier)
-		assert.Equal(t, q, n)
+		n, _, lastUserIndex = uq.getNextQueueForQuerier(lastUserIndex, 0, querier)
+		assert.Same(t, q, n)
 		assert.NoError(t, isConsistent(uq))
 	}
 	return lastUserIndex
@@ -230,6 +295,10 @@ func isConsistent(uq *queues) error {
 			return fmt.Errorf("user %s has queriers set despite not enough queriers available", u)
 		}
 
+		if q.weight < 1 {
+			return fmt.Errorf("user %s has invalid weight %d", u, q.weight)
+		}
+
 		if q.maxQueriers > 0 && len(uq.sortedQueriers) > q.maxQueriers && len(q.queriers) != q.maxQueriers {
 			return fmt.Errorf("user %s has incorrect number of queriers, expected=%d, got=%d", u, len(q.queriers), q.maxQueriers)
 		}
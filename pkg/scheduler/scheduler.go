@@ -6,6 +6,8 @@ import (
 	"flag"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/weaveworks/common/user"
 	"google.golang.org/grpc"
 
+	"github.com/cortexproject/cortex/pkg/frontend/activequeries"
 	"github.com/cortexproject/cortex/pkg/frontend/v2/frontendv2pb"
 	"github.com/cortexproject/cortex/pkg/scheduler/queue"
 	"github.com/cortexproject/cortex/pkg/scheduler/schedulerpb"
@@ -74,27 +77,38 @@ type connectedFrontend struct {
 type Config struct {
 	MaxOutstandingPerTenant int `yaml:"max_outstanding_requests_per_tenant"`
 
+	Priority queue.PriorityConfig `yaml:"priority" doc:"description=Configures how the queued requests of a tenant are dequeued, based on their priority. The priority of a request is taken from the X-Cortex-Query-Priority header (high, normal or low) if set, otherwise queries with a time range longer than the tenant's -query-scheduler.low-priority-query-range get the low priority, and the other requests get the tenant's -query-scheduler.default-priority."`
+
 	GRPCClientConfig grpcclient.ConfigWithTLS `yaml:"grpc_client_config" doc:"description=This configures the gRPC client used to report errors back to the query-frontend."`
 }
 
 func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&cfg.MaxOutstandingPerTenant, "query-scheduler.max-outstanding-requests-per-tenant", 100, "Maximum number of outstanding requests per tenant per query-scheduler. In-flight requests above this limit will fail with HTTP response status code 429.")
+	cfg.Priority.RegisterFlagsWithPrefix("query-scheduler.priority", f)
 	cfg.GRPCClientConfig.RegisterFlagsWithPrefix("query-scheduler.grpc-client-config", f)
 }
 
+func (cfg *Config) Validate() error {
+	return cfg.Priority.Validate()
+}
+
 // NewScheduler creates a new Scheduler.
 func NewScheduler(cfg Config, limits Limits, log log.Logger, registerer prometheus.Registerer) (*Scheduler, error) {
 	queueLength := promauto.With(registerer).NewGaugeVec(prometheus.GaugeOpts{
 		Name: "cortex_query_scheduler_queue_length",
 		Help: "Number of queries in the queue.",
 	}, []string{"user"})
+	priorityQueueLength := promauto.With(registerer).NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cortex_query_scheduler_queue_length_by_priority",
+		Help: "Number of queries in the queue, by priority.",
+	}, []string{"priority"})
 
 	s := &Scheduler{
 		cfg:    cfg,
 		log:    log,
 		limits: limits,
 
-		requestQueue:       queue.NewRequestQueue(cfg.MaxOutstandingPerTenant, queueLength),
+		requestQueue:       queue.NewRequestQueue(cfg.MaxOutstandingPerTenant, cfg.Priority, queueLength, priorityQueueLength),
 		pendingRequests:    map[requestKey]*schedulerRequest{},
 		connectedFrontends: map[string]*connectedFrontend{},
 	}
@@ -121,6 +135,15 @@ func NewScheduler(cfg Config, limits Limits, log log.Logger, registerer promethe
 type Limits interface {
 	// Returns max queriers to use per tenant, or 0 if shuffle sharding is disabled.
 	MaxQueriersPerUser(user string) int
+
+	// Returns the priority of the tenant requests, when not set by the request itself.
+	QuerySchedulerDefaultPriority(user string) string
+
+	// Returns the query time range above which the tenant queries get the low priority, or 0 if disabled.
+	QuerySchedulerLowPriorityQueryRange(user string) time.Duration
+
+	// Returns the weight of the tenant, compared to the other tenants, when dispatching queries to queriers.
+	QuerySchedulerWeight(user string) int
 }
 
 type schedulerRequest struct {
@@ -132,6 +155,9 @@ type schedulerRequest struct {
 
 	enqueueTime time.Time
 
+	// ID of the querier the request has been dispatched to. Guarded by Scheduler.pendingRequestsMu.
+	querierID string
+
 	ctx       context.Context
 	ctxCancel context.CancelFunc
 	queueSpan opentracing.Span
@@ -281,8 +307,10 @@ func (s *Scheduler) enqueueRequest(frontendContext context.Context, frontendAddr
 		return err
 	}
 	maxQueriers := validation.SmallestPositiveNonZeroIntPerTenant(tenantIDs, s.limits.MaxQueriersPerUser)
+	weight := validation.SmallestPositiveNonZeroIntPerTenant(tenantIDs, s.limits.QuerySchedulerWeight)
+	priority := s.getRequestPriority(msg.HttpRequest, tenantIDs)
 
-	return s.requestQueue.EnqueueRequest(userID, req, maxQueriers, func() {
+	return s.requestQueue.EnqueueRequest(userID, req, priority, maxQueriers, weight, func() {
 		shouldCancel = false
 
 		s.pendingRequestsMu.Lock()
@@ -359,6 +387,10 @@ func (s *Scheduler) QuerierLoop(querier schedulerpb.SchedulerForQuerier_QuerierL
 			continue
 		}
 
+		s.pendingRequestsMu.Lock()
+		r.querierID = querierID
+		s.pendingRequestsMu.Unlock()
+
 		if err := s.forwardRequestToQuerier(querier, r); err != nil {
 			return err
 		}
@@ -431,13 +463,18 @@ func (s *Scheduler) forwardErrorToFrontend(ctx context.Context, req *schedulerRe
 
 	client := frontendv2pb.NewFrontendForQuerierClient(conn)
 
-	userCtx := user.InjectOrgID(ctx, req.userID)
-	_, err = client.QueryResult(userCtx, &frontendv2pb.QueryResultRequest{
-		QueryID: req.queryID,
-		HttpResponse: &httpgrpc.HTTPResponse{
+	resp, ok := httpgrpc.HTTPResponseFromError(requestErr)
+	if !ok {
+		resp = &httpgrpc.HTTPResponse{
 			Code: http.StatusInternalServerError,
 			Body: []byte(requestErr.Error()),
-		},
+		}
+	}
+
+	userCtx := user.InjectOrgID(ctx, req.userID)
+	_, err = client.QueryResult(userCtx, &frontendv2pb.QueryResultRequest{
+		QueryID:      req.queryID,
+		HttpResponse: resp,
 	})
 
 	if err != nil {
@@ -446,6 +483,68 @@ func (s *Scheduler) forwardErrorToFrontend(ctx context.Context, req *schedulerRe
 	}
 }
 
+// ActiveQueries implements activequeries.Tracker. The query IDs are only unique per query-frontend,
+// so the IDs returned by the query-scheduler also contain the address of the query-frontend.
+func (s *Scheduler) ActiveQueries(tenantID string) []activequeries.Query {
+	s.pendingRequestsMu.Lock()
+	defer s.pendingRequestsMu.Unlock()
+
+	var result []activequeries.Query
+	for key, req := range s.pendingRequests {
+		if req.userID != tenantID {
+			continue
+		}
+
+		q := activequeries.NewQuery(formatActiveQueryID(key), req.userID, req.request, req.enqueueTime, req.querierID)
+		q.Frontend = req.frontendAddress
+		result = append(result, q)
+	}
+	return result
+}
+
+// CancelQuery implements activequeries.Tracker. The query is removed from the queue or, if already
+// dispatched, the stream to the querier is closed, which cancels the query in the querier. The query-frontend
+// which sent the query is notified with activequeries.ErrCancelled.
+func (s *Scheduler) CancelQuery(ctx context.Context, tenantID, id string) bool {
+	key, ok := parseActiveQueryID(id)
+	if !ok {
+		return false
+	}
+
+	s.pendingRequestsMu.Lock()
+	req := s.pendingRequests[key]
+	if req == nil || req.userID != tenantID {
+		s.pendingRequestsMu.Unlock()
+		return false
+	}
+
+	req.ctxCancel()
+	delete(s.pendingRequests, key)
+	s.pendingRequestsMu.Unlock()
+
+	s.forwardErrorToFrontend(ctx, req, activequeries.ErrCancelled)
+	return true
+}
+
+// formatActiveQueryID returns the ID of the request exposed by the active queries API,
+// in the form <query ID>@<query-frontend address>.
+func formatActiveQueryID(key requestKey) string {
+	return strconv.FormatUint(key.queryID, 10) + "@" + key.frontendAddr
+}
+
+func parseActiveQueryID(id string) (requestKey, bool) {
+	parts := strings.SplitN(id, "@", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return requestKey{}, false
+	}
+
+	queryID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return requestKey{}, false
+	}
+	return requestKey{frontendAddr: parts[1], queryID: queryID}, true
+}
+
 func (s *Scheduler) isRunningOrStopping() bool {
 	st := s.State()
 	return st == services.Running || st == services.Stopping
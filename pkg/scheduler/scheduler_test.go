@@ -403,6 +403,75 @@ func TestSchedulerForwardsErrorToFrontend(t *testing.T) {
 	})
 }
 
+func TestSchedulerCancelActiveQuery(t *testing.T) {
+	scheduler, frontendClient, querierClient := setupScheduler(t)
+
+	fm := &frontendMock{resp: map[uint64]*httpgrpc.HTTPResponse{}}
+	frontendAddress := ""
+
+	// Setup frontend grpc server
+	{
+		frontendGrpcServer := grpc.NewServer()
+		frontendv2pb.RegisterFrontendForQuerierServer(frontendGrpcServer, fm)
+
+		l, err := net.Listen("tcp", "")
+		require.NoError(t, err)
+
+		frontendAddress = l.Addr().String()
+
+		go func() {
+			_ = frontendGrpcServer.Serve(l)
+		}()
+
+		t.Cleanup(func() {
+			_ = l.Close()
+		})
+	}
+
+	frontendLoop := initFrontendLoop(t, frontendClient, frontendAddress)
+	frontendToScheduler(t, frontendLoop, &schedulerpb.FrontendToScheduler{
+		Type:        schedulerpb.ENQUEUE,
+		QueryID:     100,
+		UserID:      "test",
+		HttpRequest: &httpgrpc.HTTPRequest{Method: "GET", Url: "/api/v1/query?query=up"},
+	})
+
+	querierLoop, err := querierClient.QuerierLoop(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, querierLoop.Send(&schedulerpb.QuerierToScheduler{QuerierID: "querier-1"}))
+
+	_, err = querierLoop.Recv()
+	require.NoError(t, err)
+
+	require.Empty(t, scheduler.ActiveQueries("other"))
+
+	queries := scheduler.ActiveQueries("test")
+	require.Len(t, queries, 1)
+	require.Equal(t, "100@"+frontendAddress, queries[0].ID)
+	require.Equal(t, "test", queries[0].Tenant)
+	require.Equal(t, "/api/v1/query", queries[0].Path)
+	require.Equal(t, "up", queries[0].Query)
+	require.Equal(t, "querier-1", queries[0].Querier)
+	require.Equal(t, frontendAddress, queries[0].Frontend)
+
+	require.False(t, scheduler.CancelQuery(context.Background(), "test", "100"))
+	require.False(t, scheduler.CancelQuery(context.Background(), "test", "101@"+frontendAddress))
+	require.False(t, scheduler.CancelQuery(context.Background(), "test", "100@other-frontend:9095"))
+	require.False(t, scheduler.CancelQuery(context.Background(), "other", queries[0].ID))
+	require.True(t, scheduler.CancelQuery(context.Background(), "test", queries[0].ID))
+
+	// The stream to the querier is closed, cancelling the query in the querier.
+	_, err = querierLoop.Recv()
+	require.Error(t, err)
+
+	// Verify that frontend was notified about the cancellation.
+	resp := fm.getRequest(100)
+	require.NotNil(t, resp)
+	require.Equal(t, int32(http.StatusServiceUnavailable), resp.Code)
+
+	verifyNoPendingRequestsLeft(t, scheduler)
+}
+
 func initFrontendLoop(t *testing.T, client schedulerpb.SchedulerForFrontendClient, frontendAddr string) schedulerpb.SchedulerForFrontend_FrontendLoopClient {
 	loop, err := client.FrontendLoop(context.Background())
 	require.NoError(t, err)
@@ -457,13 +526,28 @@ func verifyNoPendingRequestsLeft(t *testing.T, scheduler *Scheduler) {
 }
 
 type limits struct {
-	queriers int
+	queriers              int
+	defaultPriority       string
+	lowPriorityQueryRange time.Duration
+	weight                int
 }
 
 func (l limits) MaxQueriersPerUser(_ string) int {
 	return l.queriers
 }
 
+func (l limits) QuerySchedulerDefaultPriority(_ string) string {
+	return l.defaultPriority
+}
+
+func (l limits) QuerySchedulerLowPriorityQueryRange(_ string) time.Duration {
+	return l.lowPriorityQueryRange
+}
+
+func (l limits) QuerySchedulerWeight(_ string) int {
+	return l.weight
+}
+
 type frontendMock struct {
 	mu   sync.Mutex
 	resp map[uint64]*httpgrpc.HTTPResponse
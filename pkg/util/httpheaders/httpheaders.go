@@ -0,0 +1,5 @@
+// Package httpheaders contains the names of the custom HTTP headers shared by multiple Cortex components.
+package httpheaders
+
+// QueryPriority is the HTTP header which can be used by clients to set the priority of a query.
+const QueryPriority = "X-Cortex-Query-Priority"
@@ -0,0 +1,93 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"go.uber.org/atomic"
+
+	"github.com/cortexproject/cortex/pkg/util/validation"
+)
+
+type queryLimiterCtxKey struct{}
+
+var (
+	ctxKey = &queryLimiterCtxKey{}
+
+	errMaxFetchedSeriesPerQueryHit     = "the query hit the max number of fetched series limit (limit: %d series). This limit is configured via -querier.max-fetched-series-per-query"
+	errMaxFetchedChunkBytesPerQueryHit = "the query hit the max size of fetched chunks limit (limit: %d bytes). This limit is configured via -querier.max-fetched-chunk-bytes-per-query"
+)
+
+// QueryLimiter enforces the limits on the data fetched by a single query, across all the
+// storages (ingesters and store-gateways) queried. It's safe for concurrent use.
+type QueryLimiter struct {
+	uniqueSeriesMx sync.Mutex
+	uniqueSeries   map[uint64]struct{}
+
+	chunkBytesCount atomic.Int64
+
+	maxSeriesPerQuery     int
+	maxChunkBytesPerQuery int
+}
+
+// NewQueryLimiter makes a new per-query limiter. Each query limiter is configured
+// using the max fetched series and chunk bytes limits. 0 disables a limit.
+func NewQueryLimiter(maxSeriesPerQuery, maxChunkBytesPerQuery int) *QueryLimiter {
+	return &QueryLimiter{
+		uniqueSeries:          map[uint64]struct{}{},
+		maxSeriesPerQuery:     maxSeriesPerQuery,
+		maxChunkBytesPerQuery: maxChunkBytesPerQuery,
+	}
+}
+
+// AddQueryLimiterToContext returns a context with the query limiter.
+func AddQueryLimiterToContext(ctx context.Context, limiter *QueryLimiter) context.Context {
+	return context.WithValue(ctx, ctxKey, limiter)
+}
+
+// QueryLimiterFromContextWithFallback returns a QueryLimiter from the current context.
+// If there is not a QueryLimiter on the context it will return a new no-op limiter.
+func QueryLimiterFromContextWithFallback(ctx context.Context) *QueryLimiter {
+	ql, ok := ctx.Value(ctxKey).(*QueryLimiter)
+	if !ok {
+		// If there's no limiter return a new unlimited limiter as a fallback.
+		ql = NewQueryLimiter(0, 0)
+	}
+	return ql
+}
+
+// AddSeries adds the series to the unique series fetched by the query, and returns an
+// error if the max number of fetched series has been exceeded.
+func (ql *QueryLimiter) AddSeries(seriesLabels labels.Labels) error {
+	// If the max series is unlimited just return without managing the map.
+	if ql.maxSeriesPerQuery == 0 {
+		return nil
+	}
+
+	fingerprint := seriesLabels.Hash()
+
+	ql.uniqueSeriesMx.Lock()
+	defer ql.uniqueSeriesMx.Unlock()
+
+	ql.uniqueSeries[fingerprint] = struct{}{}
+	if len(ql.uniqueSeries) > ql.maxSeriesPerQuery {
+		// Format error with max limit.
+		return validation.LimitError(fmt.Sprintf(errMaxFetchedSeriesPerQueryHit, ql.maxSeriesPerQuery))
+	}
+	return nil
+}
+
+// AddChunkBytes adds the size of the chunks fetched by the query, and returns an
+// error if the max size of the fetched chunks has been exceeded.
+func (ql *QueryLimiter) AddChunkBytes(chunkSizeInBytes int) error {
+	if ql.maxChunkBytesPerQuery == 0 {
+		return nil
+	}
+
+	if ql.chunkBytesCount.Add(int64(chunkSizeInBytes)) > int64(ql.maxChunkBytesPerQuery) {
+		return validation.LimitError(fmt.Sprintf(errMaxFetchedChunkBytesPerQueryHit, ql.maxChunkBytesPerQuery))
+	}
+	return nil
+}
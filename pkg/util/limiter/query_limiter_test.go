@@ -0,0 +1,72 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/util/validation"
+)
+
+func TestQueryLimiter_AddSeries_ShouldReturnNoErrorOnLimitNotExceeded(t *testing.T) {
+	const (
+		metricName = "test_metric"
+	)
+
+	var (
+		series1 = labels.FromStrings(labels.MetricName, metricName+"_1", "series1", "1")
+		series2 = labels.FromStrings(labels.MetricName, metricName+"_2", "series2", "1")
+		limiter = NewQueryLimiter(100, 0)
+	)
+
+	require.NoError(t, limiter.AddSeries(series1))
+	require.NoError(t, limiter.AddSeries(series2))
+	assert.Equal(t, 2, len(limiter.uniqueSeries))
+
+	// Re-add previous series to make sure it's not double counted.
+	require.NoError(t, limiter.AddSeries(series1))
+	assert.Equal(t, 2, len(limiter.uniqueSeries))
+}
+
+func TestQueryLimiter_AddSeries_ShouldReturnErrorOnLimitExceeded(t *testing.T) {
+	const (
+		metricName = "test_metric"
+	)
+
+	var (
+		series1 = labels.FromStrings(labels.MetricName, metricName+"_1", "series1", "1")
+		series2 = labels.FromStrings(labels.MetricName, metricName+"_2", "series1", "1")
+		limiter = NewQueryLimiter(1, 0)
+	)
+
+	require.NoError(t, limiter.AddSeries(series1))
+
+	err := limiter.AddSeries(series2)
+	require.Error(t, err)
+	assert.Equal(t, validation.LimitError(fmt.Sprintf(errMaxFetchedSeriesPerQueryHit, 1)), err)
+}
+
+func TestQueryLimiter_AddChunkBytes(t *testing.T) {
+	limiter := NewQueryLimiter(0, 100)
+
+	require.NoError(t, limiter.AddChunkBytes(100))
+
+	err := limiter.AddChunkBytes(1)
+	require.Error(t, err)
+	assert.Equal(t, validation.LimitError(fmt.Sprintf(errMaxFetchedChunkBytesPerQueryHit, 100)), err)
+}
+
+func TestQueryLimiterFromContextWithFallback(t *testing.T) {
+	// The fallback limiter is unlimited.
+	fallback := QueryLimiterFromContextWithFallback(context.Background())
+	require.NoError(t, fallback.AddSeries(labels.FromStrings(labels.MetricName, "test_metric")))
+	require.NoError(t, fallback.AddChunkBytes(1e9))
+
+	limiter := NewQueryLimiter(1, 1)
+	ctx := AddQueryLimiterToContext(context.Background(), limiter)
+	assert.Same(t, limiter, QueryLimiterFromContextWithFallback(ctx))
+}
@@ -0,0 +1,317 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/middleware"
+
+	"github.com/cortexproject/cortex/pkg/distributor"
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/log"
+)
+
+var (
+	errInfluxMissingFields      = errors.New("missing fields")
+	errInfluxMissingMeasurement = errors.New("missing measurement")
+	errInfluxStringField        = errors.New("string field values are not supported")
+)
+
+// influxPrecisions maps the supported values of the precision query parameter to the timestamp unit.
+var influxPrecisions = map[string]time.Duration{
+	"":   time.Nanosecond,
+	"n":  time.Nanosecond,
+	"ns": time.Nanosecond,
+	"u":  time.Microsecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+}
+
+// influxPoint is a single line of the InfluxDB line protocol.
+type influxPoint struct {
+	measurement string
+	tags        labels.Labels
+	fields      []influxField
+
+	// Timestamp in the unit specified by the request precision, or nil if not set.
+	timestamp *int64
+}
+
+type influxField struct {
+	key   string
+	value float64
+}
+
+// InfluxHandler is a http.Handler which accepts metrics in the InfluxDB line protocol and
+// pushes them as Cortex time series. Each field is converted to a series named
+// <measurement>_<field> with the tags as labels. Valid lines are pushed even if some other
+// lines can't be parsed, in which case the per-line parse errors are returned with a 400.
+func InfluxHandler(cfg distributor.Config, sourceIPs *middleware.SourceIPExtractor, push func(context.Context, *client.WriteRequest) (*client.WriteResponse, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := log.WithContext(ctx, log.Logger)
+		if sourceIPs != nil {
+			source := sourceIPs.Get(r)
+			if source != "" {
+				ctx = util.AddSourceIPsToOutgoingContext(ctx, source)
+				logger = log.WithSourceIPs(source, logger)
+			}
+		}
+
+		precision, ok := influxPrecisions[r.URL.Query().Get("precision")]
+		if !ok {
+			http.Error(w, fmt.Sprintf("invalid precision %q", r.URL.Query().Get("precision")), http.StatusBadRequest)
+			return
+		}
+
+		body, err := readRequestBody(r, cfg.MaxRecvMsgSize)
+		if err != nil {
+			level.Error(logger).Log("err", err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		req, parseErrs := influxToWriteRequest(string(body), precision, time.Now())
+
+		if len(req.Timeseries) > 0 {
+			if _, err := push(ctx, req); err != nil {
+				resp, ok := httpgrpc.HTTPResponseFromError(err)
+				if !ok {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				if resp.GetCode() != 202 {
+					level.Error(logger).Log("msg", "push error", "err", err)
+				}
+				http.Error(w, string(resp.Body), int(resp.Code))
+				return
+			}
+		}
+
+		if len(parseErrs) > 0 {
+			level.Warn(logger).Log("msg", "failed to parse lines of the InfluxDB write request", "failed", len(parseErrs))
+			http.Error(w, strings.Join(parseErrs, "\n"), http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// influxToWriteRequest parses the InfluxDB line protocol input and returns the write request
+// built from the valid lines, along with the parse error of each invalid line. Points without
+// a timestamp get the input now.
+func influxToWriteRequest(input string, precision time.Duration, now time.Time) (*client.WriteRequest, []string) {
+	req := &client.WriteRequest{Source: client.API}
+	var parseErrs []string
+
+	for i, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		point, err := parseInfluxLine(line)
+		if err != nil {
+			parseErrs = append(parseErrs, fmt.Sprintf("line %d: %s", i+1, err.Error()))
+			continue
+		}
+
+		timestampMs := util.TimeToMillis(now)
+		if point.timestamp != nil {
+			timestampMs = (time.Duration(*point.timestamp) * precision).Milliseconds()
+		}
+
+		for _, field := range point.fields {
+			lbls := labels.NewBuilder(point.tags)
+			lbls.Set(labels.MetricName, sanitizeMetricName(point.measurement+"_"+field.key))
+
+			req.Timeseries = append(req.Timeseries, client.PreallocTimeseries{
+				TimeSeries: &client.TimeSeries{
+					Labels:  client.FromLabelsToLabelAdapters(lbls.Labels()),
+					Samples: []client.Sample{{Value: field.value, TimestampMs: timestampMs}},
+				},
+			})
+		}
+	}
+
+	return req, parseErrs
+}
+
+// parseInfluxLine parses a single line of the InfluxDB line protocol:
+// <measurement>[,<tag_key>=<tag_value>...] <field_key>=<field_value>[,<field_key>=<field_value>...] [<timestamp>]
+func parseInfluxLine(line string) (influxPoint, error) {
+	point := influxPoint{}
+
+	// Measurement and tags.
+	end := influxIndexUnescaped(line, 0, ' ', false)
+	sections := influxSplitUnescaped(line[:end], ',', false)
+	point.measurement = influxUnescape(sections[0])
+	if point.measurement == "" {
+		return point, errInfluxMissingMeasurement
+	}
+
+	tags := make(map[string]string, len(sections)-1)
+	for _, tag := range sections[1:] {
+		key, value, err := influxSplitKeyValue(tag)
+		if err != nil {
+			return point, errors.Wrap(err, "invalid tag")
+		}
+		if value == "" {
+			return point, fmt.Errorf("invalid tag %q: missing tag value", key)
+		}
+		tags[sanitizeLabelName(key)] = value
+	}
+	point.tags = labels.FromMap(tags)
+
+	// Fields.
+	start := influxSkipSpaces(line, end)
+	if start == len(line) {
+		return point, errInfluxMissingFields
+	}
+	end = influxIndexUnescaped(line, start, ' ', true)
+
+	for _, field := range influxSplitUnescaped(line[start:end], ',', true) {
+		key, rawValue, err := influxSplitKeyValue(field)
+		if err != nil {
+			return point, errors.Wrap(err, "invalid field")
+		}
+
+		value, err := parseInfluxFieldValue(rawValue)
+		if err == errInfluxStringField {
+			// Strings can't be stored as samples, so the field is skipped.
+			continue
+		}
+		if err != nil {
+			return point, errors.Wrapf(err, "invalid field %q", key)
+		}
+		point.fields = append(point.fields, influxField{key: key, value: value})
+	}
+
+	// Optional timestamp.
+	if start = influxSkipSpaces(line, end); start < len(line) {
+		ts, err := strconv.ParseInt(line[start:], 10, 64)
+		if err != nil {
+			return point, fmt.Errorf("invalid timestamp %q", line[start:])
+		}
+		point.timestamp = &ts
+	}
+
+	return point, nil
+}
+
+func parseInfluxFieldValue(value string) (float64, error) {
+	if value == "" {
+		return 0, errors.New("missing field value")
+	}
+
+	if value[0] == '"' {
+		if len(value) < 2 || value[len(value)-1] != '"' {
+			return 0, errors.New("unterminated string field value")
+		}
+		return 0, errInfluxStringField
+	}
+
+	switch value {
+	case "t", "T", "true", "True", "TRUE":
+		return 1, nil
+	case "f", "F", "false", "False", "FALSE":
+		return 0, nil
+	}
+
+	switch value[len(value)-1] {
+	case 'i':
+		v, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+		return float64(v), err
+	case 'u':
+		v, err := strconv.ParseUint(value[:len(value)-1], 10, 64)
+		return float64(v), err
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
+
+// influxSplitKeyValue splits an escaped key=value pair, returning the unescaped key and
+// the value (unescaped too, unless it's a quoted string).
+func influxSplitKeyValue(pair string) (string, string, error) {
+	idx := influxIndexUnescaped(pair, 0, '=', false)
+	if idx == len(pair) {
+		return "", "", fmt.Errorf("%q: missing '='", pair)
+	}
+
+	key := influxUnescape(pair[:idx])
+	if key == "" {
+		return "", "", fmt.Errorf("%q: missing key", pair)
+	}
+
+	value := pair[idx+1:]
+	if !strings.HasPrefix(value, `"`) {
+		value = influxUnescape(value)
+	}
+	return key, value, nil
+}
+
+// influxIndexUnescaped returns the index of the first occurrence of sep, not escaped by a
+// backslash (and outside double quotes, if quotes is true), starting from start. It returns
+// len(s) if not found.
+func influxIndexUnescaped(s string, start int, sep byte, quotes bool) int {
+	quoted := false
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case quotes && s[i] == '"':
+			quoted = !quoted
+		case !quoted && s[i] == sep:
+			return i
+		}
+	}
+	return len(s)
+}
+
+func influxSplitUnescaped(s string, sep byte, quotes bool) []string {
+	var parts []string
+	for start := 0; ; {
+		end := influxIndexUnescaped(s, start, sep, quotes)
+		parts = append(parts, s[start:end])
+		if end == len(s) {
+			return parts
+		}
+		start = end + 1
+	}
+}
+
+func influxSkipSpaces(s string, start int) int {
+	for start < len(s) && s[start] == ' ' {
+		start++
+	}
+	return start
+}
+
+// influxUnescape removes the backslashes escaping commas, equal signs, spaces and backslashes.
+func influxUnescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(`,= \`, s[i+1]) >= 0 {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,178 @@
+package push
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/distributor"
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+)
+
+func TestInfluxHandler(t *testing.T) {
+	tests := map[string]struct {
+		url            string
+		body           string
+		expectedStatus int
+		expectedBody   string
+		expectedSeries []string
+	}{
+		"valid lines": {
+			url:            "/api/v1/push/influx/write?precision=s",
+			body:           "cpu,host=a usage_user=1.5,usage_system=2i 1600000000\nmem,host=a used=3u 1600000001\n",
+			expectedStatus: http.StatusNoContent,
+			expectedSeries: []string{
+				`{__name__="cpu_usage_user", host="a"} 1.5 @1600000000000`,
+				`{__name__="cpu_usage_system", host="a"} 2 @1600000000000`,
+				`{__name__="mem_used", host="a"} 3 @1600000001000`,
+			},
+		},
+		"invalid lines are reported while valid ones are pushed": {
+			url:            "/api/v1/push/influx/write?precision=ms",
+			body:           "cpu value=1 1600000000000\ncpu\n\ncpu value=abc\n",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "line 2: missing fields\nline 4: invalid field \"value\": strconv.ParseFloat: parsing \"abc\": invalid syntax\n",
+			expectedSeries: []string{
+				`{__name__="cpu_value"} 1 @1600000000000`,
+			},
+		},
+		"invalid precision": {
+			url:            "/api/v1/push/influx/write?precision=d",
+			body:           "cpu value=1\n",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid precision \"d\"\n",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var actualSeries []string
+			push := func(_ context.Context, req *client.WriteRequest) (*client.WriteResponse, error) {
+				for _, ts := range req.Timeseries {
+					for _, s := range ts.Samples {
+						actualSeries = append(actualSeries, client.FromLabelAdaptersToLabels(ts.Labels).String()+" "+formatFloat(s.Value)+" @"+formatFloat(float64(s.TimestampMs)))
+					}
+				}
+				return &client.WriteResponse{}, nil
+			}
+
+			req, err := http.NewRequest("POST", "http://localhost"+testData.url, strings.NewReader(testData.body))
+			require.NoError(t, err)
+
+			resp := httptest.NewRecorder()
+			InfluxHandler(distributor.Config{MaxRecvMsgSize: 100000}, nil, push).ServeHTTP(resp, req)
+
+			assert.Equal(t, testData.expectedStatus, resp.Code)
+			assert.Equal(t, testData.expectedBody, resp.Body.String())
+			assert.Equal(t, testData.expectedSeries, actualSeries)
+		})
+	}
+}
+
+func TestInfluxToWriteRequest_ShouldUseNowIfTimestampIsMissing(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	req, errs := influxToWriteRequest("cpu value=1", time.Nanosecond, now)
+	require.Empty(t, errs)
+	require.Len(t, req.Timeseries, 1)
+	assert.Equal(t, []client.Sample{{Value: 1, TimestampMs: 1600000000000}}, req.Timeseries[0].Samples)
+}
+
+func TestParseInfluxLine(t *testing.T) {
+	ts := int64(1465839830100400200)
+
+	tests := map[string]struct {
+		line          string
+		expected      influxPoint
+		expectedError string
+	}{
+		"measurement and single field": {
+			line:     "cpu value=1",
+			expected: influxPoint{measurement: "cpu", tags: labels.Labels{}, fields: []influxField{{key: "value", value: 1}}},
+		},
+		"tags, multiple fields and timestamp": {
+			line: "cpu,host=server01,region=us-west usage=0.64,count=10i,free=5u,up=true,down=F 1465839830100400200",
+			expected: influxPoint{
+				measurement: "cpu",
+				tags:        labels.FromStrings("host", "server01", "region", "us-west"),
+				fields: []influxField{
+					{key: "usage", value: 0.64},
+					{key: "count", value: 10},
+					{key: "free", value: 5},
+					{key: "up", value: 1},
+					{key: "down", value: 0},
+				},
+				timestamp: &ts,
+			},
+		},
+		"escaped characters": {
+			line: `disk\ io,mount\,point=/var\ log,dotted.tag=a\=b used\ bytes=1`,
+			expected: influxPoint{
+				measurement: "disk io",
+				tags:        labels.FromStrings("mount_point", "/var log", "dotted_tag", "a=b"),
+				fields:      []influxField{{key: "used bytes", value: 1}},
+			},
+		},
+		"string fields are skipped": {
+			line: `app,env=prod message="hello, world = \"quoted\"",value=2 1465839830100400200`,
+			expected: influxPoint{
+				measurement: "app",
+				tags:        labels.FromStrings("env", "prod"),
+				fields:      []influxField{{key: "value", value: 2}},
+				timestamp:   &ts,
+			},
+		},
+		"missing measurement": {
+			line:          ",host=a value=1",
+			expectedError: "missing measurement",
+		},
+		"missing fields": {
+			line:          "cpu,host=a",
+			expectedError: "missing fields",
+		},
+		"missing tag value": {
+			line:          "cpu,host= value=1",
+			expectedError: `invalid tag "host": missing tag value`,
+		},
+		"invalid tag": {
+			line:          "cpu,host value=1",
+			expectedError: `invalid tag: "host": missing '='`,
+		},
+		"missing field value": {
+			line:          "cpu value=",
+			expectedError: `invalid field "value": missing field value`,
+		},
+		"unterminated string field": {
+			line:          `cpu value="abc`,
+			expectedError: `invalid field "value": unterminated string field value`,
+		},
+		"invalid integer field": {
+			line:          "cpu value=1.5i",
+			expectedError: `invalid field "value": strconv.ParseInt: parsing "1.5": invalid syntax`,
+		},
+		"invalid timestamp": {
+			line:          "cpu value=1 abc",
+			expectedError: `invalid timestamp "abc"`,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actual, err := parseInfluxLine(testData.line)
+			if testData.expectedError != "" {
+				require.EqualError(t, err, testData.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
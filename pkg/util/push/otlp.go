@@ -0,0 +1,359 @@
+package push
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"unicode"
+
+	"github.com/go-kit/kit/log/level"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/value"
+	"github.com/prometheus/prometheus/util/strutil"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/middleware"
+
+	"github.com/cortexproject/cortex/pkg/distributor"
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/log"
+	"github.com/cortexproject/cortex/pkg/util/push/otlp"
+)
+
+const (
+	otlpContentTypeProtobuf = "application/x-protobuf"
+	otlpContentTypeJSON     = "application/json"
+
+	messageSizeLargerErrFmt = "received message larger than max (%d vs %d)"
+)
+
+// OTLPHandler is a http.Handler which accepts OpenTelemetry (OTLP) metrics export requests,
+// encoded either in protobuf or JSON, and pushes them as Cortex time series.
+func OTLPHandler(cfg distributor.Config, sourceIPs *middleware.SourceIPExtractor, push func(context.Context, *client.WriteRequest) (*client.WriteResponse, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := log.WithContext(ctx, log.Logger)
+		if sourceIPs != nil {
+			source := sourceIPs.Get(r)
+			if source != "" {
+				ctx = util.AddSourceIPsToOutgoingContext(ctx, source)
+				logger = log.WithSourceIPs(source, logger)
+			}
+		}
+
+		contentType := otlpContentTypeProtobuf
+		if header := r.Header.Get("Content-Type"); header != "" {
+			mediaType, _, err := mime.ParseMediaType(header)
+			if err != nil || (mediaType != otlpContentTypeProtobuf && mediaType != otlpContentTypeJSON) {
+				http.Error(w, fmt.Sprintf("unsupported content type %q", header), http.StatusUnsupportedMediaType)
+				return
+			}
+			contentType = mediaType
+		}
+
+		var req otlp.ExportMetricsServiceRequest
+		if err := parseOTLPRequest(r, contentType, cfg.MaxRecvMsgSize, &req); err != nil {
+			level.Error(logger).Log("err", err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		writeReq := otlpToWriteRequest(&req)
+		if _, err := push(ctx, writeReq); err != nil {
+			resp, ok := httpgrpc.HTTPResponseFromError(err)
+			if !ok {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if resp.GetCode() != 202 {
+				level.Error(logger).Log("msg", "push error", "err", err)
+			}
+			http.Error(w, string(resp.Body), int(resp.Code))
+			return
+		}
+
+		// The OTLP response is an empty ExportMetricsServiceResponse, encoded like the request.
+		w.Header().Set("Content-Type", contentType)
+		if contentType == otlpContentTypeJSON {
+			_, _ = w.Write([]byte("{}"))
+		}
+	})
+}
+
+func parseOTLPRequest(r *http.Request, contentType string, maxSize int, req *otlp.ExportMetricsServiceRequest) error {
+	body, err := readRequestBody(r, maxSize)
+	if err != nil {
+		return err
+	}
+
+	if contentType == otlpContentTypeJSON {
+		unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+		return unmarshaler.Unmarshal(bytes.NewReader(body), req)
+	}
+	return req.Unmarshal(body)
+}
+
+// readRequestBody reads the request body, optionally gzip compressed, failing if
+// the (decompressed) body is larger than maxSize.
+func readRequestBody(r *http.Request, maxSize int) ([]byte, error) {
+	var reader io.Reader = r.Body
+
+	switch encoding := r.Header.Get("Content-Encoding"); encoding {
+	case "":
+	case "gzip":
+		gzReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gzReader.Close()
+		reader = gzReader
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+
+	// Read at most maxSize+1 bytes, so that we can detect whether the body exceeds the limit.
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(io.LimitReader(reader, int64(maxSize)+1)); err != nil {
+		return nil, err
+	}
+	if buf.Len() > maxSize {
+		return nil, fmt.Errorf(messageSizeLargerErrFmt, buf.Len(), maxSize)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// otlpToWriteRequest converts OTLP metrics to a Cortex write request. Resource attributes
+// and data point attributes are mapped to labels, data point attributes taking precedence.
+// Sums and histograms with delta temporality can't be represented as Prometheus series,
+// so they're skipped.
+func otlpToWriteRequest(req *otlp.ExportMetricsServiceRequest) *client.WriteRequest {
+	writeReq := &client.WriteRequest{Source: client.API}
+
+	for _, rm := range req.ResourceMetrics {
+		if rm == nil {
+			continue
+		}
+
+		var resourceAttrs []otlp.KeyValue
+		if rm.Resource != nil {
+			resourceAttrs = rm.Resource.Attributes
+		}
+
+		for _, sm := range rm.ScopeMetrics {
+			if sm == nil {
+				continue
+			}
+
+			for _, m := range sm.Metrics {
+				if m == nil {
+					continue
+				}
+				appendOTLPMetric(writeReq, resourceAttrs, m)
+			}
+		}
+	}
+
+	return writeReq
+}
+
+func appendOTLPMetric(writeReq *client.WriteRequest, resourceAttrs []otlp.KeyValue, m *otlp.Metric) {
+	name := sanitizeMetricName(m.Name)
+	metricType := client.UNKNOWN
+
+	switch {
+	case m.GetGauge() != nil:
+		metricType = client.GAUGE
+		for _, dp := range m.GetGauge().DataPoints {
+			appendOTLPSample(writeReq, resourceAttrs, dp.Attributes, name, nil, numberDataPointValue(dp), dp.TimeUnixNano, dp.Flags)
+		}
+
+	case m.GetSum() != nil:
+		sum := m.GetSum()
+		if sum.AggregationTemporality != otlp.AGGREGATION_TEMPORALITY_CUMULATIVE {
+			return
+		}
+
+		metricType = client.GAUGE
+		if sum.IsMonotonic {
+			metricType = client.COUNTER
+		}
+		for _, dp := range sum.DataPoints {
+			appendOTLPSample(writeReq, resourceAttrs, dp.Attributes, name, nil, numberDataPointValue(dp), dp.TimeUnixNano, dp.Flags)
+		}
+
+	case m.GetHistogram() != nil:
+		histogram := m.GetHistogram()
+		if histogram.AggregationTemporality != otlp.AGGREGATION_TEMPORALITY_CUMULATIVE {
+			return
+		}
+
+		metricType = client.HISTOGRAM
+		for _, dp := range histogram.DataPoints {
+			// OTLP buckets are not cumulative, while Prometheus ones are.
+			cumulative := uint64(0)
+			for i, bound := range dp.ExplicitBounds {
+				if i < len(dp.BucketCounts) {
+					cumulative += dp.BucketCounts[i]
+				}
+				le := labels.Label{Name: labels.BucketLabel, Value: formatFloat(bound)}
+				appendOTLPSample(writeReq, resourceAttrs, dp.Attributes, name+"_bucket", &le, float64(cumulative), dp.TimeUnixNano, dp.Flags)
+			}
+
+			le := labels.Label{Name: labels.BucketLabel, Value: "+Inf"}
+			appendOTLPSample(writeReq, resourceAttrs, dp.Attributes, name+"_bucket", &le, float64(dp.Count), dp.TimeUnixNano, dp.Flags)
+			appendOTLPSample(writeReq, resourceAttrs, dp.Attributes, name+"_sum", nil, dp.Sum, dp.TimeUnixNano, dp.Flags)
+			appendOTLPSample(writeReq, resourceAttrs, dp.Attributes, name+"_count", nil, float64(dp.Count), dp.TimeUnixNano, dp.Flags)
+		}
+
+	case m.GetSummary() != nil:
+		metricType = client.SUMMARY
+		for _, dp := range m.GetSummary().DataPoints {
+			for _, q := range dp.QuantileValues {
+				quantile := labels.Label{Name: "quantile", Value: formatFloat(q.Quantile)}
+				appendOTLPSample(writeReq, resourceAttrs, dp.Attributes, name, &quantile, q.Value, dp.TimeUnixNano, dp.Flags)
+			}
+
+			appendOTLPSample(writeReq, resourceAttrs, dp.Attributes, name+"_sum", nil, dp.Sum, dp.TimeUnixNano, dp.Flags)
+			appendOTLPSample(writeReq, resourceAttrs, dp.Attributes, name+"_count", nil, float64(dp.Count), dp.TimeUnixNano, dp.Flags)
+		}
+
+	default:
+		// Unsupported (or empty) metric data.
+		return
+	}
+
+	writeReq.Metadata = append(writeReq.Metadata, &client.MetricMetadata{
+		Type:             metricType,
+		MetricFamilyName: name,
+		Help:             m.Description,
+		Unit:             m.Unit,
+	})
+}
+
+func appendOTLPSample(writeReq *client.WriteRequest, resourceAttrs, attrs []otlp.KeyValue, name string, extra *labels.Label, v float64, timeUnixNano uint64, flags uint32) {
+	lbls := make(map[string]string, len(resourceAttrs)+len(attrs)+2)
+	for _, kv := range resourceAttrs {
+		lbls[sanitizeLabelName(kv.Key)] = anyValueToString(kv.Value)
+	}
+	for _, kv := range attrs {
+		lbls[sanitizeLabelName(kv.Key)] = anyValueToString(kv.Value)
+	}
+	if extra != nil {
+		lbls[extra.Name] = extra.Value
+	}
+	lbls[labels.MetricName] = name
+
+	if flags&uint32(otlp.DATA_POINT_FLAGS_NO_RECORDED_VALUE_MASK) != 0 {
+		v = math.Float64frombits(value.StaleNaN)
+	}
+
+	writeReq.Timeseries = append(writeReq.Timeseries, client.PreallocTimeseries{
+		TimeSeries: &client.TimeSeries{
+			Labels:  client.FromLabelsToLabelAdapters(labels.FromMap(lbls)),
+			Samples: []client.Sample{{Value: v, TimestampMs: int64(timeUnixNano / 1e6)}},
+		},
+	})
+}
+
+func numberDataPointValue(dp otlp.NumberDataPoint) float64 {
+	if _, ok := dp.Value.(*otlp.NumberDataPoint_AsInt); ok {
+		return float64(dp.GetAsInt())
+	}
+	return dp.GetAsDouble()
+}
+
+// invalidMetricNameChars matches the characters not allowed in a Prometheus metric name.
+// Unlike label names, metric names can contain colons.
+var invalidMetricNameChars = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+
+// sanitizeMetricName converts a metric name to a valid Prometheus metric name.
+func sanitizeMetricName(name string) string {
+	return prefixLeadingDigit(invalidMetricNameChars.ReplaceAllString(name, "_"))
+}
+
+// sanitizeLabelName converts an attribute key to a valid Prometheus label name.
+func sanitizeLabelName(name string) string {
+	return prefixLeadingDigit(strutil.SanitizeLabelName(name))
+}
+
+func prefixLeadingDigit(name string) string {
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// anyValueToString converts an OTLP attribute value to a label value. Arrays and key-value
+// lists are encoded in JSON.
+func anyValueToString(v *otlp.AnyValue) string {
+	if v == nil {
+		return ""
+	}
+
+	switch v.Value.(type) {
+	case *otlp.AnyValue_StringValue:
+		return v.GetStringValue()
+	case *otlp.AnyValue_BoolValue:
+		return strconv.FormatBool(v.GetBoolValue())
+	case *otlp.AnyValue_IntValue:
+		return strconv.FormatInt(v.GetIntValue(), 10)
+	case *otlp.AnyValue_DoubleValue:
+		return formatFloat(v.GetDoubleValue())
+	case *otlp.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.GetBytesValue())
+	default:
+		encoded, err := json.Marshal(anyValueToInterface(v))
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	}
+}
+
+func anyValueToInterface(v *otlp.AnyValue) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	switch v.Value.(type) {
+	case *otlp.AnyValue_ArrayValue:
+		values := make([]interface{}, 0, len(v.GetArrayValue().Values))
+		for _, item := range v.GetArrayValue().Values {
+			values = append(values, anyValueToInterface(item))
+		}
+		return values
+	case *otlp.AnyValue_KvlistValue:
+		values := make(map[string]interface{}, len(v.GetKvlistValue().Values))
+		for _, kv := range v.GetKvlistValue().Values {
+			values[kv.Key] = anyValueToInterface(kv.Value)
+		}
+		return values
+	case *otlp.AnyValue_BoolValue:
+		return v.GetBoolValue()
+	case *otlp.AnyValue_IntValue:
+		return v.GetIntValue()
+	case *otlp.AnyValue_DoubleValue:
+		// JSON doesn't support non-finite numbers.
+		if f := v.GetDoubleValue(); !math.IsInf(f, 0) && !math.IsNaN(f) {
+			return f
+		}
+		return anyValueToString(v)
+	default:
+		return anyValueToString(v)
+	}
+}
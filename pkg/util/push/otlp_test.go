@@ -0,0 +1,339 @@
+package push
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/distributor"
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/util/push/otlp"
+)
+
+func TestOTLPHandler(t *testing.T) {
+	exportReq := &otlp.ExportMetricsServiceRequest{
+		ResourceMetrics: []*otlp.ResourceMetrics{{
+			Resource: &otlp.Resource{Attributes: []otlp.KeyValue{
+				{Key: "service.name", Value: &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: "api"}}},
+			}},
+			ScopeMetrics: []*otlp.ScopeMetrics{{
+				Metrics: []*otlp.Metric{{
+					Name:        "requests",
+					Description: "Total requests.",
+					Data: &otlp.Metric_Sum{Sum: &otlp.Sum{
+						AggregationTemporality: otlp.AGGREGATION_TEMPORALITY_CUMULATIVE,
+						IsMonotonic:            true,
+						DataPoints: []otlp.NumberDataPoint{{
+							Attributes:   []otlp.KeyValue{{Key: "code", Value: &otlp.AnyValue{Value: &otlp.AnyValue_IntValue{IntValue: 200}}}},
+							TimeUnixNano: 2000000000,
+							Value:        &otlp.NumberDataPoint_AsInt{AsInt: 5},
+						}},
+					}},
+				}},
+			}},
+		}},
+	}
+
+	protobuf, err := exportReq.Marshal()
+	require.NoError(t, err)
+
+	var gzipped bytes.Buffer
+	gzWriter := gzip.NewWriter(&gzipped)
+	_, err = gzWriter.Write(protobuf)
+	require.NoError(t, err)
+	require.NoError(t, gzWriter.Close())
+
+	expected := &client.WriteRequest{
+		Timeseries: []client.PreallocTimeseries{{TimeSeries: &client.TimeSeries{
+			Labels: []client.LabelAdapter{
+				{Name: "__name__", Value: "requests"},
+				{Name: "code", Value: "200"},
+				{Name: "service_name", Value: "api"},
+			},
+			Samples: []client.Sample{{Value: 5, TimestampMs: 2000}},
+		}}},
+		Metadata: []*client.MetricMetadata{{Type: client.COUNTER, MetricFamilyName: "requests", Help: "Total requests."}},
+		Source:   client.API,
+	}
+
+	tests := map[string]struct {
+		body                []byte
+		contentType         string
+		contentEncoding     string
+		pushErr             error
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		"protobuf": {
+			body:                protobuf,
+			contentType:         "application/x-protobuf",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-protobuf",
+		},
+		"gzipped protobuf": {
+			body:                gzipped.Bytes(),
+			contentType:         "application/x-protobuf",
+			contentEncoding:     "gzip",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-protobuf",
+		},
+		"json": {
+			body: []byte(`{"resourceMetrics": [{
+				"resource": {"attributes": [{"key": "service.name", "value": {"stringValue": "api"}}]},
+				"scopeMetrics": [{"scope": {"name": "test"}, "metrics": [{
+					"name": "requests",
+					"description": "Total requests.",
+					"sum": {
+						"aggregationTemporality": 2,
+						"isMonotonic": true,
+						"dataPoints": [{"attributes": [{"key": "code", "value": {"intValue": "200"}}], "timeUnixNano": "2000000000", "asInt": "5"}]
+					}
+				}]}]
+			}]}`),
+			contentType:         "application/json; charset=utf-8",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        "{}",
+		},
+		"unsupported content type": {
+			body:           protobuf,
+			contentType:    "text/plain",
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		"unsupported content encoding": {
+			body:            protobuf,
+			contentType:     "application/x-protobuf",
+			contentEncoding: "snappy",
+			expectedStatus:  http.StatusBadRequest,
+		},
+		"invalid body": {
+			body:           []byte("invalid"),
+			contentType:    "application/json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"push error": {
+			body:           protobuf,
+			contentType:    "application/x-protobuf",
+			pushErr:        httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit exceeded"),
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   "ingestion rate limit exceeded\n",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "http://localhost/otlp/v1/metrics", bytes.NewReader(testData.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", testData.contentType)
+			if testData.contentEncoding != "" {
+				req.Header.Set("Content-Encoding", testData.contentEncoding)
+			}
+
+			push := func(_ context.Context, req *client.WriteRequest) (*client.WriteResponse, error) {
+				assert.Equal(t, expected, req)
+				return &client.WriteResponse{}, testData.pushErr
+			}
+
+			resp := httptest.NewRecorder()
+			OTLPHandler(distributor.Config{MaxRecvMsgSize: 100000}, nil, push).ServeHTTP(resp, req)
+
+			assert.Equal(t, testData.expectedStatus, resp.Code)
+			if testData.expectedContentType != "" {
+				assert.Equal(t, testData.expectedContentType, resp.Header().Get("Content-Type"))
+			}
+			if testData.expectedBody != "" {
+				assert.Equal(t, testData.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestOTLPHandler_ShouldRejectRequestsLargerThanMaxSize(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://localhost/otlp/v1/metrics", strings.NewReader(strings.Repeat("x", 101)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	OTLPHandler(distributor.Config{MaxRecvMsgSize: 100}, nil, nil).ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "received message larger than max (101 vs 100)")
+}
+
+func TestOTLPToWriteRequest(t *testing.T) {
+	resource := &otlp.Resource{Attributes: []otlp.KeyValue{
+		{Key: "service.name", Value: &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: "api"}}},
+		{Key: "env", Value: &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: "resource"}}},
+	}}
+	attrs := []otlp.KeyValue{
+		{Key: "env", Value: &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: "prod"}}},
+	}
+
+	req := &otlp.ExportMetricsServiceRequest{ResourceMetrics: []*otlp.ResourceMetrics{{
+		Resource: resource,
+		ScopeMetrics: []*otlp.ScopeMetrics{{Metrics: []*otlp.Metric{
+			{
+				Name: "memory.usage",
+				Unit: "bytes",
+				Data: &otlp.Metric_Gauge{Gauge: &otlp.Gauge{DataPoints: []otlp.NumberDataPoint{
+					{Attributes: attrs, TimeUnixNano: 1000000, Value: &otlp.NumberDataPoint_AsDouble{AsDouble: 1.5}},
+					{Attributes: attrs, TimeUnixNano: 2000000, Flags: uint32(otlp.DATA_POINT_FLAGS_NO_RECORDED_VALUE_MASK)},
+				}}},
+			},
+			{
+				Name: "job:requests:rate5m",
+				Data: &otlp.Metric_Gauge{Gauge: &otlp.Gauge{DataPoints: []otlp.NumberDataPoint{
+					{Attributes: []otlp.KeyValue{{Key: "http:method", Value: &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: "GET"}}}}, TimeUnixNano: 1000000, Value: &otlp.NumberDataPoint_AsDouble{AsDouble: 2}},
+				}}},
+			},
+			{
+				Name: "delta_requests",
+				Data: &otlp.Metric_Sum{Sum: &otlp.Sum{
+					AggregationTemporality: otlp.AGGREGATION_TEMPORALITY_DELTA,
+					IsMonotonic:            true,
+					DataPoints:             []otlp.NumberDataPoint{{TimeUnixNano: 1000000, Value: &otlp.NumberDataPoint_AsInt{AsInt: 1}}},
+				}},
+			},
+			{
+				Name: "latency",
+				Data: &otlp.Metric_Histogram{Histogram: &otlp.Histogram{
+					AggregationTemporality: otlp.AGGREGATION_TEMPORALITY_CUMULATIVE,
+					DataPoints: []otlp.HistogramDataPoint{{
+						TimeUnixNano:   1000000,
+						Count:          6,
+						Sum:            12.5,
+						BucketCounts:   []uint64{1, 2, 3},
+						ExplicitBounds: []float64{0.5, 1},
+					}},
+				}},
+			},
+			{
+				Name: "duration",
+				Data: &otlp.Metric_Summary{Summary: &otlp.Summary{
+					DataPoints: []otlp.SummaryDataPoint{{
+						TimeUnixNano:   1000000,
+						Count:          10,
+						Sum:            20,
+						QuantileValues: []otlp.SummaryDataPoint_ValueAtQuantile{{Quantile: 0.99, Value: 3}},
+					}},
+				}},
+			},
+		}}},
+	}}}
+
+	actual := otlpToWriteRequest(req)
+
+	type sample struct {
+		labels string
+		value  float64
+		ts     int64
+	}
+
+	var samples []sample
+	for _, ts := range actual.Timeseries {
+		require.Len(t, ts.Samples, 1)
+		samples = append(samples, sample{
+			labels: client.FromLabelAdaptersToLabels(ts.Labels).String(),
+			value:  ts.Samples[0].Value,
+			ts:     ts.Samples[0].TimestampMs,
+		})
+	}
+
+	require.Len(t, samples, 11)
+
+	// The sample flagged with no recorded value should be a stale marker.
+	assert.True(t, value.IsStaleNaN(samples[1].value))
+	samples[1].value = 0
+
+	assert.Equal(t, []sample{
+		{labels: `{__name__="memory_usage", env="prod", service_name="api"}`, value: 1.5, ts: 1},
+		{labels: `{__name__="memory_usage", env="prod", service_name="api"}`, value: 0, ts: 2},
+		{labels: `{__name__="job:requests:rate5m", env="resource", http_method="GET", service_name="api"}`, value: 2, ts: 1},
+		{labels: `{__name__="latency_bucket", env="resource", le="0.5", service_name="api"}`, value: 1, ts: 1},
+		{labels: `{__name__="latency_bucket", env="resource", le="1", service_name="api"}`, value: 3, ts: 1},
+		{labels: `{__name__="latency_bucket", env="resource", le="+Inf", service_name="api"}`, value: 6, ts: 1},
+		{labels: `{__name__="latency_sum", env="resource", service_name="api"}`, value: 12.5, ts: 1},
+		{labels: `{__name__="latency_count", env="resource", service_name="api"}`, value: 6, ts: 1},
+		{labels: `{__name__="duration", env="resource", quantile="0.99", service_name="api"}`, value: 3, ts: 1},
+		{labels: `{__name__="duration_sum", env="resource", service_name="api"}`, value: 20, ts: 1},
+		{labels: `{__name__="duration_count", env="resource", service_name="api"}`, value: 10, ts: 1},
+	}, samples)
+
+	assert.Equal(t, []*client.MetricMetadata{
+		{Type: client.GAUGE, MetricFamilyName: "memory_usage", Unit: "bytes"},
+		{Type: client.GAUGE, MetricFamilyName: "job:requests:rate5m"},
+		{Type: client.HISTOGRAM, MetricFamilyName: "latency"},
+		{Type: client.SUMMARY, MetricFamilyName: "duration"},
+	}, actual.Metadata)
+}
+
+func TestSanitizeNames(t *testing.T) {
+	for name, expected := range map[string]string{
+		"memory.usage":        "memory_usage",
+		"job:requests:rate5m": "job:requests:rate5m",
+		"1xx.responses":       "_1xx_responses",
+		"http-requests/s":     "http_requests_s",
+	} {
+		assert.Equal(t, expected, sanitizeMetricName(name), name)
+	}
+
+	for name, expected := range map[string]string{
+		"service.name": "service_name",
+		"http:method":  "http_method",
+		"1st":          "_1st",
+	} {
+		assert.Equal(t, expected, sanitizeLabelName(name), name)
+	}
+}
+
+func TestAnyValueToString(t *testing.T) {
+	tests := map[string]struct {
+		input    *otlp.AnyValue
+		expected string
+	}{
+		"nil": {
+			input:    nil,
+			expected: "",
+		},
+		"bool": {
+			input:    &otlp.AnyValue{Value: &otlp.AnyValue_BoolValue{BoolValue: true}},
+			expected: "true",
+		},
+		"double": {
+			input:    &otlp.AnyValue{Value: &otlp.AnyValue_DoubleValue{DoubleValue: 0.25}},
+			expected: "0.25",
+		},
+		"array": {
+			input: &otlp.AnyValue{Value: &otlp.AnyValue_ArrayValue{ArrayValue: &otlp.ArrayValue{Values: []*otlp.AnyValue{
+				{Value: &otlp.AnyValue_StringValue{StringValue: "a"}},
+				{Value: &otlp.AnyValue_IntValue{IntValue: 1}},
+			}}}},
+			expected: `["a",1]`,
+		},
+		"key-value list": {
+			input: &otlp.AnyValue{Value: &otlp.AnyValue_KvlistValue{KvlistValue: &otlp.KeyValueList{Values: []otlp.KeyValue{
+				{Key: "b", Value: &otlp.AnyValue{Value: &otlp.AnyValue_BoolValue{BoolValue: false}}},
+				{Key: "a", Value: &otlp.AnyValue{Value: &otlp.AnyValue_DoubleValue{DoubleValue: math.Inf(1)}}},
+			}}}},
+			// Values which can't be encoded in JSON are converted to strings.
+			expected: `{"a":"+Inf","b":false}`,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testData.expected, anyValueToString(testData.input))
+		})
+	}
+}
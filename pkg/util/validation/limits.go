@@ -3,16 +3,21 @@ package validation
 import (
 	"errors"
 	"flag"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/pkg/relabel"
+	"github.com/prometheus/prometheus/promql/parser"
 
 	"github.com/cortexproject/cortex/pkg/util/flagext"
 )
 
 var (
 	errMaxGlobalSeriesPerUserValidation = errors.New("The ingester.max-global-series-per-user limit is unsupported if distributor.shard-by-all-labels is disabled")
+	errInvalidQuerySchedulerPriority    = errors.New("The query-scheduler.default-priority limit must be one of: high, normal, low")
 )
 
 // Supported values for enum limits
@@ -21,7 +26,7 @@ const (
 	GlobalIngestionRateStrategy = "global"
 )
 
-//LimitError are errors that do not comply with the limits specified.
+// LimitError are errors that do not comply with the limits specified.
 type LimitError string
 
 func (e LimitError) Error() string {
@@ -66,30 +71,93 @@ type Limits struct {
 	MaxLocalMetadataPerMetric           int `yaml:"max_metadata_per_metric"`
 	MaxGlobalMetricsWithMetadataPerUser int `yaml:"max_global_metadata_per_user"`
 	MaxGlobalMetadataPerMetric          int `yaml:"max_global_metadata_per_metric"`
+	// Exemplars
+	MaxLocalExemplarsPerUser int `yaml:"max_exemplars_per_user"`
+	// Out-of-order ingestion
+	OutOfOrderTimeWindow model.Duration `yaml:"out_of_order_time_window"`
+	// Active series
+	ActiveSeriesCustomTrackers map[string]string `yaml:"active_series_custom_trackers" doc:"nocli|description=Additional custom trackers for active series, as a map of tracker name to series selector. The number of active series matching each tracker is exported by the ingesters in the cortex_ingester_active_series_custom_tracker metric. Requires -ingester.active-series-metrics-enabled=true."`
 
 	// Querier enforced limits.
-	MaxChunksPerQuery    int            `yaml:"max_chunks_per_query"`
-	MaxQueryLookback     model.Duration `yaml:"max_query_lookback"`
-	MaxQueryLength       time.Duration  `yaml:"max_query_length"`
-	MaxQueryParallelism  int            `yaml:"max_query_parallelism"`
-	CardinalityLimit     int            `yaml:"cardinality_limit"`
-	MaxCacheFreshness    time.Duration  `yaml:"max_cache_freshness"`
-	MaxQueriersPerTenant int            `yaml:"max_queriers_per_tenant"`
+	MaxChunksPerQuery            int            `yaml:"max_chunks_per_query"`
+	MaxFetchedSeriesPerQuery     int            `yaml:"max_fetched_series_per_query"`
+	MaxFetchedChunkBytesPerQuery int            `yaml:"max_fetched_chunk_bytes_per_query"`
+	MaxQueryLookback             model.Duration `yaml:"max_query_lookback"`
+	MaxQueryLength               time.Duration  `yaml:"max_query_length"`
+	MaxQueryParallelism          int            `yaml:"max_query_parallelism"`
+	CardinalityLimit             int            `yaml:"cardinality_limit"`
+	MaxCacheFreshness            time.Duration  `yaml:"max_cache_freshness"`
+	MaxQueriersPerTenant         int            `yaml:"max_queriers_per_tenant"`
+
+	// Query-scheduler enforced limits.
+	QuerySchedulerDefaultPriority       string        `yaml:"query_scheduler_default_priority"`
+	QuerySchedulerLowPriorityQueryRange time.Duration `yaml:"query_scheduler_low_priority_query_range"`
+	QuerySchedulerWeight                int           `yaml:"query_scheduler_weight"`
+
+	QueryShardingTotalShards int `yaml:"query_sharding_total_shards"`
 
 	// Ruler defaults and limits.
-	RulerEvaluationDelay        time.Duration `yaml:"ruler_evaluation_delay_duration"`
-	RulerTenantShardSize        int           `yaml:"ruler_tenant_shard_size"`
-	RulerMaxRulesPerRuleGroup   int           `yaml:"ruler_max_rules_per_rule_group"`
-	RulerMaxRuleGroupsPerTenant int           `yaml:"ruler_max_rule_groups_per_tenant"`
+	RulerEvaluationDelay                         time.Duration `yaml:"ruler_evaluation_delay_duration"`
+	RulerTenantShardSize                         int           `yaml:"ruler_tenant_shard_size"`
+	RulerMaxRulesPerRuleGroup                    int           `yaml:"ruler_max_rules_per_rule_group"`
+	RulerMaxRuleGroupsPerTenant                  int           `yaml:"ruler_max_rule_groups_per_tenant"`
+	RulerMaxIndependentRuleEvaluationConcurrency int           `yaml:"ruler_max_independent_rule_evaluation_concurrency"`
+
+	RulerAlertmanagerConfig RulerAlertmanagerConfig `yaml:"ruler_alertmanager_config"`
 
 	// Store-gateway.
 	StoreGatewayTenantShardSize int `yaml:"store_gateway_tenant_shard_size"`
 
+	// Compactor.
+	CompactorBlocksRetentionPeriod time.Duration `yaml:"compactor_blocks_retention_period"`
+
+	// Alertmanager.
+	AlertmanagerMaxConfigSizeBytes   int `yaml:"alertmanager_max_config_size_bytes"`
+	AlertmanagerMaxTemplatesCount    int `yaml:"alertmanager_max_templates_count"`
+	AlertmanagerMaxTemplateSizeBytes int `yaml:"alertmanager_max_template_size_bytes"`
+
+	// API capabilities.
+	AllowPush         bool `yaml:"allow_push"`
+	AllowQuery        bool `yaml:"allow_query"`
+	AllowRules        bool `yaml:"allow_rules"`
+	AllowAlertmanager bool `yaml:"allow_alertmanager"`
+
 	// Config for overrides, convenient if it goes here. [Deprecated in favor of RuntimeConfig flag in cortex.Config]
 	PerTenantOverrideConfig string        `yaml:"per_tenant_override_config"`
 	PerTenantOverridePeriod time.Duration `yaml:"per_tenant_override_period"`
 }
 
+// RulerAlertmanagerConfig configures the Alertmanager(s) the ruler sends the alerts of a tenant to.
+type RulerAlertmanagerConfig struct {
+	URL                   string         `yaml:"url" doc:"nocli|description=Comma-separated list of URL(s) of the Alertmanager(s) to send the tenant's alerts to, in the same format of -ruler.alertmanager-url. Basic auth credentials can be set in the URL. If empty, the alerts are sent to the Alertmanager(s) configured via -ruler.alertmanager-url and the other options of this block are ignored."`
+	APIVersion            string         `yaml:"api_version" doc:"nocli|description=Alertmanager API version to use. Supported values are: v1, v2. If empty, the API version configured via -ruler.alertmanager-use-v2 is used."`
+	BearerToken           flagext.Secret `yaml:"bearer_token" doc:"nocli|description=Bearer token used to authenticate to the Alertmanager(s). Can't be set together with basic auth credentials in the URL."`
+	TLSCAPath             string         `yaml:"tls_ca_path" doc:"nocli|description=Path to the CA certificates file to validate the Alertmanager(s) certificate. If empty, the host's root CA is used."`
+	TLSCertPath           string         `yaml:"tls_cert_path" doc:"nocli|description=Path to the client certificate file used to authenticate to the Alertmanager(s)."`
+	TLSKeyPath            string         `yaml:"tls_key_path" doc:"nocli|description=Path to the key file of the client certificate."`
+	TLSServerName         string         `yaml:"tls_server_name" doc:"nocli|description=Server name used to verify the hostname of the Alertmanager(s) certificate."`
+	TLSInsecureSkipVerify bool           `yaml:"tls_insecure_skip_verify" doc:"nocli|description=Skip the validation of the Alertmanager(s) certificate."`
+}
+
+// Validate the config and returns an error if the validation doesn't pass.
+func (cfg *RulerAlertmanagerConfig) Validate() error {
+	if cfg.APIVersion != "" && cfg.APIVersion != "v1" && cfg.APIVersion != "v2" {
+		return fmt.Errorf("unsupported Alertmanager API version %q", cfg.APIVersion)
+	}
+
+	for _, rawURL := range strings.Split(cfg.URL, ",") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("invalid Alertmanager URL: %w", err)
+		}
+		if u.User != nil && cfg.BearerToken.Value != "" {
+			return errors.New("the Alertmanager bearer token can't be set together with basic auth credentials")
+		}
+	}
+
+	return nil
+}
+
 // RegisterFlags adds the flags required to config this to the given FlagSet
 func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&l.IngestionTenantShardSize, "distributor.ingestion-tenant-shard-size", 0, "The default tenant's shard size when the shuffle-sharding strategy is used. Must be set both on ingesters and distributors. When this setting is specified in the per-tenant overrides, a value of 0 disables shuffle sharding for the tenant.")
@@ -124,24 +192,48 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&l.MaxGlobalMetricsWithMetadataPerUser, "ingester.max-global-metadata-per-user", 0, "The maximum number of active metrics with metadata per user, across the cluster. 0 to disable. Supported only if -distributor.shard-by-all-labels is true.")
 	f.IntVar(&l.MaxGlobalMetadataPerMetric, "ingester.max-global-metadata-per-metric", 0, "The maximum number of metadata per metric, across the cluster. 0 to disable.")
 
+	f.IntVar(&l.MaxLocalExemplarsPerUser, "ingester.max-exemplars-per-user", 0, "The maximum number of exemplars kept in memory per user, per ingester. When the limit is reached, the oldest exemplars are replaced by the new ones. This limit is enforced only when running the Cortex blocks storage. 0 to disable exemplars storage.")
+	f.Var(&l.OutOfOrderTimeWindow, "ingester.out-of-order-time-window", "Samples older than the latest sample ingested for the tenant, but within this time window, are accepted by the ingester and stored in a separate out-of-order head, which is merged on query and compacted into blocks once its time range has been compacted from the TSDB head. Samples in the out-of-order head are written to a dedicated WAL, replayed on startup. Samples already in the TSDB head are deduplicated. This limit is enforced only when running the Cortex blocks storage. 0 to disable.")
+
 	f.IntVar(&l.MaxChunksPerQuery, "store.query-chunk-limit", 2e6, "Maximum number of chunks that can be fetched in a single query. This limit is enforced when fetching chunks from the long-term storage. When running the Cortex chunks storage, this limit is enforced in the querier, while when running the Cortex blocks storage this limit is both enforced in the querier and store-gateway. 0 to disable.")
+	f.IntVar(&l.MaxFetchedSeriesPerQuery, "querier.max-fetched-series-per-query", 0, "The maximum number of unique series a single query can fetch from the ingesters and the store-gateways. The limit is enforced while fetching the series and the query fails as soon as it's exceeded. This limit is enforced in the querier only when running the Cortex blocks storage. 0 to disable.")
+	f.IntVar(&l.MaxFetchedChunkBytesPerQuery, "querier.max-fetched-chunk-bytes-per-query", 0, "The maximum size in bytes of all the chunks a single query can fetch from the ingesters and the store-gateways. The limit is enforced while fetching the chunks and the query fails as soon as it's exceeded. The chunks of a series fetched from multiple ingester replicas are counted only once. This limit is enforced in the querier only when running the Cortex blocks storage. 0 to disable.")
 	f.DurationVar(&l.MaxQueryLength, "store.max-query-length", 0, "Limit the query time range (end - start time). This limit is enforced in the query-frontend (on the received query), in the querier (on the query possibly split by the query-frontend) and in the chunks storage. 0 to disable.")
 	f.Var(&l.MaxQueryLookback, "querier.max-query-lookback", "Limit how long back data (series and metadata) can be queried, up until <lookback> duration ago. This limit is enforced in the query-frontend, querier and ruler. If the requested time range is outside the allowed range, the request will not fail but will be manipulated to only query data within the allowed time range. 0 to disable.")
 	f.IntVar(&l.MaxQueryParallelism, "querier.max-query-parallelism", 14, "Maximum number of split queries will be scheduled in parallel by the frontend.")
 	f.IntVar(&l.CardinalityLimit, "store.cardinality-limit", 1e5, "Cardinality limit for index queries. This limit is ignored when running the Cortex blocks storage. 0 to disable.")
 	f.DurationVar(&l.MaxCacheFreshness, "frontend.max-cache-freshness", 1*time.Minute, "Most recent allowed cacheable result per-tenant, to prevent caching very recent results that might still be in flux.")
 	f.IntVar(&l.MaxQueriersPerTenant, "frontend.max-queriers-per-tenant", 0, "Maximum number of queriers that can handle requests for a single tenant. If set to 0 or value higher than number of available queriers, *all* queriers will handle requests for the tenant. Each frontend (or query-scheduler, if used) will select the same set of queriers for the same tenant (given that all queriers are connected to all frontends / query-schedulers). This option only works with queriers connecting to the query-frontend / query-scheduler, not when using downstream URL.")
+	f.StringVar(&l.QuerySchedulerDefaultPriority, "query-scheduler.default-priority", "normal", "Priority of the tenant queries in the query-scheduler queue, when not set by the X-Cortex-Query-Priority request header. Supported values are: high, normal, low. The priorities only affect the order in which the queries of the same tenant are dequeued.")
+	f.DurationVar(&l.QuerySchedulerLowPriorityQueryRange, "query-scheduler.low-priority-query-range", 0, "Queries with a time range (end - start time) longer than this duration get the low priority in the query-scheduler queue, when the priority is not set by the X-Cortex-Query-Priority request header. The query-frontend splits queries before sending them to the query-scheduler, so this is compared with the time range of the split queries. 0 to disable.")
+	f.IntVar(&l.QuerySchedulerWeight, "query-scheduler.weight", 1, "Weight of the tenant when the query-scheduler dispatches the queued queries to the queriers. The queriers iterate over the tenants with queued queries in a round-robin fashion, and dequeue up to this number of queries in a row for the tenant, so a tenant with weight N gets up to N times the querier slots of a tenant with weight 1. The queriers a tenant can use are still limited by -frontend.max-queriers-per-tenant. 0 or negative values are considered as 1.")
+	f.IntVar(&l.QueryShardingTotalShards, "frontend.query-sharding-total-shards", 0, "The number of shards shardable queries are split into by the query-frontend, when running the blocks storage with -querier.parallelise-shardable-queries enabled. When running the chunks storage, the number of shards is defined by the schema config instead. The blocks storage doesn't shard series in the ingesters and store-gateways, so each shard fetches all the series matching the query and only the PromQL evaluation is parallelised, while the load on ingesters and store-gateways is multiplied by the number of shards. 0 or 1 to disable query sharding for the tenant.")
 
 	f.DurationVar(&l.RulerEvaluationDelay, "ruler.evaluation-delay-duration", 0, "Duration to delay the evaluation of rules to ensure the underlying metrics have been pushed to Cortex.")
 	f.IntVar(&l.RulerTenantShardSize, "ruler.tenant-shard-size", 0, "The default tenant's shard size when the shuffle-sharding strategy is used by ruler. When this setting is specified in the per-tenant overrides, a value of 0 disables shuffle sharding for the tenant.")
 	f.IntVar(&l.RulerMaxRulesPerRuleGroup, "ruler.max-rules-per-rule-group", 0, "Maximum number of rules per rule group per-tenant. 0 to disable.")
 	f.IntVar(&l.RulerMaxRuleGroupsPerTenant, "ruler.max-rule-groups-per-tenant", 0, "Maximum number of rule groups per-tenant. 0 to disable.")
+	f.IntVar(&l.RulerMaxIndependentRuleEvaluationConcurrency, "ruler.max-independent-rule-evaluation-concurrency", 0, "Maximum number of rules per-tenant evaluated concurrently with the other rules of their group. Only the rules not depending on the output of the preceding rules of their group are evaluated concurrently, while the other rules are still evaluated sequentially. 0 to disable.")
 
 	f.StringVar(&l.PerTenantOverrideConfig, "limits.per-user-override-config", "", "File name of per-user overrides. [deprecated, use -runtime-config.file instead]")
 	f.DurationVar(&l.PerTenantOverridePeriod, "limits.per-user-override-period", 10*time.Second, "Period with which to reload the overrides. [deprecated, use -runtime-config.reload-period instead]")
 
 	// Store-gateway.
 	f.IntVar(&l.StoreGatewayTenantShardSize, "store-gateway.tenant-shard-size", 0, "The default tenant's shard size when the shuffle-sharding strategy is used. Must be set when the store-gateway sharding is enabled with the shuffle-sharding strategy. When this setting is specified in the per-tenant overrides, a value of 0 disables shuffle sharding for the tenant.")
+
+	// Compactor.
+	f.DurationVar(&l.CompactorBlocksRetentionPeriod, "compactor.blocks-retention-period", 0, "Delete blocks containing samples older than the specified retention period. 0 to disable.")
+
+	// Alertmanager.
+	f.IntVar(&l.AlertmanagerMaxConfigSizeBytes, "alertmanager.max-config-size-bytes", 0, "Maximum size of the configuration file, including the templates, a tenant can upload via the Alertmanager API. 0 = no limit.")
+	f.IntVar(&l.AlertmanagerMaxTemplatesCount, "alertmanager.max-templates-count", 0, "Maximum number of templates in the configuration a tenant can upload via the Alertmanager API. 0 = no limit.")
+	f.IntVar(&l.AlertmanagerMaxTemplateSizeBytes, "alertmanager.max-template-size-bytes", 0, "Maximum size of each template in the configuration a tenant can upload via the Alertmanager API. 0 = no limit.")
+
+	// API capabilities.
+	f.BoolVar(&l.AllowPush, "auth.allow-push", true, "Allow the tenant to push series via the write API endpoints, including the distributor gRPC push, and to delete series and tenants via the purger API endpoints. If disabled, requests are rejected with 403.")
+	f.BoolVar(&l.AllowQuery, "auth.allow-query", true, "Allow the tenant to run queries via the Prometheus and the other read API endpoints. If disabled, requests are rejected with 403.")
+	f.BoolVar(&l.AllowRules, "auth.allow-rules", true, "Allow the tenant to access the ruler API endpoints to list and manage rules. If disabled, requests are rejected with 403.")
+	f.BoolVar(&l.AllowAlertmanager, "auth.allow-alertmanager", true, "Allow the tenant to access the Alertmanager UI and API endpoints, including its configuration. If disabled, requests are rejected with 403.")
 }
 
 // Validate the limits config and returns an error if the validation
@@ -153,7 +245,16 @@ func (l *Limits) Validate(shardByAllLabels bool) error {
 		return errMaxGlobalSeriesPerUserValidation
 	}
 
-	return nil
+	return l.validateQuerySchedulerDefaultPriority()
+}
+
+func (l *Limits) validateQuerySchedulerDefaultPriority() error {
+	switch l.QuerySchedulerDefaultPriority {
+	case "", "high", "normal", "low":
+		return nil
+	default:
+		return errInvalidQuerySchedulerPriority
+	}
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -167,7 +268,23 @@ func (l *Limits) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		*l = *defaultLimits
 	}
 	type plain Limits
-	return unmarshal((*plain)(l))
+	if err := unmarshal((*plain)(l)); err != nil {
+		return err
+	}
+
+	for name, selector := range l.ActiveSeriesCustomTrackers {
+		if _, err := parser.ParseMetricSelector(selector); err != nil {
+			return fmt.Errorf("invalid selector for active series custom tracker %q: %w", name, err)
+		}
+	}
+
+	if err := l.RulerAlertmanagerConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid ruler Alertmanager config: %w", err)
+	}
+
+	// The per-tenant overrides are not validated by Validate(), so an invalid priority
+	// must be rejected when loading them.
+	return l.validateQuerySchedulerDefaultPriority()
 }
 
 // When we load YAML from disk, we want the various per-customer limits
@@ -313,6 +430,18 @@ func (o *Overrides) MaxChunksPerQuery(userID string) int {
 	return o.getOverridesForUser(userID).MaxChunksPerQuery
 }
 
+// MaxFetchedSeriesPerQuery returns the maximum number of series allowed per query when fetching
+// chunks from ingesters and blocks storage.
+func (o *Overrides) MaxFetchedSeriesPerQuery(userID string) int {
+	return o.getOverridesForUser(userID).MaxFetchedSeriesPerQuery
+}
+
+// MaxFetchedChunkBytesPerQuery returns the maximum number of bytes for chunks allowed per query when fetching
+// chunks from ingesters and blocks storage.
+func (o *Overrides) MaxFetchedChunkBytesPerQuery(userID string) int {
+	return o.getOverridesForUser(userID).MaxFetchedChunkBytesPerQuery
+}
+
 // MaxQueryLookback returns the max lookback period of queries.
 func (o *Overrides) MaxQueryLookback(userID string) time.Duration {
 	return time.Duration(o.getOverridesForUser(userID).MaxQueryLookback)
@@ -334,6 +463,28 @@ func (o *Overrides) MaxQueriersPerUser(userID string) int {
 	return o.getOverridesForUser(userID).MaxQueriersPerTenant
 }
 
+// QuerySchedulerDefaultPriority returns the priority of the user queries in the query-scheduler queue,
+// when not set by the query itself.
+func (o *Overrides) QuerySchedulerDefaultPriority(userID string) string {
+	return o.getOverridesForUser(userID).QuerySchedulerDefaultPriority
+}
+
+// QuerySchedulerLowPriorityQueryRange returns the query time range above which the user queries get the
+// low priority in the query-scheduler queue.
+func (o *Overrides) QuerySchedulerLowPriorityQueryRange(userID string) time.Duration {
+	return o.getOverridesForUser(userID).QuerySchedulerLowPriorityQueryRange
+}
+
+// QuerySchedulerWeight returns the weight of the user when the query-scheduler dispatches queries to queriers.
+func (o *Overrides) QuerySchedulerWeight(userID string) int {
+	return o.getOverridesForUser(userID).QuerySchedulerWeight
+}
+
+// QueryShardingTotalShards returns the number of shards queries are split into by the query-frontend.
+func (o *Overrides) QueryShardingTotalShards(userID string) int {
+	return o.getOverridesForUser(userID).QueryShardingTotalShards
+}
+
 // MaxQueryParallelism returns the limit to the number of split queries the
 // frontend will process in parallel.
 func (o *Overrides) MaxQueryParallelism(userID string) int {
@@ -380,6 +531,16 @@ func (o *Overrides) MaxGlobalMetadataPerMetric(userID string) int {
 	return o.getOverridesForUser(userID).MaxGlobalMetadataPerMetric
 }
 
+// MaxLocalExemplarsPerUser returns the maximum number of exemplars a user is allowed to store in a single ingester.
+func (o *Overrides) MaxLocalExemplarsPerUser(userID string) int {
+	return o.getOverridesForUser(userID).MaxLocalExemplarsPerUser
+}
+
+// OutOfOrderTimeWindow returns the time window within which out-of-order samples are accepted for a given user.
+func (o *Overrides) OutOfOrderTimeWindow(userID string) time.Duration {
+	return time.Duration(o.getOverridesForUser(userID).OutOfOrderTimeWindow)
+}
+
 // IngestionTenantShardSize returns the ingesters shard size for a given user.
 func (o *Overrides) IngestionTenantShardSize(userID string) int {
 	return o.getOverridesForUser(userID).IngestionTenantShardSize
@@ -395,6 +556,31 @@ func (o *Overrides) MetricRelabelConfigs(userID string) []*relabel.Config {
 	return o.getOverridesForUser(userID).MetricRelabelConfigs
 }
 
+// ActiveSeriesCustomTrackers returns the active series custom trackers for a given user.
+func (o *Overrides) ActiveSeriesCustomTrackers(userID string) map[string]string {
+	return o.getOverridesForUser(userID).ActiveSeriesCustomTrackers
+}
+
+// AllowPush returns whether the tenant is allowed to push series.
+func (o *Overrides) AllowPush(userID string) bool {
+	return o.getOverridesForUser(userID).AllowPush
+}
+
+// AllowQuery returns whether the tenant is allowed to run queries.
+func (o *Overrides) AllowQuery(userID string) bool {
+	return o.getOverridesForUser(userID).AllowQuery
+}
+
+// AllowRules returns whether the tenant is allowed to access the ruler API.
+func (o *Overrides) AllowRules(userID string) bool {
+	return o.getOverridesForUser(userID).AllowRules
+}
+
+// AllowAlertmanager returns whether the tenant is allowed to access the Alertmanager.
+func (o *Overrides) AllowAlertmanager(userID string) bool {
+	return o.getOverridesForUser(userID).AllowAlertmanager
+}
+
 // RulerTenantShardSize returns shard size (number of rulers) used by this tenant when using shuffle-sharding strategy.
 func (o *Overrides) RulerTenantShardSize(userID string) int {
 	return o.getOverridesForUser(userID).RulerTenantShardSize
@@ -410,11 +596,42 @@ func (o *Overrides) RulerMaxRuleGroupsPerTenant(userID string) int {
 	return o.getOverridesForUser(userID).RulerMaxRuleGroupsPerTenant
 }
 
+// RulerMaxIndependentRuleEvaluationConcurrency returns the maximum number of rules of a given user evaluated
+// concurrently with the other rules of their group.
+func (o *Overrides) RulerMaxIndependentRuleEvaluationConcurrency(userID string) int {
+	return o.getOverridesForUser(userID).RulerMaxIndependentRuleEvaluationConcurrency
+}
+
+// RulerAlertmanagerConfig returns the config of the Alertmanager(s) the ruler sends the alerts of a given user to.
+func (o *Overrides) RulerAlertmanagerConfig(userID string) RulerAlertmanagerConfig {
+	return o.getOverridesForUser(userID).RulerAlertmanagerConfig
+}
+
 // StoreGatewayTenantShardSize returns the store-gateway shard size for a given user.
 func (o *Overrides) StoreGatewayTenantShardSize(userID string) int {
 	return o.getOverridesForUser(userID).StoreGatewayTenantShardSize
 }
 
+// CompactorBlocksRetentionPeriod returns the retention period for a given user.
+func (o *Overrides) CompactorBlocksRetentionPeriod(userID string) time.Duration {
+	return o.getOverridesForUser(userID).CompactorBlocksRetentionPeriod
+}
+
+// AlertmanagerMaxConfigSize returns the maximum size of the Alertmanager configuration a given user can upload.
+func (o *Overrides) AlertmanagerMaxConfigSize(userID string) int {
+	return o.getOverridesForUser(userID).AlertmanagerMaxConfigSizeBytes
+}
+
+// AlertmanagerMaxTemplatesCount returns the maximum number of Alertmanager templates a given user can upload.
+func (o *Overrides) AlertmanagerMaxTemplatesCount(userID string) int {
+	return o.getOverridesForUser(userID).AlertmanagerMaxTemplatesCount
+}
+
+// AlertmanagerMaxTemplateSize returns the maximum size of each Alertmanager template a given user can upload.
+func (o *Overrides) AlertmanagerMaxTemplateSize(userID string) int {
+	return o.getOverridesForUser(userID).AlertmanagerMaxTemplateSizeBytes
+}
+
 // MaxHAClusters returns maximum number of clusters that HA tracker will track for a user.
 func (o *Overrides) MaxHAClusters(user string) int {
 	return o.getOverridesForUser(user).HAMaxClusters
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
@@ -39,6 +40,10 @@ const (
 	errDuplicateLabelName = "duplicate label name: %.200q metric %.200q"
 	errLabelsNotSorted    = "labels not sorted: %.200q metric %.200q"
 
+	errExemplarLabelsMissing    = "exemplar missing labels, timestamp: %d series: %s"
+	errExemplarLabelsTooLong    = "exemplar combined labelset exceeds %d characters, timestamp: %d series: %s labels: %s"
+	errExemplarTimestampInvalid = "exemplar missing timestamp, series: %s labels: %s"
+
 	// ErrQueryTooLong is used in chunk store, querier and query frontend.
 	ErrQueryTooLong = "the query time range exceeds the limit (query length: %s, limit: %s)"
 
@@ -53,6 +58,14 @@ const (
 	labelsNotSorted         = "labels_not_sorted"
 	labelValueTooLong       = "label_value_too_long"
 
+	exemplarLabelsMissing    = "exemplar_labels_missing"
+	exemplarLabelsTooLong    = "exemplar_labels_too_long"
+	exemplarTimestampInvalid = "exemplar_timestamp_invalid"
+
+	// ExemplarMaxLabelSetLength is the maximum number of characters of the combined exemplar labels,
+	// as defined by the OpenMetrics specification.
+	ExemplarMaxLabelSetLength = 128
+
 	// RateLimited is one of the values for the reason to discard samples.
 	// Declared here to avoid duplication in ingester and distributor.
 	RateLimited = "rate_limited"
@@ -79,9 +92,19 @@ var DiscardedMetadata = prometheus.NewCounterVec(
 	[]string{discardReasonLabel, "user"},
 )
 
+// DiscardedExemplars is a metric of the number of discarded exemplars, by reason.
+var DiscardedExemplars = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cortex_discarded_exemplars_total",
+		Help: "The total number of exemplars that were discarded.",
+	},
+	[]string{discardReasonLabel, "user"},
+)
+
 func init() {
 	prometheus.MustRegister(DiscardedSamples)
 	prometheus.MustRegister(DiscardedMetadata)
+	prometheus.MustRegister(DiscardedExemplars)
 }
 
 // SampleValidationConfig helps with getting required config to validate sample.
@@ -106,6 +129,34 @@ func ValidateSample(cfg SampleValidationConfig, userID string, metricName string
 	return nil
 }
 
+// ValidateExemplar returns an error if the exemplar is invalid.
+func ValidateExemplar(userID string, ls []client.LabelAdapter, e client.Exemplar) error {
+	if len(e.Labels) <= 0 {
+		DiscardedExemplars.WithLabelValues(exemplarLabelsMissing, userID).Inc()
+		return httpgrpc.Errorf(http.StatusBadRequest, errExemplarLabelsMissing, e.TimestampMs, client.FromLabelAdaptersToLabels(ls).String())
+	}
+
+	if e.TimestampMs == 0 {
+		DiscardedExemplars.WithLabelValues(exemplarTimestampInvalid, userID).Inc()
+		return httpgrpc.Errorf(http.StatusBadRequest, errExemplarTimestampInvalid, client.FromLabelAdaptersToLabels(ls).String(), client.FromLabelAdaptersToLabels(e.Labels).String())
+	}
+
+	// Exemplar label length does not include chars involved in text rendering such as quotes,
+	// equals sign, or commas. See the OpenMetrics specification.
+	labelSetLen := 0
+	for _, l := range e.Labels {
+		labelSetLen += utf8.RuneCountInString(l.Name)
+		labelSetLen += utf8.RuneCountInString(l.Value)
+	}
+
+	if labelSetLen > ExemplarMaxLabelSetLength {
+		DiscardedExemplars.WithLabelValues(exemplarLabelsTooLong, userID).Inc()
+		return httpgrpc.Errorf(http.StatusBadRequest, errExemplarLabelsTooLong, ExemplarMaxLabelSetLength, e.TimestampMs, client.FromLabelAdaptersToLabels(ls).String(), client.FromLabelAdaptersToLabels(e.Labels).String())
+	}
+
+	return nil
+}
+
 // LabelValidationConfig helps with getting required config to validate labels.
 type LabelValidationConfig interface {
 	EnforceMetricName(userID string) bool
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/prometheus/common/model"
@@ -152,6 +153,43 @@ func TestValidateMetadata(t *testing.T) {
 	}
 }
 
+func TestValidateExemplar(t *testing.T) {
+	userID := "testUser"
+	series := []client.LabelAdapter{{Name: model.MetricNameLabel, Value: "test"}}
+
+	for _, c := range []struct {
+		desc     string
+		exemplar client.Exemplar
+		err      error
+	}{
+		{
+			"with a valid exemplar",
+			client.Exemplar{Labels: []client.LabelAdapter{{Name: "traceID", Value: "123abc"}}, TimestampMs: 1000, Value: 1},
+			nil,
+		},
+		{
+			"with no labels",
+			client.Exemplar{TimestampMs: 1000, Value: 1},
+			httpgrpc.Errorf(http.StatusBadRequest, `exemplar missing labels, timestamp: 1000 series: {__name__="test"}`),
+		},
+		{
+			"with no timestamp",
+			client.Exemplar{Labels: []client.LabelAdapter{{Name: "traceID", Value: "123abc"}}, Value: 1},
+			httpgrpc.Errorf(http.StatusBadRequest, `exemplar missing timestamp, series: {__name__="test"} labels: {traceID="123abc"}`),
+		},
+		{
+			"with too long labels",
+			client.Exemplar{Labels: []client.LabelAdapter{{Name: "traceID", Value: strings.Repeat("a", 122)}}, TimestampMs: 1000, Value: 1},
+			httpgrpc.Errorf(http.StatusBadRequest, `exemplar combined labelset exceeds 128 characters, timestamp: 1000 series: {__name__="test"} labels: {traceID="`+strings.Repeat("a", 122)+`"}`),
+		},
+	} {
+		t.Run(c.desc, func(t *testing.T) {
+			err := ValidateExemplar(userID, series, c.exemplar)
+			assert.Equal(t, c.err, err, "wrong error")
+		})
+	}
+}
+
 func TestValidateLabelOrder(t *testing.T) {
 	var cfg validateLabelsCfg
 	cfg.maxLabelNameLength = 10